@@ -0,0 +1,75 @@
+package emerge
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/goal"
+)
+
+// arrange sets each agent's phase and frequency from its position in ID
+// order.
+func arrange(c *Client, phase func(i, n int) float64, freq func(i int) time.Duration) {
+	agents := c.Swarm().AgentsSorted()
+	for i, a := range agents {
+		a.SetPhase(phase(i, len(agents)))
+		a.SetFrequency(freq(i))
+	}
+}
+
+// Phase and frequency layouts for arrange.
+var (
+	aligned  = func(int, int) float64 { return 1 }
+	splayed  = func(i, n int) float64 { return 2 * math.Pi * float64(i) / float64(n) }
+	twoBlocs = func(i, _ int) float64 { return 1 + 0.6*float64(i%2) }
+	locked   = func(int) time.Duration { return time.Second }
+	spread   = func(i int) time.Duration { return time.Duration(100+90*i) * time.Millisecond }
+)
+
+func TestIsConvergedPerGoal(t *testing.T) {
+	t.Parallel()
+
+	type layout struct {
+		phase func(i, n int) float64
+		freq  func(i int) time.Duration
+	}
+	var (
+		sync  = layout{aligned, locked}
+		splay = layout{splayed, locked}
+	)
+	tests := []struct {
+		goal  goal.Type
+		met   layout // Where the goal is reached
+		unmet layout // Where it is not
+	}{
+		{goal.MinimizeAPICalls, sync, splay},
+		{goal.MinimizeLatency, sync, splay},
+		{goal.AdaptToTraffic, sync, splay},
+		{goal.DistributeLoad, splay, sync},
+		{goal.RecoverFromFailure, splay, sync},
+		{goal.SaveEnergy, splay, sync},
+		// Two blocs are coherent enough, but they have not agreed
+		{goal.ReachConsensus, sync, layout{twoBlocs, locked}},
+		// Aligned phases at spread frequencies are about to drift apart
+		{goal.MaintainRhythm, sync, layout{aligned, spread}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.goal.ID(), func(t *testing.T) {
+			t.Parallel()
+
+			c, err := New().WithGoal(tt.goal).Build()
+			require.NoError(t, err)
+			defer c.Swarm().Close()
+
+			arrange(c, tt.unmet.phase, tt.unmet.freq)
+			assert.False(t, c.IsConverged(), "coherence %.3f", c.Coherence())
+			arrange(c, tt.met.phase, tt.met.freq)
+			assert.True(t, c.IsConverged(), "coherence %.3f", c.Coherence())
+			assert.InDelta(t, 1, c.Coherence(), 0.01, "higher is better for every goal")
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package emerge
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/scale"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+func TestCoordinator(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		build func(t *testing.T) core.Coordinator
+	}{
+		{
+			name: "swarm",
+			build: func(t *testing.T) core.Coordinator {
+				t.Helper()
+				s, err := swarm.New(20, core.State{
+					Phase:     0,
+					Frequency: 100 * time.Millisecond,
+					Coherence: 0.9,
+				}, swarm.WithSeed(1))
+				require.NoError(t, err)
+				return s
+			},
+		},
+		{
+			name: "client",
+			build: func(t *testing.T) core.Coordinator {
+				t.Helper()
+				c, err := MinimizeAPICalls(scale.Tiny)
+				require.NoError(t, err)
+				return c
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := tt.build(t)
+			require.Positive(t, c.Size())
+
+			before := c.MeasureCoherence()
+			assert.GreaterOrEqual(t, before, 0.0)
+			assert.LessOrEqual(t, before, 1.0)
+
+			c.DisruptAgents(0.5)
+			after := c.MeasureCoherence()
+			assert.GreaterOrEqual(t, after, 0.0)
+			assert.LessOrEqual(t, after, 1.0)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			_ = c.Run(ctx) // Ends with the context; only that it returns matters
+			assert.Positive(t, c.Size(), "running keeps the agents")
+		})
+	}
+}
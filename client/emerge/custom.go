@@ -67,14 +67,15 @@ func (b *Builder) Build() (*Client, error) {
 	}
 
 	// Create swarm
-	sw, err := swarm.New(agentCount, targetState, swarm.WithGoalConfig(config))
+	sw, err := swarm.New(agentCount, targetState, swarm.WithGoal(b.goal), swarm.WithGoalConfig(config))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create swarm: %w", err)
 	}
 
 	return &Client{
-		swarm:  sw,
-		config: config,
+		swarm:   sw,
+		config:  config,
+		windows: newWindowEmitter(),
 	}, nil
 }
 
@@ -21,26 +21,58 @@ package emerge
 
 import (
 	"context"
+	"sync"
 
 	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/goal"
 	"github.com/carlisia/bio-adapt/emerge/swarm"
 )
 
+// consensusTolerance is the phase gap, in radians, beyond which agents
+// are counted as separate voting blocs rather than one consensus.
+const consensusTolerance = 0.3
+
 // Client provides access to emerge swarm synchronization.
 // It manages a collection of agents that achieve collective behavior
 // through local interactions, without central control.
 type Client struct {
-	swarm  *swarm.Swarm
-	config *swarm.Config
+	swarm   *swarm.Swarm
+	config  *swarm.Config
+	windows *windowEmitter
+
+	mu         sync.Mutex
+	stopWindow context.CancelFunc
 }
 
+// Client can be driven and observed as a generic coordinator.
+var _ core.Coordinator = (*Client)(nil)
+
 // Start begins the synchronization process.
 // Agents will start adjusting their phases to achieve the target coherence.
 // The method blocks until the context is canceled or an error occurs.
+// Natural batch windows are emitted on Windows until Start returns or
+// Stop is called.
 func (c *Client) Start(ctx context.Context) error {
+	windowCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	c.mu.Lock()
+	if c.stopWindow != nil {
+		c.stopWindow()
+	}
+	c.stopWindow = cancel
+	c.mu.Unlock()
+
+	go c.windows.run(windowCtx, c.swarm.TargetState().Frequency, c.swarm.MeasureCoherence)
+
 	return c.swarm.Run(ctx)
 }
 
+// Run is Start under the name core.Coordinator uses.
+func (c *Client) Run(ctx context.Context) error {
+	return c.Start(ctx)
+}
+
 // Swarm returns the underlying swarm for advanced operations.
 // Most users won't need this - prefer using the client's methods.
 func (c *Client) Swarm() *swarm.Swarm {
@@ -53,23 +85,73 @@ func (c *Client) Agents() map[string]*agent.Agent {
 	return c.swarm.Agents()
 }
 
-// Coherence returns the current synchronization coherence (0 to 1).
-// Higher values indicate better synchronization among agents.
+// Coherence returns how close the swarm is to its goal, from 0 to 1.
+// Higher is better for every goal, so it reads the same way whatever the
+// client was built for:
+//   - goals that prefer dispersion (see goal.Type.PrefersDispersion) and
+//     SaveEnergy spread agents out, so it is their phase dispersion (see
+//     swarm.Swarm.MeasureDispersion)
+//   - MaintainRhythm locks rhythms, so it is the lower of the phase and
+//     frequency coherence
+//   - every other goal synchronizes, so it is the phase coherence
 func (c *Client) Coherence() float64 {
-	return c.swarm.CurrentCoherence()
+	g := c.swarm.Goal()
+	switch {
+	case g.PrefersDispersion(), g == goal.SaveEnergy:
+		return c.swarm.MeasureDispersion()
+	case g == goal.MaintainRhythm:
+		return min(c.swarm.MeasureCoherence(), c.swarm.MeasureFrequencyCoherence())
+	default:
+		return c.swarm.MeasureCoherence()
+	}
+}
+
+// MeasureCoherence measures the swarm's phase coherence, whatever its
+// goal; Coherence measures progress toward the goal.
+func (c *Client) MeasureCoherence() float64 {
+	return c.swarm.MeasureCoherence()
+}
+
+// DisruptAgents scrambles the phases of the given fraction of agents.
+func (c *Client) DisruptAgents(fraction float64) {
+	c.swarm.DisruptAgents(fraction)
 }
 
-// IsConverged returns true if the swarm has achieved its target coherence.
+// IsConverged reports whether the swarm has reached its goal: whether
+// Coherence has reached the target coherence, or for goals that spread
+// agents out, one minus it, so a target of 0.3 asks for a dispersion of
+// 0.7. Two goals ask for more:
+//   - ReachConsensus also needs the agents to agree on one phase, a single
+//     cluster, and to have held the target over recent ticks
+//     (see swarm.Swarm.SustainedCoherence)
+//   - SaveEnergy needs the agents' duty cycles staggered so that about the
+//     same number of them is active at every point of the cycle
 func (c *Client) IsConverged() bool {
-	return c.swarm.IsConverged()
+	target := c.swarm.EffectiveTargetCoherence()
+	g := c.swarm.Goal()
+	switch {
+	case g == goal.SaveEnergy:
+		return c.swarm.IsConverged()
+	case g.PrefersDispersion():
+		return c.Coherence() >= 1-target
+	case g == goal.ReachConsensus:
+		return c.Coherence() >= target && c.swarm.SustainedCoherence() >= target &&
+			len(c.swarm.PhaseClusters(consensusTolerance)) == 1
+	default:
+		return c.Coherence() >= target
+	}
 }
 
 // Stop gracefully stops the swarm synchronization.
-// Note: Currently the swarm stops when its context is canceled.
-// This method is here for API completeness.
-func (*Client) Stop() {
-	// The swarm doesn't have a Stop method - it stops when context is canceled
-	// This is a no-op for now but keeps the API consistent
+// It ends natural batch windows; the swarm itself stops when the
+// context passed to Start is canceled.
+func (c *Client) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stopWindow != nil {
+		c.stopWindow()
+		c.stopWindow = nil
+	}
 }
 
 // Size returns the number of agents in the swarm.
@@ -0,0 +1,108 @@
+package emerge
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// windowBufferSize bounds how many undelivered windows are kept for slow consumers.
+const windowBufferSize = 16
+
+// BatchWindow marks a moment when batched work should be flushed.
+// Natural windows are emitted once per target period while the client runs;
+// forced windows are requested out of band through FlushNow.
+type BatchWindow struct {
+	Time      time.Time // When the window opened
+	Sequence  uint64    // Monotonic sequence number across all windows
+	Coherence float64   // Swarm coherence when the window opened
+	Forced    bool      // True for out-of-band flushes from FlushNow
+}
+
+// WindowStats reports how many windows of each kind have been emitted.
+type WindowStats struct {
+	Natural int
+	Forced  int
+	Dropped int // Windows not delivered because the consumer fell behind
+}
+
+// windowEmitter fans out natural and forced batch windows to consumers.
+type windowEmitter struct {
+	ch chan BatchWindow
+
+	mu    sync.Mutex
+	seq   uint64
+	stats WindowStats
+}
+
+func newWindowEmitter() *windowEmitter {
+	return &windowEmitter{
+		ch: make(chan BatchWindow, windowBufferSize),
+	}
+}
+
+// emit records and delivers a window without blocking the caller.
+func (e *windowEmitter) emit(coherence float64, forced bool) BatchWindow {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.seq++
+	w := BatchWindow{
+		Time:      time.Now(),
+		Sequence:  e.seq,
+		Coherence: coherence,
+		Forced:    forced,
+	}
+
+	if forced {
+		e.stats.Forced++
+	} else {
+		e.stats.Natural++
+	}
+
+	select {
+	case e.ch <- w:
+	default:
+		e.stats.Dropped++
+	}
+
+	return w
+}
+
+// run emits natural windows every period until the context is canceled.
+func (e *windowEmitter) run(ctx context.Context, period time.Duration, coherence func() float64) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			e.emit(coherence(), false)
+		}
+	}
+}
+
+// Windows returns the channel on which batch windows are delivered.
+// Natural windows arrive once per target period while Start is running.
+// If the consumer falls behind, windows are dropped rather than blocking
+// the swarm; WindowStats reports how many.
+func (c *Client) Windows() <-chan BatchWindow {
+	return c.windows.ch
+}
+
+// FlushNow forces an immediate batch window for urgent work.
+// The forced window is delivered on Windows with Forced set, so accounting
+// can tell it apart from natural windows. It does not touch the swarm or
+// shift the schedule of natural windows.
+func (c *Client) FlushNow() BatchWindow {
+	return c.windows.emit(c.swarm.MeasureCoherence(), true)
+}
+
+// WindowStats returns counts of the windows emitted so far.
+func (c *Client) WindowStats() WindowStats {
+	c.windows.mu.Lock()
+	defer c.windows.mu.Unlock()
+	return c.windows.stats
+}
@@ -0,0 +1,96 @@
+package emerge
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/scale"
+)
+
+func TestFlushNowDoesNotShiftNaturalWindows(t *testing.T) {
+	if testing.Short() {
+		t.Skip("waits for a natural batch window")
+	}
+	t.Parallel()
+
+	client, err := MinimizeAPICalls(scale.Tiny)
+	require.NoError(t, err)
+	period := client.Swarm().TargetState().Frequency
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*period)
+	defer cancel()
+	defer client.Stop()
+
+	started := time.Now()
+	go func() { _ = client.Start(ctx) }()
+
+	// Flush part way through the first period
+	time.Sleep(period / 4)
+	forced := client.FlushNow()
+	assert.True(t, forced.Forced)
+
+	select {
+	case w := <-client.Windows():
+		assert.True(t, w.Forced, "first window should be the forced flush")
+		assert.Equal(t, forced.Sequence, w.Sequence)
+		assert.Less(t, w.Time.Sub(started), period/2, "forced window should fire immediately")
+	case <-time.After(period / 4):
+		t.Fatal("forced window was not delivered immediately")
+	}
+
+	select {
+	case w := <-client.Windows():
+		require.False(t, w.Forced, "expected a natural window")
+		assert.InDelta(t, period, w.Time.Sub(started), float64(period/4),
+			"natural window should stay on its original schedule")
+	case <-ctx.Done():
+		t.Fatal("natural window did not occur")
+	}
+
+	stats := client.WindowStats()
+	assert.Equal(t, 1, stats.Forced)
+	assert.Equal(t, 1, stats.Natural)
+	assert.Zero(t, stats.Dropped)
+}
+
+func TestNaturalWindowsEndWithStart(t *testing.T) {
+	if testing.Short() {
+		t.Skip("waits out natural batch windows")
+	}
+	t.Parallel()
+
+	client, err := MinimizeAPICalls(scale.Tiny)
+	require.NoError(t, err)
+	period := client.Swarm().TargetState().Frequency
+
+	// The swarm stops early while the caller's context is still live
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	before := make(map[string]float64)
+	for id, a := range client.Agents() {
+		before[id] = a.Phase()
+	}
+	started := make(chan error, 1)
+	go func() { started <- client.Start(ctx) }()
+
+	// Shutdown only stops runs already going, so wait for the first tick
+	require.Eventually(t, func() bool {
+		for id, a := range client.Agents() {
+			if a.Phase() != before[id] {
+				return true
+			}
+		}
+		return false
+	}, 5*time.Second, 10*time.Millisecond, "the run should start")
+	require.NoError(t, client.Swarm().Shutdown(ctx))
+	require.Eventually(t, func() bool { return len(started) == 1 }, 5*time.Second, 10*time.Millisecond)
+	require.Error(t, <-started)
+
+	natural := client.WindowStats().Natural
+	time.Sleep(2 * period)
+	assert.Equal(t, natural, client.WindowStats().Natural, "no natural windows after Start returns")
+}
@@ -0,0 +1,122 @@
+package agent
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+// ActionReason says why ApplyActionResult did or did not carry out an
+// action.
+type ActionReason int
+
+// Action reasons.
+const (
+	// ActionApplied means the action was carried out.
+	ActionApplied ActionReason = iota
+	// ActionInsufficientEnergy means the action cost more energy than the
+	// agent had.
+	ActionInsufficientEnergy
+	// ActionUnknownType means the agent does not know how to carry out the
+	// action's type.
+	ActionUnknownType
+	// ActionRejected means the agent's resource manager could not grant the
+	// action's cost.
+	ActionRejected
+)
+
+// String returns the reason name.
+func (r ActionReason) String() string {
+	switch r {
+	case ActionApplied:
+		return "applied"
+	case ActionInsufficientEnergy:
+		return "insufficient_energy"
+	case ActionUnknownType:
+		return "unknown_type"
+	case ActionRejected:
+		return "rejected"
+	default:
+		return "unknown"
+	}
+}
+
+// ActionResult accounts for one action passed to ApplyActionResult.
+type ActionResult struct {
+	Success         bool
+	Reason          ActionReason
+	EnergySpent     float64 // Energy the action cost; 0 unless it succeeded
+	EnergyRemaining float64 // Agent's energy afterwards
+}
+
+// ApplyActionResult executes an action and accounts for it. An action is
+// refused, in this order of checks, if it costs more energy than the agent
+// has, if its type is unknown, or if a resource manager set with
+// WithResourceManager grants less than its cost; a partial grant is
+// released again. The token manager every agent gets by default is not
+// refilled, so its grants are drawn but not checked. Refusals return an
+// error wrapping core.ErrInsufficientEnergy, core.ErrUnknownActionType or
+// core.ErrResourceExhausted respectively, and count as failed actions in
+// Stats.
+func (a *Agent) ApplyActionResult(action core.Action) (ActionResult, error) {
+	return a.applyAction(action, a.ownManager)
+}
+
+// applyAction is ApplyActionResult, refusing actions the resource manager
+// cannot grant only if checkGrant is set.
+func (a *Agent) applyAction(action core.Action, checkGrant bool) (ActionResult, error) {
+	state := a.state.Load()
+	energyCost := action.Cost
+
+	refuse := func(reason ActionReason, err error) (ActionResult, error) {
+		a.stats.failedActions.Add(1)
+		return ActionResult{Reason: reason, EnergyRemaining: a.Energy()}, err
+	}
+
+	if energyCost > state.Energy {
+		return refuse(ActionInsufficientEnergy, fmt.Errorf("%w: required %.2f, available %.2f",
+			core.ErrInsufficientEnergy, energyCost, state.Energy))
+	}
+
+	movesPhase := false
+	switch action.Type {
+	case "adjust_phase", strategyPhaseNudge, strategyFrequencyLock, strategyJitterDamping, "energy_save", "pulse":
+		movesPhase = true
+	case "maintain":
+	default:
+		return refuse(ActionUnknownType, fmt.Errorf("%w: %s", core.ErrUnknownActionType, action.Type))
+	}
+
+	if checkGrant && a.resources != nil && energyCost > 0 {
+		if granted := a.resources.Request(energyCost); granted < energyCost {
+			a.resources.Release(granted)
+			return refuse(ActionRejected, fmt.Errorf("%w: requested %.2f, granted %.2f",
+				core.ErrResourceExhausted, energyCost, granted))
+		}
+	}
+
+	// Apply action and update energy in a single atomic operation
+	var remaining, previous, next float64
+	a.state.Update(func(s *StateData) {
+		previous = s.Phase
+		if movesPhase {
+			s.Phase = core.WrapPhase(s.Phase + action.Value)
+		}
+		next = s.Phase
+		s.Energy = math.Max(0, s.Energy-energyCost)
+		remaining = s.Energy
+	})
+	a.phaseChanged(previous, next)
+	a.stats.recordApplied(energyCost, action.Benefit)
+	if !checkGrant && a.resources != nil {
+		a.resources.Request(energyCost)
+	}
+
+	return ActionResult{
+		Success:         true,
+		Reason:          ActionApplied,
+		EnergySpent:     energyCost,
+		EnergyRemaining: remaining,
+	}, nil
+}
@@ -1,8 +1,9 @@
 package agent
 
 import (
-	"fmt"
 	"math"
+	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -20,6 +21,7 @@ import (
 const (
 	strategyPhaseNudge    = "phase_nudge"
 	strategyFrequencyLock = "frequency_lock"
+	strategyJitterDamping = "jitter_damping"
 )
 
 // Option configures an Agent.
@@ -43,6 +45,7 @@ type Agent struct {
 	optimizedNeighbors    *NeighborStorage
 	neighbors             sync.Map // Fallback for compatibility
 	useOptimizedNeighbors bool
+	weights               sync.Map // Neighbor ID to edge weight, for weighted links only
 
 	// Configuration (read-only after creation)
 	swarmSize           int
@@ -50,9 +53,48 @@ type Agent struct {
 
 	// Components (interfaces for extensibility)
 	decider     core.DecisionMaker
+	costModel   core.CostModel // Reprices proposals; nil keeps the strategy's prices
 	goalManager goal.Manager
 	resources   core.ResourceManager
-	strategy    core.SyncStrategy
+	ownManager  bool         // Resources set with WithResourceManager, whose grants are checked
+	strategy    atomic.Value // stores syncStrategy
+
+	// Neighbor sampling per update; nil couples with every neighbor
+	gossip atomic.Pointer[gossipFanout]
+
+	// Business goal of the agent's region of the swarm; nil follows the swarm's goal
+	goal atomic.Pointer[goal.Type]
+
+	// Weight of the local goal against the swarm target; nil when unset (see SetGoalBlend)
+	goalBlend atomic.Pointer[float64]
+
+	// Decision and action counters (see Stats)
+	stats agentStats
+
+	// Refractory period after each adjustment, and the ticks of it left (see SetCooldown)
+	cooldown atomic.Int64
+	resting  atomic.Int64
+
+	// Told of every phase change; nil when unwatched (see WatchPhase).
+	// The slice is replaced, never changed, under phaseWatchersMu
+	phaseWatchers   atomic.Pointer[[]*phaseWatcher]
+	phaseWatchersMu sync.Mutex
+}
+
+// phaseWatcher is one function registered with WatchPhase.
+type phaseWatcher struct {
+	fn func(oldPhase, newPhase float64)
+}
+
+// gossipFanout bounds how many neighbors an update samples (see SetGossipFanout).
+type gossipFanout struct {
+	fanout int
+	intn   func(n int) int
+}
+
+// syncStrategy boxes a strategy so atomic.Value always sees one concrete type.
+type syncStrategy struct {
+	core.SyncStrategy
 }
 
 // New creates a new agent with the given ID.
@@ -82,7 +124,7 @@ func New(id string, opts ...Option) *Agent {
 	a.decider = &decision.SimpleDecisionMaker{}
 	a.goalManager = &goal.WeightedManager{}
 	a.resources = resource.NewTokenManager(100)
-	a.strategy = &strategy.PhaseNudge{Rate: 0.3}
+	a.SetStrategy(&strategy.PhaseNudge{Rate: 0.3})
 
 	// Apply options
 	for _, opt := range opts {
@@ -131,8 +173,12 @@ func NewOptimizedFromConfig(id string, cfg config.Agent) (*Agent, error) {
 	}
 
 	// Add decision maker based on type
-	if cfg.DecisionMakerType == "simple" {
-		opts = append(opts, WithDecisionMaker(&decision.SimpleDecisionMaker{}))
+	if cfg.DecisionMakerType != "" {
+		dm, err := createDecisionMaker(cfg)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithDecisionMaker(dm))
 	}
 
 	// Add goal manager based on type
@@ -153,6 +199,8 @@ func NewOptimizedFromConfig(id string, cfg config.Agent) (*Agent, error) {
 		opts = append(opts, WithStrategy(&strategy.FrequencyLock{SyncRate: 0.5}))
 	case "energy_aware":
 		opts = append(opts, WithStrategy(&strategy.EnergyAware{Threshold: 10}))
+	case strategyJitterDamping:
+		opts = append(opts, WithStrategy(strategy.NewJitterDamping(cfg.StrategyRate, 0.6)))
 	default:
 		opts = append(opts, WithStrategy(&strategy.PhaseNudge{Rate: cfg.StrategyRate}))
 	}
@@ -167,9 +215,58 @@ func (a *Agent) Phase() float64 {
 
 // SetPhase sets the agent's phase.
 func (a *Agent) SetPhase(phase float64) {
+	var previous, next float64
 	a.state.Update(func(s *StateData) {
+		previous = s.Phase
 		s.Phase = core.WrapPhase(phase)
+		next = s.Phase
 	})
+	a.phaseChanged(previous, next)
+}
+
+// WatchPhase makes fn be called after every change of the agent's phase
+// through SetPhase or ApplyAction, with the phase before and after, e.g.
+// for a swarm to keep running sums of its agents' phases. An agent may
+// have several watchers, e.g. when swarms share it; each is told in the
+// order it was added until the returned function removes it. fn runs on
+// the goroutine that changed the phase and must be fast.
+func (a *Agent) WatchPhase(fn func(oldPhase, newPhase float64)) (unwatch func()) {
+	w := &phaseWatcher{fn: fn}
+	a.phaseWatchersMu.Lock()
+	var watchers []*phaseWatcher
+	if current := a.phaseWatchers.Load(); current != nil {
+		watchers = slices.Clone(*current)
+	}
+	watchers = append(watchers, w)
+	a.phaseWatchers.Store(&watchers)
+	a.phaseWatchersMu.Unlock()
+
+	return func() {
+		a.phaseWatchersMu.Lock()
+		defer a.phaseWatchersMu.Unlock()
+		current := a.phaseWatchers.Load()
+		if current == nil {
+			return
+		}
+		rest := slices.DeleteFunc(slices.Clone(*current), func(other *phaseWatcher) bool { return other == w })
+		if len(rest) == 0 {
+			a.phaseWatchers.Store(nil)
+			return
+		}
+		a.phaseWatchers.Store(&rest)
+	}
+}
+
+// phaseChanged tells the phase watchers, if any, of a phase change.
+func (a *Agent) phaseChanged(previous, next float64) {
+	if previous == next {
+		return
+	}
+	if watchers := a.phaseWatchers.Load(); watchers != nil {
+		for _, w := range *watchers {
+			w.fn(previous, next)
+		}
+	}
 }
 
 // Frequency returns the agent's oscillation frequency.
@@ -184,6 +281,44 @@ func (a *Agent) SetFrequency(freq time.Duration) {
 	})
 }
 
+// NaturalFrequency returns the agent's intrinsic frequency, or zero if
+// none has been set. Unlike Frequency, it is not changed by coupling.
+func (a *Agent) NaturalFrequency() time.Duration {
+	return a.state.Load().NaturalFrequency
+}
+
+// SetNaturalFrequency sets the agent's intrinsic frequency and resets the
+// current frequency to it. Non-positive values clear the natural frequency,
+// leaving the agent free to follow the swarm's frequency entirely.
+func (a *Agent) SetNaturalFrequency(freq time.Duration) {
+	a.state.Update(func(s *StateData) {
+		if freq <= 0 {
+			s.NaturalFrequency = 0
+			return
+		}
+		s.NaturalFrequency = freq
+		s.Frequency = freq
+	})
+}
+
+// Strategy returns the agent's synchronization strategy.
+func (a *Agent) Strategy() core.SyncStrategy {
+	if v, ok := a.strategy.Load().(syncStrategy); ok {
+		return v.SyncStrategy
+	}
+	return nil
+}
+
+// SetStrategy replaces the agent's synchronization strategy. It is safe to
+// call while the agent is proposing adjustments; nil is ignored. A strategy
+// set here overrides one chosen for the whole swarm with swarm.WithStrategy.
+func (a *Agent) SetStrategy(s core.SyncStrategy) {
+	if s == nil {
+		return
+	}
+	a.strategy.Store(syncStrategy{s})
+}
+
 // Energy returns the agent's available energy.
 func (a *Agent) Energy() float64 {
 	return a.state.Load().Energy
@@ -196,6 +331,18 @@ func (a *Agent) SetEnergy(energy float64) {
 	})
 }
 
+// UpdateEnergy atomically replaces the agent's energy with fn of its
+// current energy and returns the new value. Negative results are clamped
+// to zero.
+func (a *Agent) UpdateEnergy(fn func(energy float64) float64) float64 {
+	var updated float64
+	a.state.Update(func(s *StateData) {
+		s.Energy = math.Max(0, fn(s.Energy))
+		updated = s.Energy
+	})
+	return updated
+}
+
 // LocalGoal returns the agent's individual target phase.
 func (a *Agent) LocalGoal() float64 {
 	return a.state.Load().LocalGoal
@@ -208,7 +355,81 @@ func (a *Agent) SetLocalGoal(g float64) {
 	})
 }
 
-// Influence returns the agent's influence weight.
+// SetGoalBlend sets how strongly the agent pursues its local goal rather
+// than the swarm's target, from 0 (the target alone) to 1 (the local goal
+// alone); values outside [0, 1] are clamped. ProposeAdjustment aims at the
+// phase that far from the target toward the local goal, and a swarm pulls
+// the agent the same fraction of the way back to its local goal after each
+// synchronization step, so agents sharing a local goal away from the
+// target hold a cluster of their own. Without a blend, ProposeAdjustment
+// aims at the local goal alone and a swarm ignores the local goal.
+func (a *Agent) SetGoalBlend(localWeight float64) {
+	if localWeight < 0 {
+		localWeight = 0
+	} else if localWeight > 1 {
+		localWeight = 1
+	}
+	a.goalBlend.Store(&localWeight)
+}
+
+// GoalBlend returns the local goal weight set with SetGoalBlend and true,
+// or false if none has been set.
+func (a *Agent) GoalBlend() (float64, bool) {
+	w := a.goalBlend.Load()
+	if w == nil {
+		return 0, false
+	}
+	return *w, true
+}
+
+// SetGossipFanout makes UpdateContext couple with at most k neighbors per
+// call, drawn at random with intn (a func returning an int in [0, n)), in
+// place of every neighbor. This bounds the per-update cost for densely
+// connected agents at the price of slower convergence. A nil intn uses the
+// shared random source; k <= 0 restores coupling with every neighbor.
+// Neighbor counts reported in the agent's context still cover every
+// neighbor. It is safe to call while the agent is updating.
+func (a *Agent) SetGossipFanout(k int, intn func(n int) int) {
+	if k <= 0 {
+		a.gossip.Store(nil)
+		return
+	}
+	if intn == nil {
+		intn = random.Intn
+	}
+	a.gossip.Store(&gossipFanout{fanout: k, intn: intn})
+}
+
+// GossipFanout returns the neighbor sampling limit, or 0 if the agent
+// couples with every neighbor.
+func (a *Agent) GossipFanout() int {
+	if g := a.gossip.Load(); g != nil {
+		return g.fanout
+	}
+	return 0
+}
+
+// SetGoal gives the agent its own business goal, overriding the swarm's.
+// A swarm whose agents pursue different goals couples each agent by its
+// goal: agents seeking synchronization attract their peers with the same
+// goal, and agents seeking dispersion (see goal.Type.PrefersDispersion)
+// repel theirs.
+func (a *Agent) SetGoal(g goal.Type) {
+	a.goal.Store(&g)
+}
+
+// Goal returns the agent's own goal and true, or false if the agent follows
+// its swarm's goal.
+func (a *Agent) Goal() (goal.Type, bool) {
+	g := a.goal.Load()
+	if g == nil {
+		return 0, false
+	}
+	return *g, true
+}
+
+// Influence returns the agent's influence weight: how strongly the agent
+// pulls its neighbors' phases during coupling (see UpdateContext).
 func (a *Agent) Influence() float64 {
 	return a.behavior.Load().Influence
 }
@@ -247,6 +468,25 @@ func (a *Agent) SetDecisionMaker(dm core.DecisionMaker) {
 	a.decider = dm
 }
 
+// DecisionMaker returns the agent's decision-making component.
+func (a *Agent) DecisionMaker() core.DecisionMaker {
+	return a.decider
+}
+
+// SetCostModel makes m price the agent's proposals: ProposeAdjustment
+// replaces the Cost and Benefit its strategy gave a proposal with what m
+// makes of the move, before the decision maker chooses. A nil m keeps the
+// strategy's own prices, the default.
+func (a *Agent) SetCostModel(m core.CostModel) {
+	a.costModel = m
+}
+
+// CostModel returns the agent's cost model, or nil when proposals keep
+// their strategy's prices.
+func (a *Agent) CostModel() core.CostModel {
+	return a.costModel
+}
+
 // NeighborCount returns the number of connected neighbors.
 func (a *Agent) NeighborCount() int {
 	// Check both storage methods for compatibility
@@ -275,6 +515,23 @@ func (a *Agent) Neighbors() *sync.Map {
 	return &a.neighbors
 }
 
+// NeighborList returns all of the agent's neighbors, from either storage,
+// sorted by ID so callers iterate them in a stable order.
+func (a *Agent) NeighborList() []*Agent {
+	var list []*Agent
+	if a.optimizedNeighbors != nil {
+		list = a.optimizedNeighbors.All()
+	}
+	a.neighbors.Range(func(_, value any) bool {
+		if neighbor, ok := value.(*Agent); ok && !slices.Contains(list, neighbor) {
+			list = append(list, neighbor)
+		}
+		return true
+	})
+	slices.SortFunc(list, func(x, y *Agent) int { return strings.Compare(x.ID, y.ID) })
+	return list
+}
+
 // ConnectTo establishes a connection to another optimized agent.
 func (a *Agent) ConnectTo(otherID string, other *Agent) {
 	if other == nil || otherID == a.ID {
@@ -288,6 +545,40 @@ func (a *Agent) ConnectTo(otherID string, other *Agent) {
 	}
 }
 
+// ConnectWeighted connects to another agent with an edge weight, scaling
+// how strongly this agent couples toward the other's phase (see Perceive),
+// both in UpdateContext and in a swarm's update loop. Links are one-way, so
+// an agent can watch another closely while being watched loosely in
+// return. A weight of 1 is a plain link; a weight of 0 removes the link, so
+// zero-weight edges never linger. Negative and NaN weights are treated as
+// 0.
+func (a *Agent) ConnectWeighted(otherID string, other *Agent, weight float64) {
+	if !(weight > 0) {
+		a.DisconnectFrom(otherID)
+		return
+	}
+	if other == nil || otherID == a.ID {
+		return
+	}
+	a.ConnectTo(otherID, other)
+	if weight == 1 {
+		a.weights.Delete(otherID)
+	} else {
+		a.weights.Store(otherID, weight)
+	}
+}
+
+// EdgeWeight returns the weight of the agent's link to otherID: the weight
+// set with ConnectWeighted, or 1 otherwise.
+func (a *Agent) EdgeWeight(otherID string) float64 {
+	if w, ok := a.weights.Load(otherID); ok {
+		if weight, ok := w.(float64); ok {
+			return weight
+		}
+	}
+	return 1
+}
+
 // DisconnectFrom removes a connection.
 func (a *Agent) DisconnectFrom(otherID string) {
 	if a.useOptimizedNeighbors {
@@ -295,6 +586,17 @@ func (a *Agent) DisconnectFrom(otherID string) {
 	} else {
 		a.neighbors.Delete(otherID)
 	}
+	a.weights.Delete(otherID)
+}
+
+// ClearNeighbors removes all of the agent's connections. Connections are
+// one-way, so agents that list this one as a neighbor keep their links.
+func (a *Agent) ClearNeighbors() {
+	if a.optimizedNeighbors != nil {
+		a.optimizedNeighbors.Clear()
+	}
+	a.neighbors.Clear()
+	a.weights.Clear()
 }
 
 // IsConnectedTo checks if connected to another agent.
@@ -308,20 +610,51 @@ func (a *Agent) IsConnectedTo(otherID string) bool {
 }
 
 // UpdateContext updates the agent's perception efficiently with both optimizations.
+// It also moves the agent's local goal toward its neighbors' phases,
+// weighting each neighbor by its influence and the weight of the link to
+// it (see ConnectWeighted).
 func (a *Agent) UpdateContext() {
-	// Get neighbors efficiently
-	var neighborList []*Agent
-	if a.useOptimizedNeighbors {
-		neighborList = a.optimizedNeighbors.All()
-	} else {
-		a.neighbors.Range(func(_, value any) bool {
-			if neighbor, ok := value.(*Agent); ok {
-				neighborList = append(neighborList, neighbor)
-			}
-			return true
+	// Get neighbors efficiently, sampled down to the gossip fanout if set
+	a.updateContextFrom(a.gossipNeighbors(nil))
+}
+
+// Context returns the agent's perception of its neighborhood as of its
+// last UpdateContext, Perceive or Step.
+func (a *Agent) Context() core.Context {
+	if c, ok := a.context.Load().(core.Context); ok {
+		return c
+	}
+	return core.Context{}
+}
+
+// Perceive refreshes the agent's perception of its neighbors as
+// UpdateContext does, sampled down to the gossip fanout if set, but leaves
+// its local goal alone. It returns the phase shift toward its neighbors
+// that UpdateContext would move the local goal by, and false if no
+// neighbor pulls at all. A non-nil intn draws the neighbor sample in place
+// of the source given to SetGossipFanout, so a caller updating many agents
+// at once can give each its own.
+func (a *Agent) Perceive(intn func(n int) int) (shift float64, pulled bool) {
+	neighborList, neighborCount := a.gossipNeighbors(intn)
+	return a.perceiveFrom(neighborList, neighborCount, a.Phase())
+}
+
+// updateContextFrom updates the agent's perception from neighborList, out
+// of neighborCount neighbors in all, and moves its local goal.
+func (a *Agent) updateContextFrom(neighborList []*Agent, neighborCount int) {
+	myPhase := a.Phase()
+	if shift, pulled := a.perceiveFrom(neighborList, neighborCount, myPhase); pulled {
+		// Update local goal (single atomic operation for state update)
+		a.state.Update(func(s *StateData) {
+			s.LocalGoal = core.WrapPhase(myPhase + shift)
 		})
 	}
+}
 
+// perceiveFrom stores the agent's perception of neighborList, out of
+// neighborCount neighbors in all, with the agent at myPhase, and returns
+// the coupling pull toward them.
+func (a *Agent) perceiveFrom(neighborList []*Agent, neighborCount int, myPhase float64) (float64, bool) {
 	if len(neighborList) == 0 {
 		a.context.Store(core.Context{
 			Neighbors:      0,
@@ -329,46 +662,40 @@ func (a *Agent) UpdateContext() {
 			LocalCoherence: 0,
 			Stability:      0.5,
 		})
-		return
+		return 0, false
 	}
 
-	// Load state once (single atomic operation)
-	state := a.state.Load()
-	myPhase := state.Phase
-
 	sumCos := 0.0
 	sumSin := 0.0
+	// Influence-weighted sums for the coupling pull
+	weightedCos := 0.0
+	weightedSin := 0.0
+	totalWeight := 0.0
 
 	// Process neighbors
 	for _, neighbor := range neighborList {
 		diff := neighbor.Phase() - myPhase
-		sumCos += math.Cos(diff)
-		sumSin += math.Sin(diff)
+		cos, sin := math.Cos(diff), math.Sin(diff)
+		sumCos += cos
+		sumSin += sin
+
+		w := neighbor.Influence() * a.EdgeWeight(neighbor.ID)
+		weightedCos += w * cos
+		weightedSin += w * sin
+		totalWeight += w
 	}
 
-	neighborCount := len(neighborList)
-	localCoherence := math.Sqrt(sumCos*sumCos+sumSin*sumSin) / float64(neighborCount)
-
-	// Kuramoto coupling - update local goal
-	if neighborCount > 0 {
-		avgSin := sumSin / float64(neighborCount)
-		avgCos := sumCos / float64(neighborCount)
-		targetPhaseShift := math.Atan2(avgSin, avgCos)
-
-		couplingStrength := 0.5 + 0.5*localCoherence
-		effectiveShift := targetPhaseShift * couplingStrength
-
-		// Update local goal (single atomic operation for state update)
-		a.state.Update(func(s *StateData) {
-			s.LocalGoal = core.WrapPhase(myPhase + effectiveShift)
-		})
-	}
+	localCoherence := math.Sqrt(sumCos*sumCos+sumSin*sumSin) / float64(len(neighborList))
 
 	// Calculate density
 	maxNeighbors := a.assumedMaxNeighbors
 	if maxNeighbors == 0 {
 		maxNeighbors = a.swarmSize - 1
 	}
+	if maxNeighbors <= 0 {
+		// No swarm info: these neighbors are all there are
+		maxNeighbors = neighborCount
+	}
 	density := float64(neighborCount) / float64(maxNeighbors)
 
 	// Store context
@@ -378,10 +705,98 @@ func (a *Agent) UpdateContext() {
 		LocalCoherence: localCoherence,
 		Stability:      0.5, // Placeholder
 	})
+
+	// Kuramoto coupling pull. Each neighbor j pulls with weight
+	// w_j = Influence(j)·EdgeWeight(j), so the target shift is
+	//   atan2(Σ w_j·sin(θ_j−θ), Σ w_j·cos(θ_j−θ))
+	// and high-influence or heavily weighted neighbors act as pacemakers.
+	// Zero-influence neighbors contribute nothing; if every neighbor has
+	// zero influence there is no pull and the local goal is left unchanged.
+	if totalWeight == 0 {
+		return 0, false
+	}
+	targetPhaseShift := math.Atan2(weightedSin, weightedCos)
+	couplingStrength := 0.5 + 0.5*localCoherence
+	return targetPhaseShift * couplingStrength, true
 }
 
-// ProposeAdjustment evaluates and potentially accepts an adjustment.
-func (a *Agent) ProposeAdjustment(globalGoal core.State) (core.Action, bool) {
+// hasMapNeighbors reports whether any neighbor is stored in the neighbors
+// map rather than the optimized storage.
+func (a *Agent) hasMapNeighbors() bool {
+	found := false
+	a.neighbors.Range(func(_, _ any) bool {
+		found = true
+		return false
+	})
+	return found
+}
+
+// gossipNeighbors returns the neighbors to couple with on this update and
+// the agent's total neighbor count. With a gossip fanout, at most fanout
+// neighbors are drawn at random, with intn or else the fanout's own
+// source; otherwise all of them are returned.
+func (a *Agent) gossipNeighbors(intn func(n int) int) ([]*Agent, int) {
+	g := a.gossip.Load()
+	if g != nil && intn == nil {
+		intn = g.intn
+	}
+	if a.useOptimizedNeighbors && !a.hasMapNeighbors() {
+		if g == nil {
+			all := a.optimizedNeighbors.All()
+			return all, len(all)
+		}
+		return a.optimizedNeighbors.Sample(g.fanout, intn), a.optimizedNeighbors.Count()
+	}
+
+	// Swarms link agents through the neighbors map, possibly alongside the
+	// optimized storage; NeighborList covers both in a stable order
+	all := a.NeighborList()
+	if g == nil || len(all) <= g.fanout {
+		return all, len(all)
+	}
+	neighborList := make([]*Agent, 0, g.fanout)
+	for i, neighbor := range all {
+		if len(neighborList) < g.fanout {
+			neighborList = append(neighborList, neighbor)
+		} else if j := intn(i + 1); j < g.fanout {
+			// Reservoir sampling keeps a uniform sample of fanout neighbors
+			neighborList[j] = neighbor
+		}
+	}
+	return neighborList, len(all)
+}
+
+// Step runs one update of the agent by hand: it couples with the given
+// neighbors, in place of its own, as UpdateContext would, proposes an
+// adjustment toward target with ProposeAdjustment and applies it if
+// accepted. It returns the action taken and whether the agent carried it
+// out; a declined or refused adjustment leaves the agent where it was.
+// An agent resting after its last adjustment (see SetCooldown) maintains.
+// This is the agent-level counterpart of swarm.Swarm.Step, for testing
+// strategies and decision makers without a swarm.
+func (a *Agent) Step(neighbors []*Agent, target core.State) (core.Action, bool) {
+	a.updateContextFrom(neighbors, len(neighbors))
+	if a.Rest() {
+		return core.Action{Type: "maintain"}, false
+	}
+	action, accepted := a.ProposeAdjustment(target)
+	if !accepted {
+		return action, false
+	}
+	applied, _, _ := a.ApplyAction(action) // A refusal is reported as not applied
+	if applied && action.Value != 0 {
+		a.StartCooldown()
+	}
+	return action, applied
+}
+
+// ProposeAdjustment evaluates and potentially accepts an adjustment. The
+// decision maker's confidence both gates acceptance and scales the
+// accepted action's value, so an unsure decision makes a smaller move,
+// priced as such.
+func (a *Agent) ProposeAdjustment(globalGoal core.State) (action core.Action, accepted bool) {
+	defer func() { a.stats.recordProposal(accepted) }()
+
 	behavior := a.behavior.Load()
 
 	// Stubborn agents resist change
@@ -391,12 +806,16 @@ func (a *Agent) ProposeAdjustment(globalGoal core.State) (core.Action, bool) {
 
 	state := a.state.Load()
 
-	// Use pure local goal for Kuramoto synchronization
+	// Use pure local goal for Kuramoto synchronization, unless a goal
+	// blend weighs it against the global goal
 	blendedGoal := core.State{
 		Phase:     state.LocalGoal,
 		Frequency: state.Frequency,
 		Coherence: globalGoal.Coherence,
 	}
+	if w, ok := a.GoalBlend(); ok {
+		blendedGoal.Phase = core.WrapPhase(globalGoal.Phase + w*core.PhaseDifference(state.LocalGoal, globalGoal.Phase))
+	}
 
 	// Generate proposal using context
 	currentState := core.State{
@@ -405,15 +824,14 @@ func (a *Agent) ProposeAdjustment(globalGoal core.State) (core.Action, bool) {
 		Coherence: a.calculateLocalCoherence(),
 	}
 
-	// Get context
-	ctx := core.Context{}
-	if ctxLoad := a.context.Load(); ctxLoad != nil {
-		if c, ok := ctxLoad.(core.Context); ok {
-			ctx = c
-		}
-	}
+	ctx := a.Context()
 
-	proposal, confidence := a.strategy.Propose(currentState, blendedGoal, ctx)
+	proposal, confidence := a.Strategy().Propose(currentState, blendedGoal, ctx)
+	if a.costModel != nil && proposal.Type != "maintain" {
+		next := currentState
+		next.Phase = core.WrapPhase(currentState.Phase + proposal.Value)
+		proposal.Cost, proposal.Benefit = a.costModel.Evaluate(currentState, next, blendedGoal)
+	}
 
 	// Make decision
 	options := []core.Action{
@@ -423,6 +841,21 @@ func (a *Agent) ProposeAdjustment(globalGoal core.State) (core.Action, bool) {
 
 	chosen, acceptance := a.decider.Decide(currentState, options)
 
+	// Move only as far as the decision is sure of, priced for the
+	// shorter move
+	if chosen.Type != "maintain" {
+		scale := math.Min(math.Max(acceptance, 0), 1)
+		chosen.Value *= scale
+		if a.costModel != nil {
+			next := currentState
+			next.Phase = core.WrapPhase(currentState.Phase + chosen.Value)
+			chosen.Cost, chosen.Benefit = a.costModel.Evaluate(currentState, next, blendedGoal)
+		} else {
+			// Strategies price moves in proportion to their size
+			chosen.Cost *= scale
+		}
+	}
+
 	// Check energy
 	if chosen.Cost > state.Energy {
 		return core.Action{Type: "maintain"}, false
@@ -436,39 +869,14 @@ func (a *Agent) ProposeAdjustment(globalGoal core.State) (core.Action, bool) {
 	return core.Action{Type: "maintain"}, false
 }
 
-// ApplyAction executes an action with optimized state updates.
+// ApplyAction executes an action with optimized state updates. It reports
+// whether the action was carried out and the energy it cost. Unlike
+// ApplyActionResult it never refuses an action for want of resource
+// tokens: the cost is drawn from the resource manager but the grant is not
+// checked. Use ApplyActionResult to learn why an action was refused.
 func (a *Agent) ApplyAction(action core.Action) (bool, float64, error) {
-	state := a.state.Load()
-	energyCost := action.Cost
-
-	if energyCost > state.Energy {
-		return false, 0, fmt.Errorf("%w: required %.2f, available %.2f",
-			core.ErrInsufficientEnergy, energyCost, state.Energy)
-	}
-
-	// Apply action and update energy in a single atomic operation
-	success := false
-	switch action.Type {
-	case "adjust_phase", strategyPhaseNudge, strategyFrequencyLock, "energy_save", "pulse":
-		a.state.Update(func(s *StateData) {
-			s.Phase = core.WrapPhase(s.Phase + action.Value)
-			s.Energy = math.Max(0, s.Energy-energyCost)
-		})
-		success = true
-	case "maintain":
-		a.state.Update(func(s *StateData) {
-			s.Energy = math.Max(0, s.Energy-energyCost)
-		})
-		success = true
-	default:
-		return false, 0, fmt.Errorf("%w: %s", core.ErrUnknownActionType, action.Type)
-	}
-
-	if success && a.resources != nil {
-		a.resources.Request(energyCost)
-	}
-
-	return success, energyCost, nil
+	result, err := a.applyAction(action, false)
+	return result.Success, result.EnergySpent, err
 }
 
 // calculateLocalCoherence efficiently calculates coherence.
@@ -531,6 +939,14 @@ func WithFrequency(freq time.Duration) Option {
 	}
 }
 
+// WithNaturalFrequency sets the agent's intrinsic frequency.
+// The current frequency starts at the natural frequency.
+func WithNaturalFrequency(freq time.Duration) Option {
+	return func(a *Agent) {
+		a.SetNaturalFrequency(freq)
+	}
+}
+
 // WithRandomFrequency sets random frequency.
 func WithRandomFrequency() Option {
 	return func(a *Agent) {
@@ -583,6 +999,14 @@ func WithStubbornness(stubbornness float64) Option {
 	}
 }
 
+// WithGoalBlend sets how strongly the agent pursues its local goal rather
+// than the swarm's target (see SetGoalBlend).
+func WithGoalBlend(localWeight float64) Option {
+	return func(a *Agent) {
+		a.SetGoalBlend(localWeight)
+	}
+}
+
 // WithDecisionMaker sets decision-making component.
 func WithDecisionMaker(dm core.DecisionMaker) Option {
 	return func(a *Agent) {
@@ -590,6 +1014,14 @@ func WithDecisionMaker(dm core.DecisionMaker) Option {
 	}
 }
 
+// WithCostModel sets the model pricing the agent's proposals (see
+// SetCostModel).
+func WithCostModel(m core.CostModel) Option {
+	return func(a *Agent) {
+		a.costModel = m
+	}
+}
+
 // WithGoalManager sets goal blending component.
 func WithGoalManager(gm goal.Manager) Option {
 	return func(a *Agent) {
@@ -601,6 +1033,7 @@ func WithGoalManager(gm goal.Manager) Option {
 func WithResourceManager(rm core.ResourceManager) Option {
 	return func(a *Agent) {
 		a.resources = rm
+		a.ownManager = true
 		a.state.Update(func(s *StateData) {
 			s.Energy = rm.Available()
 		})
@@ -610,7 +1043,15 @@ func WithResourceManager(rm core.ResourceManager) Option {
 // WithStrategy sets synchronization strategy.
 func WithStrategy(s core.SyncStrategy) Option {
 	return func(a *Agent) {
-		a.strategy = s
+		a.SetStrategy(s)
+	}
+}
+
+// WithGossipFanout limits each update to k randomly sampled neighbors.
+// See SetGossipFanout.
+func WithGossipFanout(k int) Option {
+	return func(a *Agent) {
+		a.SetGossipFanout(k, nil)
 	}
 }
 
@@ -1,7 +1,9 @@
 package agent_test
 
 import (
+	"fmt"
 	"math"
+	"math/rand/v2"
 	"testing"
 	"time"
 
@@ -10,7 +12,10 @@ import (
 
 	"github.com/carlisia/bio-adapt/emerge/agent"
 	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/goal"
+	"github.com/carlisia/bio-adapt/emerge/strategy"
 	"github.com/carlisia/bio-adapt/internal/config"
+	"github.com/carlisia/bio-adapt/internal/resource"
 )
 
 func TestNew(t *testing.T) {
@@ -189,6 +194,51 @@ func TestAgentSettersAndGetters(t *testing.T) {
 				assert.GreaterOrEqual(t, agent.Stubbornness(), 0.0, "Stubbornness should be clamped to >= 0")
 			},
 		},
+		{
+			name: "set natural frequency",
+			setupFn: func(agent *agent.Agent) {
+				agent.SetNaturalFrequency(80 * time.Millisecond)
+				agent.SetFrequency(120 * time.Millisecond)
+			},
+			checkFn: func(t *testing.T, agent *agent.Agent) {
+				t.Helper()
+				assert.Equal(t, 80*time.Millisecond, agent.NaturalFrequency(), "Natural frequency should survive frequency changes")
+				assert.Equal(t, 120*time.Millisecond, agent.Frequency())
+			},
+		},
+		{
+			name: "clear natural frequency",
+			setupFn: func(agent *agent.Agent) {
+				agent.SetNaturalFrequency(80 * time.Millisecond)
+				agent.SetNaturalFrequency(0)
+			},
+			checkFn: func(t *testing.T, agent *agent.Agent) {
+				t.Helper()
+				assert.Zero(t, agent.NaturalFrequency(), "Non-positive natural frequency should clear it")
+				assert.Equal(t, 80*time.Millisecond, agent.Frequency(), "Current frequency should be kept")
+			},
+		},
+		{
+			name:    "follows swarm goal by default",
+			setupFn: func(*agent.Agent) {},
+			checkFn: func(t *testing.T, agent *agent.Agent) {
+				t.Helper()
+				_, ok := agent.Goal()
+				assert.False(t, ok, "A new agent should have no goal of its own")
+			},
+		},
+		{
+			name: "set goal",
+			setupFn: func(agent *agent.Agent) {
+				agent.SetGoal(goal.MinimizeAPICalls) // The zero goal still counts as set
+			},
+			checkFn: func(t *testing.T, agent *agent.Agent) {
+				t.Helper()
+				g, ok := agent.Goal()
+				assert.True(t, ok)
+				assert.Equal(t, goal.MinimizeAPICalls, g)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -532,6 +582,121 @@ func TestAgentApplyAction(t *testing.T) {
 	}
 }
 
+func TestAgentApplyActionResult(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		energy        float64
+		tokens        float64
+		action        core.Action
+		want          agent.ActionResult
+		wantErr       error
+		wantTokensOut float64
+	}{
+		{
+			name:          "applied",
+			energy:        50,
+			tokens:        100,
+			action:        core.Action{Type: "adjust_phase", Value: 0.5, Cost: 10},
+			want:          agent.ActionResult{Success: true, Reason: agent.ActionApplied, EnergySpent: 10, EnergyRemaining: 40},
+			wantTokensOut: 90,
+		},
+		{
+			name:          "insufficient energy",
+			energy:        5,
+			tokens:        100,
+			action:        core.Action{Type: "adjust_phase", Value: 0.5, Cost: 10},
+			want:          agent.ActionResult{Reason: agent.ActionInsufficientEnergy, EnergyRemaining: 5},
+			wantErr:       core.ErrInsufficientEnergy,
+			wantTokensOut: 100,
+		},
+		{
+			name:          "unknown type",
+			energy:        50,
+			tokens:        100,
+			action:        core.Action{Type: "teleport", Cost: 10},
+			want:          agent.ActionResult{Reason: agent.ActionUnknownType, EnergyRemaining: 50},
+			wantErr:       core.ErrUnknownActionType,
+			wantTokensOut: 100,
+		},
+		{
+			name:          "rejected by resource manager",
+			energy:        50,
+			tokens:        4,
+			action:        core.Action{Type: "maintain", Cost: 10},
+			want:          agent.ActionResult{Reason: agent.ActionRejected, EnergyRemaining: 50},
+			wantErr:       core.ErrResourceExhausted,
+			wantTokensOut: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rm := resource.NewTokenManager(tt.tokens)
+			a := agent.New("test", agent.WithResourceManager(rm))
+			a.SetEnergy(tt.energy)
+			a.SetPhase(1)
+
+			got, err := a.ApplyActionResult(tt.action)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.InDelta(t, tt.wantTokensOut, rm.Available(), 1e-9, "partial grants are released")
+
+			wantPhase := 1.0
+			if got.Success {
+				wantPhase += tt.action.Value
+			}
+			assert.InDelta(t, wantPhase, a.Phase(), 1e-9)
+
+			wantFailed := uint64(1)
+			if got.Success {
+				wantFailed = 0
+			}
+			assert.Equal(t, wantFailed, a.Stats().FailedActions)
+		})
+	}
+}
+
+// TestAgentApplyActionDefaultTokens checks the default token manager, which
+// is never refilled, does not refuse actions once its tokens run out.
+func TestAgentApplyActionDefaultTokens(t *testing.T) {
+	t.Parallel()
+
+	a := agent.New("test")
+	action := core.Action{Type: "adjust_phase", Value: 0.1, Cost: 30}
+	for i := range 10 { // 300 in all, three times the default tokens
+		a.SetEnergy(100)
+		result, err := a.ApplyActionResult(action)
+		require.NoError(t, err, "call %d", i)
+		assert.True(t, result.Success, "call %d", i)
+
+		a.SetEnergy(100)
+		applied, spent, err := a.ApplyAction(action)
+		require.NoError(t, err, "call %d", i)
+		assert.True(t, applied, "call %d", i)
+		assert.InDelta(t, 30, spent, 1e-9)
+	}
+	assert.Zero(t, a.Stats().FailedActions)
+
+	// ApplyAction keeps drawing on an explicit manager without checking it
+	rm := resource.NewTokenManager(40)
+	owned := agent.New("owned", agent.WithResourceManager(rm))
+	for range 3 {
+		owned.SetEnergy(100)
+		applied, _, err := owned.ApplyAction(action)
+		require.NoError(t, err)
+		assert.True(t, applied)
+	}
+	owned.SetEnergy(100)
+	_, err := owned.ApplyActionResult(action)
+	require.ErrorIs(t, err, core.ErrResourceExhausted)
+}
+
 func TestAgentEnergyManagement(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -916,3 +1081,466 @@ func TestAgentNeighborManagement(t *testing.T) {
 		})
 	}
 }
+
+func TestInfluenceWeightedCoupling(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		pacemaker float64 // Influence of the pacemaker; the others have 0.1
+	}{
+		{"weighted", 1.0},
+		{"unweighted", 0.1},
+	}
+
+	// Fully connected swarm: a pacemaker at phase 0, the rest at π/2.
+	// Agents repeatedly move to their coupled local goal.
+	final := make(map[string]float64)
+	for _, tt := range tests {
+		agents := make([]*agent.Agent, 10)
+		for i := range agents {
+			influence, phase := 0.1, math.Pi/2
+			if i == 0 {
+				influence, phase = tt.pacemaker, 0
+			}
+			agents[i] = agent.New(fmt.Sprintf("agent-%d", i),
+				agent.WithPhase(phase), agent.WithInfluence(influence))
+		}
+		for _, a := range agents {
+			for _, b := range agents {
+				a.ConnectTo(b.ID, b)
+			}
+		}
+
+		for range 200 {
+			for _, a := range agents {
+				a.UpdateContext()
+			}
+			for _, a := range agents {
+				a.SetPhase(a.LocalGoal())
+			}
+		}
+
+		phases := make([]float64, len(agents))
+		for i, a := range agents {
+			phases[i] = a.Phase()
+		}
+		require.InDelta(t, 1.0, core.MeasureCoherence(phases), 1e-6, "%s: agents should converge", tt.name)
+		final[tt.name] = phases[1]
+	}
+
+	// The unweighted consensus sits near the mean of the initial phases;
+	// with a pacemaker it is pulled much closer to the pacemaker's phase
+	assert.Less(t, final["weighted"], final["unweighted"]-0.3,
+		"the swarm should converge toward the pacemaker's phase")
+}
+
+func TestZeroInfluenceNeighborsAreIgnored(t *testing.T) {
+	t.Parallel()
+
+	a := agent.New("a", agent.WithPhase(1.0))
+	a.SetLocalGoal(2.0)
+	silent := agent.New("silent", agent.WithPhase(3.0), agent.WithInfluence(0))
+	a.ConnectTo(silent.ID, silent)
+
+	a.UpdateContext()
+	assert.InDelta(t, 2.0, a.LocalGoal(), 1e-9, "zero-influence neighbors should not pull")
+	assert.False(t, math.IsNaN(a.LocalGoal()))
+
+	loud := agent.New("loud", agent.WithPhase(1.5), agent.WithInfluence(1))
+	a.ConnectTo(loud.ID, loud)
+	a.UpdateContext()
+	assert.Greater(t, a.LocalGoal(), 1.0, "the influential neighbor should pull the local goal toward it")
+	assert.Less(t, a.LocalGoal(), 1.5)
+}
+
+func TestGossipFanout(t *testing.T) {
+	t.Parallel()
+
+	// Fully connected agents that couple with only 3 sampled neighbors
+	run := func(seed uint64) []float64 {
+		rng := rand.New(rand.NewPCG(seed, seed))
+		agents := make([]*agent.Agent, 30)
+		for i := range agents {
+			agents[i] = agent.New(fmt.Sprintf("agent-%d", i),
+				agent.WithPhase(rng.Float64()*2*math.Pi), agent.WithInfluence(1))
+			agents[i].SetGossipFanout(3, rng.IntN)
+		}
+		for _, a := range agents {
+			for _, b := range agents {
+				a.ConnectTo(b.ID, b)
+			}
+		}
+
+		for range 300 {
+			for _, a := range agents {
+				a.UpdateContext()
+			}
+			for _, a := range agents {
+				a.SetPhase(a.LocalGoal())
+			}
+		}
+
+		phases := make([]float64, len(agents))
+		for i, a := range agents {
+			phases[i] = a.Phase()
+		}
+		return phases
+	}
+
+	phases := run(1)
+	assert.Greater(t, core.MeasureCoherence(phases), 0.99, "sampled coupling should still converge")
+	assert.Equal(t, phases, run(1), "the same random source should give the same samples")
+}
+
+func TestGossipFanoutSetting(t *testing.T) {
+	t.Parallel()
+
+	a := agent.New("a", agent.WithGossipFanout(4))
+	assert.Equal(t, 4, a.GossipFanout())
+
+	a.SetGossipFanout(0, nil)
+	assert.Equal(t, 0, a.GossipFanout(), "non-positive fanout couples with every neighbor")
+
+}
+
+func TestNeighborStorageSample(t *testing.T) {
+	t.Parallel()
+
+	ns := agent.NewNeighborStorage(4)
+	for i := range 10 {
+		b := agent.New(fmt.Sprintf("b-%d", i))
+		ns.Store(b.ID, b)
+	}
+	rng := rand.New(rand.NewPCG(7, 7))
+
+	for range 100 {
+		sample := ns.Sample(3, rng.IntN)
+		require.Len(t, sample, 3)
+		seen := make(map[string]bool)
+		for _, b := range sample {
+			assert.False(t, seen[b.ID], "samples should be distinct")
+			seen[b.ID] = true
+		}
+	}
+	assert.Len(t, ns.Sample(20, rng.IntN), 10, "oversized samples return every neighbor")
+	assert.Empty(t, ns.Sample(0, rng.IntN))
+}
+
+func TestAgentStats(t *testing.T) {
+	t.Parallel()
+
+	a := agent.New("stats")
+	assert.Zero(t, a.Stats())
+
+	goalState := core.State{Phase: math.Pi, Frequency: 100 * time.Millisecond, Coherence: 0.9}
+	for range 20 {
+		a.ProposeAdjustment(goalState)
+	}
+
+	_, _, err := a.ApplyAction(core.Action{Type: "adjust_phase", Value: 0.1, Cost: 2, Benefit: 3})
+	require.NoError(t, err)
+	_, _, err = a.ApplyAction(core.Action{Type: "maintain", Cost: 0.5, Benefit: 0.25})
+	require.NoError(t, err)
+	_, _, err = a.ApplyAction(core.Action{Type: "teleport"})
+	require.ErrorIs(t, err, core.ErrUnknownActionType)
+	_, _, err = a.ApplyAction(core.Action{Type: "adjust_phase", Cost: 1e6})
+	require.ErrorIs(t, err, core.ErrInsufficientEnergy)
+
+	stats := a.Stats()
+	assert.Equal(t, uint64(20), stats.Proposals)
+	assert.Equal(t, stats.Proposals, stats.Acceptances+stats.Rejections)
+	assert.InDelta(t, float64(stats.Acceptances)/20, stats.AcceptanceRate(), 1e-9)
+	assert.Equal(t, uint64(2), stats.FailedActions)
+	assert.InDelta(t, 2.5, stats.TotalCost, 1e-9)
+	assert.InDelta(t, 3.25, stats.TotalBenefit, 1e-9)
+
+	// Reset hands back the window and starts a new one
+	assert.Equal(t, stats, a.ResetStats())
+	assert.Zero(t, a.Stats())
+	assert.Zero(t, a.Stats().AcceptanceRate())
+
+	// Decisions and actions made outside ProposeAdjustment and ApplyAction
+	a.RecordDecision(true)
+	a.RecordDecision(false)
+	a.RecordAction(core.Action{Type: "adjust_phase", Cost: 1, Benefit: 2}, true)
+	a.RecordAction(core.Action{Type: "adjust_phase", Cost: 4, Benefit: 8}, false)
+	assert.Equal(t, agent.AgentStats{
+		Proposals:     2,
+		Acceptances:   1,
+		Rejections:    1,
+		FailedActions: 1,
+		TotalCost:     1,
+		TotalBenefit:  2,
+	}, a.Stats())
+}
+
+func TestAgentStatsConcurrent(t *testing.T) {
+	t.Parallel()
+
+	a := agent.New("stats-concurrent")
+	const workers, actions = 8, 100
+
+	done := make(chan struct{})
+	for range workers {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			for range actions {
+				_, _, _ = a.ApplyAction(core.Action{Type: "maintain", Benefit: 1})
+			}
+		}()
+	}
+	for range workers {
+		<-done
+	}
+
+	assert.InDelta(t, workers*actions, a.Stats().TotalBenefit, 1e-9, "no update should be lost")
+}
+
+// eager is a decision maker that always takes the strategy's proposal.
+type eager struct{}
+
+func (eager) Decide(_ core.State, options []core.Action) (core.Action, float64) {
+	return options[0], 1
+}
+
+func TestAgentStep(t *testing.T) {
+	t.Parallel()
+
+	neighbors := make([]*agent.Agent, 5)
+	for i := range neighbors {
+		neighbors[i] = agent.New(fmt.Sprintf("n-%d", i), agent.WithPhase(0))
+	}
+	a := agent.New("a", agent.WithPhase(1.5), agent.WithStubbornness(0), agent.WithDecisionMaker(eager{}))
+	target := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.9}
+
+	applied := 0
+	for range 50 {
+		if _, ok := a.Step(neighbors, target); ok {
+			applied++
+		}
+	}
+	assert.Positive(t, applied)
+	assert.InDelta(t, 0, core.PhaseDifference(a.Phase(), 0), 0.1, "the agent settles on its neighbors' phase")
+	assert.Zero(t, a.NeighborCount(), "stepping does not wire the agent to its neighbors")
+	for _, n := range neighbors {
+		assert.InDelta(t, 0, n.Phase(), 1e-12, "neighbors are left alone")
+	}
+
+	// Without neighbors there is no pull and the agent holds its local goal
+	lone := agent.New("lone", agent.WithPhase(2), agent.WithLocalGoal(2), agent.WithStubbornness(0),
+		agent.WithDecisionMaker(eager{}))
+	for range 10 {
+		lone.Step(nil, target)
+	}
+	assert.InDelta(t, 2, lone.Phase(), 1e-9)
+}
+
+// sure is a decision maker that takes the strategy's proposal with a
+// fixed confidence.
+type sure float64
+
+func (c sure) Decide(_ core.State, options []core.Action) (core.Action, float64) {
+	return options[0], float64(c)
+}
+
+// TestDecisionConfidence checks an agent moves only as far as its
+// decision maker is confident: each accepted move is the proposal scaled
+// by the confidence, so an unsure agent closes on its goal more slowly.
+func TestDecisionConfidence(t *testing.T) {
+	t.Parallel()
+
+	const rate = 0.5
+	target := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.9}
+	build := func(confidence float64) *agent.Agent {
+		return agent.New("a", agent.WithPhase(1.2), agent.WithLocalGoal(0), agent.WithStubbornness(0),
+			agent.WithStrategy(strategy.NewPhaseNudge(rate)), agent.WithDecisionMaker(sure(confidence)))
+	}
+
+	for _, confidence := range []float64{0.2, 1} {
+		a := build(confidence)
+		for {
+			if action, ok := a.ProposeAdjustment(target); ok {
+				assert.InDelta(t, -1.2*rate*confidence, action.Value, 1e-12, "confidence %v", confidence)
+				// The default cost model charges 2 per radian moved
+				assert.InDelta(t, 2*1.2*rate*confidence, action.Cost, 1e-12, "confidence %v", confidence)
+				break
+			}
+		}
+	}
+
+	// A cost model prices the scaled move
+	a := agent.New("a", agent.WithPhase(1.2), agent.WithLocalGoal(0), agent.WithStubbornness(0),
+		agent.WithStrategy(strategy.NewPhaseNudge(rate)), agent.WithDecisionMaker(sure(0.2)),
+		agent.WithCostModel(jumpCost{perRadian: 10}))
+	for {
+		if action, ok := a.ProposeAdjustment(target); ok {
+			assert.InDelta(t, 10*1.2*rate*0.2, action.Cost, 1e-12)
+			break
+		}
+	}
+
+	settle := func(confidence float64) float64 {
+		a := build(confidence)
+		for range 30 {
+			a.Step(nil, target)
+		}
+		return math.Abs(core.PhaseDifference(a.Phase(), 0))
+	}
+	unsure, confident := settle(0.2), settle(1)
+	assert.Less(t, confident, 1e-6)
+	assert.Greater(t, unsure, 0.04, "low confidence makes fewer and smaller moves")
+}
+
+// TestAgentCooldown checks an agent sits out the ticks after each move.
+func TestAgentCooldown(t *testing.T) {
+	t.Parallel()
+
+	target := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.9}
+	a := agent.New("a", agent.WithPhase(3), agent.WithLocalGoal(0), agent.WithStubbornness(0),
+		agent.WithStrategy(strategy.NewPhaseNudge(0.1)), agent.WithDecisionMaker(eager{}))
+	a.SetCooldown(2)
+	assert.Equal(t, 2, a.Cooldown())
+
+	moves, resting := 0, 0
+	for range 30 {
+		phase := a.Phase()
+		action, ok := a.Step(nil, target)
+		if resting > 0 {
+			assert.False(t, ok)
+			assert.Equal(t, "maintain", action.Type)
+			assert.InDelta(t, phase, a.Phase(), 0, "a resting agent keeps its phase")
+			resting--
+			continue
+		}
+		if ok {
+			moves++
+			resting = 2
+		}
+	}
+	assert.Positive(t, moves)
+	assert.LessOrEqual(t, moves, 10)
+
+	a.SetCooldown(0)
+	assert.Zero(t, a.Cooldown())
+	assert.False(t, a.Rest(), "removing the cooldown ends the rest")
+}
+
+// jumpCost charges perRadian for each radian a move covers, or a flat
+// amount per move when perRadian is 0, keeping the default benefit.
+type jumpCost struct {
+	perRadian float64
+	flat      float64
+}
+
+func (m jumpCost) Evaluate(from, to, target core.State) (float64, float64) {
+	_, benefit := core.DefaultCostModel{}.Evaluate(from, to, target)
+	return m.flat + m.perRadian*math.Abs(core.PhaseDifference(to.Phase, from.Phase)), benefit
+}
+
+// netGain takes the option with the highest benefit less cost.
+type netGain struct{}
+
+func (netGain) Decide(_ core.State, options []core.Action) (core.Action, float64) {
+	best := options[0]
+	for _, o := range options[1:] {
+		if o.Benefit-o.Cost > best.Benefit-best.Cost {
+			best = o
+		}
+	}
+	return best, 1
+}
+
+// TestCostModel checks a distance-proportional cost model steers agents to
+// small incremental adjustments: under it, an agent that jumps straight to
+// its goal is priced out, while one that nudges its way there arrives. A
+// flat price per move lets the jump through.
+func TestCostModel(t *testing.T) {
+	t.Parallel()
+
+	target := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.9}
+	settle := func(rate float64, model core.CostModel) float64 {
+		a := agent.New("a", agent.WithPhase(1.2), agent.WithLocalGoal(0), agent.WithStubbornness(0),
+			agent.WithStrategy(strategy.NewPhaseNudge(rate)), agent.WithDecisionMaker(netGain{}),
+			agent.WithCostModel(model))
+		assert.Equal(t, model, a.CostModel())
+		for range 100 {
+			a.Step(nil, target)
+		}
+		return math.Abs(core.PhaseDifference(a.Phase(), 0))
+	}
+
+	proportional := jumpCost{perRadian: 1}
+	assert.InDelta(t, 1.2, settle(1, proportional), 1e-9, "a jump costing more than it gains is never taken")
+	assert.Less(t, settle(0.3, proportional), 0.05, "small steps pay for themselves and arrive")
+	assert.Less(t, settle(1, jumpCost{flat: 0.3}), 1e-9, "at a flat price the jump is taken")
+
+	// The default pricing is the phase nudge's own
+	current := core.State{Phase: 1.2, Frequency: target.Frequency}
+	action, _ := strategy.NewPhaseNudge(0.3).Propose(current, target, core.Context{})
+	next := current
+	next.Phase += action.Value
+	cost, benefit := core.DefaultCostModel{}.Evaluate(current, next, target)
+	assert.InDelta(t, action.Cost, cost, 1e-12)
+	assert.InDelta(t, action.Benefit, benefit, 1e-12)
+}
+
+// TestGoalBlend checks an agent with a goal blend aims between the swarm
+// target and its local goal in proportion to the local weight.
+func TestGoalBlend(t *testing.T) {
+	t.Parallel()
+
+	target := core.State{Phase: math.Pi / 2, Frequency: 100 * time.Millisecond, Coherence: 0.9}
+	settle := func(opts ...agent.Option) float64 {
+		opts = append([]agent.Option{agent.WithPhase(1), agent.WithLocalGoal(0), agent.WithStubbornness(0),
+			agent.WithStrategy(strategy.NewPhaseNudge(0.3)), agent.WithDecisionMaker(netGain{}),
+			agent.WithCostModel(jumpCost{flat: 0.3})}, opts...)
+		a := agent.New("a", opts...)
+		for range 100 {
+			a.Step(nil, target)
+		}
+		return a.Phase()
+	}
+
+	assert.InDelta(t, 0, core.PhaseDifference(settle(), 0), 0.05, "without a blend the local goal alone is pursued")
+	assert.InDelta(t, 0, core.PhaseDifference(settle(agent.WithGoalBlend(1)), 0), 0.05)
+	assert.InDelta(t, math.Pi/2, settle(agent.WithGoalBlend(0)), 0.05)
+	assert.InDelta(t, math.Pi/8, settle(agent.WithGoalBlend(0.75)), 0.05)
+
+	a := agent.New("a")
+	_, ok := a.GoalBlend()
+	assert.False(t, ok)
+	a.SetGoalBlend(1.5)
+	w, ok := a.GoalBlend()
+	assert.True(t, ok)
+	assert.InDelta(t, 1.0, w, 0)
+	a.SetGoalBlend(-1)
+	w, _ = a.GoalBlend()
+	assert.InDelta(t, 0.0, w, 0)
+}
+
+// TestWatchPhase checks an agent reports each phase change to each of its
+// watchers until they are removed.
+func TestWatchPhase(t *testing.T) {
+	t.Parallel()
+
+	a := agent.New("a", agent.WithPhase(0.5))
+	var first, second [][2]float64
+	unwatchFirst := a.WatchPhase(func(oldPhase, newPhase float64) {
+		first = append(first, [2]float64{oldPhase, newPhase})
+	})
+	unwatchSecond := a.WatchPhase(func(oldPhase, newPhase float64) {
+		second = append(second, [2]float64{oldPhase, newPhase})
+	})
+	a.SetPhase(1)
+	a.SetPhase(1) // Unchanged: not reported
+	unwatchFirst()
+	unwatchFirst() // Safe to call twice
+	a.SetPhase(2)
+	unwatchSecond()
+	a.SetPhase(3)
+
+	require.Len(t, first, 1)
+	assert.Equal(t, [2]float64{0.5, 1}, first[0])
+	assert.Equal(t, [][2]float64{{0.5, 1}, {1, 2}}, second)
+}
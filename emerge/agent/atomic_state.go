@@ -16,11 +16,13 @@ type StateData struct {
 	Energy    float64       // Available energy
 	LocalGoal float64       // Preferred phase
 	Frequency time.Duration // Current frequency
+
+	NaturalFrequency time.Duration // Intrinsic frequency; zero when unset
 }
 
 // BehaviorData contains behavioral parameters.
 type BehaviorData struct {
-	Influence    float64 // Local vs global weight [0, 1]
+	Influence    float64 // Coupling weight of this agent's pull on neighbors [0, 1]
 	Stubbornness float64 // Resistance to change [0, 1]
 }
 
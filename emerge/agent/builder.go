@@ -54,17 +54,18 @@ func NewFromConfig(id string, cfg config.Agent) (*Agent, error) {
 	return New(id, opts...), nil
 }
 
-// createDecisionMaker creates a decision maker based on configuration
+// createDecisionMaker creates a decision maker based on configuration,
+// looking the type up in the decision registry
 func createDecisionMaker(cfg config.Agent) (core.DecisionMaker, error) {
-	switch cfg.DecisionMakerType {
-	case "", "simple":
-		return &decision.SimpleDecisionMaker{}, nil
-	case "adaptive":
-		// Future: could add adaptive decision maker
-		return &decision.SimpleDecisionMaker{}, nil
-	default:
-		return nil, fmt.Errorf("unknown decision maker type: %s", cfg.DecisionMakerType)
+	name := cfg.DecisionMakerType
+	if name == "" {
+		name = decision.NameSimple
+	}
+	dm, err := decision.New(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid decision maker type: %w", err)
 	}
+	return dm, nil
 }
 
 // createGoalManager creates a goal manager based on configuration
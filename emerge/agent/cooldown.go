@@ -0,0 +1,44 @@
+package agent
+
+// SetCooldown gives the agent a refractory period: after each adjustment
+// it sits out its next ticks updates, leaving its phase alone, however far
+// it is from its goal. Near convergence this damps over-correction, where
+// agents that all move at once overshoot together and ring. Unlike
+// weaker coupling, which shrinks every move, a cooldown keeps moves at
+// full size but spaces them out. Ticks <= 0 removes the cooldown. It is
+// safe to call while the agent is updating.
+func (a *Agent) SetCooldown(ticks int) {
+	a.cooldown.Store(int64(max(ticks, 0)))
+	if ticks <= 0 {
+		a.resting.Store(0)
+	}
+}
+
+// Cooldown returns the refractory period set with SetCooldown, in ticks,
+// or 0 without one.
+func (a *Agent) Cooldown() int {
+	return int(a.cooldown.Load())
+}
+
+// Rest counts one tick toward the agent's refractory period and reports
+// whether the agent must sit that tick out. Step calls it once per tick;
+// code that updates agents itself, like the swarm's loop, calls it once
+// per update before moving the agent.
+func (a *Agent) Rest() bool {
+	for {
+		left := a.resting.Load()
+		if left <= 0 {
+			return false
+		}
+		if a.resting.CompareAndSwap(left, left-1) {
+			return true
+		}
+	}
+}
+
+// StartCooldown starts the agent's refractory period after an adjustment.
+// Step starts it itself; code that moves the agent's phase on its behalf,
+// like the swarm's loop, calls it after each move.
+func (a *Agent) StartCooldown() {
+	a.resting.Store(a.cooldown.Load())
+}
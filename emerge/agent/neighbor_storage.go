@@ -1,12 +1,14 @@
 package agent
 
 import (
+	"slices"
 	"sync"
 	"sync/atomic"
 )
 
 // NeighborStorage provides an optimized storage for agent neighbors.
-// It uses a fixed-size slice for better cache locality and reduced allocations.
+// It uses a pre-allocated slice for better cache locality and reduced
+// allocations, growing only when an agent exceeds its initial capacity.
 type NeighborStorage struct {
 	// Fixed-size arrays for better cache locality
 	neighbors []*Agent // Pre-allocated slice of neighbors
@@ -42,16 +44,17 @@ func (ns *NeighborStorage) Store(id string, agent *Agent) bool {
 		}
 	}
 
-	// Add new neighbor if there's space
-	if currentCount < ns.capacity {
-		ns.ids[currentCount] = id
-		ns.neighbors[currentCount] = agent
-		atomic.AddInt32(&ns.count, 1)
-		return true
+	// Grow when full so high-degree agents (e.g. scale-free hubs) keep every link
+	if currentCount == ns.capacity {
+		ns.capacity = max(2*ns.capacity, 1)
+		ns.neighbors = append(ns.neighbors[:currentCount], make([]*Agent, ns.capacity-currentCount)...)
+		ns.ids = append(ns.ids[:currentCount], make([]string, ns.capacity-currentCount)...)
 	}
 
-	// No space available
-	return false
+	ns.ids[currentCount] = id
+	ns.neighbors[currentCount] = agent
+	atomic.AddInt32(&ns.count, 1)
+	return true
 }
 
 // Load retrieves a neighbor by ID.
@@ -124,6 +127,38 @@ func (ns *NeighborStorage) All() []*Agent {
 	return result
 }
 
+// Sample returns up to k distinct neighbors chosen uniformly at random,
+// using intn(n) to draw an int in [0, n). It costs O(k) regardless of the
+// neighbor count (Floyd's algorithm), so it bounds per-update work for
+// densely connected agents. It returns all neighbors when k >= Count.
+func (ns *NeighborStorage) Sample(k int, intn func(n int) int) []*Agent {
+	ns.mu.RLock()
+	defer ns.mu.RUnlock()
+
+	currentCount := int(atomic.LoadInt32(&ns.count))
+	if k >= currentCount {
+		result := make([]*Agent, currentCount)
+		copy(result, ns.neighbors[:currentCount])
+		return result
+	}
+	if k <= 0 {
+		return nil
+	}
+
+	// Fanouts are small, so a linear scan beats a set
+	chosen := make([]int, 0, k)
+	result := make([]*Agent, 0, k)
+	for j := currentCount - k; j < currentCount; j++ {
+		idx := intn(j + 1)
+		if slices.Contains(chosen, idx) {
+			idx = j
+		}
+		chosen = append(chosen, idx)
+		result = append(result, ns.neighbors[idx])
+	}
+	return result
+}
+
 // Clear removes all neighbors.
 func (ns *NeighborStorage) Clear() {
 	ns.mu.Lock()
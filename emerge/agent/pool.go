@@ -102,8 +102,9 @@ func (a *Agent) Reset(id string) {
 		return true
 	})
 
-	// Reset context
+	// Reset context and statistics
 	a.context.Store(core.Context{})
+	a.stats.reset()
 
 	// Keep decision maker if set, otherwise use default
 	if a.decider == nil {
@@ -0,0 +1,152 @@
+package agent
+
+import (
+	"math"
+	"sync/atomic"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+// AgentStats counts an agent's decisions and the actions it applied.
+// Every ProposeAdjustment call is a proposal and ends in either an
+// acceptance or a rejection. Costs and benefits add up the actions
+// ApplyAction carried out; actions it refused, for lack of energy, an
+// unknown type or a resource manager's grant, are counted as failed.
+// Decisions and moves made without those calls, such as by the swarm's
+// synchronization loop, are counted through RecordDecision and
+// RecordAction.
+//
+//nolint:revive // AgentStats reads better than Stats at call sites outside the package
+type AgentStats struct {
+	Proposals     uint64
+	Acceptances   uint64
+	Rejections    uint64
+	FailedActions uint64
+	TotalCost     float64
+	TotalBenefit  float64
+}
+
+// Add returns the sum of two sets of statistics.
+func (s AgentStats) Add(other AgentStats) AgentStats {
+	return AgentStats{
+		Proposals:     s.Proposals + other.Proposals,
+		Acceptances:   s.Acceptances + other.Acceptances,
+		Rejections:    s.Rejections + other.Rejections,
+		FailedActions: s.FailedActions + other.FailedActions,
+		TotalCost:     s.TotalCost + other.TotalCost,
+		TotalBenefit:  s.TotalBenefit + other.TotalBenefit,
+	}
+}
+
+// AcceptanceRate returns the fraction of proposals accepted, or 0 before
+// the first proposal.
+func (s AgentStats) AcceptanceRate() float64 {
+	if s.Proposals == 0 {
+		return 0
+	}
+	return float64(s.Acceptances) / float64(s.Proposals)
+}
+
+// agentStats holds the counters behind AgentStats. Each counter is updated
+// atomically, so agents can be updated concurrently.
+type agentStats struct {
+	proposals     atomic.Uint64
+	acceptances   atomic.Uint64
+	rejections    atomic.Uint64
+	failedActions atomic.Uint64
+	totalCost     atomicFloat
+	totalBenefit  atomicFloat
+}
+
+// recordProposal counts a proposal and its outcome.
+func (s *agentStats) recordProposal(accepted bool) {
+	s.proposals.Add(1)
+	if accepted {
+		s.acceptances.Add(1)
+	} else {
+		s.rejections.Add(1)
+	}
+}
+
+// recordApplied adds an applied action's cost and benefit.
+func (s *agentStats) recordApplied(cost, benefit float64) {
+	s.totalCost.add(cost)
+	s.totalBenefit.add(benefit)
+}
+
+// snapshot reads the counters. Counters are read one at a time, so a
+// snapshot taken during updates may be off by the updates in flight.
+func (s *agentStats) snapshot() AgentStats {
+	return AgentStats{
+		Proposals:     s.proposals.Load(),
+		Acceptances:   s.acceptances.Load(),
+		Rejections:    s.rejections.Load(),
+		FailedActions: s.failedActions.Load(),
+		TotalCost:     s.totalCost.load(),
+		TotalBenefit:  s.totalBenefit.load(),
+	}
+}
+
+// reset zeroes the counters and returns what they held. Each counter is
+// swapped atomically, so every update lands in exactly one window.
+func (s *agentStats) reset() AgentStats {
+	return AgentStats{
+		Proposals:     s.proposals.Swap(0),
+		Acceptances:   s.acceptances.Swap(0),
+		Rejections:    s.rejections.Swap(0),
+		FailedActions: s.failedActions.Swap(0),
+		TotalCost:     s.totalCost.swap(0),
+		TotalBenefit:  s.totalBenefit.swap(0),
+	}
+}
+
+// atomicFloat is a float64 updated atomically through its bits.
+type atomicFloat struct {
+	bits atomic.Uint64
+}
+
+func (f *atomicFloat) load() float64 {
+	return math.Float64frombits(f.bits.Load())
+}
+
+func (f *atomicFloat) swap(v float64) float64 {
+	return math.Float64frombits(f.bits.Swap(math.Float64bits(v)))
+}
+
+func (f *atomicFloat) add(delta float64) {
+	for {
+		old := f.bits.Load()
+		next := math.Float64bits(math.Float64frombits(old) + delta)
+		if f.bits.CompareAndSwap(old, next) {
+			return
+		}
+	}
+}
+
+// RecordDecision counts a decision made for the agent without
+// ProposeAdjustment as a proposal, accepted or rejected.
+func (a *Agent) RecordDecision(accepted bool) {
+	a.stats.recordProposal(accepted)
+}
+
+// RecordAction records the outcome of an action carried out without
+// ApplyAction: an applied action adds its cost and benefit, one that was
+// not counts as failed.
+func (a *Agent) RecordAction(action core.Action, applied bool) {
+	if !applied {
+		a.stats.failedActions.Add(1)
+		return
+	}
+	a.stats.recordApplied(action.Cost, action.Benefit)
+}
+
+// Stats returns the agent's decision and action statistics.
+func (a *Agent) Stats() AgentStats {
+	return a.stats.snapshot()
+}
+
+// ResetStats zeroes the agent's statistics and returns the ones collected
+// since the previous reset, for callers that keep windowed statistics.
+func (a *Agent) ResetStats() AgentStats {
+	return a.stats.reset()
+}
@@ -0,0 +1,21 @@
+package core
+
+import "context"
+
+// Coordinator is a running collection of synchronizing agents, seen from
+// outside. Monitoring and visualization code can be written against it
+// instead of a concrete swarm type; emerge's swarm.Swarm and its client
+// both implement it.
+type Coordinator interface {
+	// Run synchronizes the agents until ctx is done or the work is over.
+	Run(ctx context.Context) error
+
+	// MeasureCoherence returns the agents' current coherence in [0, 1].
+	MeasureCoherence() float64
+
+	// DisruptAgents scrambles the phases of the given fraction of agents.
+	DisruptAgents(fraction float64)
+
+	// Size returns the number of agents.
+	Size() int
+}
@@ -0,0 +1,25 @@
+package core
+
+import "math"
+
+// CostModel prices a proposed move. Decision makers choose among actions
+// by their Cost and Benefit; a cost model decides where those numbers come
+// from, so the economics can match the system being coordinated, e.g.
+// making large phase jumps cost more when reconfiguration is expensive.
+type CostModel interface {
+	// Evaluate returns the cost of moving from one state to another, and
+	// the benefit of the move toward target.
+	Evaluate(from, to, target State) (cost, benefit float64)
+}
+
+// DefaultCostModel is the economics of the built-in phase nudge: a move
+// costs 2 per radian of phase it moves, and is worth up to 1.5, falling
+// linearly to 0 as the starting phase gets half a cycle from the target.
+type DefaultCostModel struct{}
+
+// Evaluate implements CostModel.
+func (DefaultCostModel) Evaluate(from, to, target State) (cost, benefit float64) {
+	moved := math.Abs(PhaseDifference(to.Phase, from.Phase))
+	remaining := math.Abs(PhaseDifference(target.Phase, from.Phase))
+	return moved * 2.0, (1.0 - remaining/math.Pi) * 1.5
+}
@@ -1,10 +1,45 @@
 package core
 
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
 // DecisionMaker allows agents to make autonomous choices.
 // This interface enables upgrading from simple to complex decision-making
 // (e.g., machine learning models) without changing the agent structure.
 type DecisionMaker interface {
 	// Decide chooses an action based on current state and options.
-	// Returns confidence level [0, 1] with the decision.
+	// Returns confidence level [0, 1] with the decision; agents scale the
+	// chosen adjustment by it, so low confidence means small moves. Agents
+	// pass options in a fixed order, their strategy's proposal first and
+	// "maintain" second. Implementations should break ties between
+	// equally good options by CompareActions rather than by position, so
+	// a seeded run makes the same choices whatever order options come in.
 	Decide(state State, options []Action) (Action, float64)
 }
+
+// CompareActions orders actions for breaking ties deterministically: the
+// cheaper action first, then by type, then the smaller value, then the
+// larger benefit. It returns a negative number when a comes first, a
+// positive one when b does, and zero for identical actions.
+func CompareActions(a, b Action) int {
+	if c := cmp.Compare(a.Cost, b.Cost); c != 0 {
+		return c
+	}
+	if c := strings.Compare(a.Type, b.Type); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.Value, b.Value); c != 0 {
+		return c
+	}
+	return cmp.Compare(b.Benefit, a.Benefit)
+}
+
+// SortActions sorts options into CompareActions order, for callers that
+// gather options in an unstable order, e.g. from a map, before passing
+// them to Decide.
+func SortActions(options []Action) {
+	slices.SortStableFunc(options, CompareActions)
+}
@@ -0,0 +1,36 @@
+package core
+
+import (
+	"math"
+	"time"
+)
+
+// MeasureFrequencyCoherence measures how tightly frequencies cluster.
+// It returns 1 when all frequencies are identical and falls toward 0 as
+// they spread: one minus the coefficient of variation (standard deviation
+// over mean), clamped to [0, 1]. An empty input, or one whose mean is not
+// positive, has no coherence and returns 0.
+func MeasureFrequencyCoherence(freqs []time.Duration) float64 {
+	if len(freqs) == 0 {
+		return 0
+	}
+
+	n := float64(len(freqs))
+	mean := 0.0
+	for _, f := range freqs {
+		mean += float64(f)
+	}
+	mean /= n
+	if mean <= 0 {
+		return 0
+	}
+
+	variance := 0.0
+	for _, f := range freqs {
+		d := float64(f) - mean
+		variance += d * d
+	}
+	variance /= n
+
+	return math.Max(0, 1-math.Sqrt(variance)/mean)
+}
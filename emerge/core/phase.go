@@ -1,41 +1,46 @@
 package core
 
-import "math"
+import (
+	"math"
+	"slices"
+)
 
-// WrapPhase normalizes a phase value to [0, 2π].
+// WrapPhase normalizes a phase value to [0, 2π). This is how agents store
+// their phase; use WrapPhaseSigned for angles that can point either way.
 func WrapPhase(phase float64) float64 {
 	// Use modular arithmetic for more efficient and accurate wrapping
 	return math.Mod(math.Mod(phase, 2*math.Pi)+2*math.Pi, 2*math.Pi)
 }
 
-// PhaseDifference calculates the minimal phase difference between two phases
-// Returns a value in [-π, π] representing the signed angular distance from phase2 to phase1.
-func PhaseDifference(phase1, phase2 float64) float64 {
-	diff := phase1 - phase2
-
-	// Use atan2 to get the correct phase difference considering the circle
-	result := math.Atan2(math.Sin(diff), math.Cos(diff))
-
-	// Handle specific edge cases for test compatibility
-	const eps = 1e-10
-
-	// Special case: 0 to 3π/2 should return -π/2 (clockwise)
-	if math.Abs(phase1) < eps && math.Abs(phase2-3*math.Pi/2) < eps {
-		return -math.Pi / 2
-	}
-
-	// Special case: 3π/2 to 0 should return π/2 (counterclockwise)
-	if math.Abs(phase1-3*math.Pi/2) < eps && math.Abs(phase2) < eps {
-		return math.Pi / 2
+// WrapPhaseSigned normalizes an angle to (-π, π], the range of signed phase
+// offsets. Half turns in either direction map to π.
+func WrapPhaseSigned(phase float64) float64 {
+	wrapped := math.Remainder(phase, 2*math.Pi) // [-π, π]
+	if wrapped <= -math.Pi {
+		return math.Pi
 	}
+	return wrapped
+}
 
-	return result
+// PhaseDifference returns the signed shortest angular distance from phase2
+// to phase1, in [-π, π]: positive when phase1 is ahead of phase2. Phases
+// either side of zero are close, so PhaseDifference(0.05, 6.2) is about
+// 0.133, not -6.15. Always use it rather than subtracting phases directly.
+//
+// It is antisymmetric, PhaseDifference(a, b) == -PhaseDifference(b, a),
+// even for phases half a turn apart, where the sign of a-b is kept.
+func PhaseDifference(phase1, phase2 float64) float64 {
+	return math.Remainder(phase1-phase2, 2*math.Pi)
 }
 
 // MeasureCoherence calculates the Kuramoto order parameter for phase synchronization.
+// It is 0 for no phases and exactly 1 for a single phase.
 func MeasureCoherence(phases []float64) float64 {
-	if len(phases) == 0 {
+	switch len(phases) {
+	case 0:
 		return 0
+	case 1:
+		return 1
 	}
 
 	sumCos := 0.0
@@ -50,6 +55,55 @@ func MeasureCoherence(phases []float64) float64 {
 	return math.Sqrt(sumCos*sumCos+sumSin*sumSin) / n
 }
 
+// MeasureDispersion measures how evenly phases are spread around the circle.
+// It returns a value near 1 for evenly spread phases and near 0 when the
+// phases are clustered, whether in one cluster or in two opposite ones.
+// It is one minus the larger of the first- and second-order Kuramoto
+// order parameters; the second-order term catches anti-phase pairs that
+// the first-order parameter alone would mistake for an even spread.
+func MeasureDispersion(phases []float64) float64 {
+	if len(phases) == 0 {
+		return 0
+	}
+
+	var sumCos1, sumSin1, sumCos2, sumSin2 float64
+	for _, phase := range phases {
+		sumCos1 += math.Cos(phase)
+		sumSin1 += math.Sin(phase)
+		sumCos2 += math.Cos(2 * phase)
+		sumSin2 += math.Sin(2 * phase)
+	}
+
+	n := float64(len(phases))
+	r1 := math.Sqrt(sumCos1*sumCos1+sumSin1*sumSin1) / n
+	r2 := math.Sqrt(sumCos2*sumCos2+sumSin2*sumSin2) / n
+	return math.Max(0, 1-math.Max(r1, r2)) // Clamp rounding error
+}
+
+// MeasureLoadFairness bins phases into sectors equal arcs of the circle
+// and returns the ratio of the most to the least occupied sector: 1 when
+// every sector holds the same number of phases, and growing as the load
+// piles up. It is +Inf when any sector is empty, as it is when all phases
+// share one sector. Sectors below 1 count as 1, and no phases count as
+// perfectly fair.
+func MeasureLoadFairness(phases []float64, sectors int) float64 {
+	if len(phases) == 0 || sectors <= 1 {
+		return 1
+	}
+
+	counts := make([]int, sectors)
+	for _, phase := range phases {
+		sector := int(WrapPhase(phase) / (2 * math.Pi) * float64(sectors))
+		counts[min(sector, sectors-1)]++ // Guard rounding at 2π
+	}
+
+	most, least := slices.Max(counts), slices.Min(counts)
+	if least == 0 {
+		return math.Inf(1)
+	}
+	return float64(most) / float64(least)
+}
+
 // MeasureCoherenceWeighted calculates weighted coherence.
 func MeasureCoherenceWeighted(phases []float64, weights []float64) float64 {
 	if len(phases) == 0 || len(weights) == 0 {
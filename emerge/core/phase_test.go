@@ -0,0 +1,131 @@
+package core_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+func TestWrapPhase(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		phase float64
+		want  float64
+	}{
+		{"zero", 0, 0},
+		{"inside range", 1, 1},
+		{"full turn", 2 * math.Pi, 0},
+		{"just under full turn", 2*math.Pi - 1e-9, 2*math.Pi - 1e-9},
+		{"just over full turn", 2*math.Pi + 0.5, 0.5},
+		{"negative", -0.5, 2*math.Pi - 0.5},
+		{"negative full turn", -2 * math.Pi, 0},
+		{"several turns", 7 * math.Pi, math.Pi},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := core.WrapPhase(tt.phase)
+			assert.InDelta(t, tt.want, got, 1e-9)
+			assert.GreaterOrEqual(t, got, 0.0)
+			assert.Less(t, got, 2*math.Pi)
+		})
+	}
+}
+
+func TestWrapPhaseSigned(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		phase float64
+		want  float64
+	}{
+		{"zero", 0, 0},
+		{"positive half turn", math.Pi, math.Pi},
+		{"negative half turn maps to positive", -math.Pi, math.Pi},
+		{"just past half turn", math.Pi + 0.1, -math.Pi + 0.1},
+		{"just short of negative half turn", -math.Pi + 0.1, -math.Pi + 0.1},
+		{"full turn", 2 * math.Pi, 0},
+		{"three half turns", 3 * math.Pi, math.Pi},
+		{"negative three quarter turn", -3 * math.Pi / 2, math.Pi / 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := core.WrapPhaseSigned(tt.phase)
+			assert.InDelta(t, tt.want, got, 1e-9)
+			assert.Greater(t, got, -math.Pi)
+			assert.LessOrEqual(t, got, math.Pi)
+		})
+	}
+}
+
+func TestPhaseDifference(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		phase1, phase2 float64
+		want           float64
+	}{
+		{"equal", 1, 1, 0},
+		{"ahead", 1, 0.5, 0.5},
+		{"behind", 0.5, 1, -0.5},
+		{"across zero forward", 0.05, 6.2, 0.05 + 2*math.Pi - 6.2},
+		{"across zero backward", 6.2, 0.05, -(0.05 + 2*math.Pi - 6.2)},
+		{"quarter turn across zero", 0, 3 * math.Pi / 2, math.Pi / 2},
+		{"quarter turn back across zero", 3 * math.Pi / 2, 0, -math.Pi / 2},
+		{"opposite", math.Pi, 0, math.Pi},
+		{"opposite reversed", 0, math.Pi, -math.Pi},
+		{"unwrapped inputs", 4*math.Pi + 0.3, -2*math.Pi + 0.1, 0.2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.InDelta(t, tt.want, core.PhaseDifference(tt.phase1, tt.phase2), 1e-9)
+			assert.InDelta(t, -tt.want, core.PhaseDifference(tt.phase2, tt.phase1), 1e-9, "should be antisymmetric")
+		})
+	}
+}
+
+//nolint:paralleltest // AllocsPerRun must not overlap with other tests
+func TestPhaseDifferenceDoesNotAllocate(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = core.PhaseDifference(0.05, 6.2)
+		_ = core.WrapPhaseSigned(-7)
+	})
+	assert.Zero(t, allocs)
+}
+
+func TestMeasureLoadFairness(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		phases  []float64
+		sectors int
+		want    float64
+	}{
+		{"no phases", nil, 4, 1},
+		{"one sector", []float64{0, 1, 2}, 1, 1},
+		{"even", []float64{0.1, 1.7, 3.2, 4.8}, 4, 1},
+		{"uneven", []float64{0.1, 0.2, 1.7, 3.2, 4.8}, 4, 2},
+		{"wraps", []float64{0.1, 2*math.Pi + 0.2, 1.7, 3.2, -1.5}, 4, 2},
+		{"empty sector", []float64{0.1, 0.2, 1.7}, 4, math.Inf(1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, core.MeasureLoadFairness(tt.phases, tt.sectors))
+		})
+	}
+}
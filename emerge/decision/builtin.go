@@ -0,0 +1,122 @@
+package decision
+
+import (
+	"math"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+// maintain is the action chosen when no option is acceptable.
+func maintain(state core.State) (core.Action, float64) {
+	return core.Action{Type: "maintain"}, state.Coherence
+}
+
+// confidence maps a benefit/cost score to [0, 1] the way
+// SimpleDecisionMaker does.
+func confidence(score float64) float64 {
+	return math.Min(math.Max(score/2.0, 0), 1.0)
+}
+
+// better reports whether action, scoring score, beats best, scoring
+// bestScore: by a higher score, or on a tie by coming first in
+// core.CompareActions order, so the choice never depends on the order of
+// the options.
+func better(score, bestScore float64, action, best core.Action) bool {
+	if score != bestScore {
+		return score > bestScore
+	}
+	return core.CompareActions(action, best) < 0
+}
+
+// RiskAverse never takes an action costing more than MaxCost. Among the
+// affordable actions it picks the best benefit/cost ratio; when none is
+// affordable it maintains, with the swarm's coherence as confidence.
+type RiskAverse struct {
+	MaxCost float64
+}
+
+// NewRiskAverse creates a risk-averse decision maker that rejects actions
+// costing more than maxCost.
+func NewRiskAverse(maxCost float64) *RiskAverse {
+	return &RiskAverse{MaxCost: maxCost}
+}
+
+// Decide selects the best affordable action.
+func (r *RiskAverse) Decide(state core.State, options []core.Action) (core.Action, float64) {
+	bestScore := -math.MaxFloat64
+	var best core.Action
+	found := false
+	for _, action := range options {
+		if action.Cost > r.MaxCost {
+			continue
+		}
+		score := action.Benefit / math.Max(action.Cost, 0.1)
+		if better(score, bestScore, action, best) {
+			bestScore, best, found = score, action, true
+		}
+	}
+	if !found {
+		return maintain(state)
+	}
+	return best, confidence(bestScore)
+}
+
+// Aggressive goes for the biggest payoff: it picks the action with the
+// highest benefit less CostWeight times its cost. With a CostWeight below 1
+// it accepts expensive actions that a benefit/cost ratio would pass over.
+// Confidence is the chosen action's benefit, clamped to [0, 1].
+type Aggressive struct {
+	CostWeight float64
+}
+
+// NewAggressive creates an aggressive decision maker that discounts cost
+// by costWeight, typically well below 1.
+func NewAggressive(costWeight float64) *Aggressive {
+	return &Aggressive{CostWeight: costWeight}
+}
+
+// Decide selects the action with the best net benefit.
+func (a *Aggressive) Decide(state core.State, options []core.Action) (core.Action, float64) {
+	if len(options) == 0 {
+		return maintain(state)
+	}
+
+	best := options[0]
+	bestScore := -math.MaxFloat64
+	for _, action := range options {
+		score := action.Benefit - a.CostWeight*action.Cost
+		if better(score, bestScore, action, best) {
+			bestScore, best = score, action
+		}
+	}
+	return best, math.Min(math.Max(best.Benefit, 0), 1.0)
+}
+
+// Adaptive is aggressive while the swarm is far from synchronized and
+// risk-averse once it is close: below Threshold coherence it decides like
+// Aggressive, at or above it like RiskAverse, so it moves fast when there
+// is much to gain and protects coherence once it has been reached.
+type Adaptive struct {
+	Threshold  float64
+	Aggressive Aggressive
+	RiskAverse RiskAverse
+}
+
+// NewAdaptive creates an adaptive decision maker that switches from
+// aggressive to risk-averse at the given coherence, rejecting actions
+// costing more than maxCost once it does.
+func NewAdaptive(threshold, maxCost float64) *Adaptive {
+	return &Adaptive{
+		Threshold:  threshold,
+		Aggressive: Aggressive{CostWeight: 0.1},
+		RiskAverse: RiskAverse{MaxCost: maxCost},
+	}
+}
+
+// Decide delegates to the aggressive or risk-averse maker by coherence.
+func (a *Adaptive) Decide(state core.State, options []core.Action) (core.Action, float64) {
+	if state.Coherence < a.Threshold {
+		return a.Aggressive.Decide(state, options)
+	}
+	return a.RiskAverse.Decide(state, options)
+}
@@ -713,3 +713,61 @@ func BenchmarkSimpleDecisionMakerZeroCost(b *testing.B) {
 		dm.Decide(state, options)
 	}
 }
+
+// TestTieBreaking checks every decision maker picks the same action from
+// equally scored options whatever order they arrive in.
+func TestTieBreaking(t *testing.T) {
+	t.Parallel()
+
+	state := core.State{Frequency: 100 * time.Millisecond, Coherence: 0.5}
+	// Equal benefit/cost ratios and equal net benefits at a cost weight of 0
+	cheap := core.Action{Type: "adjust_phase", Value: 0.1, Cost: 0.5, Benefit: 1}
+	dear := core.Action{Type: "adjust_phase", Value: 0.2, Cost: 1, Benefit: 2}
+	// Equal in every score, differing only by type
+	nudge := core.Action{Type: "adjust_phase", Value: 0.1, Cost: 0.5, Benefit: 1.5}
+	retune := core.Action{Type: "change_freq", Value: 0.1, Cost: 0.5, Benefit: 1.5}
+
+	makers := map[string]core.DecisionMaker{
+		"simple":      &SimpleDecisionMaker{},
+		"risk averse": NewRiskAverse(5),
+		"adaptive":    NewAdaptive(0.1, 5), // Risk-averse at this coherence
+	}
+	for name, dm := range makers {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			for range 10 {
+				chosen, _ := dm.Decide(state, []core.Action{dear, cheap})
+				assert.Equal(t, cheap, chosen, "the cheaper of equal ratios wins")
+				chosen, _ = dm.Decide(state, []core.Action{cheap, dear})
+				assert.Equal(t, cheap, chosen)
+
+				chosen, _ = dm.Decide(state, []core.Action{retune, nudge})
+				assert.Equal(t, nudge, chosen, "equal cost falls back to type")
+				chosen, _ = dm.Decide(state, []core.Action{nudge, retune})
+				assert.Equal(t, nudge, chosen)
+			}
+		})
+	}
+
+	t.Run("aggressive", func(t *testing.T) {
+		t.Parallel()
+
+		dm := NewAggressive(0)
+		tied := core.Action{Type: "adjust_phase", Value: 0.2, Cost: 1, Benefit: 1}
+		for range 10 {
+			chosen, _ := dm.Decide(state, []core.Action{tied, cheap})
+			assert.Equal(t, cheap, chosen)
+			chosen, _ = dm.Decide(state, []core.Action{cheap, tied})
+			assert.Equal(t, cheap, chosen)
+		}
+	})
+
+	t.Run("sorted options", func(t *testing.T) {
+		t.Parallel()
+
+		options := []core.Action{retune, dear, nudge, cheap}
+		core.SortActions(options)
+		assert.Equal(t, []core.Action{nudge, cheap, retune, dear}, options)
+	})
+}
@@ -1,4 +1,8 @@
 // Package decision provides decision-making implementations for agent autonomy.
 // Decision makers evaluate current state and available actions to choose
 // optimal behaviors based on confidence levels and resource constraints.
+//
+// Built-in decision makers (simple, risk-averse, aggressive, adaptive) and
+// custom ones added with Register can be selected by name, through
+// config.Agent.DecisionMakerType or swarm.WithDecisionMaker.
 package decision
@@ -0,0 +1,86 @@
+package decision
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+// Factory creates a fresh decision maker instance. Factories are called
+// once per agent, so decision makers that keep state are never shared
+// between agents.
+type Factory func() core.DecisionMaker
+
+// Registry errors.
+var (
+	ErrUnknownDecisionMaker    = errors.New("unknown decision maker")
+	ErrDecisionMakerRegistered = errors.New("decision maker already registered")
+)
+
+// Names of the built-in decision makers. These are stable and are the
+// values config.Agent.DecisionMakerType accepts out of the box.
+const (
+	NameSimple     = "simple"
+	NameRiskAverse = "risk_averse"
+	NameAggressive = "aggressive"
+	NameAdaptive   = "adaptive"
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Factory{
+		NameSimple:     func() core.DecisionMaker { return &SimpleDecisionMaker{} },
+		NameRiskAverse: func() core.DecisionMaker { return NewRiskAverse(1.0) },
+		NameAggressive: func() core.DecisionMaker { return NewAggressive(0.1) },
+		NameAdaptive:   func() core.DecisionMaker { return NewAdaptive(0.5, 1.0) },
+	}
+)
+
+// Register makes a decision maker available by name, e.g. for
+// swarm.WithDecisionMaker. Names must be unique; registering a name twice,
+// including a built-in name, returns ErrDecisionMakerRegistered.
+func Register(name string, factory Factory) error {
+	if name == "" {
+		return errors.New("decision maker name must not be empty")
+	}
+	if factory == nil {
+		return fmt.Errorf("decision maker %q: factory must not be nil", name)
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, ok := registry[name]; ok {
+		return fmt.Errorf("%w: %s", ErrDecisionMakerRegistered, name)
+	}
+	registry[name] = factory
+	return nil
+}
+
+// New creates a new instance of the named decision maker.
+func New(name string) (core.DecisionMaker, error) {
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownDecisionMaker, name)
+	}
+	return factory(), nil
+}
+
+// Names returns the names of all registered decision makers in sorted order.
+func Names() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,92 @@
+package decision
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+type firstOption struct{ calls int }
+
+func (f *firstOption) Decide(state core.State, options []core.Action) (core.Action, float64) {
+	f.calls++
+	if len(options) == 0 {
+		return core.Action{Type: "maintain"}, state.Coherence
+	}
+	return options[0], 1
+}
+
+func TestRegistryBuiltins(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{NameSimple, NameRiskAverse, NameAggressive, NameAdaptive} {
+		dm, err := New(name)
+		require.NoError(t, err, name)
+		assert.NotNil(t, dm, name)
+		assert.Contains(t, Names(), name)
+	}
+}
+
+func TestRegistryRegister(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, Register("registry_test_first", func() core.DecisionMaker {
+		return &firstOption{}
+	}))
+
+	a, err := New("registry_test_first")
+	require.NoError(t, err)
+	b, err := New("registry_test_first")
+	require.NoError(t, err)
+	assert.NotSame(t, a, b, "each call should get a fresh instance")
+
+	err = Register("registry_test_first", func() core.DecisionMaker { return &firstOption{} })
+	require.ErrorIs(t, err, ErrDecisionMakerRegistered)
+	require.ErrorIs(t, Register(NameSimple, func() core.DecisionMaker { return &firstOption{} }), ErrDecisionMakerRegistered)
+	require.Error(t, Register("", func() core.DecisionMaker { return &firstOption{} }))
+	require.Error(t, Register("registry_test_nil", nil))
+
+	_, err = New("registry_test_missing")
+	require.ErrorIs(t, err, ErrUnknownDecisionMaker)
+}
+
+func TestBuiltinBiases(t *testing.T) {
+	t.Parallel()
+
+	cheap := core.Action{Type: "adjust_phase", Value: 0.1, Cost: 0.5, Benefit: 0.6}
+	costly := core.Action{Type: "adjust_phase", Value: 0.8, Cost: 5, Benefit: 3}
+	options := []core.Action{cheap, costly}
+	low := core.State{Coherence: 0.2}
+	high := core.State{Coherence: 0.9}
+
+	tests := []struct {
+		name    string
+		dm      core.DecisionMaker
+		state   core.State
+		options []core.Action
+		want    core.Action
+	}{
+		{"risk-averse takes the affordable action", NewRiskAverse(1), low, options, cheap},
+		{"risk-averse rejects high-cost actions", NewRiskAverse(1), low, []core.Action{costly}, core.Action{Type: "maintain"}},
+		{"risk-averse with a high cap takes the best ratio", NewRiskAverse(10), low, options, cheap},
+		{"aggressive takes the biggest payoff", NewAggressive(0.1), low, options, costly},
+		{"aggressive weighing cost fully prefers the cheap action", NewAggressive(1), low, options, cheap},
+		{"aggressive with no options maintains", NewAggressive(0.1), low, nil, core.Action{Type: "maintain"}},
+		{"adaptive is aggressive below threshold", NewAdaptive(0.5, 1), low, options, costly},
+		{"adaptive is risk-averse at threshold", NewAdaptive(0.5, 1), high, options, cheap},
+		{"adaptive rejects high-cost actions when synchronized", NewAdaptive(0.5, 1), high, []core.Action{costly}, core.Action{Type: "maintain"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, confidence := tt.dm.Decide(tt.state, tt.options)
+			assert.Equal(t, tt.want, got)
+			assert.GreaterOrEqual(t, confidence, 0.0)
+			assert.LessOrEqual(t, confidence, 1.0)
+		})
+	}
+}
@@ -24,7 +24,7 @@ func (*SimpleDecisionMaker) Decide(state core.State, options []core.Action) (cor
 		cost := math.Max(action.Cost, 0.1)
 		score := action.Benefit / cost
 
-		if score > bestScore {
+		if better(score, bestScore, action, bestAction) {
 			bestScore = score
 			bestAction = action
 		}
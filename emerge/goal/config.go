@@ -2,7 +2,11 @@
 // Goals represent WHAT you want to achieve with the swarm.
 package goal
 
-// No imports needed
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 // Type represents a business objective that the swarm should achieve.
 type Type int
@@ -61,6 +65,9 @@ func (g Type) String() string {
 	case AdaptToTraffic:
 		return "Traffic Adaptation"
 	default:
+		if spec, ok := g.Spec(); ok {
+			return spec.Name
+		}
 		return "Custom Goal"
 	}
 }
@@ -89,34 +96,57 @@ func (g Type) ShortKey() string {
 	}
 }
 
-// IsRecommendedForSize returns whether this goal works well with the given swarm size.
-func (g Type) IsRecommendedForSize(agentCount int) bool {
-	switch g {
-	case MinimizeAPICalls:
-		// Works well at all sizes - batching benefits increase with size
-		return true
-	case DistributeLoad:
-		// Needs enough agents to distribute load effectively (20+)
-		return agentCount >= 20
-	case ReachConsensus:
-		// 50-1000 agents is optimal - too hard at huge sizes
-		return agentCount >= 50 && agentCount <= 1000
-	case MinimizeLatency:
-		// Better at smaller sizes for quick response (≤200)
-		return agentCount <= 200
-	case SaveEnergy:
-		// Energy savings harder to coordinate at large sizes (≤200)
-		return agentCount <= 200
-	case MaintainRhythm:
-		// Works at all sizes
-		return true
-	case RecoverFromFailure:
-		// Better with redundancy, needs at least 20 agents
-		return agentCount >= 20
-	case AdaptToTraffic:
-		// Needs enough agents to handle varying traffic (20-1000)
-		return agentCount >= 20 && agentCount <= 1000
-	default:
-		return true
+// PrefersDispersion reports whether this goal wants agents spread out in
+// phase rather than synchronized. Convergence for these goals is judged by
+// dispersion instead of coherence.
+func (g Type) PrefersDispersion() bool {
+	return g == DistributeLoad || g == RecoverFromFailure
+}
+
+// names maps each goal to its stable identifier, as used in configuration
+// files.
+var names = map[Type]string{
+	MinimizeAPICalls:   "minimize_api_calls",
+	DistributeLoad:     "distribute_load",
+	ReachConsensus:     "reach_consensus",
+	MinimizeLatency:    "minimize_latency",
+	SaveEnergy:         "save_energy",
+	MaintainRhythm:     "maintain_rhythm",
+	RecoverFromFailure: "recover_from_failure",
+	AdaptToTraffic:     "adapt_to_traffic",
+}
+
+// Parse returns the goal with the given identifier, e.g.
+// "minimize_api_calls", or a custom goal by name (see Define). Matching
+// ignores case.
+func Parse(name string) (Type, error) {
+	if g, ok := builtinByName(name); ok {
+		return g, nil
+	}
+	if g, ok := customByName(name); ok {
+		return g, nil
+	}
+	if strings.TrimSpace(name) == "" {
+		return 0, errors.New("empty goal")
+	}
+	return 0, fmt.Errorf("unknown goal %q", name)
+}
+
+// builtinByName looks up a built-in goal by its identifier, ignoring case.
+func builtinByName(name string) (Type, bool) {
+	for g, n := range names {
+		if strings.EqualFold(n, strings.TrimSpace(name)) {
+			return g, true
+		}
+	}
+	return 0, false
+}
+
+// ID returns the goal's stable identifier, the inverse of Parse. Custom
+// goals are identified by their name. It is empty for unknown goals.
+func (g Type) ID() string {
+	if spec, ok := g.Spec(); ok {
+		return spec.Name
 	}
+	return names[g]
 }
@@ -0,0 +1,136 @@
+package goal
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// customBase is the first Type handed out to custom goals, well clear of
+// the built-in goals.
+const customBase Type = 1 << 16
+
+// Custom goal errors.
+var (
+	ErrInvalidSpec = errors.New("invalid goal spec")
+	ErrGoalDefined = errors.New("goal already defined")
+)
+
+// GoalSpec describes a custom goal as a set of phase slots. The swarm forms
+// one synchronized cluster per slot, each agent joining the slot nearest
+// its phase, so the slots fix the phase relationships between clusters.
+// Slots are offsets from the swarm's target phase: {0, 2π/3, 4π/3} asks for
+// a three-phase rotation.
+//
+//nolint:revive // GoalSpec reads better than Spec at call sites outside the package
+type GoalSpec struct {
+	Name  string    // Unique identifier, as returned by Type.ID
+	Slots []float64 // Phase offsets of the clusters (radians)
+}
+
+// EvenSlots returns n slots spread evenly around the circle, starting at 0.
+func EvenSlots(n int) []float64 {
+	slots := make([]float64, n)
+	for i := range slots {
+		slots[i] = 2 * math.Pi * float64(i) / float64(n)
+	}
+	return slots
+}
+
+// validate checks that the spec names its goal and has usable slots.
+func (spec GoalSpec) validate() error {
+	if spec.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidSpec)
+	}
+	if len(spec.Slots) == 0 {
+		return fmt.Errorf("%w: goal %q needs at least one slot", ErrInvalidSpec, spec.Name)
+	}
+	for i, s := range spec.Slots {
+		if math.IsNaN(s) || math.IsInf(s, 0) {
+			return fmt.Errorf("%w: goal %q has slot %v", ErrInvalidSpec, spec.Name, s)
+		}
+		for _, other := range spec.Slots[:i] {
+			if math.Abs(math.Remainder(s-other, 2*math.Pi)) < 1e-9 {
+				return fmt.Errorf("%w: goal %q has slot %v twice", ErrInvalidSpec, spec.Name, s)
+			}
+		}
+	}
+	if _, ok := builtinByName(spec.Name); ok {
+		return fmt.Errorf("%w: %s is a built-in goal", ErrGoalDefined, spec.Name)
+	}
+	return nil
+}
+
+var (
+	customMu sync.RWMutex
+	customs  []GoalSpec
+)
+
+// Define adds a custom goal and returns its Type, which the swarm accepts
+// wherever it takes a goal, e.g. swarm.For and swarm.WithGoal. Defining
+// the same spec again returns the same Type; reusing a name for different
+// slots returns ErrGoalDefined.
+func Define(spec GoalSpec) (Type, error) {
+	if err := spec.validate(); err != nil {
+		return 0, err
+	}
+
+	customMu.Lock()
+	defer customMu.Unlock()
+
+	for i, c := range customs {
+		if !strings.EqualFold(c.Name, spec.Name) {
+			continue
+		}
+		if !slices.Equal(c.Slots, spec.Slots) {
+			return 0, fmt.Errorf("%w: %s", ErrGoalDefined, spec.Name)
+		}
+		return customBase + Type(i), nil
+	}
+	customs = append(customs, GoalSpec{Name: spec.Name, Slots: slices.Clone(spec.Slots)})
+	return customBase + Type(len(customs)-1), nil
+}
+
+// Custom is like Define but panics if the spec is invalid, for goals
+// declared inline, e.g. swarm.For(goal.Custom(spec)).
+func Custom(spec GoalSpec) Type {
+	g, err := Define(spec)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// Spec returns the spec of a custom goal. It reports false for built-in
+// goals.
+func (g Type) Spec() (GoalSpec, bool) {
+	if g < customBase {
+		return GoalSpec{}, false
+	}
+	customMu.RLock()
+	defer customMu.RUnlock()
+
+	i := int(g - customBase)
+	if i >= len(customs) {
+		return GoalSpec{}, false
+	}
+	spec := customs[i]
+	spec.Slots = slices.Clone(spec.Slots)
+	return spec, true
+}
+
+// customByName looks up a custom goal by its name.
+func customByName(name string) (Type, bool) {
+	customMu.RLock()
+	defer customMu.RUnlock()
+
+	for i, c := range customs {
+		if strings.EqualFold(c.Name, strings.TrimSpace(name)) {
+			return customBase + Type(i), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,63 @@
+package goal_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/goal"
+)
+
+func TestDefineCustomGoal(t *testing.T) {
+	t.Parallel()
+
+	spec := goal.GoalSpec{Name: "goal_test_three_phase", Slots: goal.EvenSlots(3)}
+	g, err := goal.Define(spec)
+	require.NoError(t, err)
+
+	got, ok := g.Spec()
+	require.True(t, ok)
+	assert.Equal(t, spec, got)
+	assert.Equal(t, "goal_test_three_phase", g.ID())
+	assert.Equal(t, "goal_test_three_phase", g.String())
+	assert.False(t, g.PrefersDispersion())
+
+	parsed, err := goal.Parse("GOAL_TEST_THREE_PHASE")
+	require.NoError(t, err)
+	assert.Equal(t, g, parsed)
+
+	// Defining the same spec again is harmless; changing its slots is not
+	assert.Equal(t, g, goal.Custom(spec))
+	_, err = goal.Define(goal.GoalSpec{Name: spec.Name, Slots: goal.EvenSlots(2)})
+	require.ErrorIs(t, err, goal.ErrGoalDefined)
+
+	_, ok = goal.MinimizeAPICalls.Spec()
+	assert.False(t, ok, "built-in goals have no spec")
+	assert.InDeltaSlice(t, []float64{0, 2 * math.Pi / 3, 4 * math.Pi / 3}, goal.EvenSlots(3), 1e-9)
+}
+
+func TestDefineCustomGoalInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		spec goal.GoalSpec
+		err  error
+	}{
+		{"no name", goal.GoalSpec{Slots: []float64{0}}, goal.ErrInvalidSpec},
+		{"no slots", goal.GoalSpec{Name: "goal_test_empty"}, goal.ErrInvalidSpec},
+		{"NaN slot", goal.GoalSpec{Name: "goal_test_nan", Slots: []float64{math.NaN()}}, goal.ErrInvalidSpec},
+		{"duplicate slot", goal.GoalSpec{Name: "goal_test_dup", Slots: []float64{0, 2 * math.Pi}}, goal.ErrInvalidSpec},
+		{"built-in name", goal.GoalSpec{Name: "save_energy", Slots: []float64{0}}, goal.ErrGoalDefined},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := goal.Define(tt.spec)
+			require.ErrorIs(t, err, tt.err)
+			assert.Panics(t, func() { goal.Custom(tt.spec) })
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/carlisia/bio-adapt/emerge/core"
 	"github.com/carlisia/bio-adapt/emerge/goal"
@@ -846,3 +847,23 @@ func BenchmarkWeightedGoalManagerWrapping(b *testing.B) {
 		gm.Blend(local, global, weight)
 	}
 }
+
+func TestParseGoalID(t *testing.T) {
+	t.Parallel()
+
+	for g := goal.MinimizeAPICalls; g <= goal.AdaptToTraffic; g++ {
+		parsed, err := goal.Parse(g.ID())
+		require.NoError(t, err, g.String())
+		assert.Equal(t, g, parsed)
+	}
+
+	parsed, err := goal.Parse(" Minimize_API_Calls ")
+	require.NoError(t, err)
+	assert.Equal(t, goal.MinimizeAPICalls, parsed)
+
+	_, err = goal.Parse("")
+	require.Error(t, err)
+	_, err = goal.Parse("unknown")
+	require.Error(t, err)
+	assert.Empty(t, goal.Type(99).ID())
+}
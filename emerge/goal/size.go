@@ -0,0 +1,87 @@
+package goal
+
+import (
+	"fmt"
+
+	"github.com/carlisia/bio-adapt/emerge/scale"
+)
+
+// sizeRange is the span of swarm sizes a goal works well with, and why.
+// A zero bound is open.
+type sizeRange struct {
+	min, max int
+	why      string
+}
+
+// String describes the range, e.g. "50–1000 agents".
+func (r sizeRange) String() string {
+	switch {
+	case r.min == 0 && r.max == 0:
+		return "any number of agents"
+	case r.max == 0:
+		return fmt.Sprintf("%d+ agents", r.min)
+	case r.min == 0:
+		return fmt.Sprintf("up to %d agents", r.max)
+	default:
+		return fmt.Sprintf("%d–%d agents", r.min, r.max)
+	}
+}
+
+// contains reports whether agentCount falls in the range.
+func (r sizeRange) contains(agentCount int) bool {
+	return agentCount >= r.min && (r.max == 0 || agentCount <= r.max)
+}
+
+// sizeRanges gives the recommended swarm sizes of each built-in goal.
+// Goals not listed, custom goals included, work at any size.
+var sizeRanges = map[Type]sizeRange{
+	MinimizeAPICalls:   {why: "batching benefits increase with size"},
+	DistributeLoad:     {min: 20, why: "load needs enough agents to spread across"},
+	ReachConsensus:     {min: 50, max: 1000, why: "agreement gets too hard at huge sizes"},
+	MinimizeLatency:    {max: 200, why: "smaller swarms respond more quickly"},
+	SaveEnergy:         {max: 200, why: "energy savings are harder to coordinate at large sizes"},
+	MaintainRhythm:     {why: "frequency locking holds at all sizes"},
+	RecoverFromFailure: {min: 20, why: "healing needs redundancy"},
+	AdaptToTraffic:     {min: 20, max: 1000, why: "varying traffic needs enough agents to absorb it"},
+}
+
+// sizeRange returns the goal's recommended swarm sizes.
+func (g Type) sizeRange() sizeRange {
+	if r, ok := sizeRanges[g]; ok {
+		return r
+	}
+	return sizeRange{why: "no size preference"}
+}
+
+// IsRecommendedForSize returns whether this goal works well with the given swarm size.
+func (g Type) IsRecommendedForSize(agentCount int) bool {
+	return g.sizeRange().contains(agentCount)
+}
+
+// RecommendedScales returns the scales, smallest first, whose default
+// agent count this goal works well with, e.g. small, medium and large for
+// ReachConsensus.
+func (g Type) RecommendedScales() []scale.Size {
+	var sizes []scale.Size
+	for _, s := range scale.Sizes() {
+		if g.IsRecommendedForSize(s.DefaultAgentCount()) {
+			sizes = append(sizes, s)
+		}
+	}
+	return sizes
+}
+
+// SuggestScale returns the scale to configure a swarm of agentCount agents
+// with for this goal, and a human-readable reason, e.g.
+//
+//	Consensus Building works best with 50–1000 agents (agreement gets too hard
+//	at huge sizes); 2000 agents is a huge swarm; large (1000 agents) is the
+//	nearest recommended size
+//
+// It is the count's own scale when the goal recommends that scale, and
+// otherwise the nearest one it does (see scale.SuggestForAgentCount).
+func (g Type) SuggestScale(agentCount int) (scale.Size, string) {
+	r := g.sizeRange()
+	size, reason := scale.SuggestForAgentCount(agentCount, g.RecommendedScales())
+	return size, fmt.Sprintf("%s works best with %s (%s); %s", g, r, r.why, reason)
+}
@@ -0,0 +1,70 @@
+package goal_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/carlisia/bio-adapt/emerge/goal"
+	"github.com/carlisia/bio-adapt/emerge/scale"
+)
+
+func TestRecommendedScales(t *testing.T) {
+	t.Parallel()
+
+	all := scale.Sizes()
+	tests := []struct {
+		goal goal.Type
+		want []scale.Size
+	}{
+		{goal.MinimizeAPICalls, all},
+		{goal.DistributeLoad, all},
+		{goal.ReachConsensus, []scale.Size{scale.Small, scale.Medium, scale.Large}},
+		{goal.MinimizeLatency, []scale.Size{scale.Tiny, scale.Small, scale.Medium}},
+		{goal.SaveEnergy, []scale.Size{scale.Tiny, scale.Small, scale.Medium}},
+		{goal.MaintainRhythm, all},
+		{goal.RecoverFromFailure, all},
+		{goal.AdaptToTraffic, []scale.Size{scale.Tiny, scale.Small, scale.Medium, scale.Large}},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.goal.RecommendedScales(), tt.goal.String())
+	}
+}
+
+func TestSuggestScale(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		goal        goal.Type
+		agents      int
+		recommended bool
+		want        scale.Size
+	}{
+		{"consensus below range", goal.ReachConsensus, 10, false, scale.Small},
+		{"consensus lower bound", goal.ReachConsensus, 50, true, scale.Small},
+		{"consensus in range", goal.ReachConsensus, 500, true, scale.Large},
+		{"consensus upper bound", goal.ReachConsensus, 1000, true, scale.Large},
+		{"consensus above range", goal.ReachConsensus, 5000, false, scale.Large},
+		{"latency in range", goal.MinimizeLatency, 200, true, scale.Medium},
+		{"latency above range", goal.MinimizeLatency, 800, false, scale.Medium},
+		{"load below range", goal.DistributeLoad, 19, false, scale.Tiny},
+		{"load in range", goal.DistributeLoad, 5000, true, scale.Huge},
+		{"traffic above range", goal.AdaptToTraffic, 1500, false, scale.Large},
+		{"rhythm any size", goal.MaintainRhythm, 3, true, scale.Tiny},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.recommended, tt.goal.IsRecommendedForSize(tt.agents))
+			got, reason := tt.goal.SuggestScale(tt.agents)
+			assert.Equal(t, tt.want, got)
+			assert.Contains(t, reason, tt.goal.String())
+		})
+	}
+
+	_, reason := goal.ReachConsensus.SuggestScale(5000)
+	assert.Equal(t, "Consensus Building works best with 50–1000 agents (agreement gets too hard at huge sizes); "+
+		"5000 agents is a huge swarm; large (1000 agents) is the nearest recommended size", reason)
+}
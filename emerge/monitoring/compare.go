@@ -0,0 +1,145 @@
+package monitoring
+
+// RunRecord is what a convergence run leaves behind, for comparing runs
+// with CompareRuns. The headless simulation runner produces them; for a
+// run watched by a Monitor, NewRunRecord builds one from its history.
+type RunRecord struct {
+	Name          string    // Labels the run, e.g. by strategy or coupling
+	Target        float64   // Coherence the run worked toward
+	Coherence     []float64 // Coherence after each tick
+	TicksToTarget int       // First tick at the target, 1-based; 0 if never reached
+	EnergyUsed    float64   // Energy the swarm spent over the run, net of recharge
+}
+
+// NewRunRecord builds a record from a run's coherence after each tick,
+// e.g. Monitor.History, taking the first sample at or above target as the
+// tick the target was reached.
+func NewRunRecord(name string, coherence []float64, target float64) RunRecord {
+	r := RunRecord{Name: name, Target: target, Coherence: coherence}
+	for i, c := range coherence {
+		if c >= target {
+			r.TicksToTarget = i + 1
+			break
+		}
+	}
+	return r
+}
+
+// Converged reports whether the run reached its target.
+func (r RunRecord) Converged() bool {
+	return r.TicksToTarget > 0
+}
+
+// FinalCoherence returns the coherence the run ended on, or 0 for a run
+// with no ticks.
+func (r RunRecord) FinalCoherence() float64 {
+	if len(r.Coherence) == 0 {
+		return 0
+	}
+	return r.Coherence[len(r.Coherence)-1]
+}
+
+// Stability scores how steady the run's coherence was over its last 20
+// ticks, from 1 for steady toward 0, as ConvergenceMonitor.Stability does.
+func (r RunRecord) Stability() float64 {
+	return stability(r.Coherence)
+}
+
+// Winner names the better of two compared runs.
+type Winner int
+
+const (
+	// Tie means neither run did better.
+	Tie Winner = iota
+	// RunA means the first run did better.
+	RunA
+	// RunB means the second run did better.
+	RunB
+)
+
+// String returns "tie", "a" or "b".
+func (w Winner) String() string {
+	switch w {
+	case RunA:
+		return "a"
+	case RunB:
+		return "b"
+	default:
+		return "tie"
+	}
+}
+
+// Comparison is the difference between two runs, a and b. Deltas are b's
+// value less a's, so a positive delta means b's is higher.
+type Comparison struct {
+	A, B string // The runs' names
+
+	// Faster is the run that reached its target in fewer ticks. A run that
+	// reached it beats one that never did.
+	Faster Winner
+	// Speedup is how much sooner the faster run reached its target, as a
+	// percentage of the slower run's ticks; 0 unless both did.
+	Speedup float64
+
+	TicksDelta     int     // Ticks to target; 0 unless both reached it
+	CoherenceDelta float64 // Final coherence
+	EnergyDelta    float64 // Energy used
+	StabilityDelta float64 // Stability
+
+	// Winner is the better run overall: the faster one, or if neither is,
+	// the one that ended more coherent, or if neither did, the one that
+	// used less energy.
+	Winner Winner
+}
+
+// CompareRuns compares two runs, e.g. two clones of a swarm run with
+// different coupling strengths.
+func CompareRuns(a, b RunRecord) Comparison {
+	c := Comparison{
+		A:              a.Name,
+		B:              b.Name,
+		CoherenceDelta: b.FinalCoherence() - a.FinalCoherence(),
+		EnergyDelta:    b.EnergyUsed - a.EnergyUsed,
+		StabilityDelta: b.Stability() - a.Stability(),
+	}
+
+	switch {
+	case a.Converged() && b.Converged():
+		c.TicksDelta = b.TicksToTarget - a.TicksToTarget
+		c.Faster = better(-c.TicksDelta)
+		slower := max(a.TicksToTarget, b.TicksToTarget)
+		c.Speedup = 100 * float64(abs(c.TicksDelta)) / float64(slower)
+	case a.Converged():
+		c.Faster = RunA
+	case b.Converged():
+		c.Faster = RunB
+	}
+
+	c.Winner = c.Faster
+	if c.Winner == Tie {
+		c.Winner = better(c.CoherenceDelta)
+	}
+	if c.Winner == Tie {
+		c.Winner = better(-c.EnergyDelta)
+	}
+	return c
+}
+
+// better names the winner by a delta where positive favors b.
+func better[T int | float64](delta T) Winner {
+	switch {
+	case delta > 0:
+		return RunB
+	case delta < 0:
+		return RunA
+	default:
+		return Tie
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
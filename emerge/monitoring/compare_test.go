@@ -0,0 +1,64 @@
+package monitoring_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/carlisia/bio-adapt/emerge/monitoring"
+)
+
+func TestCompareRuns(t *testing.T) {
+	t.Parallel()
+
+	// approach returns n ticks of coherence closing on 1 at the given rate.
+	approach := func(n int, rate float64) []float64 {
+		samples := make([]float64, n)
+		for i := range samples {
+			samples[i] = 1 - 0.8*math.Pow(1-rate, float64(i+1))
+		}
+		return samples
+	}
+	fast := monitoring.NewRunRecord("K=2", approach(60, 0.2), 0.9)
+	slow := monitoring.NewRunRecord("K=0.5", approach(60, 0.05), 0.9)
+	fast.EnergyUsed, slow.EnergyUsed = 30, 20
+	assert.Equal(t, 10, fast.TicksToTarget)
+	assert.Equal(t, 41, slow.TicksToTarget)
+
+	c := monitoring.CompareRuns(slow, fast)
+	assert.Equal(t, monitoring.RunB, c.Faster)
+	assert.Equal(t, monitoring.RunB, c.Winner, "the faster run wins despite spending more energy")
+	assert.Equal(t, "b", c.Winner.String())
+	assert.Equal(t, -31, c.TicksDelta)
+	assert.InDelta(t, 100*31.0/41, c.Speedup, 1e-9)
+	assert.Positive(t, c.CoherenceDelta)
+	assert.InDelta(t, 10, c.EnergyDelta, 1e-9)
+	assert.Equal(t, "K=0.5", c.A)
+	assert.Equal(t, "K=2", c.B)
+
+	// Swapping the runs flips every verdict and delta
+	r := monitoring.CompareRuns(fast, slow)
+	assert.Equal(t, monitoring.RunA, r.Winner)
+	assert.Equal(t, -c.TicksDelta, r.TicksDelta)
+	assert.InDelta(t, -c.CoherenceDelta, r.CoherenceDelta, 1e-12)
+	assert.InDelta(t, c.Speedup, r.Speedup, 1e-12)
+
+	// A run that never gets there loses to one that does
+	stuck := monitoring.NewRunRecord("stuck", approach(60, 0.01), 0.9)
+	assert.False(t, stuck.Converged())
+	c = monitoring.CompareRuns(slow, stuck)
+	assert.Equal(t, monitoring.RunA, c.Faster)
+	assert.Zero(t, c.TicksDelta)
+	assert.Zero(t, c.Speedup)
+
+	// Neither converging, the more coherent run wins; then the cheaper one
+	jittery := monitoring.NewRunRecord("jittery", []float64{0.5, 0.7, 0.5, 0.7}, 0.9)
+	steady := monitoring.NewRunRecord("steady", []float64{0.6, 0.6, 0.6, 0.7}, 0.9)
+	c = monitoring.CompareRuns(jittery, steady)
+	assert.Equal(t, monitoring.Tie, c.Faster)
+	assert.Equal(t, monitoring.Tie, c.Winner)
+	assert.Positive(t, c.StabilityDelta)
+	steady.EnergyUsed = 1
+	assert.Equal(t, monitoring.RunA, monitoring.CompareRuns(jittery, steady).Winner)
+}
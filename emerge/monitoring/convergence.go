@@ -136,29 +136,35 @@ func (m *ConvergenceMonitor) Stability() float64 {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	if len(m.history) < 2 {
+	return stability(m.history)
+}
+
+// stability scores the last 20 samples of history from 1 (steady) toward
+// 0 (fluctuating). Fewer than two samples count as steady.
+func stability(history []float64) float64 {
+	if len(history) < 2 {
 		return 1.0
 	}
 
 	// Calculate variance over recent history
 	samples := 20
-	if len(m.history) < samples {
-		samples = len(m.history)
+	if len(history) < samples {
+		samples = len(history)
 	}
 
-	startIdx := len(m.history) - samples
+	startIdx := len(history) - samples
 
 	// Calculate mean
 	var sum float64
-	for i := startIdx; i < len(m.history); i++ {
-		sum += m.history[i]
+	for i := startIdx; i < len(history); i++ {
+		sum += history[i]
 	}
 	mean := sum / float64(samples)
 
 	// Calculate variance
 	var variance float64
-	for i := startIdx; i < len(m.history); i++ {
-		diff := m.history[i] - mean
+	for i := startIdx; i < len(history); i++ {
+		diff := history[i] - mean
 		variance += diff * diff
 	}
 	variance /= float64(samples)
@@ -259,6 +265,18 @@ func (m *ConvergenceMonitor) Statistics() map[string]any {
 	return stats
 }
 
+// SetTarget changes the target state and the coherence level considered
+// converged. History is kept; convergence is judged again on the next
+// measurement.
+func (m *ConvergenceMonitor) SetTarget(target core.State, convergenceThreshold float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.targetState = target
+	m.convergenceAt = convergenceThreshold
+	m.convergedTime = nil
+}
+
 // Reset clears the monitor for a new convergence run.
 func (m *ConvergenceMonitor) Reset() {
 	m.mu.Lock()
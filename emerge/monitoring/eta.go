@@ -0,0 +1,151 @@
+package monitoring
+
+import (
+	"math"
+	"time"
+)
+
+// Unreachable is the ETA EstimateTimeToTarget returns when the trend never
+// reaches the target: the longest Duration, so it sorts after every real
+// estimate.
+const Unreachable = time.Duration(math.MaxInt64)
+
+// ETA fitting limits.
+const (
+	etaWindow     = 20 // Most recent samples the trend is fitted to
+	minETASamples = 4  // Fewest samples a trend is fitted to
+)
+
+// EstimateTimeToTarget predicts how long after the last of samples, taken
+// every interval, coherence will reach target. It returns the estimate and
+// a confidence from 0 to 1, the share of the recent samples' variance the
+// fitted trend explains.
+//
+// The trend is fitted to the last 20 samples as an exponential approach to
+// an asymptote, c(t) = A - B·q^t, the shape convergence usually takes, or
+// as a straight line if that fits better. If coherence has already reached
+// the target it returns 0 with full confidence. If the trend is flat or
+// falling, its asymptote lies below the target, or there are fewer than 4
+// samples, it returns Unreachable with zero confidence.
+func EstimateTimeToTarget(history []float64, target float64, interval time.Duration) (time.Duration, float64) {
+	if len(history) < minETASamples || interval <= 0 {
+		return Unreachable, 0
+	}
+	samples := history[max(0, len(history)-etaWindow):]
+	last := len(samples) - 1
+	if samples[last] >= target {
+		return 0, 1
+	}
+
+	// A straight line, unless an exponential approach explains more
+	slope, intercept, r2 := fitLine(samples, func(i int) float64 { return float64(i) })
+	q, asymptote, scale, qr2 := fitApproach(samples)
+	if qr2 <= r2 || scale <= 0 {
+		if slope <= 0 {
+			return Unreachable, 0
+		}
+		steps := (target-intercept)/slope - float64(last)
+		return stepsToDuration(steps, interval), r2
+	}
+
+	if asymptote <= target {
+		return Unreachable, 0
+	}
+	steps := math.Log((asymptote-target)/scale)/math.Log(q) - float64(last)
+	return stepsToDuration(steps, interval), qr2
+}
+
+// fitApproach fits samples as c = A - B·q^i, returning q, the asymptote A,
+// the scale B and the coefficient of determination. Given q the fit is a
+// line in q^i, so q is searched on a log-spaced grid of time constants,
+// from half a sample to ten thousand, then refined by golden-section
+// search around the best one.
+func fitApproach(samples []float64) (q, asymptote, scale, r2 float64) {
+	fit := func(q float64) (float64, float64, float64) {
+		slope, intercept, fitR2 := fitLine(samples, func(i int) float64 { return math.Pow(q, float64(i)) })
+		return intercept, -slope, fitR2
+	}
+	ratio := func(logTau float64) float64 { return math.Exp(-math.Exp(-logTau)) }
+
+	const steps = 100
+	lo, hi := math.Log(0.5), math.Log(10000)
+	step := (hi - lo) / steps
+	best, bestR2 := lo, -1.0
+	for i := range steps + 1 {
+		logTau := lo + float64(i)*step
+		if _, _, r2 := fit(ratio(logTau)); r2 > bestR2 {
+			best, bestR2 = logTau, r2
+		}
+	}
+
+	a, b := best-step, best+step
+	golden := (math.Sqrt(5) - 1) / 2
+	for range 40 {
+		c, d := b-golden*(b-a), a+golden*(b-a)
+		_, _, rc := fit(ratio(c))
+		_, _, rd := fit(ratio(d))
+		if rc >= rd {
+			b = d
+		} else {
+			a = c
+		}
+	}
+	q = ratio((a + b) / 2)
+	asymptote, scale, r2 = fit(q)
+	return q, asymptote, scale, r2
+}
+
+// fitLine fits samples against x(i) by least squares, returning the slope,
+// the intercept, and the coefficient of determination clamped to [0, 1].
+func fitLine(samples []float64, x func(i int) float64) (slope, intercept, r2 float64) {
+	n := float64(len(samples))
+	meanX, meanY := 0.0, 0.0
+	for i, y := range samples {
+		meanX += x(i)
+		meanY += y
+	}
+	meanX /= n
+	meanY /= n
+
+	sxy, sxx, syy := 0.0, 0.0, 0.0
+	for i, y := range samples {
+		dx, dy := x(i)-meanX, y-meanY
+		sxy += dx * dy
+		sxx += dx * dx
+		syy += dy * dy
+	}
+	if sxx == 0 {
+		return 0, meanY, 0
+	}
+	slope = sxy / sxx
+	intercept = meanY - slope*meanX
+	if syy == 0 {
+		return slope, intercept, 0
+	}
+	return slope, intercept, math.Max(0, math.Min(1, sxy*sxy/(sxx*syy)))
+}
+
+// stepsToDuration converts a number of intervals to a duration, at least 0.
+func stepsToDuration(steps float64, interval time.Duration) time.Duration {
+	eta := math.Max(0, steps) * float64(interval)
+	if eta >= float64(Unreachable) {
+		return Unreachable
+	}
+	return time.Duration(eta)
+}
+
+// ETA predicts how long until coherence reaches target from the coherence
+// history, as EstimateTimeToTarget does, taking the interval from the
+// samples' timestamps.
+func (m *Monitor) ETA(target float64) (time.Duration, float64) {
+	samples := m.Samples()
+	if len(samples) < 2 {
+		return Unreachable, 0
+	}
+	values := make([]float64, len(samples))
+	for i, s := range samples {
+		values[i] = s.Coherence
+	}
+	interval := samples[len(samples)-1].Time.Sub(samples[0].Time) / time.Duration(len(samples)-1)
+	return EstimateTimeToTarget(values, target, interval)
+}
@@ -0,0 +1,115 @@
+package monitoring_test
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/carlisia/bio-adapt/emerge/monitoring"
+)
+
+// approach returns n samples taken every interval of coherence rising from
+// start toward asymptote with time constant tau.
+func approach(n int, interval, tau time.Duration, start, asymptote float64) []float64 {
+	samples := make([]float64, n)
+	for i := range samples {
+		t := float64(time.Duration(i) * interval)
+		samples[i] = asymptote - (asymptote-start)*math.Exp(-t/float64(tau))
+	}
+	return samples
+}
+
+func TestEstimateTimeToTarget(t *testing.T) {
+	t.Parallel()
+
+	const interval = 100 * time.Millisecond
+	tau := 2 * time.Second
+	samples := approach(30, interval, tau, 0.2, 0.95)
+
+	// Coherence reaches 0.9 at tau·ln(0.75/0.05), after the last sample at 2.9s
+	want := time.Duration(float64(tau)*math.Log(0.75/0.05)) - 29*interval
+
+	t.Run("exponential approach", func(t *testing.T) {
+		t.Parallel()
+
+		eta, confidence := monitoring.EstimateTimeToTarget(samples, 0.9, interval)
+		assert.InEpsilon(t, want, eta, 0.05)
+		assert.Greater(t, confidence, 0.99)
+	})
+
+	t.Run("noisy approach", func(t *testing.T) {
+		t.Parallel()
+
+		rng := rand.New(rand.NewPCG(1, 2))
+		noisy := make([]float64, len(samples))
+		for i, c := range samples {
+			noisy[i] = c + (rng.Float64()-0.5)*0.002
+		}
+		eta, confidence := monitoring.EstimateTimeToTarget(noisy, 0.9, interval)
+		assert.InEpsilon(t, want, eta, 0.2)
+		assert.Greater(t, confidence, 0.9)
+	})
+
+	t.Run("straight line", func(t *testing.T) {
+		t.Parallel()
+
+		line := make([]float64, 10)
+		for i := range line {
+			line[i] = 0.1 + 0.05*float64(i)
+		}
+		eta, confidence := monitoring.EstimateTimeToTarget(line, 0.8, interval)
+		assert.InEpsilon(t, 5*interval, eta, 1e-6, "0.55 to 0.8 at 0.05 per sample")
+		assert.InDelta(t, 1, confidence, 1e-9)
+	})
+
+	t.Run("already reached", func(t *testing.T) {
+		t.Parallel()
+
+		eta, confidence := monitoring.EstimateTimeToTarget(samples, 0.5, interval)
+		assert.Zero(t, eta)
+		assert.InDelta(t, 1, confidence, 1e-9)
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		t.Parallel()
+
+		falling := make([]float64, len(samples))
+		for i, c := range samples {
+			falling[i] = 1 - c
+		}
+		for name, history := range map[string][]float64{
+			"asymptote below the target": samples,
+			"flat":                       {0.5, 0.5, 0.5, 0.5, 0.5},
+			"falling":                    falling,
+			"too few samples":            samples[:3],
+		} {
+			eta, confidence := monitoring.EstimateTimeToTarget(history, 0.97, interval)
+			assert.Equal(t, monitoring.Unreachable, eta, name)
+			assert.Zero(t, confidence, name)
+		}
+	})
+}
+
+func TestMonitorETA(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		m := monitoring.New()
+		eta, confidence := m.ETA(0.9)
+		assert.Equal(t, monitoring.Unreachable, eta)
+		assert.Zero(t, confidence)
+
+		for _, c := range approach(30, 100*time.Millisecond, 2*time.Second, 0.2, 0.95) {
+			m.RecordSample(c)
+			time.Sleep(100 * time.Millisecond)
+		}
+		want := time.Duration(float64(2*time.Second)*math.Log(0.75/0.05)) - 2900*time.Millisecond
+		eta, confidence = m.ETA(0.9)
+		assert.InEpsilon(t, want, eta, 0.05)
+		assert.Greater(t, confidence, 0.99)
+	})
+}
@@ -0,0 +1,120 @@
+package monitoring_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/monitoring"
+)
+
+func TestMonitorHistoryLimit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		opts  []monitoring.Option
+		want  int
+		first float64
+	}{
+		{"default limit", nil, 100, 50},
+		{"custom limit", []monitoring.Option{monitoring.WithHistoryLimit(10)}, 10, 140},
+		{"unbounded", []monitoring.Option{monitoring.WithHistoryLimit(0)}, 150, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := monitoring.New(tt.opts...)
+			for i := range 150 {
+				m.RecordSample(float64(i))
+			}
+
+			history := m.History()
+			require.Len(t, history, tt.want)
+			assert.InDelta(t, tt.first, history[0], 1e-9, "oldest samples should be dropped first")
+			assert.InDelta(t, 149.0, m.Latest(), 1e-9)
+		})
+	}
+}
+
+func TestMonitorWriteCSV(t *testing.T) {
+	t.Parallel()
+
+	m := monitoring.New()
+	before := time.Now()
+	m.RecordSample(0.25)
+	m.RecordSample(0.5)
+
+	var buf bytes.Buffer
+	require.NoError(t, m.WriteCSV(&buf))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{"time", "coherence"}, records[0])
+
+	var last time.Time
+	for i, want := range []float64{0.25, 0.5} {
+		ts, err := time.Parse(time.RFC3339Nano, records[i+1][0])
+		require.NoError(t, err)
+		assert.False(t, ts.Before(before.Truncate(time.Nanosecond)), "timestamps should come from recording")
+		assert.False(t, ts.Before(last), "timestamps should be in order")
+		last = ts
+
+		c, err := strconv.ParseFloat(records[i+1][1], 64)
+		require.NoError(t, err)
+		assert.InDelta(t, want, c, 1e-12)
+	}
+}
+
+func TestMonitorWriteJSON(t *testing.T) {
+	t.Parallel()
+
+	m := monitoring.New()
+	m.RecordSample(0.75)
+
+	var buf bytes.Buffer
+	require.NoError(t, m.WriteJSON(&buf))
+
+	var samples []monitoring.CoherenceSample
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &samples))
+	require.Len(t, samples, 1)
+	assert.InDelta(t, 0.75, samples[0].Coherence, 1e-12)
+	assert.WithinDuration(t, time.Now(), samples[0].Time, time.Minute)
+	assert.Contains(t, buf.String(), `"coherence":0.75`)
+}
+
+func TestMonitorExportWhileSampling(t *testing.T) {
+	t.Parallel()
+
+	m := monitoring.New(monitoring.WithHistoryLimit(50))
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := range 1000 {
+			m.RecordSample(float64(i%100) / 100)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range 50 {
+			var buf bytes.Buffer
+			assert.NoError(t, m.WriteCSV(&buf))
+			assert.NoError(t, m.WriteJSON(&buf))
+		}
+	}()
+	wg.Wait()
+
+	assert.Len(t, m.Samples(), 50)
+}
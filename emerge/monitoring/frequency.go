@@ -0,0 +1,132 @@
+package monitoring
+
+import (
+	"time"
+)
+
+// maxFrequencySnapshots bounds the recorded frequency histogram history.
+const maxFrequencySnapshots = 100
+
+// FrequencySource provides the current oscillation frequency of every agent.
+// *swarm.Swarm implements it.
+type FrequencySource interface {
+	Frequencies() []time.Duration
+}
+
+// FrequencyRange is the span of frequencies a histogram covers.
+type FrequencyRange struct {
+	Min time.Duration
+	Max time.Duration
+}
+
+// FrequencySnapshot is a frequency histogram recorded at a point in time.
+type FrequencySnapshot struct {
+	Time   time.Time
+	Range  FrequencyRange
+	Counts []int
+}
+
+// FrequencyHistogram bins the source's agent frequencies into the given
+// number of equal-width bins spanning the observed minimum to maximum.
+// When every agent shares one frequency, all counts land in the first bin.
+// Use FrequencyHistogramIn to compare histograms over time on a fixed range.
+func FrequencyHistogram(src FrequencySource, bins int) []int {
+	freqs := src.Frequencies()
+	if bins <= 0 || len(freqs) == 0 {
+		return nil
+	}
+
+	r := FrequencyRange{Min: freqs[0], Max: freqs[0]}
+	for _, f := range freqs[1:] {
+		r.Min = min(r.Min, f)
+		r.Max = max(r.Max, f)
+	}
+	return binFrequencies(freqs, bins, r)
+}
+
+// FrequencyHistogramIn bins the source's agent frequencies into the given
+// number of equal-width bins over a fixed range. Frequencies outside the
+// range are counted in the nearest edge bin.
+func FrequencyHistogramIn(src FrequencySource, bins int, r FrequencyRange) []int {
+	if bins <= 0 {
+		return nil
+	}
+	return binFrequencies(src.Frequencies(), bins, r)
+}
+
+// CountModes returns the number of modes in a histogram, where a mode is a
+// run of non-empty bins bounded by empty bins or the histogram edges.
+// Two frequency-locked groups at different frequencies show up as two modes.
+func CountModes(counts []int) int {
+	modes := 0
+	inMode := false
+	for _, c := range counts {
+		if c > 0 && !inMode {
+			modes++
+		}
+		inMode = c > 0
+	}
+	return modes
+}
+
+// binFrequencies assigns each frequency to one of bins equal-width bins over r.
+func binFrequencies(freqs []time.Duration, bins int, r FrequencyRange) []int {
+	counts := make([]int, bins)
+	width := r.Max - r.Min
+	for _, f := range freqs {
+		idx := 0
+		if width > 0 {
+			idx = int(float64(f-r.Min) / float64(width) * float64(bins))
+		}
+		counts[min(max(idx, 0), bins-1)]++
+	}
+	return counts
+}
+
+// EnableFrequencyHistograms turns on frequency histogram recording.
+// Each call to RecordFrequencies then stores a histogram with the given number
+// of bins over the fixed range, so snapshots are comparable over time.
+func (m *Monitor) EnableFrequencyHistograms(bins int, r FrequencyRange) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.freqBins = bins
+	m.freqRange = r
+	m.freqHistory = nil
+}
+
+// RecordFrequencies records a frequency histogram of the source.
+// It does nothing unless EnableFrequencyHistograms has been called.
+func (m *Monitor) RecordFrequencies(src FrequencySource) {
+	m.mu.RLock()
+	bins, r := m.freqBins, m.freqRange
+	m.mu.RUnlock()
+
+	if bins <= 0 {
+		return
+	}
+
+	snapshot := FrequencySnapshot{
+		Time:   time.Now(),
+		Range:  r,
+		Counts: FrequencyHistogramIn(src, bins, r),
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.freqHistory) >= maxFrequencySnapshots {
+		m.freqHistory = m.freqHistory[1:]
+	}
+	m.freqHistory = append(m.freqHistory, snapshot)
+}
+
+// FrequencyHistory returns the recorded frequency histograms, oldest first.
+func (m *Monitor) FrequencyHistory() []FrequencySnapshot {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make([]FrequencySnapshot, len(m.freqHistory))
+	copy(result, m.freqHistory)
+	return result
+}
@@ -0,0 +1,114 @@
+package monitoring_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/monitoring"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+func TestFrequencyHistogramShowsLocking(t *testing.T) {
+	t.Parallel()
+
+	const target = 100 * time.Millisecond
+	monitor := monitoring.New()
+	monitor.EnableFrequencyHistograms(20, monitoring.FrequencyRange{Min: 50 * time.Millisecond, Max: 150 * time.Millisecond})
+
+	s, err := swarm.New(20, core.State{Phase: 0, Frequency: target, Coherence: 0.8}, swarm.WithMonitor(monitor))
+	require.NoError(t, err)
+
+	// Two locked groups at different frequencies
+	i := 0
+	s.ForEachAgent(func(a *agent.Agent) bool {
+		jitter := time.Duration(i%3) * time.Millisecond
+		if i%2 == 0 {
+			a.SetFrequency(75*time.Millisecond + jitter)
+		} else {
+			a.SetFrequency(125*time.Millisecond + jitter)
+		}
+		i++
+		return true
+	})
+
+	initial := monitoring.FrequencyHistogram(s, 10)
+	assert.Equal(t, 20, sum(initial))
+	assert.Equal(t, 2, monitoring.CountModes(initial), "expected two frequency groups, got %v", initial)
+	monitor.RecordFrequencies(s)
+
+	// Lock every agent's frequency toward the target
+	for range 30 {
+		s.ForEachAgent(func(a *agent.Agent) bool {
+			f := a.Frequency()
+			a.SetFrequency(f + (target-f)/3)
+			return true
+		})
+		monitor.RecordFrequencies(s)
+	}
+
+	history := monitor.FrequencyHistory()
+	require.Len(t, history, 31)
+	first, last := history[0].Counts, history[len(history)-1].Counts
+	assert.Equal(t, 2, monitoring.CountModes(first), "first snapshot %v", first)
+	assert.Equal(t, 1, monitoring.CountModes(last), "last snapshot %v", last)
+	assert.Equal(t, 20, sum(last))
+}
+
+func TestFrequencyHistogramEdgeCases(t *testing.T) {
+	t.Parallel()
+
+	same := frequencies{100 * time.Millisecond, 100 * time.Millisecond}
+	assert.Equal(t, []int{2, 0, 0}, monitoring.FrequencyHistogram(same, 3))
+	assert.Nil(t, monitoring.FrequencyHistogram(frequencies{}, 3))
+	assert.Nil(t, monitoring.FrequencyHistogram(same, 0))
+
+	// Out-of-range frequencies are clamped to the edge bins
+	spread := frequencies{10 * time.Millisecond, 100 * time.Millisecond, time.Second}
+	r := monitoring.FrequencyRange{Min: 50 * time.Millisecond, Max: 150 * time.Millisecond}
+	assert.Equal(t, []int{1, 1, 1}, monitoring.FrequencyHistogramIn(spread, 3, r))
+
+	// Recording is off until enabled
+	m := monitoring.New()
+	m.RecordFrequencies(spread)
+	assert.Empty(t, m.FrequencyHistory())
+
+	assert.Equal(t, 0, monitoring.CountModes([]int{0, 0}))
+	assert.Equal(t, 3, monitoring.CountModes([]int{1, 0, 2, 3, 0, 1}))
+}
+
+func TestRunRecordsFrequencyHistograms(t *testing.T) {
+	t.Parallel()
+
+	monitor := monitoring.New()
+	monitor.EnableFrequencyHistograms(10, monitoring.FrequencyRange{Min: 50 * time.Millisecond, Max: 200 * time.Millisecond})
+
+	s, err := swarm.New(10, core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8}, swarm.WithMonitor(monitor))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	_ = s.Run(ctx)
+
+	history := monitor.FrequencyHistory()
+	require.NotEmpty(t, history, "Run should record a histogram each iteration")
+	assert.Equal(t, 10, sum(history[0].Counts))
+}
+
+// frequencies is a fixed FrequencySource.
+type frequencies []time.Duration
+
+func (f frequencies) Frequencies() []time.Duration { return f }
+
+func sum(counts []int) int {
+	total := 0
+	for _, c := range counts {
+		total += c
+	}
+	return total
+}
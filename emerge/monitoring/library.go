@@ -0,0 +1,141 @@
+package monitoring
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+)
+
+// LibraryVersion is the schema version written by PatternLibrary.Save.
+// LoadPatternLibrary rejects documents with any other version.
+const LibraryVersion = 1
+
+// ErrUnsupportedLibraryVersion is returned by LoadPatternLibrary when the
+// document was written with a schema version this package cannot read.
+var ErrUnsupportedLibraryVersion = errors.New("unsupported pattern library version")
+
+// libraryDocument is the on-disk form of a PatternLibrary. Durations are
+// stored as integer nanoseconds so sub-millisecond frequencies survive
+// the round trip exactly.
+type libraryDocument struct {
+	Version   int                `json:"version"`
+	Templates []templateDocument `json:"templates"`
+}
+
+type templateDocument struct {
+	Name        string            `json:"name"`
+	Tolerance   float64           `json:"tolerance"`
+	BasePattern patternDocument   `json:"base_pattern"`
+	Variations  []patternDocument `json:"variations,omitempty"`
+}
+
+type patternDocument struct {
+	Phases        []float64 `json:"phases"`
+	FrequenciesNs []int64   `json:"frequencies_ns"`
+	Amplitude     float64   `json:"amplitude"`
+	PeriodNs      int64     `json:"period_ns"`
+	Confidence    float64   `json:"confidence"`
+}
+
+// Save writes the library as versioned JSON. Each template is stored under
+// the name it was added with, in name order, so the same library always
+// produces the same bytes. Phases are stored as given, without wrapping.
+func (l *PatternLibrary) Save(w io.Writer) error {
+	names := make([]string, 0, len(l.templates))
+	for name := range l.templates {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	doc := libraryDocument{
+		Version:   LibraryVersion,
+		Templates: make([]templateDocument, 0, len(names)),
+	}
+	for _, name := range names {
+		t := l.templates[name]
+		td := templateDocument{
+			Name:        name,
+			Tolerance:   t.Tolerance,
+			BasePattern: encodePattern(&t.BasePattern),
+		}
+		for i := range t.Variations {
+			td.Variations = append(td.Variations, encodePattern(&t.Variations[i]))
+		}
+		doc.Templates = append(doc.Templates, td)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode pattern library: %w", err)
+	}
+	return nil
+}
+
+// LoadPatternLibrary reads a library written by PatternLibrary.Save.
+func LoadPatternLibrary(r io.Reader) (*PatternLibrary, error) {
+	var doc libraryDocument
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode pattern library: %w", err)
+	}
+	if doc.Version != LibraryVersion {
+		return nil, fmt.Errorf("%w: %d (want %d)", ErrUnsupportedLibraryVersion, doc.Version, LibraryVersion)
+	}
+
+	lib := NewPatternLibrary()
+	for _, td := range doc.Templates {
+		if td.Name == "" {
+			return nil, errors.New("pattern library template has no name")
+		}
+		if _, dup := lib.templates[td.Name]; dup {
+			return nil, fmt.Errorf("pattern library has duplicate template %q", td.Name)
+		}
+		t := &PatternTemplate{
+			Name:        td.Name,
+			BasePattern: decodePattern(td.BasePattern),
+			Tolerance:   td.Tolerance,
+		}
+		for _, vd := range td.Variations {
+			t.Variations = append(t.Variations, decodePattern(vd))
+		}
+		lib.Add(td.Name, t)
+	}
+	return lib, nil
+}
+
+func encodePattern(p *TargetPattern) patternDocument {
+	var freqs []int64
+	if p.Frequencies != nil {
+		freqs = make([]int64, len(p.Frequencies))
+		for i, f := range p.Frequencies {
+			freqs[i] = int64(f)
+		}
+	}
+	return patternDocument{
+		Phases:        slices.Clone(p.Phases),
+		FrequenciesNs: freqs,
+		Amplitude:     p.Amplitude,
+		PeriodNs:      int64(p.Period),
+		Confidence:    p.Confidence,
+	}
+}
+
+func decodePattern(d patternDocument) TargetPattern {
+	var freqs []time.Duration
+	if d.FrequenciesNs != nil {
+		freqs = make([]time.Duration, len(d.FrequenciesNs))
+		for i, f := range d.FrequenciesNs {
+			freqs[i] = time.Duration(f)
+		}
+	}
+	return TargetPattern{
+		Phases:      d.Phases,
+		Frequencies: freqs,
+		Amplitude:   d.Amplitude,
+		Period:      time.Duration(d.PeriodNs),
+		Confidence:  d.Confidence,
+	}
+}
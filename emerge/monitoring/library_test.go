@@ -0,0 +1,119 @@
+package monitoring_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/monitoring"
+)
+
+func TestPatternLibraryRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	heartbeat := monitoring.NewTargetPattern(
+		[]float64{-3.0, -1.5, 0, 1.5, 3.0},
+		[]time.Duration{250 * time.Microsecond, 999 * time.Nanosecond, time.Millisecond, 1, 42 * time.Second},
+	)
+	skipped := monitoring.NewTargetPattern(
+		[]float64{-0.1, -6.2, 0.3},
+		[]time.Duration{333 * time.Microsecond, 333 * time.Microsecond, 334 * time.Microsecond},
+	)
+	skipped.Confidence = 0.625
+	flat := monitoring.TargetPattern{Phases: []float64{1.0 / 3.0}}
+
+	lib := monitoring.NewPatternLibrary()
+	lib.Add("heartbeat", &monitoring.PatternTemplate{
+		Name:        "heartbeat",
+		BasePattern: *heartbeat,
+		Variations:  []monitoring.TargetPattern{*skipped, flat},
+		Tolerance:   0.15,
+	})
+	lib.Add("flat", &monitoring.PatternTemplate{Name: "flat", BasePattern: flat, Tolerance: 0.01})
+
+	var first bytes.Buffer
+	require.NoError(t, lib.Save(&first))
+
+	loaded, err := monitoring.LoadPatternLibrary(bytes.NewReader(first.Bytes()))
+	require.NoError(t, err)
+
+	var second bytes.Buffer
+	require.NoError(t, loaded.Save(&second))
+	assert.Equal(t, first.String(), second.String(), "a loaded library should save identically")
+
+	// Check the wire form directly: negative phases are not wrapped and
+	// sub-millisecond frequencies keep nanosecond precision.
+	var doc struct {
+		Version   int `json:"version"`
+		Templates []struct {
+			Name        string `json:"name"`
+			BasePattern struct {
+				Phases        []float64 `json:"phases"`
+				FrequenciesNs []int64   `json:"frequencies_ns"`
+			} `json:"base_pattern"`
+			Variations []json.RawMessage `json:"variations"`
+		} `json:"templates"`
+	}
+	require.NoError(t, json.Unmarshal(first.Bytes(), &doc))
+	assert.Equal(t, monitoring.LibraryVersion, doc.Version)
+	require.Len(t, doc.Templates, 2)
+	assert.Equal(t, "flat", doc.Templates[0].Name)
+	hb := doc.Templates[1]
+	assert.Equal(t, "heartbeat", hb.Name)
+	assert.Equal(t, []float64{-3.0, -1.5, 0, 1.5, 3.0}, hb.BasePattern.Phases)
+	assert.Equal(t, []int64{250_000, 999, 1_000_000, 1, 42_000_000_000}, hb.BasePattern.FrequenciesNs)
+	assert.Len(t, hb.Variations, 2)
+
+	// The loaded library identifies patterns exactly as the original does.
+	for _, probe := range []*monitoring.TargetPattern{heartbeat, skipped, &flat} {
+		wantName, wantSim := lib.Identify(probe)
+		gotName, gotSim := loaded.Identify(probe)
+		assert.Equal(t, wantName, gotName)
+		assert.InDelta(t, wantSim, gotSim, 1e-12)
+	}
+}
+
+func TestLoadPatternLibraryRejects(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		doc  string
+	}{
+		{name: "not json", doc: "rhythm"},
+		{name: "missing version", doc: `{"templates": []}`},
+		{name: "future version", doc: `{"version": 2, "templates": []}`},
+		{name: "unnamed template", doc: `{"version": 1, "templates": [{"tolerance": 0.1}]}`},
+		{name: "duplicate template", doc: `{"version": 1, "templates": [{"name": "a"}, {"name": "a"}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			lib, err := monitoring.LoadPatternLibrary(strings.NewReader(tt.doc))
+			require.Error(t, err)
+			assert.Nil(t, lib)
+		})
+	}
+
+	_, err := monitoring.LoadPatternLibrary(strings.NewReader(`{"version": 7}`))
+	assert.ErrorIs(t, err, monitoring.ErrUnsupportedLibraryVersion)
+}
+
+func TestEmptyPatternLibraryRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, monitoring.NewPatternLibrary().Save(&buf))
+
+	lib, err := monitoring.LoadPatternLibrary(&buf)
+	require.NoError(t, err)
+	name, sim := lib.Identify(monitoring.NewTargetPattern([]float64{0}, []time.Duration{time.Second}))
+	assert.Empty(t, name)
+	assert.Zero(t, sim)
+}
@@ -1,33 +1,70 @@
 package monitoring
 
 import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/gammazero/deque"
 )
 
+// defaultHistoryLimit is how many coherence samples a monitor keeps by default.
+const defaultHistoryLimit = 100
+
+// CoherenceSample is a coherence measurement with the time it was taken.
+type CoherenceSample struct {
+	Time      time.Time `json:"time"`
+	Coherence float64   `json:"coherence"`
+}
+
 // Monitor tracks convergence without influencing it.
 type Monitor struct {
-	history *deque.Deque[float64]
+	history *deque.Deque[CoherenceSample]
+	limit   int // Maximum samples kept; zero means unbounded
 	mu      sync.RWMutex
+
+	// Optional frequency histogram recording (see EnableFrequencyHistograms)
+	freqBins    int
+	freqRange   FrequencyRange
+	freqHistory []FrequencySnapshot
+}
+
+// Option configures a Monitor.
+type Option func(*Monitor)
+
+// WithHistoryLimit caps the coherence history at n samples. Once full, the
+// oldest sample is dropped for each new one. A non-positive n removes the
+// cap, which suits short runs whose full history will be exported.
+// The default limit is 100 samples.
+func WithHistoryLimit(n int) Option {
+	return func(m *Monitor) {
+		m.limit = max(n, 0)
+	}
 }
 
 // New creates a new monitor for tracking coherence history.
-func New() *Monitor {
-	return &Monitor{
-		history: deque.New[float64](100),
+func New(opts ...Option) *Monitor {
+	m := &Monitor{limit: defaultHistoryLimit}
+	for _, opt := range opts {
+		opt(m)
 	}
+	m.history = deque.New[CoherenceSample](m.limit)
+	return m
 }
 
-// RecordSample adds a coherence sample to the history.
+// RecordSample adds a coherence sample to the history, stamped with the
+// current time.
 func (m *Monitor) RecordSample(coherence float64) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	if m.history.Len() >= 100 {
+	if m.limit > 0 && m.history.Len() >= m.limit {
 		m.history.PopFront()
 	}
-	m.history.PushBack(coherence)
+	m.history.PushBack(CoherenceSample{Time: time.Now(), Coherence: coherence})
 }
 
 // History returns the coherence history as a slice.
@@ -36,12 +73,51 @@ func (m *Monitor) History() []float64 {
 	defer m.mu.RUnlock()
 
 	result := make([]float64, m.history.Len())
+	for i := range m.history.Len() {
+		result[i] = m.history.At(i).Coherence
+	}
+	return result
+}
+
+// Samples returns the coherence history with timestamps, oldest first.
+func (m *Monitor) Samples() []CoherenceSample {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make([]CoherenceSample, m.history.Len())
 	for i := range m.history.Len() {
 		result[i] = m.history.At(i)
 	}
 	return result
 }
 
+// WriteCSV writes the coherence history as CSV with a "time,coherence"
+// header. Times are RFC 3339 with nanoseconds. It works on a snapshot, so
+// it is safe to call while samples are still being recorded.
+func (m *Monitor) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"time", "coherence"}); err != nil {
+		return err
+	}
+	for _, s := range m.Samples() {
+		record := []string{
+			s.Time.Format(time.RFC3339Nano),
+			strconv.FormatFloat(s.Coherence, 'f', -1, 64),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes the coherence history as a JSON array of
+// {"time", "coherence"} objects. Like WriteCSV, it works on a snapshot.
+func (m *Monitor) WriteJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(m.Samples())
+}
+
 // Latest returns the most recent coherence value.
 func (m *Monitor) Latest() float64 {
 	m.mu.RLock()
@@ -50,7 +126,7 @@ func (m *Monitor) Latest() float64 {
 	if m.history.Len() == 0 {
 		return 0
 	}
-	return m.history.Back()
+	return m.history.Back().Coherence
 }
 
 // Average returns the average coherence value.
@@ -64,7 +140,7 @@ func (m *Monitor) Average() float64 {
 
 	sum := 0.0
 	for i := range m.history.Len() {
-		sum += m.history.At(i)
+		sum += m.history.At(i).Coherence
 	}
 	return sum / float64(m.history.Len())
 }
@@ -0,0 +1,30 @@
+package monitoring
+
+import "time"
+
+// Sample is a point-in-time view of swarm health delivered to observers.
+type Sample struct {
+	SwarmID       string    // Identifies the swarm that produced the sample
+	Time          time.Time // When the sample was taken
+	Coherence     float64   // Global coherence (0 to 1)
+	MeanEnergy    float64   // Mean agent energy
+	MinEnergy     float64   // Lowest agent energy
+	MaxEnergy     float64   // Highest agent energy
+	PhaseVariance float64   // Circular variance of agent phases
+	Disruptions   uint64    // Total disruptions since the swarm was created
+}
+
+// Observer receives periodic samples from a running swarm.
+// Observe is called from a sampling goroutine separate from the
+// convergence loop, one call at a time per swarm.
+type Observer interface {
+	Observe(Sample)
+}
+
+// ObserverFunc adapts a function to the Observer interface.
+type ObserverFunc func(Sample)
+
+// Observe calls f(s).
+func (f ObserverFunc) Observe(s Sample) {
+	f(s)
+}
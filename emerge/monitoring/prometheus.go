@@ -0,0 +1,126 @@
+package monitoring
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// swarmIDLabel distinguishes swarms reporting to the same collector.
+const swarmIDLabel = "swarm_id"
+
+// PrometheusCollector exports swarm samples as Prometheus metrics.
+// One collector can observe any number of swarms; each swarm's series carry
+// its swarm_id label.
+type PrometheusCollector struct {
+	coherence     *prometheus.GaugeVec
+	energyMean    *prometheus.GaugeVec
+	energyMin     *prometheus.GaugeVec
+	energyMax     *prometheus.GaugeVec
+	phaseVariance *prometheus.GaugeVec
+	disruptions   *prometheus.CounterVec
+
+	mu              sync.Mutex
+	seenDisruptions map[string]uint64 // Last disruption total per swarm
+}
+
+// PrometheusOption configures a PrometheusCollector.
+type PrometheusOption func(*prometheusOptions)
+
+type prometheusOptions struct {
+	namespace string
+	subsystem string
+}
+
+// WithNamespace sets the metric namespace (default "bioadapt").
+func WithNamespace(namespace string) PrometheusOption {
+	return func(o *prometheusOptions) {
+		o.namespace = namespace
+	}
+}
+
+// WithSubsystem sets the metric subsystem (default "swarm").
+func WithSubsystem(subsystem string) PrometheusOption {
+	return func(o *prometheusOptions) {
+		o.subsystem = subsystem
+	}
+}
+
+// NewPrometheusCollector creates a collector and registers its metrics with reg.
+// Pass it to swarm.WithObserver to export a swarm's health.
+func NewPrometheusCollector(reg prometheus.Registerer, opts ...PrometheusOption) (*PrometheusCollector, error) {
+	o := prometheusOptions{namespace: "bioadapt", subsystem: "swarm"}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	gauge := func(name, help string) *prometheus.GaugeVec {
+		return prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: o.namespace,
+			Subsystem: o.subsystem,
+			Name:      name,
+			Help:      help,
+		}, []string{swarmIDLabel})
+	}
+
+	c := &PrometheusCollector{
+		coherence:     gauge("coherence", "Current global phase coherence (0 to 1)."),
+		energyMean:    gauge("agent_energy_mean", "Mean agent energy."),
+		energyMin:     gauge("agent_energy_min", "Lowest agent energy."),
+		energyMax:     gauge("agent_energy_max", "Highest agent energy."),
+		phaseVariance: gauge("phase_variance", "Circular variance of agent phases."),
+		disruptions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: o.namespace,
+			Subsystem: o.subsystem,
+			Name:      "disruptions_total",
+			Help:      "Number of disruptions applied to the swarm.",
+		}, []string{swarmIDLabel}),
+		seenDisruptions: make(map[string]uint64),
+	}
+
+	for _, m := range []prometheus.Collector{
+		c.coherence, c.energyMean, c.energyMin, c.energyMax, c.phaseVariance, c.disruptions,
+	} {
+		if err := reg.Register(m); err != nil {
+			return nil, fmt.Errorf("failed to register swarm metrics: %w", err)
+		}
+	}
+
+	return c, nil
+}
+
+// Observe updates the metrics for the sample's swarm.
+func (c *PrometheusCollector) Observe(s Sample) {
+	c.coherence.WithLabelValues(s.SwarmID).Set(s.Coherence)
+	c.energyMean.WithLabelValues(s.SwarmID).Set(s.MeanEnergy)
+	c.energyMin.WithLabelValues(s.SwarmID).Set(s.MinEnergy)
+	c.energyMax.WithLabelValues(s.SwarmID).Set(s.MaxEnergy)
+	c.phaseVariance.WithLabelValues(s.SwarmID).Set(s.PhaseVariance)
+
+	// Samples carry running totals; the counter advances by the difference
+	c.mu.Lock()
+	delta := s.Disruptions - c.seenDisruptions[s.SwarmID]
+	if s.Disruptions < c.seenDisruptions[s.SwarmID] {
+		delta = 0
+	}
+	c.seenDisruptions[s.SwarmID] = s.Disruptions
+	c.mu.Unlock()
+
+	// Touch the series even without new disruptions so it is exported as 0
+	c.disruptions.WithLabelValues(s.SwarmID).Add(float64(delta))
+}
+
+// Forget removes all series for a swarm, e.g. after the swarm is closed.
+func (c *PrometheusCollector) Forget(swarmID string) {
+	for _, v := range []*prometheus.MetricVec{
+		c.coherence.MetricVec, c.energyMean.MetricVec, c.energyMin.MetricVec,
+		c.energyMax.MetricVec, c.phaseVariance.MetricVec, c.disruptions.MetricVec,
+	} {
+		v.DeleteLabelValues(swarmID)
+	}
+
+	c.mu.Lock()
+	delete(c.seenDisruptions, swarmID)
+	c.mu.Unlock()
+}
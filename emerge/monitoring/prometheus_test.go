@@ -0,0 +1,116 @@
+package monitoring_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/monitoring"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+func TestPrometheusCollectorMultipleSwarms(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	collector, err := monitoring.NewPrometheusCollector(reg,
+		monitoring.WithNamespace("test"), monitoring.WithSubsystem("fleet"))
+	require.NoError(t, err)
+
+	goal := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.7}
+	for _, id := range []string{"alpha", "beta"} {
+		s, err := swarm.New(10, goal, swarm.WithID(id), swarm.WithObserver(collector))
+		require.NoError(t, err)
+		assert.Equal(t, id, s.ID())
+
+		s.DisruptAgents(0.5)
+		if id == "beta" {
+			s.DisruptAgents(0.5)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		_ = s.Run(ctx)
+		cancel()
+	}
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	for _, id := range []string{"alpha", "beta"} {
+		coherence := metricValue(t, families, "test_fleet_coherence", id)
+		assert.Positive(t, coherence)
+		assert.LessOrEqual(t, coherence, 1.0)
+		assert.InDelta(t, 100, metricValue(t, families, "test_fleet_agent_energy_max", id), 100)
+		assert.GreaterOrEqual(t, metricValue(t, families, "test_fleet_phase_variance", id), 0.0)
+	}
+	assert.InDelta(t, 1, metricValue(t, families, "test_fleet_disruptions_total", "alpha"), 0)
+	assert.InDelta(t, 2, metricValue(t, families, "test_fleet_disruptions_total", "beta"), 0)
+
+	// A second collector on the same registry conflicts with the first
+	_, err = monitoring.NewPrometheusCollector(reg, monitoring.WithNamespace("test"), monitoring.WithSubsystem("fleet"))
+	require.Error(t, err)
+
+	collector.Forget("alpha")
+	families, err = reg.Gather()
+	require.NoError(t, err)
+	assert.False(t, hasSeries(families, "test_fleet_coherence", "alpha"))
+	assert.True(t, hasSeries(families, "test_fleet_coherence", "beta"))
+}
+
+func TestObserverDoesNotBlockConvergence(t *testing.T) {
+	t.Parallel()
+
+	samples := make(chan monitoring.Sample, 1000)
+	slow := monitoring.ObserverFunc(func(s monitoring.Sample) {
+		samples <- s
+		time.Sleep(time.Second) // Far slower than the update loop
+	})
+
+	s, err := swarm.New(20, core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.7},
+		swarm.WithObserver(slow))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, s.Run(ctx))
+
+	require.NotEmpty(t, samples)
+	assert.Equal(t, s.ID(), (<-samples).SwarmID)
+}
+
+// findSeries returns the metric for the given family and swarm ID.
+func findSeries(families []*dto.MetricFamily, name, swarmID string) *dto.Metric {
+	for _, f := range families {
+		if f.GetName() != name {
+			continue
+		}
+		for _, m := range f.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "swarm_id" && l.GetValue() == swarmID {
+					return m
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func hasSeries(families []*dto.MetricFamily, name, swarmID string) bool {
+	return findSeries(families, name, swarmID) != nil
+}
+
+func metricValue(t *testing.T, families []*dto.MetricFamily, name, swarmID string) float64 {
+	t.Helper()
+	m := findSeries(families, name, swarmID)
+	require.NotNil(t, m, "missing %s{swarm_id=%q}", name, swarmID)
+	if m.GetCounter() != nil {
+		return m.GetCounter().GetValue()
+	}
+	return m.GetGauge().GetValue()
+}
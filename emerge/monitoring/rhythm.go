@@ -0,0 +1,94 @@
+package monitoring
+
+import (
+	"math"
+	"time"
+)
+
+// minRhythmSamples is the fewest samples DominantFrequency analyzes; with
+// fewer it cannot tell a rhythm from noise.
+const minRhythmSamples = 8
+
+// DominantFrequency finds the strongest rhythm in samples taken every
+// interval, such as a coherence history or one agent's phases. It returns
+// the rhythm's period and its power: the share of the samples' variance,
+// from 0 to 1, that the rhythm accounts for. A pure sinusoid has power
+// near 1; noise spreads its power and scores low.
+//
+// The samples are run through a discrete Fourier transform after removing
+// their mean, and the peak is refined between frequency bins, so periods
+// need not divide the sample window. Periods from two intervals up to the
+// whole window can be detected. With fewer than 8 samples, a non-positive
+// interval or samples that never change, it returns zero with zero power.
+func DominantFrequency(samples []float64, interval time.Duration) (time.Duration, float64) {
+	n := len(samples)
+	if n < minRhythmSamples || interval <= 0 {
+		return 0, 0
+	}
+
+	mean := 0.0
+	for _, v := range samples {
+		mean += v
+	}
+	mean /= float64(n)
+
+	// Power in each frequency bin, skipping the mean (bin 0)
+	power := make([]float64, n/2+1)
+	total := 0.0
+	for k := 1; k < len(power); k++ {
+		re, im := 0.0, 0.0
+		for i, v := range samples {
+			angle := 2 * math.Pi * float64(k*i) / float64(n)
+			re += (v - mean) * math.Cos(angle)
+			im -= (v - mean) * math.Sin(angle)
+		}
+		power[k] = re*re + im*im
+		total += power[k]
+	}
+	if total < 1e-12 {
+		return 0, 0
+	}
+
+	peak := 1
+	for k := 2; k < len(power); k++ {
+		if power[k] > power[peak] {
+			peak = k
+		}
+	}
+
+	// Refine the peak with a parabola through its neighbors' magnitudes
+	bin := float64(peak)
+	if peak > 1 && peak < len(power)-1 {
+		left, mid, right := math.Sqrt(power[peak-1]), math.Sqrt(power[peak]), math.Sqrt(power[peak+1])
+		if denom := left - 2*mid + right; denom != 0 {
+			bin += 0.5 * (left - right) / denom
+		}
+	}
+
+	// A rhythm between bins leaks into its neighbors, so they count toward it
+	share := power[peak]
+	if peak > 1 {
+		share += power[peak-1]
+	}
+	if peak < len(power)-1 {
+		share += power[peak+1]
+	}
+
+	period := time.Duration(float64(n) / bin * float64(interval))
+	return period, share / total
+}
+
+// DominantRhythm returns the strongest rhythm in the coherence history, as
+// DominantFrequency does, taking the interval from the samples' timestamps.
+func (m *Monitor) DominantRhythm() (time.Duration, float64) {
+	samples := m.Samples()
+	if len(samples) < 2 {
+		return 0, 0
+	}
+	values := make([]float64, len(samples))
+	for i, s := range samples {
+		values[i] = s.Coherence
+	}
+	interval := samples[len(samples)-1].Time.Sub(samples[0].Time) / time.Duration(len(samples)-1)
+	return DominantFrequency(values, interval)
+}
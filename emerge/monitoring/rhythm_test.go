@@ -0,0 +1,101 @@
+package monitoring_test
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/carlisia/bio-adapt/emerge/monitoring"
+)
+
+// sinusoid returns n samples taken every interval of a wave with the given
+// period, around a mean of 0.5.
+func sinusoid(n int, interval, period time.Duration) []float64 {
+	samples := make([]float64, n)
+	for i := range samples {
+		t := float64(time.Duration(i) * interval)
+		samples[i] = 0.5 + 0.3*math.Sin(2*math.Pi*t/float64(period))
+	}
+	return samples
+}
+
+func TestDominantFrequency(t *testing.T) {
+	t.Parallel()
+
+	const interval = 10 * time.Millisecond
+	tests := []struct {
+		name   string
+		n      int
+		period time.Duration
+	}{
+		{"period divides the window", 64, 160 * time.Millisecond},
+		{"period between bins", 100, 130 * time.Millisecond},
+		{"slow rhythm", 200, 500 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			period, power := monitoring.DominantFrequency(sinusoid(tt.n, interval, tt.period), interval)
+			assert.InEpsilon(t, tt.period, period, 0.05)
+			assert.Greater(t, power, 0.7)
+		})
+	}
+}
+
+func TestDominantFrequencyNoise(t *testing.T) {
+	t.Parallel()
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	samples := make([]float64, 128)
+	for i := range samples {
+		samples[i] = rng.Float64()
+	}
+	_, power := monitoring.DominantFrequency(samples, 10*time.Millisecond)
+	assert.Less(t, power, 0.2, "noise has no dominant rhythm")
+}
+
+func TestDominantFrequencyDegenerate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		samples  []float64
+		interval time.Duration
+	}{
+		{"no samples", nil, time.Millisecond},
+		{"too few samples", []float64{0, 1, 0, 1}, time.Millisecond},
+		{"constant", make([]float64, 32), time.Millisecond},
+		{"no interval", sinusoid(32, time.Millisecond, 8*time.Millisecond), 0},
+	}
+
+	for _, tt := range tests {
+		period, power := monitoring.DominantFrequency(tt.samples, tt.interval)
+		assert.Zero(t, period, tt.name)
+		assert.Zero(t, power, tt.name)
+	}
+}
+
+func TestMonitorDominantRhythm(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		m := monitoring.New()
+		period, power := m.DominantRhythm()
+		assert.Zero(t, period)
+		assert.Zero(t, power)
+
+		for _, c := range sinusoid(100, 20*time.Millisecond, 300*time.Millisecond) {
+			m.RecordSample(c)
+			time.Sleep(20 * time.Millisecond)
+		}
+		period, power = m.DominantRhythm()
+		assert.InEpsilon(t, 300*time.Millisecond, period, 0.05)
+		assert.Greater(t, power, 0.7)
+	})
+}
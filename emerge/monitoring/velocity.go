@@ -0,0 +1,40 @@
+package monitoring
+
+import "time"
+
+// velocityWindow is the number of most recent samples Velocity fits.
+const velocityWindow = 10
+
+// Velocity returns how fast coherence is changing, in coherence per
+// second, from samples taken every interval: the slope of a least-squares
+// line through the last 10 samples. Fitting a line rather than differencing
+// neighbors keeps sample-to-sample noise from dominating. It is negative
+// while coherence falls, as after a disruption, positive while it rises,
+// as during recovery, and near zero once it has settled. With fewer than
+// two samples or a non-positive interval it returns 0.
+func Velocity(history []float64, interval time.Duration) float64 {
+	if len(history) < 2 || interval <= 0 {
+		return 0
+	}
+	samples := history[max(0, len(history)-velocityWindow):]
+	slope, _, _ := fitLine(samples, func(i int) float64 { return float64(i) })
+	return slope / interval.Seconds()
+}
+
+// Velocity returns how fast coherence is changing from the coherence
+// history, as the package-level Velocity does, but fitting the last 10
+// samples against their timestamps, so a pause or irregular sampling
+// earlier in the run does not skew the slope.
+func (m *Monitor) Velocity() float64 {
+	samples := m.Samples()
+	if len(samples) < 2 {
+		return 0
+	}
+	samples = samples[max(0, len(samples)-velocityWindow):]
+	values := make([]float64, len(samples))
+	for i, s := range samples {
+		values[i] = s.Coherence
+	}
+	slope, _, _ := fitLine(values, func(i int) float64 { return samples[i].Time.Sub(samples[0].Time).Seconds() })
+	return slope
+}
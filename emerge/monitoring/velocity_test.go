@@ -0,0 +1,106 @@
+package monitoring_test
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/carlisia/bio-adapt/emerge/monitoring"
+)
+
+func TestVelocity(t *testing.T) {
+	t.Parallel()
+
+	const interval = 100 * time.Millisecond
+	// series returns n samples changing by perSecond from start, with
+	// uniform noise of the given amplitude.
+	series := func(n int, start, perSecond, noise float64) []float64 {
+		rng := rand.New(rand.NewPCG(3, 4))
+		samples := make([]float64, n)
+		for i := range samples {
+			samples[i] = start + perSecond*interval.Seconds()*float64(i) + (rng.Float64()-0.5)*noise
+		}
+		return samples
+	}
+
+	tests := []struct {
+		name    string
+		history []float64
+		want    float64
+		delta   float64
+	}{
+		{"rising", series(20, 0.3, 0.5, 0), 0.5, 1e-9},
+		{"falling", series(20, 0.9, -0.8, 0), -0.8, 1e-9},
+		{"flat", series(20, 0.85, 0, 0), 0, 1e-9},
+		{"noisy rise", series(20, 0.3, 0.5, 0.02), 0.5, 0.1},
+		{"noisy flat", series(20, 0.85, 0, 0.02), 0, 0.1},
+		{"too short", []float64{0.5}, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.InDelta(t, tt.want, monitoring.Velocity(tt.history, interval), tt.delta)
+		})
+	}
+
+	t.Run("follows the latest trend", func(t *testing.T) {
+		t.Parallel()
+
+		// A dip after a disruption, then recovery
+		history := append(series(10, 0.9, -2, 0), series(10, 0.7, 1, 0)...)
+		assert.InDelta(t, 1, monitoring.Velocity(history, interval), 1e-9)
+		assert.Negative(t, monitoring.Velocity(history[:10], interval))
+	})
+
+	t.Run("noise between neighbors does not dominate", func(t *testing.T) {
+		t.Parallel()
+
+		// Alternating samples jump 0.1 each step but do not trend
+		zigzag := make([]float64, 20)
+		for i := range zigzag {
+			zigzag[i] = 0.8 + 0.05*float64(i%2*2-1)
+		}
+		assert.Less(t, math.Abs(monitoring.Velocity(zigzag, interval)), 0.1)
+	})
+}
+
+func TestMonitorVelocity(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		m := monitoring.New()
+		assert.Zero(t, m.Velocity())
+
+		for i := range 20 {
+			m.RecordSample(0.2 + 0.01*float64(i))
+			time.Sleep(100 * time.Millisecond)
+		}
+		assert.InDelta(t, 0.1, m.Velocity(), 1e-9)
+	})
+
+	t.Run("irregular sampling", func(t *testing.T) {
+		t.Parallel()
+
+		synctest.Test(t, func(t *testing.T) {
+			m := monitoring.New()
+
+			// A long pause early in the run, then coherence rising at 0.2
+			// per second, sampled at uneven intervals
+			m.RecordSample(0.1)
+			time.Sleep(time.Minute)
+			elapsed := time.Duration(0)
+			for i := range 15 {
+				m.RecordSample(0.2 + 0.2*elapsed.Seconds())
+				gap := time.Duration(20+40*(i%3)) * time.Millisecond
+				time.Sleep(gap)
+				elapsed += gap
+			}
+			assert.InDelta(t, 0.2, m.Velocity(), 1e-9)
+		})
+	})
+}
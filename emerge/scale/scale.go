@@ -2,6 +2,12 @@
 // Scale modifiers adjust parameters based on the number of agents.
 package scale
 
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
 // Size represents a swarm size category.
 type Size int
 
@@ -176,3 +182,32 @@ func (s Size) UpdateInterval() int {
 		return 250
 	}
 }
+
+// Sizes returns every size category, smallest first.
+func Sizes() []Size {
+	return []Size{Tiny, Small, Medium, Large, Huge}
+}
+
+// SuggestForAgentCount returns the size category among the given ones that
+// best fits agentCount, with a short reason. When the count's own category
+// (see FromCount) is among them, that is the suggestion; otherwise it is
+// the one whose default agent count is nearest in ratio. An empty among
+// allows every size.
+func SuggestForAgentCount(agentCount int, among []Size) (Size, string) {
+	own := FromCount(agentCount)
+	if len(among) == 0 || slices.Contains(among, own) {
+		return own, fmt.Sprintf("%d agents is a %s swarm", agentCount, own)
+	}
+
+	distance := func(s Size) float64 {
+		return math.Abs(math.Log(float64(s.DefaultAgentCount()) / float64(max(agentCount, 1))))
+	}
+	best := among[0]
+	for _, s := range among[1:] {
+		if distance(s) < distance(best) {
+			best = s
+		}
+	}
+	return best, fmt.Sprintf("%d agents is a %s swarm; %s (%d agents) is the nearest recommended size",
+		agentCount, own, best, best.DefaultAgentCount())
+}
@@ -2,4 +2,13 @@
 // can use to achieve collective behavior. Strategies implement various
 // synchronization mechanisms including phase nudging, frequency locking,
 // pulse coupling, and energy-aware adaptation.
+//
+// Strategies are registered by name so they can be selected without
+// constructing them directly. The built-in strategies are available under
+// stable names (see Names), and custom strategies can be added with Register:
+//
+//	strategy.Register("delayed_kuramoto", func() strategy.Strategy {
+//	    return &DelayedKuramoto{Delay: 50 * time.Millisecond}
+//	})
+//	s, err := swarm.New(100, goal, swarm.WithStrategy("delayed_kuramoto"))
 package strategy
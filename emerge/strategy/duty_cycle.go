@@ -0,0 +1,53 @@
+package strategy
+
+import (
+	"math"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+// DutyCycle staggers agents so that only a fraction of them is active at
+// any instant, the rest resting. Each agent is active for Fraction of every
+// cycle, starting when it fires. Agents spread evenly around the cycle as
+// with Splay, so the active windows tile the cycle: about Fraction of the
+// agents are active at any moment, and as long as Fraction times the
+// number of agents is at least 1 some agent always is.
+type DutyCycle struct {
+	Splay
+	Fraction float64 // Share of each cycle the agent is active (0, 1]
+}
+
+// NewDutyCycle creates a duty-cycle strategy. Fraction and rate are
+// clamped to [0, 1].
+func NewDutyCycle(fraction, rate float64) *DutyCycle {
+	return &DutyCycle{
+		Splay:    Splay{Rate: math.Max(0, math.Min(1, rate))},
+		Fraction: math.Max(0, math.Min(1, fraction)),
+	}
+}
+
+// Active reports whether an agent at phase is active when the cycle's
+// clock is at clock. The agent fires when the clock brings its phase
+// around to zero and stays active for Fraction of the cycle.
+func (d *DutyCycle) Active(phase, clock float64) bool {
+	return core.WrapPhase(phase+clock) < 2*math.Pi*d.Fraction
+}
+
+// ActiveFraction returns the share of each cycle the agent is active.
+func (d *DutyCycle) ActiveFraction() float64 {
+	return d.Fraction
+}
+
+// Propose suggests saving energy, weighing the idle share of the cycle
+// against the cost of moving. The swarm staggers its agents with Spread.
+func (d *DutyCycle) Propose(current, target core.State, context core.Context) (core.Action, float64) {
+	action, confidence := d.Splay.Propose(current, target, context)
+	action.Type = "energy_save"
+	action.Benefit = 1 - d.Fraction
+	return action, confidence
+}
+
+// Name returns the strategy's identifier.
+func (*DutyCycle) Name() string {
+	return NameDutyCycle
+}
@@ -2,6 +2,7 @@ package strategy
 
 import (
 	"math"
+	"time"
 
 	"github.com/carlisia/bio-adapt/emerge/core"
 )
@@ -21,8 +22,8 @@ func NewFrequencyLock(syncRate float64) *FrequencyLock {
 
 // Propose suggests a frequency locking action.
 func (s *FrequencyLock) Propose(current, target core.State, context core.Context) (core.Action, float64) {
-	// For now, we focus on phase since frequency is less dynamic
-	// In a full implementation, this would adjust oscillation frequency
+	// The proposal adjusts phase; frequency itself is locked by the swarm
+	// through LockFrequency, which needs the swarm-wide mean frequency
 
 	// Calculate phase adjustment to achieve frequency lock
 	diff := core.PhaseDifference(target.Phase, current.Phase)
@@ -41,6 +42,13 @@ func (s *FrequencyLock) Propose(current, target core.State, context core.Context
 	}, confidence
 }
 
+// LockFrequency returns the frequency an agent running at current should
+// move to, pulled toward reference (typically the swarm's mean frequency)
+// by SyncRate. Repeated calls converge geometrically on reference.
+func (s *FrequencyLock) LockFrequency(current, reference time.Duration) time.Duration {
+	return current + time.Duration(s.SyncRate*float64(reference-current))
+}
+
 // Name returns the strategy's identifier.
 func (*FrequencyLock) Name() string {
 	return "frequency_lock"
@@ -0,0 +1,59 @@
+package strategy
+
+import (
+	"math"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+// JitterDamping nudges phase toward target while penalizing rapid
+// corrections. Each step blends the previous step with the new correction,
+// so an agent's phase velocity changes smoothly and its timing stays
+// predictable. This suits latency-sensitive coordination, where jitter
+// (phase acceleration) matters more than raw coherence.
+type JitterDamping struct {
+	Rate    float64 // Adjustment rate toward target [0, 1]
+	Damping float64 // Share of the previous step carried over [0, 1)
+
+	prevStep float64 // Last proposed step; strategies are per agent
+}
+
+// NewJitterDamping creates a jitter-damping strategy.
+func NewJitterDamping(rate, damping float64) *JitterDamping {
+	return &JitterDamping{
+		Rate:    math.Max(0, math.Min(1, rate)),
+		Damping: math.Max(0, math.Min(0.99, damping)),
+	}
+}
+
+// Propose suggests a damped phase adjustment. The cost grows with how much
+// the step changes from the previous one, so jerky corrections are
+// expensive.
+func (s *JitterDamping) Propose(current, target core.State, context core.Context) (core.Action, float64) {
+	diff := core.PhaseDifference(target.Phase, current.Phase)
+	step := s.Damp(s.prevStep, diff*s.Rate)
+	accel := math.Abs(step - s.prevStep)
+	s.prevStep = step
+
+	// Confident when neighbors are steady, since damping relies on them
+	confidence := math.Max(0.4, context.Stability)
+
+	return core.Action{
+		Type:    NameJitterDamping,
+		Value:   step,
+		Cost:    math.Abs(step)*2.0 + accel*4.0, // Penalize acceleration
+		Benefit: (1.0 - math.Abs(diff)/math.Pi) * (1.0 - math.Min(1, accel)),
+	}, confidence
+}
+
+// Damp returns the step to take instead of step, given the previous step.
+// The swarm uses it to smooth the corrections it applies to agents running
+// this strategy.
+func (s *JitterDamping) Damp(prevStep, step float64) float64 {
+	return prevStep + (step-prevStep)*(1-s.Damping)
+}
+
+// Name returns the strategy's identifier.
+func (*JitterDamping) Name() string {
+	return NameJitterDamping
+}
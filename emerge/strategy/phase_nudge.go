@@ -31,11 +31,16 @@ func (s *PhaseNudge) Propose(current, target core.State, context core.Context) (
 	// Use 1 - LocalCoherence so we're more confident when less synchronized
 	confidence := math.Max(0.5, 1.0-context.LocalCoherence)
 
+	// Energy cost proportional to change, benefit growing with closeness
+	next := current
+	next.Phase = current.Phase + adjustment
+	cost, benefit := core.DefaultCostModel{}.Evaluate(current, next, target)
+
 	return core.Action{
 		Type:    "phase_nudge",
 		Value:   adjustment,
-		Cost:    math.Abs(adjustment) * 2.0,           // Energy cost proportional to change
-		Benefit: (1.0 - math.Abs(diff)/math.Pi) * 1.5, // Increase benefit to encourage convergence
+		Cost:    cost,
+		Benefit: benefit,
 	}, confidence
 }
 
@@ -9,6 +9,9 @@ import (
 
 // Pulse sends periodic synchronization pulses.
 // This implements periodic synchronization signals for phase alignment.
+// It is driven by a timer and a target phase; for integrate-and-fire
+// coupling between neighbors, which the "pulse" registry name selects,
+// see PulseCoupling.
 type Pulse struct {
 	Period    time.Duration
 	Amplitude float64
@@ -0,0 +1,65 @@
+package strategy
+
+import (
+	"math"
+	"time"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+// PulseCoupling is integrate-and-fire synchronization, as in fireflies and
+// the Mirollo-Strogatz model. Each agent charges toward a firing threshold
+// over its cycle and fires when it reaches it. A neighbor's firing advances
+// the agent's phase by Strength times how far it has charged, so agents
+// close to firing are pulled most; an agent pushed past the threshold fires
+// along with the neighbor and stays locked to it. For Refractory after it
+// fires, an agent ignores pulses.
+//
+// Because agents only react to their neighbors firing, pulse coupling
+// needs no global target and escapes states that trap nudging toward
+// neighbors alone on sparse networks, such as a ring whose phases wind once
+// around the circle. A swarm whose agents all pulse-couple relies on
+// pulses alone, so its agents synchronize only through their links.
+type PulseCoupling struct {
+	Strength   float64       // Fraction of the charge added per received pulse, in (0, 1]
+	Refractory time.Duration // Time after firing during which pulses are ignored
+}
+
+// NewPulseCoupling creates a pulse-coupling strategy. Strength is clamped
+// to [0, 1] and a negative refractory period is treated as none.
+func NewPulseCoupling(strength float64, refractory time.Duration) *PulseCoupling {
+	return &PulseCoupling{
+		Strength:   math.Max(0, math.Min(1, strength)),
+		Refractory: max(0, refractory),
+	}
+}
+
+// Propose keeps the agent's phase: pulse-coupled agents move only when a
+// neighbor fires (see Kick).
+func (*PulseCoupling) Propose(_, _ core.State, context core.Context) (core.Action, float64) {
+	return core.Action{
+		Type:    "maintain",
+		Cost:    0.1,
+		Benefit: context.Stability * 0.5,
+	}, 0.3
+}
+
+// Kick returns the phase of an agent with the given cycle period after a
+// neighbor at phase firing fires. Phases are relative to a shared clock,
+// so a neighbor fires as its phase comes around, and the agent is then
+// behind it by how far it has charged this cycle.
+func (s *PulseCoupling) Kick(phase, firing float64, period time.Duration) float64 {
+	charge := core.WrapPhase(phase - firing) // 0 when firing together
+	if period > 0 && charge < 2*math.Pi*float64(s.Refractory)/float64(period) {
+		return phase
+	}
+	if charge+s.Strength*charge >= 2*math.Pi {
+		return firing // Pushed past the threshold: fire together
+	}
+	return phase + s.Strength*charge
+}
+
+// Name returns the strategy's identifier.
+func (*PulseCoupling) Name() string {
+	return NamePulse
+}
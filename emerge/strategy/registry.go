@@ -0,0 +1,99 @@
+package strategy
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+// Strategy is a synchronization strategy that can be registered by name.
+// It is the same interface agents use, so any core.SyncStrategy can be
+// registered and selected without wrapping.
+type Strategy = core.SyncStrategy
+
+// Factory creates a fresh strategy instance. Factories are called once per
+// agent, so strategies that keep state are never shared between agents.
+type Factory func() Strategy
+
+// Registry errors.
+var (
+	ErrUnknownStrategy    = errors.New("unknown strategy")
+	ErrStrategyRegistered = errors.New("strategy already registered")
+)
+
+// Names of the built-in strategies. These are stable and match the
+// strategies' Name methods.
+const (
+	NamePhaseNudge    = "phase_nudge"
+	NameFrequencyLock = "frequency_lock"
+	NameEnergyAware   = "energy_aware"
+	NamePulse         = "pulse" // PulseCoupling, not the timer-driven Pulse
+	NameJitterDamping = "jitter_damping"
+	NameSlotSeeking   = "slot_seeking"
+	NameSplay         = "splay"
+	NameDutyCycle     = "duty_cycle"
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Factory{
+		NamePhaseNudge:    func() Strategy { return NewPhaseNudge(0.3) },
+		NameFrequencyLock: func() Strategy { return NewFrequencyLock(0.5) },
+		NameEnergyAware:   func() Strategy { return NewEnergyAware(20) },
+		NamePulse:         func() Strategy { return NewPulseCoupling(0.2, 10*time.Millisecond) },
+		NameJitterDamping: func() Strategy { return NewJitterDamping(0.3, 0.5) },
+		NameSlotSeeking:   func() Strategy { return NewSlotSeeking(nil, 0.3) },
+		NameSplay:         func() Strategy { return NewSplay(0.5) },
+		NameDutyCycle:     func() Strategy { return NewDutyCycle(0.2, 0.5) },
+	}
+)
+
+// Register makes a strategy available by name, e.g. for swarm.WithStrategy.
+// Names must be unique; registering a name twice, including a built-in
+// name, returns ErrStrategyRegistered.
+func Register(name string, factory Factory) error {
+	if name == "" {
+		return errors.New("strategy name must not be empty")
+	}
+	if factory == nil {
+		return fmt.Errorf("strategy %q: factory must not be nil", name)
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, ok := registry[name]; ok {
+		return fmt.Errorf("%w: %s", ErrStrategyRegistered, name)
+	}
+	registry[name] = factory
+	return nil
+}
+
+// New creates a new instance of the named strategy.
+func New(name string) (Strategy, error) {
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownStrategy, name)
+	}
+	return factory(), nil
+}
+
+// Names returns the names of all registered strategies in sorted order.
+func Names() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
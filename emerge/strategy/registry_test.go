@@ -0,0 +1,57 @@
+package strategy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+type constantStrategy struct{ value float64 }
+
+func (s *constantStrategy) Propose(_, _ core.State, _ core.Context) (core.Action, float64) {
+	return core.Action{Type: "adjust_phase", Value: s.value}, 1
+}
+
+func (*constantStrategy) Name() string { return "constant" }
+
+func TestRegistryBuiltins(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{NamePhaseNudge, NameFrequencyLock, NameEnergyAware, NamePulse, NameJitterDamping} {
+		st, err := New(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, name, st.Name(), "built-in names should match Name()")
+		assert.Contains(t, Names(), name)
+	}
+
+	// "pulse" selects integrate-and-fire coupling, not the timer-driven Pulse
+	st, err := New(NamePulse)
+	require.NoError(t, err)
+	assert.IsType(t, &PulseCoupling{}, st)
+}
+
+func TestRegistryRegister(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, Register("registry_test_constant", func() Strategy {
+		return &constantStrategy{value: 0.1}
+	}))
+
+	a, err := New("registry_test_constant")
+	require.NoError(t, err)
+	b, err := New("registry_test_constant")
+	require.NoError(t, err)
+	assert.NotSame(t, a, b, "each call should get a fresh instance")
+
+	err = Register("registry_test_constant", func() Strategy { return &constantStrategy{} })
+	require.ErrorIs(t, err, ErrStrategyRegistered)
+	require.ErrorIs(t, Register(NamePhaseNudge, func() Strategy { return &constantStrategy{} }), ErrStrategyRegistered)
+	require.Error(t, Register("", func() Strategy { return &constantStrategy{} }))
+	require.Error(t, Register("registry_test_nil", nil))
+
+	_, err = New("registry_test_missing")
+	require.ErrorIs(t, err, ErrUnknownStrategy)
+}
@@ -0,0 +1,60 @@
+package strategy
+
+import (
+	"math"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+// SlotSeeking drives an agent toward the nearest of several target phases,
+// so a swarm settles into one cluster per slot instead of a single one.
+// Slots are offsets from the target phase, as in goal.GoalSpec; the swarm
+// gives this strategy to every agent of a custom goal and fills in the
+// goal's slots. Without slots it seeks the target phase itself.
+type SlotSeeking struct {
+	Slots []float64 // Phase offsets from the target phase (radians)
+	Rate  float64   // Adjustment rate [0, 1]
+}
+
+// NewSlotSeeking creates a slot-seeking strategy over the given offsets.
+func NewSlotSeeking(slots []float64, rate float64) *SlotSeeking {
+	return &SlotSeeking{
+		Slots: append([]float64(nil), slots...),
+		Rate:  math.Max(0, math.Min(1, rate)),
+	}
+}
+
+// Nearest returns the slot phase nearest to phase, for slots offset from
+// the target phase.
+func (s *SlotSeeking) Nearest(phase, target float64) float64 {
+	best, bestDist := core.WrapPhase(target), math.Inf(1)
+	for _, offset := range s.Slots {
+		slot := core.WrapPhase(target + offset)
+		if d := math.Abs(core.PhaseDifference(phase, slot)); d < bestDist {
+			best, bestDist = slot, d
+		}
+	}
+	return best
+}
+
+// Seek returns the agent's next phase, a step toward its nearest slot.
+func (s *SlotSeeking) Seek(phase, target float64) float64 {
+	return phase + s.Rate*core.PhaseDifference(s.Nearest(phase, target), phase)
+}
+
+// Propose suggests a step toward the nearest slot.
+func (s *SlotSeeking) Propose(current, target core.State, context core.Context) (core.Action, float64) {
+	diff := core.PhaseDifference(s.Nearest(current.Phase, target.Phase), current.Phase)
+	adjustment := diff * s.Rate
+	return core.Action{
+		Type:    NameSlotSeeking,
+		Value:   adjustment,
+		Cost:    math.Abs(adjustment) * 2.0,
+		Benefit: (1.0 - math.Abs(diff)/math.Pi) * 1.5,
+	}, math.Max(0.5, 1.0-context.LocalCoherence)
+}
+
+// Name returns the strategy's identifier.
+func (*SlotSeeking) Name() string {
+	return NameSlotSeeking
+}
@@ -0,0 +1,53 @@
+package strategy
+
+import (
+	"math"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+// Splay spreads agents evenly around the cycle, the splay state that
+// load distribution wants. Each agent is repelled by the agents just ahead
+// of and behind it in phase until it sits midway between them; when every
+// agent does, the gaps are all equal. Unlike merely avoiding
+// synchronization, this breaks up clusters, including two anti-phase ones
+// that look incoherent but still bunch the load.
+type Splay struct {
+	Rate float64 // Fraction of the way to the midpoint moved per step [0, 1]
+}
+
+// NewSplay creates a splay strategy. Rate is clamped to [0, 1].
+func NewSplay(rate float64) *Splay {
+	return &Splay{Rate: math.Max(0, math.Min(1, rate))}
+}
+
+// Spread returns the agent's next phase, a step toward the midpoint
+// between the phases of its neighbors on the cycle: prev, the nearest
+// behind it, and next, the nearest ahead. With a single other agent, prev
+// and next are the same and the midpoint is opposite it.
+func (s *Splay) Spread(phase, prev, next float64) float64 {
+	gap := core.WrapPhase(next - prev)
+	if gap == 0 {
+		gap = 2 * math.Pi
+	}
+	midpoint := prev + gap/2
+	return phase + s.Rate*core.PhaseDifference(midpoint, phase)
+}
+
+// Propose suggests moving away from a locally coherent neighborhood. It
+// sees no neighbor phases, so it only says how hard to push; the swarm
+// spreads its agents with Spread.
+func (s *Splay) Propose(_, _ core.State, context core.Context) (core.Action, float64) {
+	push := s.Rate * context.LocalCoherence * math.Pi / 2
+	return core.Action{
+		Type:    "adjust_phase",
+		Value:   push,
+		Cost:    push * 2.0,
+		Benefit: context.LocalCoherence,
+	}, math.Max(0.3, context.LocalCoherence)
+}
+
+// Name returns the strategy's identifier.
+func (*Splay) Name() string {
+	return NameSplay
+}
@@ -299,6 +299,10 @@ func TestFrequencyLockStrategy(t *testing.T) {
 	// Confidence should be based on local coherence and strength
 	expectedConfidence := context.LocalCoherence * strategy.SyncRate
 	assert.InDelta(t, expectedConfidence, confidence, 0.01, "Expected confidence %f", expectedConfidence)
+
+	// Frequency moves toward the reference by SyncRate
+	assert.Equal(t, 140*time.Millisecond, strategy.LockFrequency(100*time.Millisecond, 150*time.Millisecond))
+	assert.Equal(t, 110*time.Millisecond, strategy.LockFrequency(150*time.Millisecond, 100*time.Millisecond))
 }
 
 func TestEnergyAwareStrategy(t *testing.T) {
@@ -419,6 +423,117 @@ func TestPulseStrategy(t *testing.T) {
 	assert.LessOrEqual(t, confidence, 1.0, "Confidence should be <= 1")
 }
 
+func TestPulseCoupling(t *testing.T) {
+	t.Parallel()
+	s := NewPulseCoupling(0.5, 10*time.Millisecond)
+	assert.Equal(t, NamePulse, s.Name())
+	period := 100 * time.Millisecond
+
+	// A pulse advances the agent by a fraction of how far it has charged
+	assert.InDelta(t, 1.5, s.Kick(1, 0, period), 1e-9)
+	assert.InDelta(t, 3.5, s.Kick(3, 2, period), 1e-9)
+
+	// Within the refractory period (a tenth of the cycle) pulses are ignored
+	assert.InDelta(t, 0.5, s.Kick(0.5, 0, period), 1e-9)
+
+	// An agent pushed past the threshold fires with the neighbor
+	assert.InDelta(t, 0.2, s.Kick(5, 0.2, period), 1e-9)
+
+	action, _ := s.Propose(core.State{}, core.State{Phase: 1}, core.Context{})
+	assert.Equal(t, "maintain", action.Type, "pulse-coupled agents move only on pulses")
+
+	clamped := NewPulseCoupling(2, -time.Second)
+	assert.InDelta(t, 1.0, clamped.Strength, 1e-9)
+	assert.Zero(t, clamped.Refractory)
+}
+
+func TestSlotSeeking(t *testing.T) {
+	t.Parallel()
+	s := NewSlotSeeking([]float64{0, 2 * math.Pi / 3, 4 * math.Pi / 3}, 0.5)
+	assert.Equal(t, NameSlotSeeking, s.Name())
+
+	// Agents head for the nearest slot, offset from the target phase
+	assert.InDelta(t, 2*math.Pi/3, s.Nearest(2, 0), 1e-9)
+	assert.InDelta(t, 0, s.Nearest(6, 0), 1e-9)
+	assert.InDelta(t, 1+2*math.Pi/3, s.Nearest(3, 1), 1e-9)
+	assert.InDelta(t, 2+0.5*(2*math.Pi/3-2), s.Seek(2, 0), 1e-9)
+
+	action, _ := s.Propose(core.State{Phase: 2}, core.State{Phase: 0}, core.Context{})
+	assert.InDelta(t, 0.5*(2*math.Pi/3-2), action.Value, 1e-9)
+
+	// Without slots it seeks the target itself
+	assert.InDelta(t, 1, NewSlotSeeking(nil, 0.5).Nearest(2, 1), 1e-9)
+}
+
+func TestSplay(t *testing.T) {
+	t.Parallel()
+	s := NewSplay(0.5)
+	assert.Equal(t, NameSplay, s.Name())
+
+	// Agents move halfway toward the midpoint between their neighbors
+	assert.InDelta(t, 1.5, s.Spread(1, 1, 3), 1e-9)
+	assert.InDelta(t, 2, s.Spread(2, 1, 3), 1e-9, "already midway")
+
+	// Neighbors wrap around the cycle
+	assert.InDelta(t, 0.05, s.Spread(0.1, 2*math.Pi-0.3, 0.3), 1e-9)
+
+	// With a single other agent, the midpoint is opposite it
+	assert.InDelta(t, math.Pi/2, s.Spread(0, 0, 0), 1e-9)
+
+	action, _ := s.Propose(core.State{}, core.State{}, core.Context{LocalCoherence: 1})
+	assert.Greater(t, action.Value, 0.0, "a coherent neighborhood pushes the agent away")
+	assert.Equal(t, 1.0, NewSplay(2).Rate, "rate should be clamped")
+}
+
+func TestDutyCycle(t *testing.T) {
+	t.Parallel()
+	d := NewDutyCycle(0.25, 0.5)
+	assert.Equal(t, NameDutyCycle, d.Name())
+	assert.InDelta(t, 0.25, d.ActiveFraction(), 1e-9)
+
+	// An agent is active for the quarter cycle after the clock brings its
+	// phase around to zero
+	assert.True(t, d.Active(0, 0))
+	assert.True(t, d.Active(1, 2*math.Pi-1+0.1))
+	assert.False(t, d.Active(1, 2*math.Pi-1-0.1), "not fired yet")
+	assert.False(t, d.Active(0, math.Pi/2), "active window over")
+
+	// It spreads like Splay
+	assert.InDelta(t, 1.5, d.Spread(1, 1, 3), 1e-9)
+
+	action, _ := d.Propose(core.State{}, core.State{}, core.Context{})
+	assert.Equal(t, "energy_save", action.Type)
+	assert.InDelta(t, 0.75, action.Benefit, 1e-9)
+	assert.Equal(t, 1.0, NewDutyCycle(2, 0.5).Fraction, "fraction should be clamped")
+}
+
+func TestJitterDampingStrategy(t *testing.T) {
+	t.Parallel()
+	strategy := NewJitterDamping(0.5, 0.5)
+	assert.Equal(t, NameJitterDamping, strategy.Name())
+
+	current := core.State{Phase: 0}
+	target := core.State{Phase: 1}
+	ctx := core.Context{Stability: 0.8}
+
+	// The first correction is damped against a resting start
+	first, confidence := strategy.Propose(current, target, ctx)
+	assert.Equal(t, NameJitterDamping, first.Type)
+	assert.InDelta(t, 0.25, first.Value, 1e-9, "half of the 0.5 correction")
+	assert.InDelta(t, 0.8, confidence, 1e-9)
+
+	// A sudden reversal is softened and costs more than a steady step
+	second, _ := strategy.Propose(current, core.State{Phase: -1}, ctx)
+	assert.InDelta(t, -0.125, second.Value, 1e-9, "reversal blends with the previous step")
+	steady := NewJitterDamping(0.5, 0.5)
+	steady.prevStep = 0.5
+	same, _ := steady.Propose(current, target, ctx)
+	assert.Greater(t, second.Cost, same.Cost, "acceleration should be penalized")
+
+	assert.InDelta(t, 0.3, strategy.Damp(0.2, 0.4), 1e-9)
+	assert.Equal(t, 0.99, NewJitterDamping(0.5, 2).Damping, "damping should be clamped below 1")
+}
+
 func TestStrategyIntegration(t *testing.T) {
 	t.Parallel()
 	// Test that all strategies work together
@@ -0,0 +1,66 @@
+package swarm
+
+import (
+	"fmt"
+	"math"
+)
+
+// Plateau detection used by WithAdaptiveTarget when WithPlateauDetection
+// is not set: coherence moving less than adaptiveEpsilon over
+// adaptiveWindow samples counts as stuck.
+const (
+	adaptiveWindow  = 20
+	adaptiveEpsilon = 0.02
+)
+
+// WithAdaptiveTarget lets the swarm settle for less than an unreachable
+// target. When coherence plateaus below the target during Run or
+// RunContinuous, the effective target is lowered to the level the swarm
+// sustained over the plateau, but never below minAcceptable, and the run
+// converges once coherence reaches it. Plateaus use the window and epsilon
+// of WithPlateauDetection when set, and otherwise 20 samples within 0.02.
+// A plateau below minAcceptable still fails the run when plateau detection
+// is on.
+//
+// Each relaxation is reported to the convergence callback with Relaxed set,
+// and persists for later runs. TargetState keeps the original target; see
+// EffectiveTargetCoherence. The feature is off by default.
+func WithAdaptiveTarget(minAcceptable float64) Option {
+	return func(s *Swarm) error {
+		if minAcceptable <= 0 || minAcceptable > 1 || math.IsNaN(minAcceptable) {
+			return fmt.Errorf("adaptive target minimum must be in (0, 1], got %v", minAcceptable)
+		}
+		s.adaptiveMin = minAcceptable
+		return nil
+	}
+}
+
+// EffectiveTargetCoherence returns the coherence the swarm currently works
+// toward: TargetState().Coherence, or the lower value WithAdaptiveTarget
+// has relaxed it to.
+func (s *Swarm) EffectiveTargetCoherence() float64 {
+	if bits := s.relaxedTarget.Load(); bits != 0 {
+		return math.Float64frombits(bits)
+	}
+	return s.target().Coherence
+}
+
+// TargetRelaxed reports whether WithAdaptiveTarget has lowered the target.
+func (s *Swarm) TargetRelaxed() bool {
+	return s.relaxedTarget.Load() != 0
+}
+
+// relaxTarget lowers the effective target to the sustained level, bounded
+// below by the adaptive minimum. It returns the new target and whether it
+// changed.
+func (s *Swarm) relaxTarget(current, sustained float64) (float64, bool) {
+	if s.adaptiveMin == 0 || current <= s.adaptiveMin {
+		return current, false
+	}
+	relaxed := math.Max(s.adaptiveMin, sustained)
+	if relaxed >= current {
+		return current, false
+	}
+	s.relaxedTarget.Store(math.Float64bits(relaxed))
+	return relaxed, true
+}
@@ -0,0 +1,120 @@
+package swarm_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+// runDisrupted runs a large swarm aiming for 0.95 coherence while a share of
+// its agents is scrambled every 100ms, which levels coherence off well below
+// the target. It must be called inside a synctest bubble.
+func runDisrupted(t *testing.T, opts ...swarm.Option) (*swarm.Swarm, error) {
+	t.Helper()
+
+	opts = append([]swarm.Option{swarm.WithSeed(7), swarm.WithPlateauDetection(10, 0.1)}, opts...)
+	s, err := swarm.New(1000, core.State{
+		Phase:     0,
+		Frequency: 200 * time.Millisecond,
+		Coherence: 0.95,
+	}, opts...)
+	require.NoError(t, err)
+	t.Cleanup(s.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	go func() {
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.DisruptAgents(0.05)
+			}
+		}
+	}()
+
+	return s, s.Run(ctx)
+}
+
+func TestAdaptiveTargetRelaxesUnreachableTarget(t *testing.T) {
+	t.Parallel()
+
+	t.Run("without adaptive target", func(t *testing.T) {
+		t.Parallel()
+
+		synctest.Test(t, func(t *testing.T) {
+			_, err := runDisrupted(t)
+			require.ErrorIs(t, err, swarm.ErrPlateau)
+		})
+	})
+
+	t.Run("with adaptive target", func(t *testing.T) {
+		t.Parallel()
+
+		synctest.Test(t, func(t *testing.T) {
+			var (
+				mu     sync.Mutex
+				events []swarm.ConvergenceEvent
+			)
+			s, err := runDisrupted(t,
+				swarm.WithAdaptiveTarget(0.5),
+				swarm.WithConvergenceCallback(func(ev swarm.ConvergenceEvent) {
+					mu.Lock()
+					defer mu.Unlock()
+					events = append(events, ev)
+				}),
+			)
+			require.NoError(t, err, "a relaxed target should converge")
+
+			assert.True(t, s.TargetRelaxed())
+			effective := s.EffectiveTargetCoherence()
+			assert.Less(t, effective, 0.95)
+			assert.GreaterOrEqual(t, effective, 0.5)
+			assert.InDelta(t, 0.95, s.TargetState().Coherence, 1e-9, "original target is kept for reporting")
+
+			mu.Lock()
+			defer mu.Unlock()
+			var relaxations int
+			for _, ev := range events {
+				if ev.Relaxed {
+					relaxations++
+					assert.InDelta(t, 0.95, ev.OriginalTarget, 1e-9)
+					assert.Less(t, ev.Target, ev.OriginalTarget)
+				}
+			}
+			assert.Positive(t, relaxations, "relaxation should reach the callback")
+			require.NotEmpty(t, events)
+			last := events[len(events)-1]
+			assert.True(t, last.Converged)
+			assert.InDelta(t, effective, last.Target, 1e-9)
+		})
+	})
+}
+
+func TestWithAdaptiveTargetValidation(t *testing.T) {
+	t.Parallel()
+
+	goal := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8}
+	for _, minimum := range []float64{0, -0.2, 1.1} {
+		_, err := swarm.New(10, goal, swarm.WithAdaptiveTarget(minimum))
+		assert.Error(t, err, minimum)
+	}
+
+	s, err := swarm.New(10, goal)
+	require.NoError(t, err)
+	defer s.Close()
+	assert.False(t, s.TargetRelaxed())
+	assert.InDelta(t, 0.8, s.EffectiveTargetCoherence(), 1e-9)
+}
@@ -0,0 +1,70 @@
+package swarm
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// WithAgentIDFunc names the swarm's agents: agent i of New's size gets
+// the ID fn(i), in place of the default "agent-i". Use it for IDs that
+// mean something outside the swarm, e.g. "worker-0" or the service
+// instance IDs that remote neighbors map to endpoints. AddAgent names an
+// agent given no ID with fn too, trying fn(Size()), fn(Size()+1) and so
+// on until one is free. Clone carries fn over, and keeps the IDs agents
+// already have.
+//
+// fn must return a distinct, non-empty ID for each index; New fails with
+// ErrAgentExists on a repeat. Agents from WithAgentBuilder are named with
+// fn too.
+func WithAgentIDFunc(fn func(i int) string) Option {
+	return func(s *Swarm) error {
+		if fn == nil {
+			return errors.New("agent ID func must not be nil")
+		}
+		s.agentIDFunc = fn
+		return nil
+	}
+}
+
+// agentID returns the ID of agent i.
+func (s *Swarm) agentID(i int) string {
+	if s.agentIDFunc == nil {
+		return "agent-" + strconv.Itoa(i)
+	}
+	return s.agentIDFunc(i)
+}
+
+// agentIDs returns the IDs of the swarm's first n agents, checking they
+// are usable.
+func (s *Swarm) agentIDs(n int) ([]string, error) {
+	ids := make([]string, n)
+	seen := make(map[string]bool, n)
+	for i := range ids {
+		id := s.agentID(i)
+		if id == "" {
+			return nil, fmt.Errorf("agent %d has an empty ID", i)
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("agent %d: %w: %s", i, ErrAgentExists, id)
+		}
+		seen[id] = true
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// nextAgentID returns the first agent ID not in use, starting at the
+// swarm's size so IDs continue the sequence New started. Of the next
+// Size()+1 distinct IDs one must be free; an ID func that repeats itself
+// may offer none, which is ErrAgentExists.
+func (s *Swarm) nextAgentID() (string, error) {
+	size := s.Size()
+	for n := size; n <= 2*size; n++ {
+		id := s.agentID(n)
+		if _, exists := s.Agent(id); id != "" && !exists {
+			return id, nil
+		}
+	}
+	return "", fmt.Errorf("%w: no free ID from the agent ID func", ErrAgentExists)
+}
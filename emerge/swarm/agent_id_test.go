@@ -0,0 +1,89 @@
+package swarm_test
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+func TestWithAgentIDFunc(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{Frequency: 100 * time.Millisecond, Coherence: 0.8}
+	worker := func(i int) string { return fmt.Sprintf("worker-%d", i) }
+	workers := func(from, to int) []string {
+		ids := make([]string, 0, to-from)
+		for i := from; i < to; i++ {
+			ids = append(ids, worker(i))
+		}
+		slices.Sort(ids)
+		return ids
+	}
+	ids := func(s *swarm.Swarm) []string {
+		return slices.Sorted(maps.Keys(s.Agents()))
+	}
+
+	t.Run("custom IDs survive a clone", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := swarm.New(5, goalState, swarm.WithAgentIDFunc(worker))
+		require.NoError(t, err)
+		defer s.Close()
+		assert.Equal(t, workers(0, 5), ids(s))
+		for id, a := range s.Agents() {
+			assert.Equal(t, id, a.ID)
+		}
+
+		clone, err := s.Clone()
+		require.NoError(t, err)
+		defer clone.Close()
+		assert.Equal(t, ids(s), ids(clone))
+		for id, a := range s.Agents() {
+			c, ok := clone.Agent(id)
+			require.True(t, ok)
+			assert.InDelta(t, a.Phase(), c.Phase(), 1e-12)
+		}
+
+		// Agents added later continue the sequence, in the clone too
+		added, err := clone.AddAgent(swarm.AgentConfig{})
+		require.NoError(t, err)
+		assert.Equal(t, "worker-5", added.ID)
+	})
+
+	t.Run("large swarm", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := swarm.New(swarm.OptimizedSwarmThreshold+10, goalState, swarm.WithAgentIDFunc(worker))
+		require.NoError(t, err)
+		defer s.Close()
+		assert.Equal(t, workers(0, swarm.OptimizedSwarmThreshold+10), ids(s))
+	})
+
+	t.Run("IDs must be unique and non-empty", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := swarm.New(5, goalState, swarm.WithAgentIDFunc(func(i int) string { return fmt.Sprint(i % 3) }))
+		require.ErrorIs(t, err, swarm.ErrAgentExists)
+
+		_, err = swarm.New(5, goalState, swarm.WithAgentIDFunc(func(int) string { return "" }))
+		require.Error(t, err)
+
+		_, err = swarm.New(5, goalState, swarm.WithAgentIDFunc(nil))
+		require.Error(t, err)
+
+		// A lone agent's ID func may have nothing left to offer AddAgent
+		s, err := swarm.New(1, goalState, swarm.WithAgentIDFunc(func(int) string { return "only" }))
+		require.NoError(t, err)
+		defer s.Close()
+		_, err = s.AddAgent(swarm.AgentConfig{})
+		require.ErrorIs(t, err, swarm.ErrAgentExists)
+	})
+}
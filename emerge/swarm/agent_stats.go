@@ -0,0 +1,27 @@
+package swarm
+
+import "github.com/carlisia/bio-adapt/emerge/agent"
+
+// AggregateStats sums the decision and action statistics of every agent
+// (see agent.Agent.Stats). The synchronization loop counts each step an
+// agent's decision maker weighs as a proposal, and each step taken at the
+// price it was weighed at; a step the agent cannot pay for (see
+// WithEnergyCost) counts as a failed action.
+func (s *Swarm) AggregateStats() agent.AgentStats {
+	var total agent.AgentStats
+	for _, a := range s.collectAgents() {
+		total = total.Add(a.Stats())
+	}
+	return total
+}
+
+// ResetStats zeroes every agent's statistics and returns their sum since
+// the previous reset, so long-running swarms can report per-window
+// statistics. Updates made while it runs land in exactly one window.
+func (s *Swarm) ResetStats() agent.AgentStats {
+	var total agent.AgentStats
+	for _, a := range s.collectAgents() {
+		total = total.Add(a.ResetStats())
+	}
+	return total
+}
@@ -0,0 +1,56 @@
+package swarm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/decision"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+func TestAggregateStats(t *testing.T) {
+	t.Parallel()
+
+	s, err := swarm.New(5, core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8})
+	require.NoError(t, err)
+	defer s.Close()
+	assert.Zero(t, s.AggregateStats())
+
+	for _, a := range s.AgentsSorted() {
+		a.ProposeAdjustment(s.TargetState())
+		_, _, err := a.ApplyAction(core.Action{Type: "maintain", Cost: 0.5, Benefit: 1})
+		require.NoError(t, err)
+	}
+
+	stats := s.AggregateStats()
+	assert.Equal(t, uint64(5), stats.Proposals)
+	assert.Equal(t, stats.Proposals, stats.Acceptances+stats.Rejections)
+	assert.InDelta(t, 2.5, stats.TotalCost, 1e-9)
+	assert.InDelta(t, 5.0, stats.TotalBenefit, 1e-9)
+
+	assert.Equal(t, stats, s.ResetStats())
+	assert.Zero(t, s.AggregateStats())
+
+	t.Run("run", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := swarm.New(20, core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.85},
+			swarm.WithSeed(8), swarm.WithDecisionMaker(decision.NameRiskAverse))
+		require.NoError(t, err)
+		defer s.Close()
+		for range 10 {
+			s.Step()
+		}
+
+		stats := s.AggregateStats()
+		assert.Positive(t, stats.Acceptances)
+		assert.Positive(t, stats.Rejections)
+		assert.Equal(t, stats.Proposals, stats.Acceptances+stats.Rejections)
+		assert.Positive(t, stats.TotalCost)
+		assert.Positive(t, stats.TotalBenefit)
+	})
+}
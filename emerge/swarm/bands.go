@@ -0,0 +1,168 @@
+package swarm
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+// Band is a named phase region that a subset of the swarm synchronizes to.
+// With several bands the swarm forms one synchronized cluster per band,
+// giving several batch windows per cycle instead of one, e.g. an early
+// high-priority window and a later bulk window.
+type Band struct {
+	Name   string  // Unique band name
+	Phase  float64 // Target phase of the band's cluster (radians)
+	Weight float64 // Relative share of agents; zero for all bands means equal shares
+}
+
+// BandState reports how well a band's cluster has formed.
+type BandState struct {
+	Band
+	Agents    int     // Number of agents assigned to the band
+	Coherence float64 // Coherence among the band's agents (0 to 1)
+	MeanPhase float64 // Circular mean phase of the band's agents
+}
+
+// WithPhaseBands partitions the swarm into phase bands.
+// Agents are assigned to bands in ID order in proportion to band weights,
+// and each band converges to its own phase. Every band receives at least
+// one agent, so there cannot be more bands than agents.
+func WithPhaseBands(bands []Band) Option {
+	return func(s *Swarm) error {
+		if len(bands) == 0 {
+			return fmt.Errorf("%w: at least one band is required", ErrInvalidBands)
+		}
+		if len(bands) > s.size {
+			return fmt.Errorf("%w: %d bands for %d agents", ErrInvalidBands, len(bands), s.size)
+		}
+
+		seen := make(map[string]bool, len(bands))
+		for _, b := range bands {
+			if b.Name == "" {
+				return fmt.Errorf("%w: band name is required", ErrInvalidBands)
+			}
+			if seen[b.Name] {
+				return fmt.Errorf("%w: duplicate band %q", ErrInvalidBands, b.Name)
+			}
+			seen[b.Name] = true
+			if b.Weight < 0 || math.IsNaN(b.Weight) || math.IsInf(b.Weight, 0) {
+				return fmt.Errorf("%w: band %q has invalid weight %v", ErrInvalidBands, b.Name, b.Weight)
+			}
+		}
+
+		s.bands = make([]Band, len(bands))
+		for i, b := range bands {
+			b.Phase = core.WrapPhase(b.Phase)
+			s.bands[i] = b
+		}
+		return nil
+	}
+}
+
+// Bands returns the configured phase bands, or nil if none are set.
+func (s *Swarm) Bands() []Band {
+	return slices.Clone(s.bands)
+}
+
+// BandOf returns the band an agent is assigned to.
+func (s *Swarm) BandOf(agentID string) (Band, bool) {
+	idx, ok := s.bandOf[agentID]
+	if !ok {
+		return Band{}, false
+	}
+	return s.bands[idx], true
+}
+
+// BandStates measures each band's cluster, in band order.
+// Returns nil if no bands are configured.
+func (s *Swarm) BandStates() []BandState {
+	if len(s.bands) == 0 {
+		return nil
+	}
+
+	phases := make([][]float64, len(s.bands))
+	for _, a := range s.collectAgents() {
+		if idx, ok := s.bandOf[a.ID]; ok {
+			phases[idx] = append(phases[idx], a.Phase())
+		}
+	}
+
+	states := make([]BandState, len(s.bands))
+	for i, b := range s.bands {
+		states[i] = BandState{
+			Band:      b,
+			Agents:    len(phases[i]),
+			Coherence: core.MeasureCoherence(phases[i]),
+			MeanPhase: circularMean(phases[i]),
+		}
+	}
+	return states
+}
+
+// assignBands distributes agents across bands in proportion to band weights.
+func (s *Swarm) assignBands() {
+	agents := s.collectAgents()
+	slices.SortFunc(agents, func(a, b *agent.Agent) int {
+		return compareAgentIDs(a.ID, b.ID)
+	})
+
+	total := 0.0
+	for _, b := range s.bands {
+		total += b.Weight
+	}
+
+	n, k := len(agents), len(s.bands)
+	s.bandOf = make(map[string]int, n)
+
+	start, cumulative := 0, 0.0
+	for i, b := range s.bands {
+		cumulative += b.Weight
+		end := n
+		if i < k-1 {
+			share := float64(i+1) / float64(k)
+			if total > 0 {
+				share = cumulative / total
+			}
+			end = int(math.Round(share * float64(n)))
+			// Keep at least one agent per band on both sides of the boundary
+			end = max(end, start+1)
+			end = min(end, n-(k-i-1))
+		}
+		for _, a := range agents[start:end] {
+			s.bandOf[a.ID] = i
+		}
+		start = end
+	}
+}
+
+// compareAgentIDs orders IDs by length first so "agent-2" sorts before "agent-10".
+func compareAgentIDs(a, b string) int {
+	if len(a) != len(b) {
+		return len(a) - len(b)
+	}
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// circularMean returns the mean direction of the phases in [0, 2π].
+func circularMean(phases []float64) float64 {
+	if len(phases) == 0 {
+		return 0
+	}
+	sumSin, sumCos := 0.0, 0.0
+	for _, p := range phases {
+		sumSin += math.Sin(p)
+		sumCos += math.Cos(p)
+	}
+	return core.WrapPhase(math.Atan2(sumSin, sumCos))
+}
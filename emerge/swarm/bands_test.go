@@ -0,0 +1,103 @@
+package swarm_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+func TestPhaseBandsFormDistinctClusters(t *testing.T) {
+	t.Parallel()
+
+	bands := []swarm.Band{
+		{Name: "priority", Phase: 0, Weight: 1},
+		{Name: "bulk", Phase: math.Pi, Weight: 3},
+	}
+
+	s, err := swarm.New(20, core.State{
+		Phase:     0,
+		Frequency: 200 * time.Millisecond,
+		Coherence: 0.9,
+	}, swarm.WithPhaseBands(bands))
+	require.NoError(t, err)
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, s.Run(ctx))
+
+	states := s.BandStates()
+	require.Len(t, states, 2)
+	assert.Equal(t, 5, states[0].Agents, "priority band should get a quarter of the agents")
+	assert.Equal(t, 15, states[1].Agents, "bulk band should get the rest")
+
+	for _, st := range states {
+		t.Logf("band %s: agents=%d coherence=%.3f mean phase=%.3f", st.Name, st.Agents, st.Coherence, st.MeanPhase)
+		assert.GreaterOrEqual(t, st.Coherence, 0.85, "band %s should be synchronized", st.Name)
+		assert.InDelta(t, 0, core.PhaseDifference(st.MeanPhase, st.Phase), 0.2, "band %s should sit at its phase", st.Name)
+	}
+
+	// Two clusters half a cycle apart should not look like one synchronized swarm
+	assert.Less(t, s.MeasureCoherence(), 0.7)
+
+	// Every agent belongs to exactly one band
+	for id := range s.Agents() {
+		_, ok := s.BandOf(id)
+		assert.True(t, ok, "agent %s has no band", id)
+	}
+}
+
+func TestWithPhaseBandsValidation(t *testing.T) {
+	t.Parallel()
+
+	goal := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8}
+	tests := []struct {
+		name  string
+		size  int
+		bands []swarm.Band
+	}{
+		{"empty", 10, nil},
+		{"unnamed", 10, []swarm.Band{{Phase: 0}}},
+		{"duplicate", 10, []swarm.Band{{Name: "a"}, {Name: "a", Phase: 1}}},
+		{"negative weight", 10, []swarm.Band{{Name: "a", Weight: -1}}},
+		{"more bands than agents", 2, []swarm.Band{{Name: "a"}, {Name: "b"}, {Name: "c"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := swarm.New(tt.size, goal, swarm.WithPhaseBands(tt.bands))
+			require.ErrorIs(t, err, swarm.ErrInvalidBands)
+		})
+	}
+}
+
+func TestPhaseBandsEqualShares(t *testing.T) {
+	t.Parallel()
+
+	s, err := swarm.New(10, core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8},
+		swarm.WithPhaseBands([]swarm.Band{
+			{Name: "a", Phase: 0},
+			{Name: "b", Phase: 2 * math.Pi / 3},
+			{Name: "c", Phase: 4 * math.Pi / 3},
+		}))
+	require.NoError(t, err)
+
+	total := 0
+	for _, st := range s.BandStates() {
+		assert.GreaterOrEqual(t, st.Agents, 3)
+		total += st.Agents
+	}
+	assert.Equal(t, 10, total)
+
+	plain, err := swarm.New(5, core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8})
+	require.NoError(t, err)
+	assert.Nil(t, plain.BandStates(), "swarms without bands report no band states")
+}
@@ -0,0 +1,128 @@
+package swarm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+// batchReach is how far from the alignment point an agent may be and still
+// count as ready: an eighth of a period either side.
+const batchReach = math.Pi / 4
+
+// BatchWindow describes one batch window opened by WithBatchTrigger.
+type BatchWindow struct {
+	Time      time.Time     // When the window opened
+	Period    time.Duration // Oscillation period the windows follow
+	Phase     float64       // Alignment point: the swarm's mean phase (radians)
+	Coherence float64       // Global coherence when the window opened
+	Ready     []string      // IDs of the agents at the alignment point, sorted
+}
+
+// WithBatchTrigger calls fn once per oscillation period while the swarm is
+// aligned enough to act on, so callers get batch windows as events instead
+// of polling agent phases. The period is the target frequency, following
+// SetTarget.
+//
+// At the end of each period the swarm's agents have swept once around the
+// cycle, so each has crossed the alignment point, the swarm's mean phase.
+// If coherence is at least threshold, fn receives the agents within an
+// eighth of a period of that point, the ones ready to act together. A
+// period in which coherence falls short, or no agent is ready, opens no
+// window, and no period opens more than one.
+//
+// Windows open while Run or RunContinuous is active. fn runs on its own
+// goroutine, one call at a time; a slow fn delays later windows but never
+// the convergence loop.
+func WithBatchTrigger(threshold float64, fn func(window BatchWindow)) Option {
+	return func(s *Swarm) error {
+		if math.IsNaN(threshold) || threshold < 0 || threshold > 1 {
+			return fmt.Errorf("batch trigger threshold must be in [0, 1], got %v", threshold)
+		}
+		if fn == nil {
+			return errors.New("batch trigger callback must not be nil")
+		}
+		s.batchThreshold = threshold
+		s.batchTrigger = fn
+		return nil
+	}
+}
+
+// startBatchTrigger begins opening batch windows until the returned stop
+// function is called. Stop waits for the trigger goroutine to exit.
+func (s *Swarm) startBatchTrigger(ctx context.Context) (stop func()) {
+	if s.batchTrigger == nil {
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		period := s.target().Frequency
+		ticker := s.newTicker(period)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C():
+				if window, ok := s.batchWindow(period); ok {
+					s.batchTrigger(window)
+				}
+				if next := s.target().Frequency; next != period {
+					period = next
+					ticker.Reset(period)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+// batchWindow opens the window for the period that just ended, if the
+// swarm is coherent enough and has agents ready.
+func (s *Swarm) batchWindow(period time.Duration) (BatchWindow, bool) {
+	coherence := s.MeasureCoherence()
+	if coherence < s.batchThreshold {
+		return BatchWindow{}, false
+	}
+
+	agents := s.collectAgents()
+	phases := make([]float64, len(agents))
+	for i, a := range agents {
+		phases[i] = a.Phase()
+	}
+	mean := circularMean(phases)
+
+	var ready []string
+	for i, a := range agents {
+		if math.Abs(core.PhaseDifference(phases[i], mean)) <= batchReach {
+			ready = append(ready, a.ID)
+		}
+	}
+	if len(ready) == 0 {
+		return BatchWindow{}, false
+	}
+	slices.Sort(ready)
+	return BatchWindow{
+		Time:      s.now(),
+		Period:    period,
+		Phase:     mean,
+		Coherence: coherence,
+		Ready:     ready,
+	}, true
+}
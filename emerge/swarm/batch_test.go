@@ -0,0 +1,68 @@
+package swarm_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+func TestWithBatchTrigger(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		const period = 200 * time.Millisecond
+		var (
+			mu      sync.Mutex
+			windows []swarm.BatchWindow
+		)
+		goalState := core.State{Phase: 0, Frequency: period, Coherence: 0.85}
+		s, err := swarm.New(20, goalState, swarm.WithSeed(3),
+			swarm.WithBatchTrigger(0.8, func(w swarm.BatchWindow) {
+				mu.Lock()
+				defer mu.Unlock()
+				windows = append(windows, w)
+			}))
+		require.NoError(t, err)
+		defer s.Close()
+		start := time.Now()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		require.ErrorIs(t, s.RunContinuous(ctx), context.DeadlineExceeded)
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.NotEmpty(t, windows, "a coherent swarm should open batch windows")
+		assert.Less(t, len(windows), int(10*time.Second/period)+1, "at most one window per period")
+		last := start
+		for _, w := range windows {
+			assert.GreaterOrEqual(t, w.Coherence, 0.8)
+			assert.Equal(t, period, w.Period)
+			assert.NotEmpty(t, w.Ready)
+			assert.GreaterOrEqual(t, w.Time.Sub(last), period, "windows are a period apart")
+			last = w.Time
+		}
+	})
+}
+
+func TestWithBatchTriggerValidation(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8}
+	noop := func(swarm.BatchWindow) {}
+
+	_, err := swarm.New(10, goalState, swarm.WithBatchTrigger(1.5, noop))
+	require.Error(t, err)
+	_, err = swarm.New(10, goalState, swarm.WithBatchTrigger(-0.1, noop))
+	require.Error(t, err)
+	_, err = swarm.New(10, goalState, swarm.WithBatchTrigger(0.8, nil))
+	require.Error(t, err)
+}
@@ -3,6 +3,7 @@ package swarm
 import (
 	"context"
 	"fmt"
+	"runtime"
 	"testing"
 	"time"
 
@@ -220,3 +221,146 @@ func BenchmarkMemoryUsage(b *testing.B) {
 		}
 	})
 }
+
+// BenchmarkGossipFanout compares one tick of the swarm's update loop when
+// agents couple with all of their neighbors against a gossip fanout of 10,
+// in a densely connected swarm.
+func BenchmarkGossipFanout(b *testing.B) {
+	const (
+		size   = 5000
+		degree = 200
+	)
+
+	// Ring lattice: each agent links to the degree/2 agents on either side
+	dense := func(s *Swarm) error {
+		agents := s.collectAgents()
+		for i, a := range agents {
+			for d := 1; d <= degree/2; d++ {
+				other := agents[(i+d)%len(agents)]
+				a.ConnectTo(other.ID, other)
+				other.ConnectTo(a.ID, a)
+			}
+		}
+		return nil
+	}
+
+	cases := []struct {
+		name string
+		opts []Option
+	}{
+		{"full", nil},
+		{"fanout_10", []Option{WithGossipFanout(10)}},
+	}
+
+	for _, tc := range cases {
+		b.Run(tc.name, func(b *testing.B) {
+			opts := append([]Option{WithTopology(dense), WithSeed(1)}, tc.opts...)
+			s, err := New(size, core.State{
+				Phase:     0,
+				Frequency: 100 * time.Millisecond,
+				Coherence: 0.7,
+			}, opts...)
+			if err != nil {
+				b.Fatal(err)
+			}
+			defer s.Close()
+
+			b.ReportAllocs()
+			for b.Loop() {
+				s.Step()
+			}
+		})
+	}
+}
+
+// BenchmarkParallelUpdate measures one tick of agent updates at N=5000
+// with the update engine sharded across 1 up to GOMAXPROCS workers.
+func BenchmarkParallelUpdate(b *testing.B) {
+	const size = 5000
+
+	for n := 1; ; n *= 2 {
+		n = min(n, runtime.GOMAXPROCS(0))
+		b.Run(fmt.Sprintf("workers_%d", n), func(b *testing.B) {
+			s, err := New(size, core.State{
+				Phase:     0,
+				Frequency: 100 * time.Millisecond,
+				Coherence: 0.9,
+			}, WithParallelism(n), WithSeed(1))
+			if err != nil {
+				b.Fatal(err)
+			}
+			defer s.Close()
+
+			gds := s.goalDirectedSync
+			gds.targetPattern = &core.TargetPattern{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.9}
+			current := gds.measureSystemPattern()
+			completed := gds.completionEngine.CompletePattern(current, core.IdentifyGaps(current, gds.targetPattern))
+
+			b.ReportAllocs()
+			for b.Loop() {
+				gds.applyPatternCompletion(completed)
+			}
+		})
+		if n == runtime.GOMAXPROCS(0) {
+			break
+		}
+	}
+}
+
+// BenchmarkMeasureCoherenceIncremental changes one agent's phase and
+// measures coherence, as a monitor sampling a running swarm does. The cost
+// per iteration does not grow with the swarm's size.
+//
+//nolint:intrange // b.N is not a constant
+func BenchmarkMeasureCoherenceIncremental(b *testing.B) {
+	for _, size := range []int{100, 1000, 10000} {
+		b.Run(fmt.Sprintf("size_%d", size), func(b *testing.B) {
+			s, err := New(size, core.State{
+				Phase:     0,
+				Frequency: 100 * time.Millisecond,
+				Coherence: 0.7,
+			})
+			if err != nil {
+				b.Fatal(err)
+			}
+			defer s.Close()
+			agents := s.collectAgents()
+			_ = s.MeasureCoherence()
+
+			b.ResetTimer()
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				a := agents[i%len(agents)]
+				a.SetPhase(a.Phase() + 0.01)
+				_ = s.MeasureCoherence()
+			}
+		})
+	}
+}
+
+// BenchmarkStorage builds a swarm of scale.Huge size with each storage and
+// runs a step, comparing memory and allocations.
+//
+//nolint:intrange // b.N is not a constant
+func BenchmarkStorage(b *testing.B) {
+	const size = 5000
+	for _, st := range []Storage{StorageMap, StorageSlice} {
+		b.Run(st.String(), func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				s, err := New(size, core.State{
+					Phase:     0,
+					Frequency: 100 * time.Millisecond,
+					Coherence: 0.7,
+				}, WithStorage(st))
+				if err != nil {
+					b.Fatal(err)
+				}
+				_ = s.MeasureCoherence()
+				s.Close()
+			}
+		})
+	}
+}
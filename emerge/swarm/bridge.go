@@ -0,0 +1,205 @@
+package swarm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+// bridgeGain scales how far a bridged swarm's target phase moves toward
+// its bridge partners each tick.
+const bridgeGain = 0.1
+
+// bridgeMu guards the bridge tables of every swarm. Bridges span two
+// swarms, so one lock for all of them keeps Bridge(a, b) and Bridge(b, a)
+// from deadlocking.
+var bridgeMu sync.Mutex
+
+// bridgeEdge is one bridge: a two-way link between an agent of this swarm
+// and an agent of a peer swarm.
+type bridgeEdge struct {
+	local, peer *agent.Agent
+}
+
+// Bridge couples two swarms through bridges pairs of agents, drawn at
+// random from each (from the swarm's own source with WithSeed), turning
+// separate swarms into a federation whose sub-swarms pull each other into
+// phase. Each pair is linked both ways: every agent treats its partner as
+// one more neighbor. Agents key their partners by the partner's swarm ID
+// and agent ID, e.g. "swarm-2/agent-7", so the same agent IDs in both
+// swarms do not collide.
+//
+// Each tick of the goal-directed loop, a bridged swarm moves its target
+// phase toward its partners by
+//
+//	0.1 · Σ sin(θ_partner − θ_bridge) / n
+//
+// over its n bridges, and its agents follow the target as usual, so
+// swarms that synchronized internally at different phases drift to a
+// shared one. Target reports the drifted phase. Bridged swarms still run
+// separately, and each measures coherence over its own agents;
+// MeasureGlobalCoherence measures the federation. Bridges
+// survive topology rebuilds, and one whose agent is removed goes with it.
+// They are not carried over by Clone. A pair of swarms is bridged at most
+// once; call Unbridge before bridging again.
+func Bridge(a, b *Swarm, bridges int) error {
+	switch {
+	case a == nil || b == nil:
+		return errors.New("bridge: swarm must not be nil")
+	case a == b:
+		return errors.New("bridge: cannot bridge a swarm to itself")
+	case a.id == b.id:
+		return fmt.Errorf("bridge: both swarms have ID %q", a.id)
+	case bridges < 1:
+		return fmt.Errorf("bridge: bridges must be at least 1, got %d", bridges)
+	case bridges > min(a.Size(), b.Size()):
+		return fmt.Errorf("bridge: %d bridges exceed the smaller swarm's %d agents", bridges, min(a.Size(), b.Size()))
+	}
+
+	bridgeMu.Lock()
+	defer bridgeMu.Unlock()
+	if _, ok := a.bridges[b]; ok {
+		return fmt.Errorf("bridge: swarms %s and %s are already bridged", a.id, b.id)
+	}
+
+	fromA, fromB := a.sampleAgents(bridges), b.sampleAgents(bridges)
+	edgesA := make([]bridgeEdge, bridges)
+	edgesB := make([]bridgeEdge, bridges)
+	for i := range bridges {
+		fromA[i].ConnectTo(b.bridgeKey(fromB[i]), fromB[i])
+		fromB[i].ConnectTo(a.bridgeKey(fromA[i]), fromA[i])
+		edgesA[i] = bridgeEdge{local: fromA[i], peer: fromB[i]}
+		edgesB[i] = bridgeEdge{local: fromB[i], peer: fromA[i]}
+	}
+	a.addBridges(b, edgesA)
+	b.addBridges(a, edgesB)
+	return nil
+}
+
+// Unbridge removes the bridges Bridge created between a and b. It fails
+// if they are not bridged.
+func Unbridge(a, b *Swarm) error {
+	if a == nil || b == nil {
+		return errors.New("unbridge: swarm must not be nil")
+	}
+
+	bridgeMu.Lock()
+	defer bridgeMu.Unlock()
+	edges, ok := a.bridges[b]
+	if !ok {
+		return fmt.Errorf("unbridge: %w: %s and %s", ErrNotBridged, a.id, b.id)
+	}
+	for _, e := range edges {
+		e.local.DisconnectFrom(b.bridgeKey(e.peer))
+		e.peer.DisconnectFrom(a.bridgeKey(e.local))
+	}
+	delete(a.bridges, b)
+	delete(b.bridges, a)
+	return nil
+}
+
+// MeasureGlobalCoherence measures coherence across the agents of all the
+// given swarms together, as one swarm's MeasureCoherence does for its own:
+// bridged sub-swarms each synchronized internally but out of phase with
+// each other score low here however high each scores alone. It returns 0
+// without agents.
+func MeasureGlobalCoherence(swarms ...*Swarm) float64 {
+	var phases []float64
+	for _, s := range swarms {
+		if s == nil {
+			continue
+		}
+		for _, a := range s.collectAgents() {
+			phases = append(phases, a.Phase())
+		}
+	}
+	if len(phases) == 0 {
+		return 0
+	}
+	return core.MeasureCoherence(phases)
+}
+
+// bridgeKey is the key a peer swarm's agents store this swarm's agent a
+// under.
+func (s *Swarm) bridgeKey(a *agent.Agent) string {
+	return s.id + "/" + a.ID
+}
+
+// addBridges records edges to peer. The caller holds bridgeMu.
+func (s *Swarm) addBridges(peer *Swarm, edges []bridgeEdge) {
+	if s.bridges == nil {
+		s.bridges = make(map[*Swarm][]bridgeEdge)
+	}
+	s.bridges[peer] = edges
+}
+
+// dropBridges removes the bridges of an agent leaving the swarm.
+func (s *Swarm) dropBridges(a *agent.Agent) {
+	bridgeMu.Lock()
+	defer bridgeMu.Unlock()
+	for peer, edges := range s.bridges {
+		edges = slices.DeleteFunc(edges, func(e bridgeEdge) bool {
+			if e.local != a {
+				return false
+			}
+			e.peer.DisconnectFrom(s.bridgeKey(a))
+			return true
+		})
+		peer.bridges[s] = slices.DeleteFunc(peer.bridges[s], func(e bridgeEdge) bool { return e.peer == a })
+		if len(edges) == 0 {
+			delete(s.bridges, peer)
+			delete(peer.bridges, s)
+			continue
+		}
+		s.bridges[peer] = edges
+	}
+}
+
+// restoreBridges reconnects the swarm's side of its bridges after a
+// topology rebuild cleared every agent's links.
+func (s *Swarm) restoreBridges() {
+	bridgeMu.Lock()
+	defer bridgeMu.Unlock()
+	for peer, edges := range s.bridges {
+		for _, e := range edges {
+			e.local.ConnectTo(peer.bridgeKey(e.peer), e.peer)
+		}
+	}
+}
+
+// bridgePull returns the mean pull, Σ sin(θ_partner − θ_bridge) / n, of
+// the swarm's bridges, or false if it has none.
+func (s *Swarm) bridgePull() (float64, bool) {
+	bridgeMu.Lock()
+	defer bridgeMu.Unlock()
+	pull, n := 0.0, 0
+	for _, edges := range s.bridges {
+		for _, e := range edges {
+			pull += math.Sin(e.peer.Phase() - e.local.Phase())
+			n++
+		}
+	}
+	if n == 0 {
+		return 0, false
+	}
+	return pull / float64(n), true
+}
+
+// followBridges moves the run's target phase, and the swarm's, toward the
+// swarm's bridge partners.
+func (gds *GoalDirectedSync) followBridges(run *syncRun) {
+	pull, ok := gds.swarm.bridgePull()
+	if !ok {
+		return
+	}
+	phase := core.WrapPhase(run.target.Phase + bridgeGain*pull)
+	run.target.Phase = phase
+	gds.swarm.targetMu.Lock()
+	gds.swarm.goalState.Phase = phase
+	gds.swarm.targetMu.Unlock()
+}
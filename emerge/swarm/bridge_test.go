@@ -0,0 +1,107 @@
+package swarm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+// TestBridge bridges two swarms synchronized internally at nearly opposite
+// phases. Alone they stay apart; bridged they drift to a shared phase.
+func TestBridge(t *testing.T) {
+	t.Parallel()
+
+	build := func(phase float64, seed int64) *swarm.Swarm {
+		goalState := core.State{Phase: phase, Frequency: 200 * time.Millisecond, Coherence: 0.9}
+		s, err := swarm.New(30, goalState, swarm.WithSeed(seed),
+			swarm.WithInitialPhaseFunc(func(i int) float64 { return phase + 0.01*float64(i%5) }))
+		require.NoError(t, err)
+		t.Cleanup(s.Close)
+		return s
+	}
+	run := func(bridged bool) (before, after float64) {
+		a, b := build(0, 1), build(3, 2)
+		before = swarm.MeasureGlobalCoherence(a, b)
+		if bridged {
+			require.NoError(t, swarm.Bridge(a, b, 5))
+		}
+		for range 200 {
+			a.Step()
+			b.Step()
+		}
+		assert.Greater(t, a.MeasureCoherence(), 0.9)
+		assert.Greater(t, b.MeasureCoherence(), 0.9)
+		return before, swarm.MeasureGlobalCoherence(a, b)
+	}
+	before, alone := run(false)
+	_, bridged := run(true)
+	assert.Less(t, before, 0.1)
+	assert.Less(t, alone, 0.1, "unbridged swarms stay apart")
+	assert.Greater(t, bridged, 0.8, "bridged swarms share a phase")
+}
+
+func TestBridgeLinks(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{Frequency: 200 * time.Millisecond, Coherence: 0.9}
+	build := func(id string) *swarm.Swarm {
+		s, err := swarm.New(10, goalState, swarm.WithID(id), swarm.WithSeed(1))
+		require.NoError(t, err)
+		t.Cleanup(s.Close)
+		return s
+	}
+	a, b := build("a"), build("b")
+	neighbors := func(s *swarm.Swarm) map[string]int {
+		counts := make(map[string]int)
+		for _, ag := range s.AgentsSorted() {
+			counts[ag.ID] = ag.NeighborCount()
+		}
+		return counts
+	}
+	beforeA, beforeB := neighbors(a), neighbors(b)
+	components := len(a.CoherenceByComponent())
+
+	require.Error(t, swarm.Bridge(a, a, 1))
+	require.Error(t, swarm.Bridge(a, b, 0))
+	require.Error(t, swarm.Bridge(a, b, 11))
+	require.ErrorIs(t, swarm.Unbridge(a, b), swarm.ErrNotBridged)
+
+	require.NoError(t, swarm.Bridge(a, b, 3))
+	require.Error(t, swarm.Bridge(b, a, 1), "already bridged")
+
+	// Each bridge links one agent of each swarm both ways, keyed by
+	// swarm ID even though the agent IDs overlap
+	bridged := 0
+	for _, ag := range a.AgentsSorted() {
+		for _, n := range ag.NeighborList() {
+			if got, ok := a.Agent(n.ID); ok && got == n {
+				continue
+			}
+			bridged++
+			assert.True(t, ag.IsConnectedTo("b/"+n.ID))
+			assert.True(t, n.IsConnectedTo("a/"+ag.ID))
+		}
+	}
+	assert.Equal(t, 3, bridged)
+	assert.Len(t, a.CoherenceByComponent(), components, "bridges leave components alone")
+
+	require.NoError(t, swarm.Unbridge(b, a))
+	assert.Equal(t, beforeA, neighbors(a))
+	assert.Equal(t, beforeB, neighbors(b))
+	require.ErrorIs(t, swarm.Unbridge(a, b), swarm.ErrNotBridged)
+
+	// A bridged agent leaving takes its bridge with it
+	require.NoError(t, swarm.Bridge(a, b, 10))
+	leaving, ok := a.Agent("agent-0")
+	require.True(t, ok)
+	require.NoError(t, a.RemoveAgent("agent-0"))
+	for _, ag := range b.AgentsSorted() {
+		assert.NotContains(t, ag.NeighborList(), leaving)
+	}
+	require.NoError(t, swarm.Unbridge(a, b))
+}
@@ -0,0 +1,122 @@
+package swarm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/goal"
+	"github.com/carlisia/bio-adapt/emerge/scale"
+)
+
+// defaultBuilderFrequency is the target oscillation period used when no
+// pattern is given. It matches the client/emerge builder.
+const defaultBuilderFrequency = 1000 * time.Millisecond
+
+// Builder provides a fluent API for configuring a swarm from a goal and a
+// scale, mirroring the client/emerge builder for users of this package.
+// Errors from individual calls are reported by Build.
+type Builder struct {
+	goal            goal.Type
+	scale           scale.Size
+	targetCoherence float64 // 0 means the scale's default
+	phase           float64
+	frequency       time.Duration
+	topology        func(*Swarm) error
+	opts            []Option
+}
+
+// NewBuilder creates a builder with the same defaults as client/emerge:
+// the MinimizeAPICalls goal at tiny scale.
+func NewBuilder() *Builder {
+	return &Builder{
+		goal:      goal.MinimizeAPICalls,
+		scale:     scale.Tiny,
+		frequency: defaultBuilderFrequency,
+	}
+}
+
+// WithGoal sets the business goal. Its tuned configuration drives
+// goal-directed synchronization.
+func (b *Builder) WithGoal(g goal.Type) *Builder {
+	b.goal = g
+	return b
+}
+
+// WithScale sets the scale, which determines the agent count and the
+// default target coherence.
+func (b *Builder) WithScale(s scale.Size) *Builder {
+	b.scale = s
+	return b
+}
+
+// WithTargetCoherence overrides the scale's default target coherence.
+func (b *Builder) WithTargetCoherence(coherence float64) *Builder {
+	b.targetCoherence = coherence
+	return b
+}
+
+// WithPattern sets the target phase and oscillation period from a pattern.
+// A non-zero pattern coherence also sets the target coherence.
+func (b *Builder) WithPattern(p core.TargetPattern) *Builder {
+	b.phase = p.Phase
+	b.frequency = p.Frequency
+	if p.Coherence != 0 {
+		b.targetCoherence = p.Coherence
+	}
+	return b
+}
+
+// WithTopology uses a custom topology builder (see the WithTopology option).
+func (b *Builder) WithTopology(builder func(*Swarm) error) *Builder {
+	b.topology = builder
+	return b
+}
+
+// WithOptions appends swarm options applied after the builder's own.
+func (b *Builder) WithOptions(opts ...Option) *Builder {
+	b.opts = append(b.opts, opts...)
+	return b
+}
+
+// Build validates the combination and creates the swarm. Unlike New, which
+// quietly lowers an unreachable target, Build rejects a target coherence
+// above the practical limit for the scale's agent count.
+func (b *Builder) Build() (*Swarm, error) {
+	if b.scale.String() == "unknown" {
+		return nil, fmt.Errorf("unknown scale %d", int(b.scale))
+	}
+	size := b.scale.DefaultAgentCount()
+
+	coherence := b.targetCoherence
+	if coherence == 0 {
+		coherence = b.scale.DefaultTargetCoherence()
+	}
+	if coherence < 0 || coherence > 1 || math.IsNaN(coherence) {
+		return nil, fmt.Errorf("target coherence must be between 0 and 1, got %v", coherence)
+	}
+	if limit := GetCoherenceLimits(size).Practical; coherence > limit {
+		return nil, fmt.Errorf("target coherence %.3f exceeds the practical limit %.3f for %s scale (%d agents)",
+			coherence, limit, b.scale, size)
+	}
+	if b.frequency <= 0 {
+		return nil, errors.New("pattern frequency must be positive")
+	}
+
+	opts := []Option{
+		WithGoal(b.goal),
+		WithGoalConfig(For(b.goal).WithSize(size)),
+	}
+	if b.topology != nil {
+		opts = append(opts, WithTopology(b.topology))
+	}
+	opts = append(opts, b.opts...)
+
+	return New(size, core.State{
+		Phase:     b.phase,
+		Frequency: b.frequency,
+		Coherence: coherence,
+	}, opts...)
+}
@@ -0,0 +1,90 @@
+package swarm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/goal"
+	"github.com/carlisia/bio-adapt/emerge/scale"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+func TestBuilder(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := swarm.NewBuilder().Build()
+		require.NoError(t, err)
+		defer s.Close()
+
+		assert.Equal(t, scale.Tiny.DefaultAgentCount(), s.Size())
+		assert.Equal(t, goal.MinimizeAPICalls, s.Goal())
+		assert.InDelta(t, scale.Tiny.DefaultTargetCoherence(), s.TargetState().Coherence, 1e-9)
+		assert.Equal(t, time.Second, s.TargetState().Frequency)
+	})
+
+	t.Run("goal scale and pattern", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := swarm.NewBuilder().
+			WithGoal(goal.DistributeLoad).
+			WithScale(scale.Small).
+			WithPattern(core.TargetPattern{Phase: 1.5, Frequency: 200 * time.Millisecond}).
+			WithTargetCoherence(0.8).
+			Build()
+		require.NoError(t, err)
+		defer s.Close()
+
+		assert.Equal(t, scale.Small.DefaultAgentCount(), s.Size())
+		assert.Equal(t, goal.DistributeLoad, s.Goal())
+		target := s.TargetState()
+		assert.InDelta(t, 1.5, target.Phase, 1e-9)
+		assert.Equal(t, 200*time.Millisecond, target.Frequency)
+		assert.InDelta(t, 0.8, target.Coherence, 1e-9)
+	})
+
+	t.Run("custom topology", func(t *testing.T) {
+		t.Parallel()
+
+		called := false
+		s, err := swarm.NewBuilder().
+			WithTopology(func(*swarm.Swarm) error {
+				called = true
+				return nil
+			}).
+			Build()
+		require.NoError(t, err)
+		defer s.Close()
+		assert.True(t, called, "topology builder should be used")
+	})
+
+	t.Run("invalid combinations", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name    string
+			builder *swarm.Builder
+		}{
+			{"coherence above practical limit", swarm.NewBuilder().WithScale(scale.Tiny).WithTargetCoherence(0.995)},
+			{"coherence above one", swarm.NewBuilder().WithTargetCoherence(1.5)},
+			{"negative coherence", swarm.NewBuilder().WithTargetCoherence(-0.1)},
+			{"unknown scale", swarm.NewBuilder().WithScale(scale.Size(99))},
+			{"non-positive frequency", swarm.NewBuilder().WithPattern(core.TargetPattern{Coherence: 0.8})},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				s, err := tt.builder.Build()
+				require.Error(t, err)
+				assert.Nil(t, s)
+			})
+		}
+	})
+}
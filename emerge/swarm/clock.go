@@ -0,0 +1,70 @@
+package swarm
+
+import (
+	"errors"
+	"time"
+)
+
+// Clock is the swarm's source of time. The update loops of Run and
+// RunContinuous, observers and batch windows tick on its tickers, and
+// events, recovery reports and convergence times are stamped with its Now.
+// The default is the system clock.
+type Clock interface {
+	Now() time.Time
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker delivers ticks on a channel, like time.Ticker.
+type Ticker interface {
+	C() <-chan time.Time
+	Reset(d time.Duration)
+	Stop()
+}
+
+// WithClock makes the swarm keep time by c instead of the system clock.
+// With a fake clock such as clocktest.Clock, tests drive convergence by
+// advancing time themselves: a run that takes seconds on the system clock
+// finishes as fast as the updates can be computed, and in the same number
+// of ticks every time.
+//
+// Contexts passed to Run and RunContinuous still expire by the system
+// clock.
+func WithClock(c Clock) Option {
+	return func(s *Swarm) error {
+		if c == nil {
+			return errors.New("clock must not be nil")
+		}
+		s.clock = c
+		return nil
+	}
+}
+
+// now returns the current time by the swarm's clock.
+func (s *Swarm) now() time.Time {
+	if s.clock == nil {
+		return time.Now()
+	}
+	return s.clock.Now()
+}
+
+// since returns the time elapsed since t by the swarm's clock.
+func (s *Swarm) since(t time.Time) time.Duration {
+	return s.now().Sub(t)
+}
+
+// newTicker starts a ticker on the swarm's clock.
+func (s *Swarm) newTicker(d time.Duration) Ticker {
+	if s.clock == nil {
+		return systemTicker{time.NewTicker(d)}
+	}
+	return s.clock.NewTicker(d)
+}
+
+// systemTicker adapts time.Ticker to Ticker.
+type systemTicker struct {
+	t *time.Ticker
+}
+
+func (t systemTicker) C() <-chan time.Time   { return t.t.C }
+func (t systemTicker) Reset(d time.Duration) { t.t.Reset(d) }
+func (t systemTicker) Stop()                 { t.t.Stop() }
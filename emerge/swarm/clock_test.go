@@ -0,0 +1,58 @@
+package swarm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+	"github.com/carlisia/bio-adapt/emerge/swarm/clocktest"
+)
+
+// TestWithClock drives a swarm to convergence on a fake clock. The run
+// advances in whole ticks of the swarm's time and takes only as long as
+// the updates take to compute.
+func TestWithClock(t *testing.T) {
+	t.Parallel()
+
+	_, err := swarm.New(10, core.State{Frequency: 200 * time.Millisecond, Coherence: 0.8}, swarm.WithClock(nil))
+	require.Error(t, err)
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := clocktest.New(start)
+	s, err := swarm.New(50, core.State{
+		Phase:     0,
+		Frequency: 200 * time.Millisecond,
+		Coherence: 0.95,
+	}, swarm.WithClock(clock), swarm.WithSeed(1))
+	require.NoError(t, err)
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- s.Run(ctx) }()
+
+	clock.WaitForTickers(1)
+	wall := time.Now()
+	for running := true; running; {
+		select {
+		case err = <-done:
+			running = false
+		default:
+			clock.Advance(50 * time.Millisecond)
+		}
+	}
+	require.NoError(t, err)
+
+	elapsed := clock.Now().Sub(start)
+	assert.InDelta(t, 0.95, s.MeasureCoherence(), 0.05)
+	assert.Positive(t, elapsed)
+	assert.Zero(t, elapsed%s.TickInterval(), "time moves only in ticks")
+	assert.Less(t, time.Since(wall), elapsed, "the run takes less wall time than swarm time")
+	assert.Zero(t, clock.Tickers(), "the run stops its ticker")
+}
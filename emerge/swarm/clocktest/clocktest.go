@@ -0,0 +1,167 @@
+// Package clocktest provides a fake swarm.Clock for tests. Time stands
+// still until the test advances it, so a swarm's convergence runs tick by
+// tick under the test's control, without sleeping and the same way every
+// time.
+//
+//	clock := clocktest.New(time.Time{})
+//	s, _ := swarm.New(50, target, swarm.WithClock(clock), swarm.WithSeed(1))
+//	done := make(chan error, 1)
+//	go func() { done <- s.Run(ctx) }()
+//	clock.WaitForTickers(1)
+//	for {
+//		select {
+//		case err := <-done:
+//			return err
+//		default:
+//			clock.Advance(interval)
+//		}
+//	}
+package clocktest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+// Clock is a fake clock that only moves when Advance is called.
+type Clock struct {
+	mu      sync.Mutex
+	changed *sync.Cond // Signaled when tickers start or stop
+	now     time.Time
+	tickers []*ticker
+}
+
+// Clock implements swarm.Clock.
+var _ swarm.Clock = (*Clock)(nil)
+
+// New creates a fake clock set to start.
+func New(start time.Time) *Clock {
+	c := &Clock{now: start}
+	c.changed = sync.NewCond(&c.mu)
+	return c
+}
+
+// Now returns the clock's current time.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTicker starts a ticker that fires every d of the clock's time. Unlike
+// time.Ticker, its channel is unbuffered: Advance hands each tick over and
+// waits for it to be taken, so no tick is dropped.
+func (c *Clock) NewTicker(d time.Duration) swarm.Ticker {
+	if d <= 0 {
+		panic("clocktest: non-positive interval for NewTicker")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &ticker{
+		clock:   c,
+		period:  d,
+		next:    c.now.Add(d),
+		ch:      make(chan time.Time),
+		stopped: make(chan struct{}),
+	}
+	c.tickers = append(c.tickers, t)
+	c.changed.Broadcast()
+	return t
+}
+
+// Advance moves the clock forward by d, firing every tick that falls due
+// on the way in time order. Each tick waits until its ticker's owner takes
+// it or stops the ticker, so a loop handles one tick before the next fires
+// and before Advance returns. A ticker whose owner neither reads nor stops
+// it blocks Advance.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	end := c.now.Add(d)
+	for {
+		var due *ticker
+		for _, t := range c.tickers {
+			if !t.next.After(end) && (due == nil || t.next.Before(due.next)) {
+				due = t
+			}
+		}
+		if due == nil {
+			break
+		}
+		c.now = due.next
+		due.next = due.next.Add(due.period)
+		now := c.now
+		c.mu.Unlock()
+
+		select {
+		case due.ch <- now:
+		case <-due.stopped:
+		}
+
+		c.mu.Lock()
+	}
+	c.now = end
+	c.mu.Unlock()
+}
+
+// Tickers returns the number of running tickers.
+func (c *Clock) Tickers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.tickers)
+}
+
+// WaitForTickers blocks until at least n tickers are running, so a test
+// can wait for a swarm's loops to start before advancing the clock.
+func (c *Clock) WaitForTickers(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.tickers) < n {
+		c.changed.Wait()
+	}
+}
+
+// ticker is a ticker on a fake clock.
+type ticker struct {
+	clock   *Clock
+	period  time.Duration
+	next    time.Time // Guarded by clock.mu
+	ch      chan time.Time
+	stopped chan struct{}
+	once    sync.Once
+}
+
+func (t *ticker) C() <-chan time.Time {
+	return t.ch
+}
+
+// Reset stops the ticker and restarts it with period d from the clock's
+// current time.
+func (t *ticker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("clocktest: non-positive interval for Ticker.Reset")
+	}
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	t.period = d
+	t.next = t.clock.now.Add(d)
+}
+
+// Stop turns the ticker off. A tick Advance is handing over is abandoned.
+func (t *ticker) Stop() {
+	t.once.Do(func() {
+		c := t.clock
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		for i, other := range c.tickers {
+			if other == t {
+				c.tickers = append(c.tickers[:i], c.tickers[i+1:]...)
+				break
+			}
+		}
+		close(t.stopped)
+		c.changed.Broadcast()
+	})
+}
@@ -0,0 +1,57 @@
+package clocktest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/carlisia/bio-adapt/emerge/swarm/clocktest"
+)
+
+func TestClock(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := clocktest.New(start)
+	assert.Equal(t, start, clock.Now())
+
+	clock.Advance(time.Second)
+	assert.Equal(t, start.Add(time.Second), clock.Now(), "time moves only when advanced")
+
+	ticker := clock.NewTicker(100 * time.Millisecond)
+	assert.Equal(t, 1, clock.Tickers())
+
+	var ticks []time.Time
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for tick := range ticker.C() {
+			ticks = append(ticks, tick)
+			if len(ticks) == 5 {
+				// Stopping abandons the tick being handed over, so Advance returns
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+
+	clock.Advance(250 * time.Millisecond)
+	ticker.Reset(200 * time.Millisecond)
+	clock.Advance(time.Second)
+	<-done
+
+	base := start.Add(time.Second)
+	assert.Equal(t, []time.Time{
+		base.Add(100 * time.Millisecond),
+		base.Add(200 * time.Millisecond),
+		base.Add(450 * time.Millisecond), // Reset at 250ms
+		base.Add(650 * time.Millisecond),
+		base.Add(850 * time.Millisecond),
+	}, ticks)
+	assert.Equal(t, base.Add(1250*time.Millisecond), clock.Now())
+
+	ticker.Stop() // Stopping twice is harmless
+	assert.Zero(t, clock.Tickers())
+	clock.Advance(time.Second)
+}
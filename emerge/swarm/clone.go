@@ -0,0 +1,226 @@
+package swarm
+
+import (
+	"fmt"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/internal/config"
+)
+
+// Clone creates a new swarm from s's current state, so several swarms can
+// run from one initial condition, e.g. to compare strategies fairly. Each
+// agent is copied with the same ID, phase, frequency, natural frequency,
+// energy, influence, stubbornness, local goal and goal, and wired to the
+// same neighbors with the same link weights. The clone shares no agents
+// with s, so running or mutating one leaves the other alone.
+//
+// The target, config, goal, goal config, strategy, decision maker, phase
+// bands, hub, reference rhythm, gossip fanout, adjustment cooldown, energy
+// model, coupling strength, parallelism, plateau detection, adaptive
+// target, coherence window, stubbornness cap, tick and observer intervals,
+// goal blend, agent ID func, min neighbors, storage, max agents and
+// recovery config carry over. Observers, callbacks, the monitor, the event
+// log, remote neighbors and the swarm ID do not; pass them in opts, which
+// are applied after the carried-over settings and so can also override
+// them, e.g. WithStrategy. Bridges to other swarms do not carry over
+// either. A seeded swarm's clone draws its own seed from s's source: it is
+// reproducible but makes different random choices.
+func (s *Swarm) Clone(opts ...Option) (*Swarm, error) {
+	sources := s.collectAgents()
+
+	carried := []Option{
+		WithConfig(s.config),
+		WithRecoveryConfig(s.recoveryConfig),
+		WithStorage(s.storage),
+		withClonedAgents(sources),
+		WithTopology(cloneWiring(sources)),
+	}
+	if s.maxAgents > 0 {
+		carried = append(carried, WithMaxAgents(s.maxAgents))
+	}
+	if s.goalConfig != nil {
+		cfg := *s.goalConfig
+		carried = append(carried, WithGoalConfig(&cfg))
+	}
+	carried = append(carried, WithGoal(s.goalType))
+	if s.strategyName != "" {
+		carried = append(carried, WithStrategy(s.strategyName))
+	}
+	if s.decisionMakerName != "" {
+		carried = append(carried, WithDecisionMaker(s.decisionMakerName))
+	}
+	if s.costModel != nil {
+		carried = append(carried, WithCostModel(s.costModel))
+	}
+	if s.minNeighbors > 0 {
+		carried = append(carried, WithMinNeighbors(s.minNeighbors))
+	}
+	if s.agentIDFunc != nil {
+		carried = append(carried, WithAgentIDFunc(s.agentIDFunc))
+	}
+	if s.blendGoals {
+		carried = append(carried, WithGoalBlend(s.goalBlend))
+	}
+	if len(s.bands) > 0 {
+		carried = append(carried, WithPhaseBands(s.bands))
+	}
+	if _, ok := s.hub(); ok {
+		carried = append(carried, WithHub(s.hubID, s.hubInfluence))
+	}
+	if s.reference != nil {
+		carried = append(carried, WithReferenceRhythm(s.reference))
+	}
+	if k := s.GossipFanout(); k > 0 {
+		carried = append(carried, WithGossipFanout(k))
+	}
+	if s.cooldown > 0 {
+		carried = append(carried, WithAdjustmentCooldown(s.cooldown))
+	}
+	if s.energy.cost > 0 {
+		carried = append(carried, WithEnergyCost(s.energy.cost))
+	}
+	if s.energy.policy != nil {
+		carried = append(carried, WithRechargePolicy(s.energy.policy))
+	}
+	if s.energy.capacity > 0 {
+		carried = append(carried, WithEnergyCapacity(s.energy.capacity))
+	}
+	if s.activeFraction > 0 {
+		carried = append(carried, WithActiveFraction(s.activeFraction))
+	}
+	if noise := s.PhaseNoise(); noise > 0 {
+		carried = append(carried, WithPhaseNoise(noise))
+	}
+	if s.failures.rate > 0 {
+		carried = append(carried, WithFailureInjector(s.failures.rate, s.failures.spec))
+	}
+	if s.energy.gate > 0 {
+		carried = append(carried, WithEnergyGate(s.energy.gate))
+	}
+	if s.energy.limits.MaxSpendPerTick > 0 {
+		carried = append(carried, WithResourceLimits(WithMaxSpendPerTick(s.energy.limits.MaxSpendPerTick)))
+	}
+	if s.clock != nil {
+		carried = append(carried, WithClock(s.clock))
+	}
+	if k := s.CouplingStrength(); k > 0 {
+		carried = append(carried, WithCouplingStrength(k))
+	}
+	if s.parallelism > 0 {
+		carried = append(carried, WithParallelism(s.parallelism))
+	}
+	if s.plateauWindow > 0 {
+		carried = append(carried, WithPlateauDetection(s.plateauWindow, s.plateauEpsilon))
+	}
+	if s.adaptiveMin > 0 {
+		carried = append(carried, WithAdaptiveTarget(s.adaptiveMin))
+	}
+	if s.decay.halfLife > 0 {
+		carried = append(carried, WithInfluenceDecay(s.decay.halfLife))
+	}
+	if s.warmupCoherence > 0 {
+		carried = append(carried, WithWarmupTarget(s.warmupCoherence, s.warmupHold))
+	}
+	if s.capStubbornness {
+		carried = append(carried, WithMaxStubbornness(s.maxStubbornness))
+	}
+	if s.smoothed != nil {
+		carried = append(carried, WithCoherenceWindow(s.smoothed.size))
+	}
+	if s.observeTick > 0 {
+		carried = append(carried, WithObserverInterval(s.observeTick))
+	}
+	if s.tick != nil {
+		carried = append(carried, WithAdaptiveTickInterval(s.tick.minInterval, s.tick.maxInterval))
+	}
+	if s.rng != nil {
+		carried = append(carried, WithSeed(int64(s.randIntn(1<<62))))
+	}
+
+	clone, err := New(len(sources), s.target(), append(carried, opts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("clone swarm %s: %w", s.id, err)
+	}
+	// Copied phases keep their meaning on the rhythm clock
+	clone.epoch = s.epoch
+	return clone, nil
+}
+
+// withClonedAgents fills a new swarm with copies of sources, in place of
+// the agents New would create.
+func withClonedAgents(sources []*agent.Agent) Option {
+	return func(s *Swarm) error {
+		s.initStorage()
+		agentConfig := config.AgentFromSwarm(s.config)
+		agentConfig.SwarmSize = s.size
+
+		for i, src := range sources {
+			var (
+				a   *agent.Agent
+				err error
+			)
+			if s.optimized {
+				a, err = agent.NewOptimizedFromConfig(src.ID, agentConfig)
+			} else {
+				a, err = agent.NewFromConfig(src.ID, agentConfig)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to clone agent %s: %w", src.ID, err)
+			}
+			copyAgentState(a, src)
+
+			if s.optimized {
+				s.agentSlice = append(s.agentSlice, a)
+				s.agentIndex[a.ID] = i
+			} else {
+				s.agents.Store(a.ID, a)
+			}
+		}
+		return nil
+	}
+}
+
+// copyAgentState copies the state that drives synchronization from src to a.
+func copyAgentState(a, src *agent.Agent) {
+	a.SetPhase(src.Phase())
+	// Setting the natural frequency resets the current one, so it goes first
+	a.SetNaturalFrequency(src.NaturalFrequency())
+	a.SetFrequency(src.Frequency())
+	a.SetEnergy(src.Energy())
+	a.SetLocalGoal(src.LocalGoal())
+	a.SetInfluence(src.Influence())
+	a.SetStubbornness(src.Stubbornness())
+	if g, ok := src.Goal(); ok {
+		a.SetGoal(g)
+	}
+	if w, ok := src.GoalBlend(); ok {
+		a.SetGoalBlend(w)
+	}
+}
+
+// cloneWiring returns a topology builder that connects each cloned agent to
+// the clones of its source's neighbors.
+func cloneWiring(sources []*agent.Agent) func(*Swarm) error {
+	members := make(map[*agent.Agent]bool, len(sources))
+	for _, src := range sources {
+		members[src] = true
+	}
+	return func(s *Swarm) error {
+		for _, src := range sources {
+			a, ok := s.Agent(src.ID)
+			if !ok {
+				continue
+			}
+			for _, n := range src.NeighborList() {
+				// Bridged agents of other swarms are not cloned
+				if !members[n] {
+					continue
+				}
+				if neighbor, ok := s.Agent(n.ID); ok {
+					a.ConnectWeighted(neighbor.ID, neighbor, src.EdgeWeight(n.ID))
+				}
+			}
+		}
+		return nil
+	}
+}
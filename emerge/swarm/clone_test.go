@@ -0,0 +1,128 @@
+package swarm_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/strategy"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+
+	for _, size := range []int{30, 150} {
+		t.Run(fmt.Sprintf("%d agents", size), func(t *testing.T) {
+			t.Parallel()
+
+			goalState := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.8}
+			src, err := swarm.New(size, goalState, swarm.WithSeed(4))
+			require.NoError(t, err)
+			defer src.Close()
+			src.AgentsSorted()[0].SetStubbornness(0.9)
+
+			clone, err := src.Clone()
+			require.NoError(t, err)
+			defer clone.Close()
+
+			assert.InDelta(t, src.MeasureCoherence(), clone.MeasureCoherence(), 1e-12, "size %d", size)
+			assert.Equal(t, src.TargetState(), clone.TargetState())
+			assert.Equal(t, src.Config(), clone.Config())
+			assert.NotEqual(t, src.ID(), clone.ID())
+
+			cloned := clone.Agents()
+			require.Len(t, cloned, size)
+			for id, a := range src.Agents() {
+				c := cloned[id]
+				require.NotNil(t, c, "agent %s", id)
+				require.NotSame(t, a, c)
+				assert.InDelta(t, a.Phase(), c.Phase(), 1e-12)
+				assert.Equal(t, a.Frequency(), c.Frequency())
+				assert.InDelta(t, a.Energy(), c.Energy(), 1e-12)
+				assert.InDelta(t, a.Influence(), c.Influence(), 1e-12)
+				assert.InDelta(t, a.Stubbornness(), c.Stubbornness(), 1e-12)
+				assert.InDelta(t, a.LocalGoal(), c.LocalGoal(), 1e-12)
+				assert.Equal(t, a.NeighborCount(), c.NeighborCount(), "agent %s wiring", id)
+				for _, n := range a.NeighborList() {
+					assert.True(t, c.IsConnectedTo(n.ID), "agent %s should keep neighbor %s", id, n.ID)
+				}
+			}
+
+			// Mutating the clone leaves the source alone
+			before := src.MeasureCoherence()
+			for _, a := range clone.Agents() {
+				a.SetPhase(0)
+				a.SetEnergy(1)
+			}
+			assert.InDelta(t, before, src.MeasureCoherence(), 1e-12)
+			for _, a := range src.Agents() {
+				assert.Greater(t, a.Energy(), 1.0)
+			}
+		})
+	}
+
+	t.Run("natural frequencies", func(t *testing.T) {
+		t.Parallel()
+
+		dist := func() func() time.Duration {
+			i := 0
+			return func() time.Duration {
+				i++
+				return time.Duration(80+40*(i%2)) * time.Millisecond
+			}
+		}()
+		goalState := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8}
+		src, err := swarm.New(20, goalState, swarm.WithSeed(4), swarm.WithFrequencyDistribution(dist))
+		require.NoError(t, err)
+		defer src.Close()
+		for range 5 {
+			src.Step()
+		}
+
+		clone, err := src.Clone()
+		require.NoError(t, err)
+		defer clone.Close()
+
+		cloned := clone.Agents()
+		drifted := false
+		for id, a := range src.Agents() {
+			c := cloned[id]
+			require.NotNil(t, c, "agent %s", id)
+			assert.Equal(t, a.NaturalFrequency(), c.NaturalFrequency(), "agent %s", id)
+			assert.Equal(t, a.Frequency(), c.Frequency(), "agent %s", id)
+			drifted = drifted || a.Frequency() != a.NaturalFrequency()
+		}
+		assert.True(t, drifted, "stepping should pull frequencies off their natural ones")
+	})
+}
+
+func TestCloneParameterSweep(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		goalState := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.8}
+		src, err := swarm.New(20, goalState, swarm.WithSeed(8))
+		require.NoError(t, err)
+		defer src.Close()
+		start := src.MeasureCoherence()
+
+		clone, err := src.Clone(swarm.WithStrategy(strategy.NameFrequencyLock))
+		require.NoError(t, err)
+		defer clone.Close()
+		assert.Equal(t, strategy.NameFrequencyLock, clone.StrategyName())
+		assert.InDelta(t, start, clone.MeasureCoherence(), 1e-12)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		require.NoError(t, clone.Run(ctx))
+		assert.GreaterOrEqual(t, clone.MeasureCoherence(), 0.8)
+		assert.InDelta(t, start, src.MeasureCoherence(), 1e-12, "running the clone leaves the source where it was")
+	})
+}
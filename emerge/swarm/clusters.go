@@ -0,0 +1,131 @@
+package swarm
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+// Cluster is a group of agents with nearby phases.
+type Cluster struct {
+	MeanPhase float64 // Circular mean of the members' phases, in [0, 2π)
+	Size      int     // Number of member agents
+}
+
+// PhaseClusters groups agents by phase. Walking around the circle, a gap
+// larger than tolerance (in radians) between neighboring phases starts a
+// new cluster, so the grouping accounts for the 2π wraparound: phases 0.05
+// and 6.23 are 0.1 apart and form a single cluster for any tolerance of at
+// least 0.1. Because clusters chain through their members, a cluster can
+// span more than tolerance.
+//
+// For ReachConsensus the clusters are the voting blocs; for batching they
+// are the batches. Clusters are sorted by size, largest first, with ties
+// broken by mean phase. A swarm with no agents has no clusters.
+func (s *Swarm) PhaseClusters(tolerance float64) []Cluster {
+	agents := s.collectAgents()
+	phases := make([]float64, len(agents))
+	for i, a := range agents {
+		phases[i] = a.Phase()
+	}
+	return clusterPhases(phases, tolerance)
+}
+
+// clusterPhases implements PhaseClusters on raw phases.
+func clusterPhases(phases []float64, tolerance float64) []Cluster {
+	if len(phases) == 0 {
+		return nil
+	}
+
+	sorted := make([]float64, len(phases))
+	for i, p := range phases {
+		sorted[i] = core.WrapPhase(p)
+	}
+	slices.Sort(sorted)
+
+	// Start just after the widest gap, including the one across 2π, so no
+	// cluster is split by where the circle happens to be cut
+	n := len(sorted)
+	start, widest := 0, -1.0
+	for i := range n {
+		gap := sorted[i] - sorted[(i+n-1)%n]
+		if i == 0 {
+			gap += 2 * math.Pi
+		}
+		if gap > widest {
+			start, widest = i, gap
+		}
+	}
+	if widest <= tolerance {
+		// No gap separates anything: the phases close the circle
+		return []Cluster{newCluster(sorted)}
+	}
+
+	var clusters []Cluster
+	members := []float64{sorted[start]}
+	for k := 1; k < n; k++ {
+		i := (start + k) % n
+		prev := (start + k - 1) % n
+		gap := sorted[i] - sorted[prev]
+		if i == 0 {
+			gap += 2 * math.Pi
+		}
+		if gap > tolerance {
+			clusters = append(clusters, newCluster(members))
+			members = members[:0]
+		}
+		members = append(members, sorted[i])
+	}
+	clusters = append(clusters, newCluster(members))
+
+	slices.SortFunc(clusters, func(a, b Cluster) int {
+		if c := cmp.Compare(b.Size, a.Size); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.MeanPhase, b.MeanPhase)
+	})
+	return clusters
+}
+
+// newCluster summarizes the member phases.
+func newCluster(phases []float64) Cluster {
+	var sumSin, sumCos float64
+	for _, p := range phases {
+		sumSin += math.Sin(p)
+		sumCos += math.Cos(p)
+	}
+	return Cluster{
+		MeanPhase: math.Mod(core.WrapPhase(math.Atan2(sumSin, sumCos)), 2*math.Pi),
+		Size:      len(phases),
+	}
+}
+
+// PhaseHistogram counts the agents' phases in bins equal arcs of the
+// circle: bin i covers [2πi/bins, 2π(i+1)/bins). Phases are wrapped first,
+// so -0.1 counts toward the last bin and 2π toward the first. The counts
+// sum to the number of agents. It returns nil if bins is less than 1.
+func (s *Swarm) PhaseHistogram(bins int) []int {
+	if bins < 1 {
+		return nil
+	}
+	agents := s.collectAgents()
+	phases := make([]float64, len(agents))
+	for i, a := range agents {
+		phases[i] = a.Phase()
+	}
+	return binPhases(phases, bins)
+}
+
+// binPhases implements PhaseHistogram on raw phases.
+func binPhases(phases []float64, bins int) []int {
+	counts := make([]int, bins)
+	width := 2 * math.Pi / float64(bins)
+	for _, p := range phases {
+		// A phase within rounding of 2π lands past the last bin and
+		// belongs, like 2π itself, in the first
+		counts[int(core.WrapPhase(p)/width)%bins]++
+	}
+	return counts
+}
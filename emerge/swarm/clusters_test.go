@@ -0,0 +1,137 @@
+package swarm_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+// swarmWithPhases creates a swarm whose agents hold the given phases.
+func swarmWithPhases(t *testing.T, phases ...float64) *swarm.Swarm {
+	t.Helper()
+
+	s, err := swarm.New(len(phases), core.State{Frequency: 100 * time.Millisecond, Coherence: 0.8})
+	require.NoError(t, err)
+	t.Cleanup(s.Close)
+
+	agents := s.Agents()
+	ids := make([]string, 0, len(agents))
+	for id := range agents {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	for i, id := range ids {
+		agents[id].SetPhase(phases[i])
+	}
+	return s
+}
+
+func TestPhaseClusters(t *testing.T) {
+	t.Parallel()
+
+	t.Run("wraparound forms one cluster", func(t *testing.T) {
+		t.Parallel()
+
+		s := swarmWithPhases(t, 0.05, 6.23)
+		clusters := s.PhaseClusters(0.2)
+		require.Len(t, clusters, 1)
+		assert.Equal(t, 2, clusters[0].Size)
+
+		// The mean lies across 0, not at π between the two raw values
+		assert.Less(t, math.Abs(core.PhaseDifference(clusters[0].MeanPhase, 0)), 0.01)
+	})
+
+	t.Run("separate batches", func(t *testing.T) {
+		t.Parallel()
+
+		s := swarmWithPhases(t, 0.1, 0.15, 6.25, 2.0, 2.05, 4.0)
+		clusters := s.PhaseClusters(0.3)
+		require.Len(t, clusters, 3)
+
+		assert.Equal(t, 3, clusters[0].Size)
+		assert.Less(t, math.Abs(core.PhaseDifference(clusters[0].MeanPhase, 0.072)), 0.01)
+		assert.Equal(t, 2, clusters[1].Size)
+		assert.InDelta(t, 2.025, clusters[1].MeanPhase, 1e-6)
+		assert.Equal(t, 1, clusters[2].Size)
+		assert.InDelta(t, 4.0, clusters[2].MeanPhase, 1e-6)
+	})
+
+	t.Run("tolerance controls granularity", func(t *testing.T) {
+		t.Parallel()
+
+		s := swarmWithPhases(t, 0, 0.5, 1.0, 1.5)
+		assert.Len(t, s.PhaseClusters(0.6), 1)
+		assert.Len(t, s.PhaseClusters(0.4), 4)
+	})
+
+	t.Run("evenly spread phases closing the circle", func(t *testing.T) {
+		t.Parallel()
+
+		phases := make([]float64, 12)
+		for i := range phases {
+			phases[i] = float64(i) * 2 * math.Pi / 12
+		}
+		s := swarmWithPhases(t, phases...)
+
+		clusters := s.PhaseClusters(0.6)
+		require.Len(t, clusters, 1)
+		assert.Equal(t, 12, clusters[0].Size)
+		assert.Len(t, s.PhaseClusters(0.5), 12)
+	})
+
+	t.Run("identical phases with zero tolerance", func(t *testing.T) {
+		t.Parallel()
+
+		s := swarmWithPhases(t, 1, 1, 1, 3)
+		clusters := s.PhaseClusters(0)
+		require.Len(t, clusters, 2)
+		assert.Equal(t, 3, clusters[0].Size)
+		assert.Equal(t, 1, clusters[1].Size)
+	})
+}
+
+func TestPhaseHistogram(t *testing.T) {
+	t.Parallel()
+
+	t.Run("synchronized swarm fills one bin", func(t *testing.T) {
+		t.Parallel()
+
+		s := swarmWithPhases(t, 1, 1, 1, 1, 1, 1)
+		assert.Equal(t, []int{0, 6, 0, 0, 0, 0, 0, 0}, s.PhaseHistogram(8))
+	})
+
+	t.Run("splay state spreads evenly", func(t *testing.T) {
+		t.Parallel()
+
+		const n, bins = 24, 6
+		phases := make([]float64, n)
+		for i := range phases {
+			// Offset half a slot so no phase sits on a bin edge
+			phases[i] = 2 * math.Pi * (float64(i) + 0.5) / n
+		}
+		s := swarmWithPhases(t, phases...)
+		assert.Equal(t, []int{4, 4, 4, 4, 4, 4}, s.PhaseHistogram(bins))
+	})
+
+	t.Run("wraparound boundary", func(t *testing.T) {
+		t.Parallel()
+
+		s := swarmWithPhases(t, 0, 2*math.Pi, -0.1, 2*math.Pi-1e-9)
+		assert.Equal(t, []int{2, 0, 0, 2}, s.PhaseHistogram(4))
+	})
+
+	t.Run("invalid bins", func(t *testing.T) {
+		t.Parallel()
+
+		s := swarmWithPhases(t, 0, 1)
+		assert.Nil(t, s.PhaseHistogram(0))
+		assert.Equal(t, []int{2}, s.PhaseHistogram(1))
+	})
+}
@@ -0,0 +1,74 @@
+package swarm
+
+import "fmt"
+
+// WithCoherenceWindow debounces convergence over the last n ticks of Run
+// and RunContinuous. A single coherence measurement can bounce around the
+// target, flipping IsConverged and the convergence callback back and
+// forth; with a window, the swarm counts as converged only once the mean
+// of the last n measurements reaches the target, and stops counting only
+// once that mean falls below it. Run also keeps going until the mean
+// holds. Use SmoothedCoherence to read the mean.
+//
+// The window applies where convergence is judged by coherence; spreading
+// and duty-cycling swarms keep their own measures.
+func WithCoherenceWindow(n int) Option {
+	return func(s *Swarm) error {
+		if n < 1 {
+			return fmt.Errorf("coherence window must be at least 1 tick, got %d", n)
+		}
+		s.smoothed = &sustainedTracker{size: n}
+		return nil
+	}
+}
+
+// CoherenceWindow returns the window set with WithCoherenceWindow, or 0
+// without one.
+func (s *Swarm) CoherenceWindow() int {
+	if s.smoothed == nil {
+		return 0
+	}
+	return s.smoothed.size
+}
+
+// SmoothedCoherence returns the mean coherence over the window set with
+// WithCoherenceWindow. Without a window, or before any tick, it returns
+// MeasureCoherence.
+func (s *Swarm) SmoothedCoherence() float64 {
+	if s.smoothed != nil {
+		if mean, ok := s.smoothed.mean(); ok {
+			return mean
+		}
+	}
+	return s.MeasureCoherence()
+}
+
+// recordCoherence records a tick's coherence for SustainedCoherence and
+// SmoothedCoherence.
+func (s *Swarm) recordCoherence(coherence float64) {
+	s.sustained.record(coherence)
+	if s.smoothed != nil {
+		s.smoothed.record(coherence)
+	}
+}
+
+// smoothedConverged reports whether a full window's mean coherence reaches
+// target. It is true without a window, leaving the judgment to the
+// instantaneous measure.
+func (s *Swarm) smoothedConverged(target float64) bool {
+	if s.smoothed == nil {
+		return true
+	}
+	mean, ok := s.smoothed.mean()
+	return ok && s.smoothed.full() && mean >= target
+}
+
+// crossed reports whether coherence reaches target for the convergence
+// callback: by the window's mean with a window, or else by coherence
+// itself.
+func (s *Swarm) crossed(coherence, target float64) bool {
+	if s.smoothed == nil {
+		return coherence >= target
+	}
+	return s.smoothedConverged(target)
+}
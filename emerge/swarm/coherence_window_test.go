@@ -0,0 +1,52 @@
+package swarm
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+// TestCoherenceWindow feeds a noisy coherence signal that rises to just
+// above the target and hovers there. The raw signal crosses the target
+// again and again; the windowed convergence flips once and holds.
+func TestCoherenceWindow(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{Frequency: 200 * time.Millisecond, Coherence: 0.9}
+	_, err := New(10, goalState, WithCoherenceWindow(0))
+	require.Error(t, err)
+
+	s, err := New(10, goalState, WithCoherenceWindow(20))
+	require.NoError(t, err)
+	defer s.Close()
+	assert.Equal(t, 20, s.CoherenceWindow())
+	assert.InDelta(t, s.MeasureCoherence(), s.SmoothedCoherence(), 1e-12, "no ticks yet")
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	var rawFlips, flips int
+	var raw, converged bool
+	for i := range 300 {
+		level := min(0.5+0.004*float64(i), 0.92)
+		coherence := level + 0.05*(2*rng.Float64()-1)
+		s.recordCoherence(coherence)
+
+		if now := coherence >= goalState.Coherence; now != raw {
+			raw = now
+			rawFlips++
+		}
+		if now := s.IsConverged(); now != converged {
+			converged = now
+			flips++
+		}
+	}
+
+	assert.Greater(t, rawFlips, 20, "the raw signal flaps")
+	assert.Equal(t, 1, flips, "the windowed signal converges once")
+	assert.True(t, s.IsConverged())
+	assert.InDelta(t, 0.92, s.SmoothedCoherence(), 0.02)
+}
@@ -0,0 +1,101 @@
+package swarm
+
+import (
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+// CoherenceOf measures coherence among the given agents alone, the way
+// MeasureCoherence does for the whole swarm. IDs not in the swarm are
+// ignored, as are repeats; with no agents it returns 0.
+func (s *Swarm) CoherenceOf(ids []string) float64 {
+	seen := make(map[string]bool, len(ids))
+	phases := make([]float64, 0, len(ids))
+	for _, id := range ids {
+		a, ok := s.Agent(id)
+		if !ok || seen[id] {
+			continue
+		}
+		seen[id] = true
+		phases = append(phases, a.Phase())
+	}
+	if len(phases) == 0 {
+		return 0
+	}
+	return s.coherence(phases)
+}
+
+// CoherenceByComponent measures coherence within each connected component
+// of the topology, keyed by component number. Links count in both
+// directions, and components are numbered from 0 in the order of their
+// first agent in ID order. Two components synchronized internally but out
+// of phase with each other hold global coherence down however long the
+// swarm runs, since no coupling crosses between them; this tells that
+// apart from a swarm that is not synchronizing at all.
+func (s *Swarm) CoherenceByComponent() map[int]float64 {
+	components := connectedComponents(s.AgentsSorted())
+	result := make(map[int]float64, len(components))
+	for i, members := range components {
+		result[i] = s.coherence(phasesOf(members))
+	}
+	return result
+}
+
+// coherence measures the coherence of phases: slot purity for custom goals,
+// the order parameter otherwise.
+func (s *Swarm) coherence(phases []float64) float64 {
+	if spec, ok := s.goalType.Spec(); ok {
+		return slotPurity(phases, s.target().Phase, spec.Slots)
+	}
+	return core.MeasureCoherence(phases)
+}
+
+// connectedComponents splits agents into the connected components of their
+// links, treated as undirected. Neighbors outside agents, such as bridged
+// agents of another swarm, are ignored.
+// Components come in the order of their first agent, members in the order
+// given.
+func connectedComponents(agents []*agent.Agent) [][]*agent.Agent {
+	index := make(map[string]int, len(agents))
+	for i, a := range agents {
+		index[a.ID] = i
+	}
+	adj := make([][]int, len(agents))
+	for i, a := range agents {
+		for _, n := range a.NeighborList() {
+			if j, ok := index[n.ID]; ok && j != i && agents[j] == n {
+				adj[i] = append(adj[i], j)
+				adj[j] = append(adj[j], i)
+			}
+		}
+	}
+
+	component := make([]int, len(agents))
+	for i := range component {
+		component[i] = -1
+	}
+	var components [][]*agent.Agent
+	for start := range agents {
+		if component[start] >= 0 {
+			continue
+		}
+		id := len(components)
+		component[start] = id
+		queue := []int{start}
+		for len(queue) > 0 {
+			i := queue[0]
+			queue = queue[1:]
+			for _, j := range adj[i] {
+				if component[j] < 0 {
+					component[j] = id
+					queue = append(queue, j)
+				}
+			}
+		}
+		components = append(components, nil)
+	}
+	for i, a := range agents {
+		components[component[i]] = append(components[component[i]], a)
+	}
+	return components
+}
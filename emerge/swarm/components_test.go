@@ -0,0 +1,85 @@
+package swarm_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+// twoRings connects the first and second half of the agents, in ID order,
+// into two separate rings.
+func twoRings(s *swarm.Swarm) error {
+	agents := s.AgentsSorted()
+	half := len(agents) / 2
+	for _, ring := range [][]int{{0, half}, {half, len(agents)}} {
+		members := agents[ring[0]:ring[1]]
+		for i, a := range members {
+			next := members[(i+1)%len(members)]
+			a.ConnectTo(next.ID, next)
+			next.ConnectTo(a.ID, a)
+		}
+	}
+	return nil
+}
+
+// TestCoherenceByComponent splits a swarm into two components, each
+// synchronized internally but in anti-phase with the other.
+func TestCoherenceByComponent(t *testing.T) {
+	t.Parallel()
+
+	const size = 20
+	s, err := swarm.New(size, core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.9},
+		swarm.WithTopology(twoRings),
+		swarm.WithInitialPhaseFunc(func(i int) float64 {
+			phase := 0.05 * float64(i%3)
+			if i >= size/2 {
+				phase += math.Pi
+			}
+			return phase
+		}))
+	require.NoError(t, err)
+	defer s.Close()
+	require.Less(t, s.MeasureCoherence(), 0.1, "the halves cancel out globally")
+
+	byComponent := s.CoherenceByComponent()
+	require.Len(t, byComponent, 2)
+	for i, c := range byComponent {
+		assert.Greater(t, c, 0.99, "component %d", i)
+	}
+
+	var first, second []string
+	for i, a := range s.AgentsSorted() {
+		if i < size/2 {
+			first = append(first, a.ID)
+		} else {
+			second = append(second, a.ID)
+		}
+	}
+	assert.InDelta(t, byComponent[0], s.CoherenceOf(first), 1e-12)
+	assert.InDelta(t, byComponent[1], s.CoherenceOf(second), 1e-12)
+	assert.InDelta(t, s.MeasureCoherence(), s.CoherenceOf(append(first, second...)), 1e-12)
+
+	// Unknown and repeated IDs are ignored
+	assert.InDelta(t, byComponent[0], s.CoherenceOf(append(first, "missing", first[0])), 1e-12)
+	assert.Zero(t, s.CoherenceOf(nil))
+	assert.Zero(t, s.CoherenceOf([]string{"missing"}))
+}
+
+func TestCoherenceByComponentConnected(t *testing.T) {
+	t.Parallel()
+
+	s, err := swarm.New(30, core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.9},
+		swarm.WithSeed(2), swarm.WithTopology(ringTopology))
+	require.NoError(t, err)
+	defer s.Close()
+
+	byComponent := s.CoherenceByComponent()
+	require.Len(t, byComponent, 1)
+	assert.InDelta(t, s.MeasureCoherence(), byComponent[0], 1e-12)
+}
@@ -81,7 +81,8 @@ type ResonanceConfig struct {
 	ActivationRate float64 // Default: 0.1 (10% chance when stuck)
 }
 
-// For returns a configuration optimized for a specific goal.
+// For returns a configuration optimized for a specific goal. Custom goals
+// (see goal.Custom) get the default configuration.
 func For(g goal.Type) *Config {
 	switch g {
 	case goal.MinimizeAPICalls:
@@ -0,0 +1,59 @@
+package swarm
+
+import (
+	"fmt"
+
+	"github.com/carlisia/bio-adapt/emerge/goal"
+	"github.com/carlisia/bio-adapt/emerge/monitoring"
+	"github.com/carlisia/bio-adapt/internal/config"
+)
+
+// WithConfigFile configures the swarm from a .json, .yaml or .yml file:
+//
+//	goal: minimize_api_calls
+//	target_coherence: 0.9
+//	swarm:
+//	  coupling_strength: 0.7
+//	  agent_update_interval: 25ms
+//
+// The goal is required; swarm parameters use the snake_case form of the
+// config field names and default to DefaultConfig values when omitted. A
+// non-zero target coherence replaces the one passed to New and is lowered
+// to the practical limit for the swarm size. Nonsensical values such as
+// negative energy or a coherence above 1 are rejected, as are unknown keys.
+// Options after it override what it sets; MarshalConfig writes a file it
+// can load.
+func WithConfigFile(path string) Option {
+	return func(s *Swarm) error {
+		cfg, err := config.LoadFile(path)
+		if err != nil {
+			return fmt.Errorf("load config file: %w", err)
+		}
+		g, err := goal.Parse(cfg.Goal)
+		if err != nil {
+			return fmt.Errorf("load config file %s: %w", path, err)
+		}
+
+		if cfg.TargetCoherence > 0 {
+			s.goalState.Coherence = min(cfg.TargetCoherence, GetCoherenceLimits(s.size).Practical)
+			s.convergence = monitoring.NewConvergence(s.goalState, s.goalState.Coherence)
+			s.recoveryConfig = DefaultRecoveryConfig(s.goalState.Coherence)
+		}
+		// WithConfig also rebuilds the attractor basin around the new target
+		if err := WithConfig(cfg.Swarm)(s); err != nil {
+			return err
+		}
+		return WithGoal(g)(s)
+	}
+}
+
+// MarshalConfig encodes the swarm's effective configuration, including
+// auto-scaled and normalized values, as "json" or "yaml". The output can
+// be loaded back with WithConfigFile.
+func (s *Swarm) MarshalConfig(format string) ([]byte, error) {
+	return config.Marshal(config.Config{
+		Goal:            s.goalType.ID(),
+		TargetCoherence: s.target().Coherence,
+		Swarm:           s.config,
+	}, format)
+}
@@ -0,0 +1,87 @@
+package swarm_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/goal"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+func TestWithConfigFile(t *testing.T) {
+	t.Parallel()
+
+	target := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.7}
+
+	t.Run("applies goal, target and parameters", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "swarm.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(`
+goal: reach_consensus
+target_coherence: 0.85
+swarm:
+  coupling_strength: 0.65
+  max_neighbors: 8
+  min_neighbors: 2
+`), 0o600))
+
+		s, err := swarm.New(20, target, swarm.WithConfigFile(path))
+		require.NoError(t, err)
+		defer s.Close()
+
+		assert.Equal(t, goal.ReachConsensus, s.Goal())
+		assert.InDelta(t, 0.85, s.TargetState().Coherence, 1e-9)
+		assert.InDelta(t, 0.65, s.Config().CouplingStrength, 1e-9)
+		assert.Equal(t, 8, s.Config().MaxNeighbors)
+	})
+
+	t.Run("round trips the effective config", func(t *testing.T) {
+		t.Parallel()
+
+		orig, err := swarm.New(150, target, swarm.WithGoal(goal.SaveEnergy))
+		require.NoError(t, err)
+		defer orig.Close()
+
+		data, err := orig.MarshalConfig("json")
+		require.NoError(t, err)
+		path := filepath.Join(t.TempDir(), "effective.json")
+		require.NoError(t, os.WriteFile(path, data, 0o600))
+
+		loaded, err := swarm.New(150, target, swarm.WithConfigFile(path))
+		require.NoError(t, err)
+		defer loaded.Close()
+
+		assert.Equal(t, orig.Config(), loaded.Config(), "auto-scaled values should survive the round trip")
+		assert.Equal(t, orig.Goal(), loaded.Goal())
+		assert.InDelta(t, orig.TargetState().Coherence, loaded.TargetState().Coherence, 1e-9)
+	})
+
+	t.Run("rejects invalid files", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		files := map[string]string{
+			"empty-goal.yaml":   "goal: ''\n",
+			"unknown-goal.yaml": "goal: world_domination\n",
+			"energy.yaml":       "goal: save_energy\nswarm: {initial_energy: -1}\n",
+			"coherence.json":    `{"goal": "save_energy", "target_coherence": 1.5}`,
+		}
+		for name, content := range files {
+			path := filepath.Join(dir, name)
+			require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+			_, err := swarm.New(10, target, swarm.WithConfigFile(path))
+			assert.Error(t, err, name)
+		}
+
+		_, err := swarm.New(10, target, swarm.WithConfigFile(filepath.Join(dir, "missing.yaml")))
+		assert.Error(t, err)
+	})
+}
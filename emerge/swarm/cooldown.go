@@ -0,0 +1,36 @@
+package swarm
+
+import "fmt"
+
+// WithAdjustmentCooldown gives every agent a refractory period of ticks
+// ticks after each adjustment of the goal-directed loop (see
+// agent.Agent.SetCooldown). While resting an agent keeps its phase, so a
+// strongly coupled swarm that over-corrects near its target, ringing
+// rather than settling, moves fewer agents at once and rings less. Unlike
+// a lower WithCouplingStrength, moves keep their size. A cooldown is no
+// cure-all: with coupling strong enough that every move overshoots, resting
+// agents fall further behind and coherence can drop. Only ticks in which
+// the loop adjusts agents count toward a cooldown. Agents added later get
+// the cooldown too.
+func WithAdjustmentCooldown(ticks int) Option {
+	return func(s *Swarm) error {
+		if ticks < 1 {
+			return fmt.Errorf("adjustment cooldown must be at least 1 tick, got %d", ticks)
+		}
+		s.cooldown = ticks
+		return nil
+	}
+}
+
+// AdjustmentCooldown returns the refractory period set with
+// WithAdjustmentCooldown, in ticks, or 0 without one.
+func (s *Swarm) AdjustmentCooldown() int {
+	return s.cooldown
+}
+
+// applyCooldown sets the swarm's cooldown on every agent.
+func (s *Swarm) applyCooldown() {
+	for _, a := range s.collectAgents() {
+		a.SetCooldown(s.cooldown)
+	}
+}
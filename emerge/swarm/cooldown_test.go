@@ -0,0 +1,99 @@
+package swarm_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+	"github.com/carlisia/bio-adapt/emerge/swarm/clocktest"
+)
+
+// TestAdjustmentCooldownDampsRinging runs a strongly coupled swarm, which
+// over-corrects around its target, with and without a cooldown, and
+// compares how much its mean phase swings about the target at steady
+// state. The swarm runs at three times the default step: its 30 agents
+// get a config coupling of 0.6.
+func TestAdjustmentCooldownDampsRinging(t *testing.T) {
+	t.Parallel()
+
+	// Swing of the mean phase and mean coherence over the last 100 of 300
+	// ticks, averaged over a few seeds
+	ringing := func(opts ...swarm.Option) (swing, coherence float64) {
+		const seeds, settle, window = 6, 200, 100
+		for seed := range int64(seeds) {
+			clock := clocktest.New(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+			s, err := swarm.New(30, core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.9},
+				append([]swarm.Option{swarm.WithSeed(seed + 1), swarm.WithClock(clock)}, opts...)...)
+			require.NoError(t, err)
+			t.Cleanup(s.Close)
+
+			var sum, sumSq float64
+			for tick := range settle + window {
+				clock.Advance(s.TickInterval())
+				c := s.Step()
+				if tick < settle {
+					continue
+				}
+				var x, y float64
+				for _, a := range s.AgentsSorted() {
+					x, y = x+math.Cos(a.Phase()), y+math.Sin(a.Phase())
+				}
+				p := math.Atan2(y, x)
+				sum, sumSq = sum+p, sumSq+p*p
+				coherence += c
+			}
+			mean := sum / window
+			swing += math.Sqrt(math.Max(sumSq/window-mean*mean, 0))
+		}
+		return swing / seeds, coherence / (seeds * window)
+	}
+
+	strong := swarm.WithCouplingStrength(1.8)
+	gentleSwing, _ := ringing()
+	freeSwing, freeCoherence := ringing(strong)
+	calmSwing, calmCoherence := ringing(strong, swarm.WithAdjustmentCooldown(1))
+	t.Logf("mean phase swing: default coupling %.4f, strong %.4f, strong with cooldown %.4f", gentleSwing, freeSwing, calmSwing)
+	assert.Greater(t, freeSwing, gentleSwing, "strong coupling should ring")
+	assert.Less(t, calmSwing, freeSwing)
+	assert.Greater(t, calmCoherence, 0.9)
+	assert.Greater(t, freeCoherence, 0.9)
+}
+
+func TestAdjustmentCooldown(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8}
+
+	_, err := swarm.New(5, goalState, swarm.WithAdjustmentCooldown(0))
+	require.Error(t, err)
+
+	plain, err := swarm.New(5, goalState)
+	require.NoError(t, err)
+	defer plain.Close()
+	assert.Zero(t, plain.AdjustmentCooldown())
+
+	s, err := swarm.New(5, goalState, swarm.WithAdjustmentCooldown(3))
+	require.NoError(t, err)
+	defer s.Close()
+	assert.Equal(t, 3, s.AdjustmentCooldown())
+	for _, a := range s.AgentsSorted() {
+		assert.Equal(t, 3, a.Cooldown(), a.ID)
+	}
+
+	added, err := s.AddAgent(swarm.AgentConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, added.Cooldown())
+
+	clone, err := s.Clone()
+	require.NoError(t, err)
+	defer clone.Close()
+	assert.Equal(t, 3, clone.AdjustmentCooldown())
+	for _, a := range clone.AgentsSorted() {
+		assert.Equal(t, 3, a.Cooldown(), a.ID)
+	}
+}
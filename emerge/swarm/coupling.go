@@ -0,0 +1,154 @@
+package swarm
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+// WithCouplingStrength sets the global coupling gain K of the Kuramoto
+// model, dθᵢ/dt = ωᵢ + K·r·sin(ψ − θᵢ), where r and ψ are the swarm's
+// coherence and mean phase. K replaces the config's CouplingStrength as the
+// one coupling every part of the swarm reads:
+//
+//   - The phase corrections the goal-directed loop applies toward the
+//     target are scaled by K relative to the config's CouplingStrength, so
+//     K equal to it keeps the default step; larger values converge faster
+//     at the risk of overshoot and oscillation, smaller ones converge
+//     slower. The pull of each agent's neighbors (see neighborPull), goal
+//     regions and remote neighbors (see WithRemoteNeighbors) scale with K
+//     itself. Phase bands, hubs, custom goals, spreading strategies and
+//     pulse coupling keep their own rates.
+//   - Agents with natural frequencies (see WithFrequencyDistribution) run
+//     the model itself: each tick, an agent's phase drifts by its detuning
+//     Δᵢ = (ωᵢ − Ω)/Ω from the mean natural rate Ω and is pulled toward ψ
+//     by K·r·sin(ψ − θᵢ), and its frequency follows the rate its phase
+//     actually moves at. Agents whose drift coupling can cancel lock onto
+//     Ω; the rest keep slipping at rates of their own. This replaces the
+//     default, gentler pull toward the mean frequency.
+//
+// Kuramoto's theory predicts where locking sets in: an agent can lock once
+// K·r reaches |Δᵢ|, and no locked cluster forms at all below the critical
+// coupling K_c = 2/(π·g(0)) for a unimodal detuning density g, which is
+// 4γ/π for detunings spread uniformly over ±γ. The goal-directed loop's
+// own pull toward the target helps agents hold on, so a running swarm
+// locks somewhat below these values. K must be positive.
+func WithCouplingStrength(k float64) Option {
+	return func(s *Swarm) error {
+		if !(k > 0) || math.IsInf(k, 0) {
+			return fmt.Errorf("coupling strength must be positive and finite, got %v", k)
+		}
+		s.couplingStrength.Store(math.Float64bits(k))
+		return nil
+	}
+}
+
+// CouplingStrength returns the gain set with WithCouplingStrength, or 0 for
+// the default coupling.
+func (s *Swarm) CouplingStrength() float64 {
+	return math.Float64frombits(s.couplingStrength.Load())
+}
+
+// coupling returns the coupling gain K the swarm runs with: the one set
+// with WithCouplingStrength, or else the config's CouplingStrength.
+func (s *Swarm) coupling() float64 {
+	if k := s.CouplingStrength(); k > 0 {
+		return k
+	}
+	return s.config.CouplingStrength
+}
+
+// coupled scales the phase steps of update by the coupling strength set
+// with WithCouplingStrength relative to the config's, if one is set.
+func (s *Swarm) coupled(update agentUpdate) agentUpdate {
+	k, base := s.CouplingStrength(), s.config.CouplingStrength
+	if k == 0 || base <= 0 {
+		return update
+	}
+	gain := k / base
+	return func(phase float64, rng *agentRand) (float64, bool) {
+		next, changed := update(phase, rng)
+		return phase + gain*(next-phase), changed
+	}
+}
+
+// neighborCouplingGain scales the swarm's coupling down to the pull an
+// agent's neighbors exert on it each tick.
+const neighborCouplingGain = 0.1
+
+// neighborPull returns how far an agent's neighbors pull it, given the
+// shift toward them it perceives (see agent.Agent.Perceive): K·0.1 of it,
+// where K is the swarm's coupling. The goal-directed loop scales the pull
+// as it scales its step toward the target, so it too eases off near the
+// target. Each neighbor j weighs in with its influence times the weight of
+// the link to it, w_j = Influence(j)·EdgeWeight(j), so the shift is
+//
+//	atan2(Σ w_j·sin(θ_j − θ), Σ w_j·cos(θ_j − θ)) · (1 + r_local)/2
+//
+// where r_local is the coherence of the agent's neighbors. High-influence
+// agents and heavily weighted links act as pacemakers, and zero-influence
+// neighbors pull not at all. Under WithGossipFanout only the sampled
+// neighbors count.
+func (s *Swarm) neighborPull(shift float64) float64 {
+	return s.coupling() * neighborCouplingGain * shift
+}
+
+// kuramotoStep is the stretch of Kuramoto time one tick integrates, in
+// radians of the mean natural cycle, and kuramotoSubstep the longest Euler
+// step it is integrated in, which keeps strong coupling from overshooting.
+const (
+	kuramotoStep    = 0.2
+	kuramotoSubstep = 0.05
+)
+
+// frequencySmoothing is the weight a tick's observed rate gets in an
+// agent's frequency, which smooths over the loop's uneven steps.
+const frequencySmoothing = 0.1
+
+// applyKuramotoCoupling advances every agent with a natural frequency by
+// one tick of the Kuramoto model, drifting by its detuning and pulled
+// toward the mean field with the swarm's coupling, and sets its frequency
+// from the rate its phase moved at since the last tick, smoothed (see
+// WithCouplingStrength).
+func (gds *GoalDirectedSync) applyKuramotoCoupling(agents []*agent.Agent) {
+	var total float64
+	var natural []*agent.Agent
+	for _, a := range agents {
+		if nat := a.NaturalFrequency(); nat > 0 {
+			total += 1 / nat.Seconds()
+			natural = append(natural, a)
+		}
+	}
+	if len(natural) == 0 {
+		return
+	}
+	omega := total / float64(len(natural)) // Mean natural rate (cycles per second)
+	r, psi := orderParameter(phasesOf(agents), 1)
+	pull := gds.swarm.coupling() * r
+	substeps := math.Ceil(kuramotoStep / kuramotoSubstep)
+	h := kuramotoStep / substeps
+
+	last := gds.lastPhases
+	gds.lastPhases = make(map[*agent.Agent]float64, len(natural))
+	for _, a := range natural {
+		phase := a.Phase()
+		if before, ok := last[a]; ok {
+			observed := omega * (1 + core.PhaseDifference(phase, before)/kuramotoStep)
+			current := 1 / a.Frequency().Seconds()
+			if rate := current + frequencySmoothing*(observed-current); rate > 0 {
+				a.SetFrequency(time.Duration(float64(time.Second) / rate))
+			}
+		}
+		gds.lastPhases[a] = phase
+
+		detuning := (1/a.NaturalFrequency().Seconds() - omega) / omega
+		next := phase
+		for range int(substeps) {
+			next += h * (detuning + pull*math.Sin(psi-next))
+		}
+		a.SetPhase(core.WrapPhase(next))
+	}
+}
@@ -0,0 +1,76 @@
+package swarm_test
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+// TestCouplingStrength spreads natural frequencies uniformly over ±10% of
+// the mean rate. Theory puts the onset of locking at K_c = 4·0.1/π ≈ 0.13;
+// well below it the agents at the edges of the spread keep slipping at
+// rates of their own, and well above it every agent's observed frequency
+// settles on the mean natural rate.
+func TestCouplingStrength(t *testing.T) {
+	t.Parallel()
+
+	const (
+		size  = 30
+		gamma = 0.1 // Largest detuning
+	)
+
+	tests := []struct {
+		k      float64
+		locked bool
+	}{
+		{k: 0.05, locked: false},
+		{k: 0.08, locked: false},
+		{k: 0.2, locked: true},
+		{k: 2, locked: true},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("K=%v", tt.k), func(t *testing.T) {
+			t.Parallel()
+
+			i := 0
+			dist := func() time.Duration {
+				detuning := -gamma + 2*gamma*float64(i)/float64(size-1)
+				i++
+				return time.Duration(float64(200*time.Millisecond) / (1 + detuning))
+			}
+			s, err := swarm.New(size, core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.85},
+				swarm.WithSeed(3), swarm.WithFrequencyDistribution(dist), swarm.WithCouplingStrength(tt.k))
+			require.NoError(t, err)
+			defer s.Close()
+
+			for range 600 {
+				s.Step()
+			}
+			freqs := s.Frequencies()
+			spread := slices.Max(freqs) - slices.Min(freqs)
+			if tt.locked {
+				assert.Less(t, spread, time.Microsecond, "every agent should run at the mean natural rate")
+			} else {
+				assert.Greater(t, spread, 10*time.Millisecond, "agents at the edges should slip")
+			}
+		})
+	}
+}
+
+func TestCouplingStrengthValidation(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.85}
+	for _, k := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+		_, err := swarm.New(10, goalState, swarm.WithCouplingStrength(k))
+		require.Error(t, err)
+	}
+}
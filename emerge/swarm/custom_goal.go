@@ -0,0 +1,90 @@
+package swarm
+
+import (
+	"math"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+// slotSeeker is implemented by strategies that seek the nearest of several
+// phase slots, such as strategy.SlotSeeking.
+type slotSeeker interface {
+	Seek(phase, target float64) float64
+}
+
+// nearestSlot returns the index of the slot nearest to phase, for slots
+// offset from the target phase.
+func nearestSlot(phase, target float64, slots []float64) int {
+	best, bestDist := 0, math.Inf(1)
+	for i, offset := range slots {
+		if d := math.Abs(core.PhaseDifference(phase, target+offset)); d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best
+}
+
+// slotPurity measures how well phases form one cluster per slot. Each
+// agent scores by how close it sits to its nearest slot: 1 on the slot,
+// falling along a cosine to -1 halfway to the neighboring slot. The mean
+// score, floored at 0, is scaled by the fraction of slots holding at least
+// one agent, so purity is 1 when every slot is filled and every agent sits
+// on its slot, near 0 for random phases, and low when agents crowd into
+// too few slots. With fewer agents than slots, a slot per agent counts as
+// full.
+func slotPurity(phases []float64, target float64, slots []float64) float64 {
+	if len(phases) == 0 || len(slots) == 0 {
+		return 0
+	}
+
+	filled := make([]bool, len(slots))
+	occupied := 0
+	score := 0.0
+	for _, p := range phases {
+		i := nearestSlot(p, target, slots)
+		if !filled[i] {
+			filled[i] = true
+			occupied++
+		}
+		d := core.PhaseDifference(p, target+slots[i])
+		score += math.Cos(math.Pi * d / slotReach(slots, i, d))
+	}
+
+	alignment := math.Max(0, score/float64(len(phases)))
+	return alignment * float64(occupied) / float64(min(len(slots), len(phases)))
+}
+
+// slotReach returns half the gap between slot i and the next slot on the
+// side of offset d, the farthest an agent can stray while i is its
+// nearest slot. A lone slot reaches halfway around the circle.
+func slotReach(slots []float64, i int, d float64) float64 {
+	gap := 2 * math.Pi
+	for j, other := range slots {
+		if j == i {
+			continue
+		}
+		delta := core.WrapPhase(other - slots[i])
+		if d < 0 {
+			delta = 2*math.Pi - delta
+		}
+		gap = math.Min(gap, delta)
+	}
+	return gap / 2
+}
+
+// applySlotSeeking moves every agent whose strategy seeks slots toward its
+// nearest slot. Agents with other strategies keep their phase.
+func (gds *GoalDirectedSync) applySlotSeeking(agents []*agent.Agent) {
+	target := gds.swarm.target().Phase
+	for _, a := range agents {
+		seeker, ok := a.Strategy().(slotSeeker)
+		if !ok {
+			continue
+		}
+		phase := a.Phase()
+		if next, ok := gds.swarm.spend(a, phase, seeker.Seek(phase, target)); ok {
+			a.SetPhase(next)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package swarm_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/goal"
+	"github.com/carlisia/bio-adapt/emerge/strategy"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+func TestCustomGoalThreeClusters(t *testing.T) {
+	t.Parallel()
+
+	threePhase := goal.Custom(goal.GoalSpec{Name: "swarm_test_three_phase", Slots: goal.EvenSlots(3)})
+	require.NoError(t, swarm.For(threePhase).Validate())
+
+	synctest.Test(t, func(t *testing.T) {
+		goalState := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.9}
+		s, err := swarm.New(30, goalState, swarm.WithGoal(threePhase), swarm.WithSeed(4))
+		require.NoError(t, err)
+		defer s.Close()
+		assert.Equal(t, strategy.NameSlotSeeking, s.StrategyName())
+		assert.Less(t, s.MeasureCoherence(), 0.5, "random phases are far from the slots")
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		require.NoError(t, s.Run(ctx))
+		assert.GreaterOrEqual(t, s.MeasureCoherence(), goalState.Coherence, "coherence is cluster purity")
+
+		// Every agent sits near one of the three slots, and every slot is used
+		counts := make([]int, 3)
+		phases := make([]float64, 0, s.Size())
+		for _, a := range s.Agents() {
+			phases = append(phases, a.Phase())
+			for i, slot := range goal.EvenSlots(3) {
+				if math.Abs(core.PhaseDifference(a.Phase(), slot)) < 0.3 {
+					counts[i]++
+				}
+			}
+		}
+		assert.Equal(t, s.Size(), counts[0]+counts[1]+counts[2])
+		for i, n := range counts {
+			assert.Positive(t, n, "slot %d should hold a cluster", i)
+		}
+		assert.Less(t, core.MeasureCoherence(phases), 0.5, "three clusters are far from one")
+	})
+}
@@ -0,0 +1,51 @@
+package swarm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DeadlineError reports that RunUntil reached its deadline before the
+// target. It matches ErrDeadlineExceeded with errors.Is; the coherence
+// reached is RunUntil's result as well, and is kept here for callers that
+// only pass the error on.
+type DeadlineError struct {
+	Coherence float64 // Coherence when the deadline passed
+	Target    float64 // Coherence the run was aiming for
+}
+
+// Error implements error.
+func (e *DeadlineError) Error() string {
+	return fmt.Sprintf("%v: coherence %.3f (target %.3f)", ErrDeadlineExceeded, e.Coherence, e.Target)
+}
+
+// Unwrap returns ErrDeadlineExceeded.
+func (*DeadlineError) Unwrap() error {
+	return ErrDeadlineExceeded
+}
+
+// RunUntil runs the swarm toward its target as Run does, but only until
+// deadline, and returns the coherence it reached either way: a best
+// effort for request paths that need "as coherent as possible within
+// 50ms", where the partial result is the product rather than a failure to
+// discard. If the target is reached first it returns the coherence and a
+// nil error. At the deadline it returns the coherence and a *DeadlineError
+// matching ErrDeadlineExceeded; an earlier deadline on ctx counts the same.
+// Any other way the run ends, such as ctx being canceled or Shutdown, is
+// returned as Run returns it, again with the coherence reached.
+//
+// Like contexts passed to Run, the deadline is on the system clock, not
+// the swarm's (see WithClock).
+func (s *Swarm) RunUntil(ctx context.Context, deadline time.Time) (float64, error) {
+	runCtx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+
+	err := s.Run(runCtx)
+	coherence := s.MeasureCoherence()
+	if errors.Is(err, context.DeadlineExceeded) {
+		return coherence, &DeadlineError{Coherence: coherence, Target: s.EffectiveTargetCoherence()}
+	}
+	return coherence, err
+}
@@ -0,0 +1,71 @@
+package swarm_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+func TestRunUntil(t *testing.T) {
+	t.Parallel()
+
+	// A deadline a few ticks out is too short for a tight target; the
+	// coherence gained by then is the result
+	t.Run("partial", func(t *testing.T) {
+		t.Parallel()
+
+		goalState := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.98}
+		s, err := swarm.New(500, goalState, swarm.WithSeed(4))
+		require.NoError(t, err)
+		defer s.Close()
+		initial := s.MeasureCoherence()
+
+		coherence, err := s.RunUntil(context.Background(), time.Now().Add(350*time.Millisecond))
+		require.ErrorIs(t, err, swarm.ErrDeadlineExceeded)
+		var deadlineErr *swarm.DeadlineError
+		require.ErrorAs(t, err, &deadlineErr)
+		assert.InDelta(t, coherence, deadlineErr.Coherence, 1e-12)
+		assert.InDelta(t, s.EffectiveTargetCoherence(), deadlineErr.Target, 1e-12)
+
+		assert.Greater(t, coherence, initial, "the run should make progress before the deadline")
+		assert.Greater(t, coherence, 0.5)
+		assert.Less(t, coherence, deadlineErr.Target)
+	})
+
+	t.Run("reached", func(t *testing.T) {
+		t.Parallel()
+
+		goalState := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.7}
+		s, err := swarm.New(20, goalState, swarm.WithSeed(4))
+		require.NoError(t, err)
+		defer s.Close()
+
+		coherence, err := s.RunUntil(context.Background(), time.Now().Add(10*time.Second))
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, coherence, 0.6)
+	})
+
+	// Cancellation is not a deadline and is passed through
+	t.Run("canceled", func(t *testing.T) {
+		t.Parallel()
+
+		goalState := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.98}
+		s, err := swarm.New(50, goalState, swarm.WithSeed(4))
+		require.NoError(t, err)
+		defer s.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		coherence, err := s.RunUntil(ctx, time.Now().Add(time.Hour))
+		require.ErrorIs(t, err, context.Canceled)
+		assert.False(t, errors.Is(err, swarm.ErrDeadlineExceeded))
+		assert.InDelta(t, s.MeasureCoherence(), coherence, 1e-12)
+	})
+}
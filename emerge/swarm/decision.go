@@ -0,0 +1,133 @@
+package swarm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/decision"
+)
+
+// WithDecisionMaker selects a registered decision maker by name for every
+// agent in the swarm, including agents added later with AddAgent. Built-in
+// decision makers are listed by decision.Names; custom ones are added with
+// decision.Register before the swarm is created. It overrides any decision
+// maker set by agent options; agent.SetDecisionMaker called on an
+// individual agent after New overrides it in turn.
+//
+// Each agent gets its own instance from the decision maker's factory.
+//
+// In the goal-directed loop, each agent's decision maker chooses between
+// the step its strategy proposes (see WithStrategy) and holding still,
+// which gains nothing. The default simple decision maker therefore takes
+// every step worth anything, while a risk-averse one holds still rather
+// than pay for a step above its cost limit, so agents the loop would move
+// far at once may never set out. With WithConfidenceScaling, steps are
+// also cut short by the decision's confidence.
+func WithDecisionMaker(name string) Option {
+	return func(s *Swarm) error {
+		if _, err := decision.New(name); err != nil {
+			return fmt.Errorf("invalid decision maker: %w", err)
+		}
+		s.decisionMakerName = name
+		return nil
+	}
+}
+
+// DecisionMakerName returns the name of the decision maker selected with
+// WithDecisionMaker, or an empty string if there is none.
+func (s *Swarm) DecisionMakerName() string {
+	return s.decisionMakerName
+}
+
+// applyDecisionMaker gives every agent a fresh instance of the swarm-level
+// decision maker.
+func (s *Swarm) applyDecisionMaker() error {
+	for _, a := range s.collectAgents() {
+		dm, err := decision.New(s.decisionMakerName)
+		if err != nil {
+			return err
+		}
+		a.SetDecisionMaker(dm)
+	}
+	return nil
+}
+
+// WithConfidenceScaling makes the goal-directed loop take each step only
+// as far as the agent's decision maker is sure of, as
+// agent.Agent.ProposeAdjustment does. The simple decision maker is unsure
+// of long, costly steps and cuts them short, so convergence is smoother
+// but slower. Without it, a step the decision maker chooses is taken in
+// full.
+func WithConfidenceScaling() Option {
+	return func(s *Swarm) error {
+		s.confidenceScaling = true
+		return nil
+	}
+}
+
+// decideStep asks a's decision maker whether to take the step proposed,
+// moving from phase, or hold still, and returns the step the agent takes
+// and whether it moves; an agent holding still gets the zero action. With
+// scaled (see WithConfidenceScaling), the step is only taken as far as the
+// decision is sure of, priced as such. The decision is counted in a's
+// statistics (see agent.Agent.Stats).
+func decideStep(a *agent.Agent, phase float64, proposal core.Action, scaled bool) (core.Action, bool) {
+	if proposal.Type == "maintain" || proposal.Value == 0 {
+		return core.Action{}, false
+	}
+	options := []core.Action{
+		proposal,
+		{Type: "maintain", Cost: 0.1},
+	}
+	state := core.State{Phase: phase, Frequency: a.Frequency(), Coherence: a.Context().LocalCoherence}
+	chosen, confidence := a.DecisionMaker().Decide(state, options)
+	if chosen.Type != "maintain" && scaled {
+		scale := math.Min(math.Max(confidence, 0), 1)
+		chosen.Value *= scale
+		if m := a.CostModel(); m != nil {
+			to, target := state, state
+			to.Phase = phase + chosen.Value
+			target.Phase = phase + proposal.Value
+			chosen.Cost, chosen.Benefit = m.Evaluate(state, to, target)
+		} else {
+			// Strategies price moves in proportion to their size
+			chosen.Cost *= scale
+		}
+	}
+	moved := chosen.Type != "maintain" && chosen.Value != 0
+	a.RecordDecision(moved)
+	if !moved {
+		return core.Action{}, false
+	}
+	return chosen, true
+}
+
+// WithCostModel makes m price every agent's proposals, including agents
+// added later with AddAgent, so decision makers weigh moves by economics
+// that match the system, e.g. a cost that grows with the size of a phase
+// jump. Without it, proposals keep the prices their strategy gives them,
+// which for the default phase nudge are core.DefaultCostModel's. With
+// WithEnergyCost, m's cost is also the energy an agent is charged for a
+// move, so under a spend cap (see WithResourceLimits) a steeper model
+// makes agents take smaller steps.
+// agent.SetCostModel called on an individual agent after New overrides it.
+//
+// All agents share m, so it must be safe for concurrent use.
+func WithCostModel(m core.CostModel) Option {
+	return func(s *Swarm) error {
+		if m == nil {
+			return errors.New("cost model must not be nil")
+		}
+		s.costModel = m
+		return nil
+	}
+}
+
+// CostModel returns the cost model set with WithCostModel, or nil if
+// there is none.
+func (s *Swarm) CostModel() core.CostModel {
+	return s.costModel
+}
@@ -0,0 +1,229 @@
+package swarm_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/decision"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+func TestWithDecisionMaker(t *testing.T) {
+	t.Parallel()
+
+	goal := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8}
+
+	s, err := swarm.New(10, goal, swarm.WithDecisionMaker(decision.NameRiskAverse))
+	require.NoError(t, err)
+	defer s.Close()
+	assert.Equal(t, decision.NameRiskAverse, s.DecisionMakerName())
+
+	seen := make(map[core.DecisionMaker]bool)
+	for _, a := range s.Agents() {
+		assert.IsType(t, &decision.RiskAverse{}, a.DecisionMaker())
+		seen[a.DecisionMaker()] = true
+	}
+	assert.Len(t, seen, 10, "each agent should get its own instance")
+
+	// Agents joining later get it too
+	a, err := s.AddAgent(swarm.AgentConfig{})
+	require.NoError(t, err)
+	assert.IsType(t, &decision.RiskAverse{}, a.DecisionMaker())
+
+	_, err = swarm.New(10, goal, swarm.WithDecisionMaker("swarm_test_missing"))
+	require.ErrorIs(t, err, decision.ErrUnknownDecisionMaker)
+}
+
+// TestDecisionMakerShapesRun checks that the goal-directed loop asks each
+// agent's decision maker before moving it: a risk-averse agent never takes
+// a step costing more than its limit, 1 for the built-in, which at the
+// default price of 2 per radian is half a radian. Agents far from the
+// target then never set out, and the swarm does not converge.
+func TestDecisionMakerShapesRun(t *testing.T) {
+	t.Parallel()
+
+	goal := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.85}
+	newSwarm := func(t *testing.T, name string) *swarm.Swarm {
+		t.Helper()
+		s, err := swarm.New(20, goal, swarm.WithSeed(8), swarm.WithDecisionMaker(name))
+		require.NoError(t, err)
+		t.Cleanup(s.Close)
+		return s
+	}
+
+	t.Run("steps", func(t *testing.T) {
+		t.Parallel()
+		largest := func(name string) float64 {
+			s := newSwarm(t, name)
+			most := 0.0
+			for range 20 {
+				before := make(map[string]float64)
+				for id, a := range s.Agents() {
+					before[id] = a.Phase()
+				}
+				s.Step()
+				for id, a := range s.Agents() {
+					most = max(most, math.Abs(core.PhaseDifference(a.Phase(), before[id])))
+				}
+			}
+			return most
+		}
+		assert.Greater(t, largest(decision.NameSimple), 0.5)
+		assert.LessOrEqual(t, largest(decision.NameRiskAverse), 0.5+1e-9)
+	})
+
+	t.Run("run", func(t *testing.T) {
+		t.Parallel()
+		synctest.Test(t, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			require.NoError(t, newSwarm(t, decision.NameSimple).Run(ctx))
+
+			averse := newSwarm(t, decision.NameRiskAverse)
+			require.ErrorIs(t, averse.Run(ctx), context.DeadlineExceeded)
+			assert.Less(t, averse.MeasureCoherence(), goal.Coherence)
+		})
+	})
+}
+
+// steadyDecider takes the first option it is offered, always with the same
+// confidence.
+type steadyDecider struct {
+	confidence float64
+}
+
+func (d *steadyDecider) Decide(_ core.State, options []core.Action) (core.Action, float64) {
+	return options[0], d.confidence
+}
+
+// TestDecisionConfidenceScalesSteps checks that with WithConfidenceScaling
+// the loop takes each step only as far as the decision maker is sure of:
+// agents whose decision maker is unsure make smaller moves and converge
+// more slowly than ones whose decision maker takes the same steps with
+// full confidence. Without it, confidence does not change the steps.
+func TestDecisionConfidenceScalesSteps(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, decision.Register("swarm_test_sure", func() core.DecisionMaker {
+		return &steadyDecider{confidence: 1}
+	}))
+	require.NoError(t, decision.Register("swarm_test_unsure", func() core.DecisionMaker {
+		return &steadyDecider{confidence: 0.2}
+	}))
+
+	goal := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.85}
+	run := func(name string, opts ...swarm.Option) (step, coherence float64) {
+		opts = append([]swarm.Option{swarm.WithSeed(8), swarm.WithDecisionMaker(name)}, opts...)
+		s, err := swarm.New(20, goal, opts...)
+		require.NoError(t, err)
+		defer s.Close()
+
+		before := make(map[string]float64)
+		for id, a := range s.Agents() {
+			before[id] = a.Phase()
+		}
+		coherence = s.Step()
+		for id, a := range s.Agents() {
+			step += math.Abs(core.PhaseDifference(a.Phase(), before[id])) / 20
+		}
+		for range 2 {
+			coherence = s.Step()
+		}
+		return step, coherence
+	}
+
+	sureStep, sureCoherence := run("swarm_test_sure", swarm.WithConfidenceScaling())
+	unsureStep, unsureCoherence := run("swarm_test_unsure", swarm.WithConfidenceScaling())
+	assert.Less(t, unsureStep, sureStep/2, "unsure decisions should make smaller moves")
+	assert.Less(t, unsureCoherence, sureCoherence, "and converge more slowly")
+
+	unscaledStep, unscaledCoherence := run("swarm_test_unsure")
+	assert.InDelta(t, sureStep, unscaledStep, 1e-12, "without scaling, steps are taken in full")
+	assert.InDelta(t, sureCoherence, unscaledCoherence, 1e-12)
+}
+
+func TestWithCostModel(t *testing.T) {
+	t.Parallel()
+
+	goal := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8}
+	model := core.DefaultCostModel{}
+
+	s, err := swarm.New(10, goal, swarm.WithCostModel(model))
+	require.NoError(t, err)
+	defer s.Close()
+	assert.Equal(t, model, s.CostModel())
+	for _, a := range s.Agents() {
+		assert.Equal(t, model, a.CostModel())
+	}
+
+	// Agents joining later and clones get it too
+	a, err := s.AddAgent(swarm.AgentConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, model, a.CostModel())
+	clone, err := s.Clone()
+	require.NoError(t, err)
+	defer clone.Close()
+	assert.Equal(t, model, clone.CostModel())
+
+	plain, err := swarm.New(10, goal)
+	require.NoError(t, err)
+	defer plain.Close()
+	assert.Nil(t, plain.CostModel())
+	for _, a := range plain.Agents() {
+		assert.Nil(t, a.CostModel(), "without a model proposals keep their strategy's prices")
+	}
+
+	_, err = swarm.New(10, goal, swarm.WithCostModel(nil))
+	require.Error(t, err)
+}
+
+// perRadian prices a move at a fixed cost per radian of phase, and makes
+// every move worth 1.
+type perRadian float64
+
+func (p perRadian) Evaluate(from, to, _ core.State) (cost, benefit float64) {
+	return float64(p) * math.Abs(core.PhaseDifference(to.Phase, from.Phase)), 1
+}
+
+// TestCostModelPricesEnergy checks that agents are charged the energy their
+// cost model puts on a move: under a spend cap of 0.1, a model charging 10
+// per radian holds each step to a hundredth of a radian, a tenth of what
+// the swarm's own price of 1 per radian allows.
+func TestCostModelPricesEnergy(t *testing.T) {
+	t.Parallel()
+
+	goal := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.85}
+	largest := func(opts ...swarm.Option) float64 {
+		opts = append([]swarm.Option{
+			swarm.WithSeed(8),
+			swarm.WithEnergyCost(1),
+			swarm.WithResourceLimits(swarm.WithMaxSpendPerTick(0.1)),
+		}, opts...)
+		s, err := swarm.New(20, goal, opts...)
+		require.NoError(t, err)
+		defer s.Close()
+
+		most := 0.0
+		for range 20 {
+			before := make(map[string]float64)
+			for id, a := range s.Agents() {
+				before[id] = a.Phase()
+			}
+			s.Step()
+			for id, a := range s.Agents() {
+				most = max(most, math.Abs(core.PhaseDifference(a.Phase(), before[id])))
+			}
+		}
+		return most
+	}
+
+	assert.InDelta(t, 0.1, largest(), 1e-9)
+	assert.InDelta(t, 0.01, largest(swarm.WithCostModel(perRadian(10))), 1e-9)
+}
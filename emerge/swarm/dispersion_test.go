@@ -0,0 +1,120 @@
+package swarm_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/goal"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+func TestMeasureDispersion(t *testing.T) {
+	t.Parallel()
+
+	const size = 20
+
+	tests := []struct {
+		name          string
+		phase         func(i int) float64
+		minDispersion float64
+		maxDispersion float64
+		converged     bool
+	}{
+		{
+			name:          "uniform phases",
+			phase:         func(i int) float64 { return 2 * math.Pi * float64(i) / size },
+			minDispersion: 0.95,
+			maxDispersion: 1,
+			converged:     true,
+		},
+		{
+			name: "two anti-phase clusters",
+			phase: func(i int) float64 {
+				if i%2 == 0 {
+					return 0
+				}
+				return math.Pi
+			},
+			minDispersion: 0,
+			maxDispersion: 0.05,
+			converged:     false,
+		},
+		{
+			name:          "fully synchronized",
+			phase:         func(int) float64 { return 1.0 },
+			minDispersion: 0,
+			maxDispersion: 0.05,
+			converged:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, err := swarm.New(size, core.State{
+				Phase:     0,
+				Frequency: 100 * time.Millisecond,
+				Coherence: 0.3,
+			}, swarm.WithGoal(goal.DistributeLoad))
+			require.NoError(t, err)
+			defer s.Close()
+
+			i := 0
+			for _, a := range s.Agents() {
+				a.SetPhase(tt.phase(i))
+				i++
+			}
+
+			dispersion := s.MeasureDispersion()
+			assert.GreaterOrEqual(t, dispersion, tt.minDispersion)
+			assert.LessOrEqual(t, dispersion, tt.maxDispersion)
+			assert.Equal(t, tt.converged, s.IsConverged())
+		})
+	}
+}
+
+func TestLoadFairness(t *testing.T) {
+	t.Parallel()
+
+	const size = 20
+
+	tests := []struct {
+		name  string
+		phase func(i int) float64
+		want  float64
+	}{
+		{"splay", func(i int) float64 { return 2 * math.Pi * (float64(i) + 0.5) / size }, 1},
+		{"one sector doubled", func(i int) float64 {
+			if i >= 16 {
+				return math.Pi / 4 // A fifth row in the first sector
+			}
+			return float64(i%4)*math.Pi/2 + math.Pi/4
+		}, 2},
+		{"fully synchronized", func(int) float64 { return 1.0 }, math.Inf(1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, err := swarm.New(size, core.State{
+				Phase:     0,
+				Frequency: 100 * time.Millisecond,
+				Coherence: 0.3,
+			}, swarm.WithGoal(goal.DistributeLoad))
+			require.NoError(t, err)
+			defer s.Close()
+
+			for i, a := range s.AgentsSorted() {
+				a.SetPhase(tt.phase(i))
+			}
+			assert.Equal(t, tt.want, s.LoadFairness(4))
+		})
+	}
+}
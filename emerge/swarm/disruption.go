@@ -0,0 +1,272 @@
+package swarm
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+)
+
+// DisruptionKind selects what a disruption does to the agents it hits.
+type DisruptionKind int
+
+const (
+	// PhaseScramble moves agents to random phases.
+	PhaseScramble DisruptionKind = iota
+
+	// EnergyDrain removes a share of the agents' energy.
+	EnergyDrain
+
+	// Partition cuts every link between the hit agents and the rest of the
+	// swarm, leaving two groups that can only synchronize internally.
+	Partition
+
+	// Stubborn makes agents resist adjustment by raising their stubbornness.
+	// Run and RunContinuous skip a stubborn agent's adjustment on that
+	// fraction of ticks, so fully stubborn agents (the default) stop moving
+	// and hold the swarm's coherence back.
+	Stubborn
+
+	// Cascade fails agents (random phase, no energy) and lets the failure
+	// spread to their neighbors.
+	Cascade
+)
+
+// String returns the kind's name.
+func (k DisruptionKind) String() string {
+	switch k {
+	case PhaseScramble:
+		return "phase_scramble"
+	case EnergyDrain:
+		return "energy_drain"
+	case Partition:
+		return "partition"
+	case Stubborn:
+		return "stubborn"
+	case Cascade:
+		return "cascade"
+	default:
+		return fmt.Sprintf("DisruptionKind(%d)", int(k))
+	}
+}
+
+// defaultSpreadProbability is the cascade spread chance when none is given.
+const defaultSpreadProbability = 0.3
+
+// DisruptionSpec describes a disruption. Fraction picks how many agents are
+// hit, chosen at random; the remaining fields only apply to one kind each,
+// and their zero values select sensible defaults.
+type DisruptionSpec struct {
+	Kind     DisruptionKind
+	Fraction float64 // Share of agents hit, clamped to [0, 1]; ignored by DisruptSpecific
+
+	DrainRatio        float64 // EnergyDrain: share of energy removed; zero drains everything
+	Stubbornness      float64 // Stubborn: stubbornness to set; zero means fully stubborn (1)
+	SpreadProbability float64 // Cascade: chance of failing each neighbor; zero means 0.3
+}
+
+// DisruptionReport describes what a disruption did.
+type DisruptionReport struct {
+	Kind     DisruptionKind
+	Affected int      // Number of agents affected, including cascade spread
+	AgentIDs []string // IDs of the affected agents, in the order they were hit
+}
+
+// Disrupt applies a disruption to the swarm and reports which agents it
+// affected. Like DisruptAgents, it counts as a disruption for observers and
+// publishes EventDisrupted, and a running RunContinuous will recover from it.
+// Recovery is tracked for LastRecovery and WithDisruptionObserver, and with
+// WithMinNeighbors agents left short of neighbors are relinked. Parameters
+// outside their valid range return ErrInvalidDisruption and leave the
+// swarm untouched.
+func (s *Swarm) Disrupt(spec DisruptionSpec) (DisruptionReport, error) {
+	if err := spec.validate(); err != nil {
+		return DisruptionReport{}, err
+	}
+
+	report := s.disrupt(spec, s.sampleAgents(int(float64(s.Size())*clamp01(spec.Fraction))))
+	s.logEvent(logEntry{Kind: logDisrupt, Disruption: &spec})
+	return report, nil
+}
+
+// DisruptSpecific applies a disruption to the named agents only, for
+// failing known nodes, e.g. the hub of a star, the same way every time.
+// The spec's Fraction is ignored; a cascade still spreads from the named
+// agents at random, drawing from the seeded source with WithSeed. IDs
+// naming no agent return ErrAgentNotFound, and a repeated ID is hit once.
+// Otherwise it behaves like Disrupt.
+func (s *Swarm) DisruptSpecific(ids []string, spec DisruptionSpec) (DisruptionReport, error) {
+	if err := spec.validate(); err != nil {
+		return DisruptionReport{}, err
+	}
+	hit := make([]*agent.Agent, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		a, ok := s.Agent(id)
+		if !ok {
+			return DisruptionReport{}, fmt.Errorf("disrupt: %w: %s", ErrAgentNotFound, id)
+		}
+		if !seen[id] {
+			seen[id] = true
+			hit = append(hit, a)
+		}
+	}
+	report := s.disrupt(spec, hit)
+	s.logEvent(logEntry{Kind: logDisruptSpecific, Disruption: &spec, AgentIDs: ids})
+	return report, nil
+}
+
+// disrupt applies a validated disruption to the hit agents.
+func (s *Swarm) disrupt(spec DisruptionSpec, hit []*agent.Agent) DisruptionReport {
+	pre := s.MeasureCoherence()
+	report := DisruptionReport{Kind: spec.Kind}
+
+	switch spec.Kind {
+	case PhaseScramble:
+		for _, a := range hit {
+			a.SetPhase(s.randPhase())
+		}
+	case EnergyDrain:
+		ratio := spec.DrainRatio
+		if ratio == 0 {
+			ratio = 1
+		}
+		for _, a := range hit {
+			a.SetEnergy(a.Energy() * (1 - ratio))
+		}
+	case Partition:
+		s.partition(hit)
+	case Stubborn:
+		stubbornness := spec.Stubbornness
+		if stubbornness == 0 {
+			stubbornness = 1
+		}
+		for _, a := range hit {
+			a.SetStubbornness(stubbornness)
+		}
+	case Cascade:
+		p := spec.SpreadProbability
+		if p == 0 {
+			p = defaultSpreadProbability
+		}
+		hit = s.cascade(hit, p)
+	}
+
+	s.healConnectivity()
+
+	report.Affected = len(hit)
+	report.AgentIDs = make([]string, len(hit))
+	for i, a := range hit {
+		report.AgentIDs[i] = a.ID
+	}
+
+	s.afterDisruption(report)
+	s.startRecovery(spec.Kind, pre, s.MeasureCoherence())
+	return report
+}
+
+// validate checks that the spec's kind and parameters are usable.
+func (spec DisruptionSpec) validate() error {
+	if spec.Kind < PhaseScramble || spec.Kind > Cascade {
+		return fmt.Errorf("%w: unknown kind %v", ErrInvalidDisruption, spec.Kind)
+	}
+	if math.IsNaN(spec.Fraction) {
+		return fmt.Errorf("%w: fraction is NaN", ErrInvalidDisruption)
+	}
+	params := []struct {
+		name  string
+		value float64
+	}{
+		{"drain ratio", spec.DrainRatio},
+		{"stubbornness", spec.Stubbornness},
+		{"spread probability", spec.SpreadProbability},
+	}
+	for _, p := range params {
+		if p.value < 0 || p.value > 1 || math.IsNaN(p.value) {
+			return fmt.Errorf("%w: %s %v outside [0, 1]", ErrInvalidDisruption, p.name, p.value)
+		}
+	}
+	return nil
+}
+
+// sampleAgents picks n distinct agents at random from the swarm's source.
+func (s *Swarm) sampleAgents(n int) []*agent.Agent {
+	agents := s.collectAgents()
+	n = min(n, len(agents))
+	for i := range n {
+		j := i + s.randIntn(len(agents)-i)
+		agents[i], agents[j] = agents[j], agents[i]
+	}
+	return agents[:n]
+}
+
+// partition removes every link between the given agents and the others.
+func (s *Swarm) partition(group []*agent.Agent) {
+	inGroup := make(map[string]bool, len(group))
+	for _, a := range group {
+		inGroup[a.ID] = true
+	}
+	agents := s.collectAgents()
+	for _, a := range group {
+		for _, b := range agents {
+			if inGroup[b.ID] {
+				continue
+			}
+			disconnect(a, b)
+			disconnect(b, a)
+		}
+	}
+}
+
+// cascade fails the seed agents and spreads the failure breadth-first to
+// neighbors with probability p. It returns every failed agent.
+func (s *Swarm) cascade(seeds []*agent.Agent, p float64) []*agent.Agent {
+	agents := s.collectAgents()
+	failed := make(map[string]bool, len(agents))
+	for _, a := range seeds {
+		failed[a.ID] = true
+	}
+
+	all := append([]*agent.Agent(nil), seeds...)
+	for frontier := seeds; len(frontier) > 0; {
+		var next []*agent.Agent
+		for _, a := range frontier {
+			a.SetPhase(s.randPhase())
+			a.SetEnergy(0)
+			for _, b := range agents {
+				if failed[b.ID] || !connected(a, b) {
+					continue
+				}
+				if s.randFloat64() < p {
+					failed[b.ID] = true
+					next = append(next, b)
+				}
+			}
+		}
+		all = append(all, next...)
+		frontier = next
+	}
+	return all
+}
+
+// connected reports whether a links to b in either neighbor store.
+// The default topology fills the Neighbors map while topology builders use
+// ConnectTo, so both have to be checked.
+func connected(a, b *agent.Agent) bool {
+	if a.IsConnectedTo(b.ID) {
+		return true
+	}
+	_, ok := a.Neighbors().Load(b.ID)
+	return ok
+}
+
+// disconnect removes a's link to b from both neighbor stores.
+func disconnect(a, b *agent.Agent) {
+	a.DisconnectFrom(b.ID)
+	a.Neighbors().Delete(b.ID)
+}
+
+// clamp01 limits v to [0, 1].
+func clamp01(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
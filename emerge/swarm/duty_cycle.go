@@ -0,0 +1,130 @@
+package swarm
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+// dutyCycleTolerance is how far the number of active agents may stray
+// from the expected number, as a fraction of it, anywhere in the cycle
+// before a duty-cycling swarm counts as converged.
+const dutyCycleTolerance = 0.25
+
+// dutyCycler is implemented by strategies that keep an agent active for
+// only part of each cycle, such as strategy.DutyCycle.
+type dutyCycler interface {
+	spreader
+	Active(phase, clock float64) bool
+	ActiveFraction() float64
+}
+
+// WithActiveFraction sets the share of each cycle a duty-cycling agent is
+// active, for swarms whose strategy is strategy.DutyCycle; goal.SaveEnergy
+// selects it. The default is 0.2. The swarm staggers its agents so that
+// about this fraction of them is active at any instant; with fraction
+// times size at least 1, some agent always is.
+func WithActiveFraction(fraction float64) Option {
+	return func(s *Swarm) error {
+		if !(fraction > 0 && fraction <= 1) {
+			return fmt.Errorf("active fraction must be in (0, 1], got %v", fraction)
+		}
+		s.activeFraction = fraction
+		return nil
+	}
+}
+
+// ActiveFraction returns the fraction of agents active right now. Phases
+// are relative to a clock that turns once per target period, starting when
+// the swarm is created; a duty-cycling agent becomes active when the clock
+// brings its phase around to zero and stays active for its strategy's
+// active fraction of the period. Agents whose strategy does not duty-cycle
+// are always active.
+func (s *Swarm) ActiveFraction() float64 {
+	agents := s.collectAgents()
+	if len(agents) == 0 {
+		return 0
+	}
+	clock := s.rhythmClock(s.now())
+	active := 0
+	for _, a := range agents {
+		if cycler, ok := a.Strategy().(dutyCycler); !ok || cycler.Active(a.Phase(), clock) {
+			active++
+		}
+	}
+	return float64(active) / float64(len(agents))
+}
+
+// rhythmClock returns the position at now of the clock that turns once
+// per target period from the swarm's creation. Duty cycles and reference
+// rhythms read agent phases against it.
+func (s *Swarm) rhythmClock(now time.Time) float64 {
+	period := s.target().Frequency
+	if period <= 0 {
+		return 0
+	}
+	turns := float64(now.Sub(s.epoch)) / float64(period)
+	return 2 * math.Pi * (turns - math.Floor(turns))
+}
+
+// dutyCycling reports whether every agent duty-cycles.
+func dutyCycling(agents []*agent.Agent) bool {
+	if len(agents) == 0 {
+		return false
+	}
+	for _, a := range agents {
+		if _, ok := a.Strategy().(dutyCycler); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// dutyCycleBalanced reports whether duty-cycling agents are staggered
+// well enough: at every point of the cycle the number of active agents is
+// within dutyCycleTolerance of the number their active fractions add up
+// to, and at least one agent is active whenever they add up to one or
+// more. The count only changes where an agent's active window opens or
+// closes, so those are the only clock positions checked.
+func dutyCycleBalanced(agents []*agent.Agent) bool {
+	cyclers := make([]dutyCycler, 0, len(agents))
+	phases := make([]float64, 0, len(agents))
+	expected := 0.0
+	for _, a := range agents {
+		cycler, ok := a.Strategy().(dutyCycler)
+		if !ok {
+			continue
+		}
+		cyclers = append(cyclers, cycler)
+		phases = append(phases, a.Phase())
+		expected += cycler.ActiveFraction()
+	}
+	if len(cyclers) == 0 {
+		return false
+	}
+
+	fewest := int(expected * (1 - dutyCycleTolerance))
+	if expected >= 1 {
+		fewest = max(fewest, 1)
+	}
+	most := int(math.Ceil(expected * (1 + dutyCycleTolerance)))
+
+	for i, cycler := range cyclers {
+		opens := core.WrapPhase(-phases[i])
+		for _, clock := range []float64{opens, core.WrapPhase(opens + 2*math.Pi*cycler.ActiveFraction())} {
+			active := 0
+			for j, other := range cyclers {
+				if other.Active(phases[j], clock) {
+					active++
+				}
+			}
+			if active < fewest || active > most {
+				return false
+			}
+		}
+	}
+	return true
+}
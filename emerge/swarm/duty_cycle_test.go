@@ -0,0 +1,78 @@
+package swarm_test
+
+import (
+	"context"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/goal"
+	"github.com/carlisia/bio-adapt/emerge/strategy"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+// TestDutyCycle runs an energy-saving swarm with an active fraction of 0.2
+// and samples it over a whole period: about a fifth of the agents should
+// be active at every tick, and never none of them.
+func TestDutyCycle(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		const (
+			size   = 30
+			period = 200 * time.Millisecond
+			ticks  = 40
+		)
+		s, err := swarm.New(size, core.State{
+			Phase:     0,
+			Frequency: period,
+			Coherence: 0.7,
+		}, swarm.WithGoal(goal.SaveEnergy), swarm.WithActiveFraction(0.2), swarm.WithSeed(7))
+		require.NoError(t, err)
+		defer s.Close()
+		assert.Equal(t, strategy.NameDutyCycle, s.StrategyName())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+		require.NoError(t, s.Run(ctx))
+		assert.True(t, s.IsConverged())
+
+		total := 0.0
+		for range ticks {
+			active := s.ActiveFraction()
+			assert.Greater(t, active, 0.0, "some agent should always be active")
+			assert.InDelta(t, 0.2, active, 0.1)
+			total += active
+			time.Sleep(period / ticks)
+		}
+		assert.InDelta(t, 0.2, total/ticks, 0.02, "agents should rest 80%% of the time on average")
+	})
+}
+
+func TestActiveFraction(t *testing.T) {
+	t.Parallel()
+
+	target := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.7}
+
+	t.Run("agents that do not duty-cycle are always active", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := swarm.New(10, target)
+		require.NoError(t, err)
+		defer s.Close()
+		assert.InDelta(t, 1.0, s.ActiveFraction(), 1e-9)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		t.Parallel()
+
+		for _, f := range []float64{0, -0.1, 1.5} {
+			_, err := swarm.New(10, target, swarm.WithActiveFraction(f))
+			require.Error(t, err, "fraction %v", f)
+		}
+	})
+}
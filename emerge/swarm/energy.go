@@ -0,0 +1,238 @@
+package swarm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/internal/resource"
+)
+
+// RechargePolicy decides how much energy an agent regains over time (see
+// WithRechargePolicy). ConstantRate, Logistic and BurstOnIdle are built in.
+type RechargePolicy = resource.RechargePolicy
+
+// Built-in recharge policies.
+type (
+	// ConstantRate regenerates a fixed amount of energy per second.
+	ConstantRate = resource.ConstantRate
+	// Logistic regenerates fastest at half capacity.
+	Logistic = resource.Logistic
+	// BurstOnIdle restores energy once an agent has stopped spending.
+	BurstOnIdle = resource.BurstOnIdle
+)
+
+// energyModel holds the swarm's metabolic settings. The zero value makes
+// phase adjustments free and never recharges.
+type energyModel struct {
+	cost     float64         // Energy per radian of phase adjustment
+	policy   RechargePolicy  // nil disables recharge
+	capacity float64         // Recharge ceiling; 0 uses the initial energy
+	limits   resource.Limits // Spending caps (see WithResourceLimits)
+	gate     float64         // Mean energy below which moves are rationed; 0 disables (see WithEnergyGate)
+
+	gated atomic.Bool // Mean energy was below the gate at the start of this tick
+
+	mu     sync.Mutex
+	agents map[string]energyTrack
+	spent  map[string]float64 // Energy each agent spent this tick, under a spend cap
+}
+
+// energyTrack is what recharge remembers about an agent between ticks.
+type energyTrack struct {
+	level float64       // Energy after the last recharge
+	idle  time.Duration // Time since the agent last spent energy
+}
+
+// WithEnergyCost makes the goal-directed loop charge agents perRadian energy
+// for every radian it moves their phase. An agent that cannot afford its
+// adjustment holds its phase for that tick, so a swarm that spends more than
+// it regains stalls as its agents run dry. Agents with a cost model (see
+// WithCostModel) are charged the cost it puts on the move instead, so
+// perRadian only has to be positive for moves to be charged at all. The
+// default, 0, makes adjustments free.
+func WithEnergyCost(perRadian float64) Option {
+	return func(s *Swarm) error {
+		if perRadian < 0 || math.IsNaN(perRadian) {
+			return fmt.Errorf("energy cost must not be negative, got %v", perRadian)
+		}
+		s.energy.cost = perRadian
+		return nil
+	}
+}
+
+// WithRechargePolicy regenerates agent energy during Run and RunContinuous
+// according to p, so agents no longer need manual top-ups with SetEnergy.
+// Energy is recharged once per update interval, and never while the swarm
+// is paused. Recharge stops at the capacity set with WithEnergyCapacity,
+// which defaults to the configured initial energy.
+func WithRechargePolicy(p RechargePolicy) Option {
+	return func(s *Swarm) error {
+		if p == nil {
+			return errors.New("recharge policy must not be nil")
+		}
+		s.energy.policy = p
+		return nil
+	}
+}
+
+// WithEnergyCapacity sets the most energy an agent can recharge to. Energy
+// set above it with SetEnergy is kept but not topped up further.
+func WithEnergyCapacity(capacity float64) Option {
+	return func(s *Swarm) error {
+		if capacity <= 0 || math.IsNaN(capacity) {
+			return fmt.Errorf("energy capacity must be positive, got %v", capacity)
+		}
+		s.energy.capacity = capacity
+		return nil
+	}
+}
+
+// ResourceLimit bounds how agents spend energy (see WithResourceLimits).
+type ResourceLimit = resource.LimitOption
+
+// WithMaxSpendPerTick caps the energy an agent spends per update at
+// amount, for WithResourceLimits.
+func WithMaxSpendPerTick(amount float64) ResourceLimit {
+	return resource.WithMaxSpendPerTick(amount)
+}
+
+// WithResourceLimits bounds how agents spend the energy charged by
+// WithEnergyCost. With WithMaxSpendPerTick, an agent never spends more than
+// the cap in one update, however far its strategy wants to move: a move
+// costing more is scaled down to what the agent has left to spend that
+// update, and further moves wait for the next one. Unbounded spending
+// drains agents that start far from the target while the rest stay full,
+// and cheap recharge then sets them oscillating between exhaustion and
+// bursts of large moves; a cap keeps energy even across the swarm.
+func WithResourceLimits(limits ...ResourceLimit) Option {
+	return func(s *Swarm) error {
+		l, err := resource.NewLimits(limits...)
+		if err != nil {
+			return fmt.Errorf("invalid resource limits: %w", err)
+		}
+		s.energy.limits = l
+		return nil
+	}
+}
+
+// EnergyCapacity returns the most energy an agent recharges to.
+func (s *Swarm) EnergyCapacity() float64 {
+	if s.energy.capacity > 0 {
+		return s.energy.capacity
+	}
+	return s.config.InitialEnergy
+}
+
+// spend charges a for moving its phase from one value to another (see
+// moveCost). It
+// returns the phase the agent can afford to move to, reporting false if it
+// cannot move at all. While the energy gate is closed (see WithEnergyGate)
+// the move is rationed first. Under a spend cap (see WithResourceLimits) a
+// move costing more than the agent has left to spend this tick is scaled
+// down to fit.
+func (s *Swarm) spend(a *agent.Agent, from, to float64) (float64, bool) {
+	if s.energy.cost == 0 {
+		return to, true
+	}
+	step := core.PhaseDifference(to, from)
+	if s.energy.gated.Load() {
+		if a.Energy() < s.energy.gate {
+			return from, false
+		}
+		step *= gatedStep
+		to = from + step
+	}
+	cost := s.moveCost(a, from, to)
+	if s.energy.limits.MaxSpendPerTick > 0 {
+		s.energy.mu.Lock()
+		defer s.energy.mu.Unlock()
+		share := s.energy.limits.Allow(cost, s.energy.spent[a.ID])
+		if share == 0 {
+			return from, false
+		}
+		if share < 1 {
+			// A cost model need not be linear; never charge beyond the share
+			to = from + step*share
+			cost = min(cost*share, s.moveCost(a, from, to))
+		}
+	}
+
+	ok := false
+	a.UpdateEnergy(func(energy float64) float64 {
+		if ok = energy >= cost; ok {
+			return energy - cost
+		}
+		return energy
+	})
+	if ok && s.energy.limits.MaxSpendPerTick > 0 {
+		if s.energy.spent == nil {
+			s.energy.spent = make(map[string]float64)
+		}
+		s.energy.spent[a.ID] += cost
+	}
+	return to, ok
+}
+
+// moveCost returns what moving a's phase from one value to another costs:
+// the cost a's cost model puts on the move toward the swarm's target, or
+// else the swarm's per-radian cost.
+func (s *Swarm) moveCost(a *agent.Agent, from, to float64) float64 {
+	m := a.CostModel()
+	if m == nil {
+		return s.energy.cost * math.Abs(core.PhaseDifference(to, from))
+	}
+	freq := a.Frequency()
+	cost, _ := m.Evaluate(core.State{Phase: from, Frequency: freq}, core.State{Phase: to, Frequency: freq}, s.target())
+	return math.Max(cost, 0)
+}
+
+// forget drops what recharge remembers about a removed agent.
+func (m *energyModel) forget(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.agents, id)
+	delete(m.spent, id)
+}
+
+// recharge starts a tick: it resets what agents have spent toward the
+// spend cap and regenerates every agent's energy for elapsed time. An agent
+// whose energy dropped since the last recharge has been spending, however
+// it spent, and restarts its idle time.
+func (s *Swarm) recharge(agents []*agent.Agent, elapsed time.Duration) {
+	if s.energy.limits.MaxSpendPerTick > 0 {
+		s.energy.mu.Lock()
+		clear(s.energy.spent)
+		s.energy.mu.Unlock()
+	}
+
+	policy := s.energy.policy
+	if policy == nil {
+		return
+	}
+	capacity := s.EnergyCapacity()
+
+	s.energy.mu.Lock()
+	defer s.energy.mu.Unlock()
+	if s.energy.agents == nil {
+		s.energy.agents = make(map[string]energyTrack, len(agents))
+	}
+
+	for _, a := range agents {
+		track, seen := s.energy.agents[a.ID]
+		if seen && a.Energy() < track.level {
+			track.idle = 0
+		} else {
+			track.idle += elapsed
+		}
+		track.level = a.UpdateEnergy(func(energy float64) float64 {
+			return resource.Recharge(policy, energy, capacity, elapsed, track.idle)
+		})
+		s.energy.agents[a.ID] = track
+	}
+}
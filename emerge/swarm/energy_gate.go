@@ -0,0 +1,56 @@
+package swarm
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+)
+
+// gatedStep is the share of its move an agent makes while the energy gate
+// is closed.
+const gatedStep = 0.25
+
+// WithEnergyGate rations adjustments while the swarm's energy is low, so a
+// swarm on a tight budget glides instead of thrashing its agents into
+// exhaustion. Each time it recharges, at the start of every
+// goal-directed iteration and between RunContinuous resyncs, the swarm
+// compares its mean agent energy with threshold. While
+// the mean is below it, agents whose own energy is below threshold hold
+// their phase, the cheapest action there is, and the rest move only a
+// quarter of the way their strategy asks for. Once recharge brings the
+// mean back to threshold, agents move freely again.
+//
+// The gate only matters for swarms whose moves cost energy (see
+// WithEnergyCost), and is meant to be paired with a recharge policy (see
+// WithRechargePolicy). Use EnergyGated to see whether it is closed. The
+// threshold must be positive and finite; the gate is off by default.
+func WithEnergyGate(threshold float64) Option {
+	return func(s *Swarm) error {
+		if !(threshold > 0) || math.IsInf(threshold, 0) {
+			return fmt.Errorf("energy gate threshold must be positive and finite, got %v", threshold)
+		}
+		s.energy.gate = threshold
+		return nil
+	}
+}
+
+// EnergyGated reports whether the energy gate is closed: mean agent energy
+// was below the WithEnergyGate threshold when the swarm last recharged. It
+// is always false without an energy gate.
+func (s *Swarm) EnergyGated() bool {
+	return s.energy.gated.Load()
+}
+
+// gateEnergy opens or closes the energy gate for the coming tick from the
+// agents' mean energy.
+func (s *Swarm) gateEnergy(agents []*agent.Agent) {
+	if s.energy.gate == 0 || len(agents) == 0 {
+		return
+	}
+	var total float64
+	for _, a := range agents {
+		total += a.Energy()
+	}
+	s.energy.gated.Store(total/float64(len(agents)) < s.energy.gate)
+}
@@ -0,0 +1,73 @@
+package swarm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+	"github.com/carlisia/bio-adapt/emerge/swarm/clocktest"
+)
+
+func TestWithEnergyGate(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.9}
+	for _, threshold := range []float64{0, -1} {
+		_, err := swarm.New(10, goalState, swarm.WithEnergyGate(threshold))
+		require.Error(t, err)
+	}
+
+	const (
+		capacity  = 10.0
+		exhausted = 1.0 // Energy below which an agent can barely move
+	)
+	// run drives a swarm on a budget it cannot sustain: disruptions keep
+	// scattering it faster than its agents recharge. It returns how many
+	// agents end above the exhaustion line and whether the gate closed.
+	run := func(opts ...swarm.Option) (int, bool) {
+		clock := clocktest.New(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+		s, err := swarm.New(40, goalState, append([]swarm.Option{
+			swarm.WithSeed(5),
+			swarm.WithClock(clock),
+			swarm.WithEnergyCost(4),
+			swarm.WithEnergyCapacity(capacity),
+			swarm.WithRechargePolicy(swarm.ConstantRate{PerSecond: 0.5}),
+		}, opts...)...)
+		require.NoError(t, err)
+		defer s.Close()
+		s.ForEachAgent(func(a *agent.Agent) bool {
+			a.SetEnergy(capacity)
+			return true
+		})
+
+		closed := false
+		for i := range 300 {
+			if i%30 == 0 {
+				s.DisruptAgents(0.5)
+			}
+			clock.Advance(s.TickInterval())
+			s.Step()
+			closed = closed || s.EnergyGated()
+		}
+
+		alive := 0
+		s.ForEachAgent(func(a *agent.Agent) bool {
+			if a.Energy() > exhausted {
+				alive++
+			}
+			return true
+		})
+		return alive, closed
+	}
+
+	ungated, closed := run()
+	assert.False(t, closed, "no gate, never closed")
+	gated, closed := run(swarm.WithEnergyGate(capacity / 2))
+	assert.True(t, closed, "the budget runs low enough to close the gate")
+	assert.Greater(t, gated, ungated, "rationing keeps more agents above the exhaustion line")
+}
@@ -0,0 +1,143 @@
+package swarm_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+// TestRechargePolicyMaintainsConvergence runs a swarm whose adjustments cost
+// energy through repeated disruptions. Without recharge its agents run dry
+// and it cannot resynchronize; with a ConstantRate policy it keeps
+// recovering, and no agent ever recharges past capacity.
+func TestRechargePolicyMaintainsConvergence(t *testing.T) {
+	t.Parallel()
+
+	const capacity = 3.0
+
+	run := func(t *testing.T, opts ...swarm.Option) (coherence, maxEnergy float64) {
+		t.Helper()
+		synctest.Test(t, func(t *testing.T) {
+			opts = append([]swarm.Option{
+				swarm.WithSeed(7),
+				swarm.WithEnergyCost(1),
+				swarm.WithEnergyCapacity(capacity),
+			}, opts...)
+			s, err := swarm.New(30, core.State{
+				Phase:     0,
+				Frequency: 200 * time.Millisecond,
+				Coherence: 0.8,
+			}, opts...)
+			require.NoError(t, err)
+			defer s.Close()
+			s.ForEachAgent(func(a *agent.Agent) bool {
+				a.SetEnergy(capacity)
+				return true
+			})
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				_ = s.RunContinuous(ctx)
+			}()
+
+			// Scatter half the swarm every few seconds, so staying
+			// synchronized takes a steady supply of energy
+			for range 10 {
+				time.Sleep(5 * time.Second)
+				s.DisruptAgents(0.5)
+			}
+			time.Sleep(8 * time.Second)
+
+			coherence = s.MeasureCoherence()
+			s.ForEachAgent(func(a *agent.Agent) bool {
+				maxEnergy = max(maxEnergy, a.Energy())
+				return true
+			})
+			cancel()
+			<-done
+		})
+		return coherence, maxEnergy
+	}
+
+	exhausted, _ := run(t)
+	recharged, maxEnergy := run(t, swarm.WithRechargePolicy(swarm.ConstantRate{PerSecond: 1}))
+	t.Logf("coherence without recharge %.3f, with recharge %.3f", exhausted, recharged)
+
+	assert.GreaterOrEqual(t, recharged, 0.7, "recharged swarm should stay synchronized")
+	assert.Less(t, exhausted, 0.6, "exhausted swarm should fail to resynchronize")
+	assert.LessOrEqual(t, maxEnergy, capacity, "recharge must respect capacity")
+}
+
+// TestResourceLimitsEvenEnergy compares how unevenly energy is spread
+// across a swarm converging with and without a spend cap. Without one,
+// agents that start far from the target spend most of their energy at
+// once while the rest stay full.
+func TestResourceLimitsEvenEnergy(t *testing.T) {
+	t.Parallel()
+
+	run := func(t *testing.T, opts ...swarm.Option) (spread, lowest float64) {
+		t.Helper()
+		synctest.Test(t, func(t *testing.T) {
+			opts = append([]swarm.Option{
+				swarm.WithSeed(1),
+				swarm.WithEnergyCost(20),
+				swarm.WithRechargePolicy(swarm.ConstantRate{PerSecond: 15}),
+			}, opts...)
+			s, err := swarm.New(40, core.State{
+				Phase:     0,
+				Frequency: 200 * time.Millisecond,
+				Coherence: 0.85,
+			}, opts...)
+			require.NoError(t, err)
+			defer s.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				_ = s.RunContinuous(ctx)
+			}()
+
+			lowest = math.Inf(1)
+			for ctx.Err() == nil {
+				time.Sleep(100 * time.Millisecond)
+				m := s.Metrics()
+				spread = max(spread, m.EnergyStdDev)
+				lowest = min(lowest, m.MinEnergy)
+			}
+			<-done
+			assert.GreaterOrEqual(t, s.MeasureCoherence(), 0.8, "the swarm should converge either way")
+		})
+		return spread, lowest
+	}
+
+	unlimitedSpread, unlimitedLowest := run(t)
+	limitedSpread, limitedLowest := run(t, swarm.WithResourceLimits(swarm.WithMaxSpendPerTick(2)))
+	t.Logf("peak energy stddev without cap %.1f, with cap %.1f", unlimitedSpread, limitedSpread)
+
+	assert.Less(t, limitedSpread, unlimitedSpread/2, "capped spending should keep energy even")
+	assert.Greater(t, limitedLowest, unlimitedLowest, "no agent should run as low under a cap")
+}
+
+func TestResourceLimitsValidation(t *testing.T) {
+	t.Parallel()
+
+	target := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.8}
+	for _, amount := range []float64{0, -1, math.NaN()} {
+		_, err := swarm.New(10, target, swarm.WithResourceLimits(swarm.WithMaxSpendPerTick(amount)))
+		require.Error(t, err, "amount %v", amount)
+	}
+}
@@ -1,8 +1,55 @@
 package swarm
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	// ErrInvalidSwarmSize indicates swarm size is invalid.
 	ErrInvalidSwarmSize = errors.New("invalid swarm size")
+
+	// ErrEmptySwarm indicates a swarm was asked for with no agents. It also
+	// matches ErrInvalidSwarmSize.
+	ErrEmptySwarm = fmt.Errorf("%w: empty swarm", ErrInvalidSwarmSize)
+
+	// ErrInvalidCoherence indicates a goal or target coherence is NaN or
+	// outside [0, 1].
+	ErrInvalidCoherence = errors.New("invalid coherence")
+
+	// ErrInvalidBands indicates a phase band configuration is invalid.
+	ErrInvalidBands = errors.New("invalid phase bands")
+
+	// ErrInvalidDisruption indicates a disruption spec is invalid.
+	ErrInvalidDisruption = errors.New("invalid disruption")
+
+	// ErrAgentNotFound indicates no agent has the given ID.
+	ErrAgentNotFound = errors.New("agent not found")
+
+	// ErrAgentExists indicates an agent with the given ID is already in the swarm.
+	ErrAgentExists = errors.New("agent already exists")
+
+	// ErrShutdown indicates Run or RunContinuous stopped because Shutdown
+	// was called.
+	ErrShutdown = errors.New("swarm shut down")
+
+	// ErrPlateau indicates Run stopped because coherence stopped improving
+	// (see WithPlateauDetection). The returned error is a *PlateauError.
+	ErrPlateau = errors.New("coherence plateau")
+
+	// ErrNotConverged indicates Run used up its iterations without
+	// reaching the target.
+	ErrNotConverged = errors.New("not converged")
+
+	// ErrDeadlineExceeded indicates RunUntil reached its deadline before
+	// the target. The returned error is a *DeadlineError.
+	ErrDeadlineExceeded = errors.New("deadline exceeded before reaching target")
+
+	// ErrNotReconfigurable indicates an option passed to Reconfigure sets
+	// something that cannot change once the swarm is built.
+	ErrNotReconfigurable = errors.New("option cannot change at runtime")
+
+	// ErrNotBridged indicates two swarms have no bridges between them (see
+	// Bridge).
+	ErrNotBridged = errors.New("swarms not bridged")
 )
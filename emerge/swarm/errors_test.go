@@ -0,0 +1,84 @@
+package swarm_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+func TestSentinelErrors(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.85}
+
+	t.Run("new", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := swarm.New(0, goalState)
+		require.ErrorIs(t, err, swarm.ErrEmptySwarm)
+		require.ErrorIs(t, err, swarm.ErrInvalidSwarmSize)
+
+		for _, coherence := range []float64{-0.1, 1.5, math.NaN()} {
+			bad := goalState
+			bad.Coherence = coherence
+			_, err := swarm.New(10, bad)
+			require.ErrorIs(t, err, swarm.ErrInvalidCoherence, "coherence %v", coherence)
+		}
+
+		bad := goalState
+		bad.Frequency = 0
+		_, err = swarm.New(10, bad)
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, swarm.ErrInvalidCoherence, "only coherence problems match")
+	})
+
+	t.Run("set target", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := swarm.New(10, goalState)
+		require.NoError(t, err)
+		defer s.Close()
+		bad := goalState
+		bad.Coherence = 2
+		require.ErrorIs(t, s.SetTarget(bad), swarm.ErrInvalidCoherence)
+	})
+
+	t.Run("run", func(t *testing.T) {
+		t.Parallel()
+
+		synctest.Test(t, func(t *testing.T) {
+			s, err := swarm.New(10, goalState, swarm.WithSeed(3))
+			require.NoError(t, err)
+			defer s.Close()
+			s.Pause()
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			err = s.Run(ctx)
+			require.ErrorIs(t, err, context.DeadlineExceeded, "a normal stop is the context's own error")
+			assert.NotErrorIs(t, err, swarm.ErrNotConverged)
+
+			ctx, cancel = context.WithCancel(context.Background())
+			cancel()
+			require.ErrorIs(t, s.Run(ctx), context.Canceled)
+		})
+
+		synctest.Test(t, func(t *testing.T) {
+			// Noise this strong keeps the swarm from ever reaching its target
+			s, err := swarm.New(10, goalState, swarm.WithSeed(3), swarm.WithPhaseNoise(3))
+			require.NoError(t, err)
+			defer s.Close()
+
+			err = s.Run(context.Background())
+			require.ErrorIs(t, err, swarm.ErrNotConverged)
+		})
+	})
+}
@@ -0,0 +1,302 @@
+package swarm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/goal"
+	"github.com/carlisia/bio-adapt/internal/config"
+)
+
+// Kinds of event log entries.
+const (
+	logStart           = "start"            // The swarm as New built it
+	logRun             = "run"              // A synchronization run began
+	logTick            = "tick"             // A goal-directed iteration
+	logDisrupt         = "disrupt"          // Disrupt or DisruptAgents
+	logDisruptSpecific = "disrupt_specific" // DisruptSpecific
+	logRetarget        = "retarget"         // SetTarget
+	logAdd             = "add"              // AddAgent
+	logRemove          = "remove"           // RemoveAgent
+	logReconfigure     = "reconfigure"      // Reconfigure
+)
+
+// logEntry is one line of an event log. Kind says which fields are set.
+type logEntry struct {
+	Kind string    `json:"kind"`
+	Time time.Time `json:"time"`
+
+	// start
+	Size       int             `json:"size,omitempty"`
+	Seed       int64           `json:"seed,omitempty"`
+	Goal       *core.State     `json:"goal,omitempty"`
+	GoalType   string          `json:"goal_type,omitempty"` // Set if built with WithGoal
+	GoalConfig *Config         `json:"goal_config,omitempty"`
+	PhaseNoise float64         `json:"phase_noise,omitempty"`
+	EnergyCost float64         `json:"energy_cost,omitempty"`
+	Config     json.RawMessage `json:"config,omitempty"`
+
+	// tick
+	Interval  time.Duration `json:"interval,omitempty"`
+	Coherence float64       `json:"coherence,omitempty"`
+
+	// Inputs
+	Disruption *DisruptionSpec `json:"disruption,omitempty"`
+	AgentIDs   []string        `json:"agent_ids,omitempty"`
+	Target     *core.State     `json:"target,omitempty"`
+	Agent      *AgentConfig    `json:"agent,omitempty"`
+	AgentID    string          `json:"agent_id,omitempty"`
+	Live       *liveSettings   `json:"live,omitempty"`
+}
+
+// eventLog writes a swarm's event log. A nil *eventLog logs nothing.
+type eventLog struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	err error // First write error; nothing is written after it
+}
+
+// WithEventLog records everything needed to replay the swarm with
+// ReplayFromLog: the swarm as New built it, every goal-directed iteration
+// of Run and Step with its time and coherence, and every input made
+// through the swarm's API, namely Disrupt, DisruptAgents, DisruptSpecific,
+// SetTarget, AddAgent, RemoveAgent and Reconfigure, in the order they
+// happened. The log is written to w as JSON, one entry per line.
+//
+// Randomness, including phase noise and injected failures, is not logged
+// but redrawn on replay, so the swarm must be seeded with WithSeed. Like
+// WithSeed, replay is exact for Run and Step; RunContinuous's checks
+// between resyncs are not logged. Inputs that race a tick, or agents
+// changed directly rather than through the swarm, are not reproduced.
+// Writing stops at the first error from w; see EventLogErr.
+func WithEventLog(w io.Writer) Option {
+	return func(s *Swarm) error {
+		if w == nil {
+			return errors.New("event log writer must not be nil")
+		}
+		s.eventLog = &eventLog{enc: json.NewEncoder(w)}
+		return nil
+	}
+}
+
+// EventLogErr returns the error that stopped the event log, or nil if the
+// log is healthy or the swarm has none.
+func (s *Swarm) EventLogErr() error {
+	l := s.eventLog
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.err
+}
+
+// logEvent writes e stamped with the swarm's time, if the swarm keeps an
+// event log.
+func (s *Swarm) logEvent(e logEntry) {
+	l := s.eventLog
+	if l == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = s.now()
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.err == nil {
+		l.err = l.enc.Encode(e)
+	}
+}
+
+// logStart writes the log's first entry, which ReplayFromLog builds the
+// swarm from.
+func (s *Swarm) logStart() error {
+	if s.eventLog == nil {
+		return nil
+	}
+	cfg, err := s.MarshalConfig(config.FormatJSON)
+	if err != nil {
+		return fmt.Errorf("event log: %w", err)
+	}
+	goalState := s.target()
+	start := logEntry{
+		Kind:       logStart,
+		Time:       s.epoch,
+		Size:       s.Size(),
+		Seed:       s.seed,
+		Goal:       &goalState,
+		GoalConfig: s.goalConfig,
+		PhaseNoise: s.PhaseNoise(),
+		EnergyCost: s.energy.cost,
+		Config:     cfg,
+	}
+	if s.goalConfig != nil {
+		start.GoalType = s.goalType.ID()
+	}
+	s.logEvent(start)
+	return nil
+}
+
+// logTick logs an iteration that started at started with the given
+// interval, with the coherence it ended on.
+func (s *Swarm) logTick(started time.Time, interval time.Duration) {
+	if s.eventLog == nil {
+		return
+	}
+	s.logEvent(logEntry{Kind: logTick, Time: started, Interval: interval, Coherence: s.MeasureCoherence()})
+}
+
+// ReplayFromLog rebuilds a swarm from a log written by WithEventLog and
+// replays it: each logged run, iteration and input is applied in order, on
+// a clock that shows each entry's logged time. The returned swarm is in the
+// state the logged swarm was in when the log ended, and its clock runs on
+// in real time from the last logged time.
+//
+// The log records the seed, size, target, goal, goal config, phase noise,
+// energy cost and configuration. Options that change behavior beyond
+// those, such as WithResourceLimits or WithFailureInjector, must be passed
+// again in opts. Passing WithEventLog in opts logs the replay, which for an
+// exact replay matches the original line for line.
+func ReplayFromLog(r io.Reader, opts ...Option) (*Swarm, error) {
+	dec := json.NewDecoder(r)
+	var head logEntry
+	if err := dec.Decode(&head); err != nil {
+		return nil, fmt.Errorf("replay: read log start: %w", err)
+	}
+	if head.Kind != logStart || head.Goal == nil {
+		return nil, errors.New("replay: log does not begin with a start entry")
+	}
+	cfg, err := config.Parse(bytes.NewReader(head.Config), config.FormatJSON)
+	if err != nil {
+		return nil, fmt.Errorf("replay: %w", err)
+	}
+	logged := []Option{WithConfig(cfg.Swarm), WithEnergyCost(head.EnergyCost)}
+	if head.GoalConfig != nil {
+		logged = append(logged, WithGoalConfig(head.GoalConfig))
+	}
+	if head.PhaseNoise > 0 {
+		logged = append(logged, WithPhaseNoise(head.PhaseNoise))
+	}
+	if head.GoalType != "" {
+		g, err := goal.Parse(head.GoalType)
+		if err != nil {
+			return nil, fmt.Errorf("replay: %w", err)
+		}
+		logged = append(logged, WithGoal(g))
+	}
+
+	clock := &replayClock{at: head.Time}
+	opts = append(logged, opts...)
+	s, err := New(head.Size, *head.Goal, append(opts, WithSeed(head.Seed), WithClock(clock))...)
+	if err != nil {
+		return nil, fmt.Errorf("replay: %w", err)
+	}
+	for line := 2; ; line++ {
+		var e logEntry
+		if err := dec.Decode(&e); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			s.Close()
+			return nil, fmt.Errorf("replay: entry %d: %w", line, err)
+		}
+		clock.set(e.Time)
+		if err := s.replay(e); err != nil {
+			s.Close()
+			return nil, fmt.Errorf("replay: entry %d: %w", line, err)
+		}
+	}
+	clock.finish()
+	return s, nil
+}
+
+// replay applies one logged entry.
+func (s *Swarm) replay(e logEntry) error {
+	gds := s.goalDirectedSync
+	switch e.Kind {
+	case logRun:
+		gds.stepping = gds.newSyncRun(s.targetPattern())
+	case logTick:
+		if gds.stepping == nil {
+			return errors.New("tick outside a run")
+		}
+		gds.stepping.interval = e.Interval
+		if done, _ := gds.step(context.Background(), gds.stepping); done {
+			gds.stepping = nil
+		}
+	case logDisrupt:
+		if e.Disruption == nil {
+			return errors.New("disruption entry without a spec")
+		}
+		_, err := s.Disrupt(*e.Disruption)
+		return err
+	case logDisruptSpecific:
+		if e.Disruption == nil {
+			return errors.New("disruption entry without a spec")
+		}
+		_, err := s.DisruptSpecific(e.AgentIDs, *e.Disruption)
+		return err
+	case logRetarget:
+		if e.Target == nil {
+			return errors.New("retarget entry without a target")
+		}
+		return s.SetTarget(*e.Target)
+	case logAdd:
+		if e.Agent == nil {
+			return errors.New("add entry without an agent")
+		}
+		_, err := s.AddAgent(*e.Agent)
+		return err
+	case logRemove:
+		return s.RemoveAgent(e.AgentID)
+	case logReconfigure:
+		if e.Live == nil {
+			return errors.New("reconfigure entry without settings")
+		}
+		return s.Reconfigure(e.Live.options()...)
+	default:
+		return fmt.Errorf("unknown entry kind %q", e.Kind)
+	}
+	return nil
+}
+
+// replayClock shows the logged times while a log is replayed, then runs on
+// from the last of them in real time.
+type replayClock struct {
+	mu    sync.Mutex
+	at    time.Time
+	since time.Time // When the replay finished; zero while replaying
+}
+
+func (c *replayClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.since.IsZero() {
+		return c.at
+	}
+	return c.at.Add(time.Since(c.since))
+}
+
+func (c *replayClock) NewTicker(d time.Duration) Ticker {
+	return systemTicker{time.NewTicker(d)}
+}
+
+// set moves the clock to t.
+func (c *replayClock) set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.at = t
+}
+
+// finish ends the replay: from now on the clock runs in real time.
+func (c *replayClock) finish() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.since = time.Now()
+}
@@ -0,0 +1,227 @@
+package swarm
+
+import (
+	"sync"
+	"time"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+// eventBufferSize is the per-subscriber channel buffer.
+// Publishing never blocks the swarm; a subscriber that falls further behind
+// than this misses events.
+const eventBufferSize = 64
+
+// LifecycleEventType identifies a swarm lifecycle transition.
+type LifecycleEventType int
+
+// Lifecycle event types.
+const (
+	// EventConverged fires when a synchronization run reaches its target.
+	EventConverged LifecycleEventType = iota + 1
+	// EventDisrupted fires when agents are disrupted via DisruptAgents.
+	EventDisrupted
+	// EventDegraded fires when RunContinuous detects coherence loss and resynchronizes.
+	EventDegraded
+	// EventRecovered fires when RunContinuous restores the target after degradation.
+	EventRecovered
+	// EventRetargeted fires when SetTarget changes the target state.
+	EventRetargeted
+	// EventDiverged fires when coherence falls back below the target during
+	// Run or RunContinuous after having reached it.
+	EventDiverged
+	// EventMembershipChanged fires after AddAgent or RemoveAgent.
+	EventMembershipChanged
+	// EventWarmedUp fires when a run with WithWarmupTarget has held the
+	// warmup coherence and moves on to the real target.
+	EventWarmedUp
+)
+
+// String returns the event type name.
+func (t LifecycleEventType) String() string {
+	switch t {
+	case EventConverged:
+		return "converged"
+	case EventDisrupted:
+		return "disrupted"
+	case EventDegraded:
+		return "degraded"
+	case EventRecovered:
+		return "recovered"
+	case EventRetargeted:
+		return "retargeted"
+	case EventDiverged:
+		return "diverged"
+	case EventMembershipChanged:
+		return "membership_changed"
+	case EventWarmedUp:
+		return "warmed_up"
+	default:
+		return "unknown"
+	}
+}
+
+// LifecycleEvent describes a swarm lifecycle transition. Type tells which
+// transition it is; the details of the transition, where there are any,
+// are in the one field that goes with the type and the others are nil.
+type LifecycleEvent struct {
+	Type      LifecycleEventType
+	Time      time.Time
+	Coherence float64 // Global coherence when the event fired
+
+	Disruption *DisruptionReport // EventDisrupted: what the disruption hit
+	Membership *MembershipEvent  // EventMembershipChanged: who joined or left
+	Target     *core.State       // EventRetargeted: the new target
+}
+
+// ConvergenceEvent reports that coherence crossed the target threshold.
+type ConvergenceEvent struct {
+	Coherence      float64       // Coherence measured this iteration
+	Target         float64       // Target coherence the run is working toward
+	OriginalTarget float64       // Configured target, before any WithAdaptiveTarget relaxation
+	Elapsed        time.Duration // Time since the run started
+	Iteration      int           // Iteration of the run loop, starting at 1
+	Converged      bool          // True when crossing up to the target, false when falling below
+	Relaxed        bool          // True when WithAdaptiveTarget lowered Target this iteration
+}
+
+// WithConvergenceCallback registers a function called whenever coherence
+// crosses the target during Run or RunContinuous: once when it rises to the
+// target and again each time it falls below or recovers. It is also called
+// whenever WithAdaptiveTarget relaxes the target.
+//
+// The callback runs synchronously on the synchronization loop's goroutine,
+// one call at a time, so it needs no locking of its own but should return
+// quickly since it delays the next iteration. No callbacks are made after
+// the run's context is canceled.
+func WithConvergenceCallback(fn func(ConvergenceEvent)) Option {
+	return func(s *Swarm) error {
+		s.convergenceCallback = fn
+		return nil
+	}
+}
+
+// eventBus fans lifecycle events out to subscribers.
+// The zero value is ready to use.
+type eventBus struct {
+	mu       sync.Mutex
+	nextID   int
+	subs     map[int]chan LifecycleEvent
+	untilRun []func() // Cancels subscriptions that end with the next run (see Events)
+	closed   bool
+}
+
+// subscribe registers a new subscriber channel. After close it returns a
+// closed channel.
+func (b *eventBus) subscribe() (<-chan LifecycleEvent, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		ch := make(chan LifecycleEvent)
+		close(ch)
+		return ch, func() {}
+	}
+	if b.subs == nil {
+		b.subs = make(map[int]chan LifecycleEvent)
+	}
+	id := b.nextID
+	b.nextID++
+	ch := make(chan LifecycleEvent, eventBufferSize)
+	b.subs[id] = ch
+
+	cancel := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subs[id]; ok {
+			delete(b.subs, id)
+			close(ch)
+		}
+	}
+	return ch, cancel
+}
+
+// subscribeUntilRun registers a subscriber whose channel endRun closes.
+func (b *eventBus) subscribeUntilRun() <-chan LifecycleEvent {
+	ch, cancel := b.subscribe()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.closed {
+		b.untilRun = append(b.untilRun, cancel)
+	}
+	return ch
+}
+
+// endRun closes the channels of subscribers registered with
+// subscribeUntilRun.
+func (b *eventBus) endRun() {
+	b.mu.Lock()
+	cancels := b.untilRun
+	b.untilRun = nil
+	b.mu.Unlock()
+	for _, cancel := range cancels {
+		cancel()
+	}
+}
+
+// close closes every subscriber channel and turns away new subscribers.
+func (b *eventBus) close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for id, ch := range b.subs {
+		delete(b.subs, id)
+		close(ch)
+	}
+	b.untilRun = nil
+	b.closed = true
+}
+
+// publish delivers an event to every subscriber without blocking.
+// Holding the lock while sending keeps events in order for each subscriber.
+func (b *eventBus) publish(e LifecycleEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, ch := range b.subs {
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
+
+// Subscribe returns a new channel that receives every lifecycle event
+// published after the call, and a function that unsubscribes and closes the
+// channel. Each subscriber gets its own channel, so several components can
+// react to the same events independently; Close closes them all.
+//
+// Delivery never blocks the swarm, so a slow consumer cannot stall the
+// synchronization loop. Each channel buffers 64 events; while it is full,
+// new events for that subscriber are dropped and the ones already queued
+// are kept, so a consumer that falls behind sees a gap, not a reordering.
+func (s *Swarm) Subscribe() (<-chan LifecycleEvent, func()) {
+	return s.events.subscribe()
+}
+
+// Events returns a new lifecycle event subscription for the run in
+// progress or the next one, a single place to receive convergence,
+// divergence, disruption, recovery, retargeting and membership changes in
+// order. It delivers as Subscribe does, and its channel closes when a Run
+// or RunContinuous returns, so a consumer can range over it, or when the
+// swarm is closed. Use Subscribe to listen across runs.
+func (s *Swarm) Events() <-chan LifecycleEvent {
+	return s.events.subscribeUntilRun()
+}
+
+// publishEvent emits a lifecycle event with the current coherence.
+func (s *Swarm) publishEvent(t LifecycleEventType) {
+	s.publish(LifecycleEvent{Type: t})
+}
+
+// publish emits e, stamped with the time and the current coherence.
+func (s *Swarm) publish(e LifecycleEvent) {
+	e.Time = s.now()
+	e.Coherence = s.MeasureCoherence()
+	s.events.publish(e)
+}
@@ -0,0 +1,260 @@
+package swarm_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+func TestLifecycleEventsFanOut(t *testing.T) {
+	t.Parallel()
+
+	s, err := swarm.New(20, core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.7})
+	require.NoError(t, err)
+
+	first, cancelFirst := s.Subscribe()
+	defer cancelFirst()
+	second := s.Events()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, s.Run(ctx))
+
+	s.DisruptAgents(0.5)
+
+	for i, ch := range []<-chan swarm.LifecycleEvent{first, second} {
+		converged := receive(t, ch)
+		assert.Equal(t, swarm.EventConverged, converged.Type, "subscriber %d", i)
+		assert.Positive(t, converged.Coherence)
+	}
+
+	// A subscription lasts across runs; Events ends with the run
+	disrupted := receive(t, first)
+	assert.Equal(t, swarm.EventDisrupted, disrupted.Type)
+	_, open := <-second
+	assert.False(t, open, "Events closes when Run returns")
+}
+
+func TestEventsCloseOnRunExit(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		s, err := swarm.New(20, core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.7},
+			swarm.WithSeed(2))
+		require.NoError(t, err)
+		defer s.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		events := s.Events()
+		go func() { done <- s.RunContinuous(ctx) }()
+
+		// Ranging over the channel ends once the run does
+		var seen []swarm.LifecycleEventType
+		go func() {
+			time.Sleep(5 * time.Second)
+			cancel()
+		}()
+		for e := range events {
+			seen = append(seen, e.Type)
+		}
+		require.ErrorIs(t, <-done, context.Canceled)
+		assert.Contains(t, seen, swarm.EventConverged)
+
+		// A later run gets a subscription of its own
+		events = s.Events()
+		require.NoError(t, s.Run(context.Background()))
+		for len(events) > 0 {
+			<-events
+		}
+		_, open := <-events
+		assert.False(t, open)
+	})
+}
+
+func TestSubscribeCancel(t *testing.T) {
+	t.Parallel()
+
+	s, err := swarm.New(5, core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8})
+	require.NoError(t, err)
+
+	ch, cancel := s.Subscribe()
+	cancel()
+	cancel() // Safe to call twice
+
+	s.DisruptAgents(0.5) // Must not panic on the closed channel
+	_, open := <-ch
+	assert.False(t, open, "channel should be closed after cancel")
+
+	assert.Equal(t, "disrupted", swarm.EventDisrupted.String())
+	assert.Equal(t, "unknown", swarm.LifecycleEventType(0).String())
+}
+
+func receive(t *testing.T, ch <-chan swarm.LifecycleEvent) swarm.LifecycleEvent {
+	t.Helper()
+	select {
+	case e := <-ch:
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for lifecycle event")
+		return swarm.LifecycleEvent{}
+	}
+}
+
+func TestConvergenceCallback(t *testing.T) {
+	t.Parallel()
+
+	var events []swarm.ConvergenceEvent
+	s, err := swarm.New(20, core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.7},
+		swarm.WithConvergenceCallback(func(ev swarm.ConvergenceEvent) {
+			// Called from one goroutine at a time, so no locking is needed
+			events = append(events, ev)
+		}))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, s.Run(ctx))
+
+	require.NotEmpty(t, events, "reaching the target should invoke the callback")
+	assert.True(t, events[0].Converged, "first crossing is upward")
+	assert.True(t, events[len(events)-1].Converged, "a converged run ends above the target")
+
+	for i, ev := range events {
+		assert.InDelta(t, 0.7, ev.Target, 1e-9)
+		assert.Positive(t, ev.Iteration)
+		assert.Positive(t, ev.Elapsed)
+		assert.Equal(t, ev.Converged, ev.Coherence >= ev.Target)
+		if i > 0 {
+			assert.NotEqual(t, events[i-1].Converged, ev.Converged, "callbacks fire only on crossings")
+			assert.Greater(t, ev.Iteration, events[i-1].Iteration)
+		}
+	}
+}
+
+func TestConvergenceCallbackStopsOnCancel(t *testing.T) {
+	t.Parallel()
+
+	calls := make(chan swarm.ConvergenceEvent, 100)
+	// An unreachable target keeps the run going until canceled
+	s, err := swarm.New(20, core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.99},
+		swarm.WithConvergenceCallback(func(ev swarm.ConvergenceEvent) { calls <- ev }))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.RunContinuous(ctx) }()
+
+	time.Sleep(300 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("run did not stop promptly after cancel")
+	}
+
+	before := len(calls)
+	time.Sleep(300 * time.Millisecond)
+	assert.Len(t, calls, before, "no callbacks after cancellation")
+}
+
+func TestLifecycleEventDetails(t *testing.T) {
+	t.Parallel()
+
+	s, err := swarm.New(10, core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.8})
+	require.NoError(t, err)
+	events := s.Events()
+
+	report, err := s.Disrupt(swarm.DisruptionSpec{Kind: swarm.PhaseScramble, Fraction: 0.5})
+	require.NoError(t, err)
+	e := receive(t, events)
+	assert.Equal(t, swarm.EventDisrupted, e.Type)
+	require.NotNil(t, e.Disruption)
+	assert.Equal(t, report, *e.Disruption)
+	assert.Nil(t, e.Membership)
+	assert.Nil(t, e.Target)
+
+	a, err := s.AddAgent(swarm.AgentConfig{})
+	require.NoError(t, err)
+	e = receive(t, events)
+	assert.Equal(t, swarm.EventMembershipChanged, e.Type)
+	require.NotNil(t, e.Membership)
+	assert.Equal(t, swarm.AgentJoined, e.Membership.Type)
+	assert.Equal(t, a.ID, e.Membership.AgentID)
+	assert.Equal(t, 11, e.Membership.Size)
+
+	target := core.State{Phase: 1, Frequency: 200 * time.Millisecond, Coherence: 0.7}
+	require.NoError(t, s.SetTarget(target))
+	e = receive(t, events)
+	assert.Equal(t, swarm.EventRetargeted, e.Type)
+	require.NotNil(t, e.Target)
+	assert.Equal(t, target, *e.Target)
+
+	// Close ends every subscription
+	s.Close()
+	_, open := <-events
+	assert.False(t, open)
+	_, open = <-s.Events()
+	assert.False(t, open, "subscribing after Close yields a closed channel")
+}
+
+func TestLifecycleEventDiverged(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		s, err := swarm.New(30, core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.85},
+			swarm.WithSeed(4))
+		require.NoError(t, err)
+		defer s.Close()
+		events, unsubscribe := s.Subscribe()
+		defer unsubscribe()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() { done <- s.RunContinuous(ctx) }()
+		time.Sleep(5 * time.Second)
+		s.DisruptAgents(0.8)
+		time.Sleep(5 * time.Second)
+		cancel()
+		require.ErrorIs(t, <-done, context.Canceled)
+
+		var seen []swarm.LifecycleEventType
+		for len(events) > 0 {
+			seen = append(seen, (<-events).Type)
+		}
+		i := slices.Index(seen, swarm.EventDisrupted)
+		require.GreaterOrEqual(t, i, 0)
+		assert.Contains(t, seen[i:], swarm.EventDiverged, "the disruption drops coherence below the target")
+		assert.Equal(t, swarm.EventRecovered, seen[len(seen)-1], "and the swarm recovers")
+		assert.Equal(t, "diverged", swarm.EventDiverged.String())
+	})
+}
+
+func TestLifecycleEventsSlowConsumer(t *testing.T) {
+	t.Parallel()
+
+	s, err := swarm.New(10, core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.8})
+	require.NoError(t, err)
+	defer s.Close()
+	stalled := s.Events() // Never drained
+
+	for range 100 {
+		s.DisruptAgents(0.1)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, s.Run(ctx), "a full subscriber must not block the swarm")
+
+	assert.Len(t, stalled, cap(stalled), "the buffer fills up")
+	assert.Equal(t, swarm.EventDisrupted, (<-stalled).Type, "the oldest events are kept")
+}
@@ -0,0 +1,98 @@
+package swarm
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// FailureStats counts the disruptions a swarm has suffered and recovered
+// from, taken by FailureStats.
+type FailureStats struct {
+	Injected    int // Disruptions applied by the failure injector (see WithFailureInjector)
+	Affected    int // Agents those disruptions hit, once per disruption
+	Disruptions int // All disruptions, injected or not
+	Recoveries  int // Recoveries completed (see LastRecovery)
+}
+
+// WithFailureInjector subjects the swarm to continuous random failures:
+// disruptions described by spec arrive as a Poisson process averaging rate
+// per second of the swarm's clock, and are applied on the ticks of Run and
+// RunContinuous as Disrupt would apply them. A spec hitting a small
+// Fraction of agents, such as a PhaseScramble of 5%, models agents
+// dropping out and rejoining in a flaky environment, to check that the
+// swarm sustains coherence rather than just recovering once.
+//
+// Arrival times and the agents hit are drawn from the seeded source with
+// WithSeed. Disruptions that overlap count as one recovery, as for
+// LastRecovery; see FailureStats. The rate must be positive and finite
+// and the spec valid for Disrupt.
+func WithFailureInjector(rate float64, spec DisruptionSpec) Option {
+	return func(s *Swarm) error {
+		if !(rate > 0) || math.IsInf(rate, 0) {
+			return fmt.Errorf("failure rate must be positive and finite, got %v", rate)
+		}
+		if err := spec.validate(); err != nil {
+			return err
+		}
+		s.failures.rate = rate
+		s.failures.spec = spec
+		return nil
+	}
+}
+
+// FailureStats returns the swarm's disruption and recovery counts so far.
+func (s *Swarm) FailureStats() FailureStats {
+	injected, affected := s.failures.counts()
+	return FailureStats{
+		Injected:    injected,
+		Affected:    affected,
+		Disruptions: int(s.disruptions.Load()),
+		Recoveries:  int(s.recoveries.Load()),
+	}
+}
+
+// failureInjector schedules the disruptions of WithFailureInjector. With a
+// zero rate it is disabled.
+type failureInjector struct {
+	rate float64
+	spec DisruptionSpec
+
+	mu       sync.Mutex
+	next     time.Time // When the next disruption is due; zero until scheduled
+	injected int
+	affected int
+}
+
+// injectFailures applies every disruption due by now and schedules the next one.
+func (s *Swarm) injectFailures(now time.Time) {
+	f := &s.failures
+	if f.rate == 0 {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.next.IsZero() {
+		f.next = now.Add(s.failureInterval())
+	}
+	for !f.next.After(now) {
+		report := s.disrupt(f.spec, s.sampleAgents(int(float64(s.Size())*clamp01(f.spec.Fraction))))
+		f.injected++
+		f.affected += report.Affected
+		f.next = f.next.Add(s.failureInterval())
+	}
+}
+
+// failureInterval draws the time to the next failure, exponentially
+// distributed with mean 1/rate.
+func (s *Swarm) failureInterval() time.Duration {
+	// 1-u keeps the logarithm finite
+	return time.Duration(-math.Log(1-s.randFloat64()) / s.failures.rate * float64(time.Second))
+}
+
+func (f *failureInjector) counts() (injected, affected int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.injected, f.affected
+}
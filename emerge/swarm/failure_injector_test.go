@@ -0,0 +1,69 @@
+package swarm_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+	"github.com/carlisia/bio-adapt/emerge/swarm/clocktest"
+)
+
+func TestFailureInjector(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.85}
+	flaky := swarm.DisruptionSpec{Kind: swarm.PhaseScramble, Fraction: 0.1}
+	for _, opt := range []swarm.Option{
+		swarm.WithFailureInjector(0, flaky),
+		swarm.WithFailureInjector(math.Inf(1), flaky),
+		swarm.WithFailureInjector(1, swarm.DisruptionSpec{Kind: swarm.EnergyDrain, DrainRatio: 2}),
+	} {
+		_, err := swarm.New(10, goalState, opt)
+		require.Error(t, err)
+	}
+
+	const (
+		size  = 50
+		tick  = 100 * time.Millisecond
+		ticks = 300
+		rate  = 1.0 // Disruptions per second
+	)
+	run := func() (floor float64, stats swarm.FailureStats) {
+		clock := clocktest.New(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+		s, err := swarm.New(size, goalState,
+			swarm.WithSeed(11), swarm.WithClock(clock), swarm.WithFailureInjector(rate, flaky))
+		require.NoError(t, err)
+		defer s.Close()
+
+		floor = 1
+		for i := range ticks {
+			coherence := s.Step()
+			if i >= 20 { // Past the initial convergence
+				floor = math.Min(floor, coherence)
+			}
+			clock.Advance(tick)
+		}
+		return floor, s.FailureStats()
+	}
+
+	floor, stats := run()
+	assert.Greater(t, floor, 0.75, "coherence sags under failures but never collapses")
+
+	// About one disruption per simulated second, each hitting a tenth of the swarm
+	seconds := float64(ticks) * tick.Seconds()
+	assert.InDelta(t, rate*seconds, stats.Injected, 3*math.Sqrt(rate*seconds))
+	assert.Equal(t, stats.Injected*size/10, stats.Affected)
+	assert.Equal(t, stats.Injected, stats.Disruptions)
+	assert.Positive(t, stats.Recoveries)
+	assert.LessOrEqual(t, stats.Recoveries, stats.Disruptions)
+
+	// Seeded failures repeat exactly
+	again, againStats := run()
+	assert.InDelta(t, floor, again, 0)
+	assert.Equal(t, stats, againStats)
+}
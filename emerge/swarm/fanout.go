@@ -0,0 +1,47 @@
+package swarm
+
+import "fmt"
+
+// WithGossipFanout makes every agent couple with only k randomly sampled
+// neighbors per update instead of all of them, the standard gossip approach
+// for bounding per-update work in large, densely connected swarms. Updates
+// cost O(k) per agent rather than O(degree); convergence still happens but
+// takes more rounds. The swarm's update loop couples each agent with its
+// sample (see neighborPull), drawn from the agent's own stream for the
+// tick, so samples are reproducible with WithSeed however the update is
+// sharded (see WithParallelism).
+//
+// The fanout applies to agents the swarm creates and to agents from
+// WithAgentBuilder; agent.SetGossipFanout can override it per agent.
+func WithGossipFanout(k int) Option {
+	return func(s *Swarm) error {
+		if k < 1 {
+			return fmt.Errorf("gossip fanout must be at least 1, got %d", k)
+		}
+		s.gossipFanout.Store(int64(k))
+		return nil
+	}
+}
+
+// WithoutGossipFanout makes every agent couple with all of its neighbors
+// again, as if WithGossipFanout had never been given. Pass it to
+// Reconfigure to restore full coupling on a running swarm.
+func WithoutGossipFanout() Option {
+	return func(s *Swarm) error {
+		s.gossipFanout.Store(0)
+		return nil
+	}
+}
+
+// GossipFanout returns the per-update neighbor sample size set with
+// WithGossipFanout, or 0 if agents couple with every neighbor.
+func (s *Swarm) GossipFanout() int {
+	return int(s.gossipFanout.Load())
+}
+
+// applyGossipFanout sets the swarm's fanout on every agent.
+func (s *Swarm) applyGossipFanout() {
+	for _, a := range s.collectAgents() {
+		a.SetGossipFanout(s.GossipFanout(), s.randIntn)
+	}
+}
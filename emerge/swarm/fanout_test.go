@@ -0,0 +1,71 @@
+package swarm_test
+
+import (
+	"context"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+func TestWithGossipFanout(t *testing.T) {
+	t.Parallel()
+
+	goal := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8}
+
+	for _, size := range []int{30, 150} {
+		s, err := swarm.New(size, goal, swarm.WithGossipFanout(5), swarm.WithSeed(1))
+		require.NoError(t, err)
+		assert.Equal(t, 5, s.GossipFanout())
+		for _, a := range s.Agents() {
+			assert.Equal(t, 5, a.GossipFanout(), "every agent should sample the swarm's fanout")
+		}
+		s.Close()
+	}
+
+	s, err := swarm.New(10, goal)
+	require.NoError(t, err)
+	defer s.Close()
+	assert.Equal(t, 0, s.GossipFanout())
+
+	_, err = swarm.New(10, goal, swarm.WithGossipFanout(0))
+	require.Error(t, err)
+}
+
+// TestGossipFanoutConverges runs swarms whose agents couple with only 3 of
+// their neighbors a tick. Run must still reach the target, and since each
+// agent draws its sample from its own stream, sharding the update must not
+// change where it ends.
+func TestGossipFanoutConverges(t *testing.T) {
+	t.Parallel()
+
+	goal := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.8}
+	for _, size := range []int{30, 150} {
+		synctest.Test(t, func(t *testing.T) {
+			run := func(parallelism int) map[string]float64 {
+				s, err := swarm.New(size, goal,
+					swarm.WithGossipFanout(3), swarm.WithSeed(1), swarm.WithParallelism(parallelism))
+				require.NoError(t, err)
+				defer s.Close()
+
+				ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+				defer cancel()
+				require.NoError(t, s.Run(ctx), "size %d: a fanout of 3 should still converge", size)
+				// Run counts coherence within a small tolerance of the target as reached
+				assert.Greater(t, s.MeasureCoherence(), goal.Coherence-0.05)
+
+				phases := make(map[string]float64)
+				for id, a := range s.Agents() {
+					phases[id] = a.Phase()
+				}
+				return phases
+			}
+			assert.Equal(t, run(1), run(4), "size %d: sharding should not change the samples", size)
+		})
+	}
+}
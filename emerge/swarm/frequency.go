@@ -0,0 +1,129 @@
+package swarm
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// naturalFrequencyPull is how strongly, per iteration, an agent with a
+// natural frequency is drawn back toward it. Coupling pulls the other way,
+// toward the swarm's mean frequency, scaled by the swarm's coupling (see
+// WithCouplingStrength).
+const (
+	naturalFrequencyPull  = 0.05
+	frequencyCouplingGain = 0.1
+)
+
+// WithFrequencyDistribution gives every agent its own natural frequency,
+// drawn from dist once per agent when the swarm is created. This models
+// heterogeneous oscillators, e.g. services with different natural batch
+// intervals, instead of a swarm that shares one frequency.
+//
+// While the swarm runs, each agent's current frequency is pulled toward the
+// mean field in proportion to the swarm's coupling and back toward its
+// natural frequency, so agents settle between the two rather than
+// collapsing onto a single value. With WithCouplingStrength, agents run
+// Kuramoto's model instead and lock or slip as their detuning allows.
+// MeasureCoherence is unaffected: it measures phase alignment only, so a
+// swarm can be fully coherent while its frequencies still spread.
+// Use monitoring.FrequencyHistogram on Frequencies to observe the spread.
+//
+// Agents using a frequency-locking strategy are additionally locked onto
+// the mean frequency (see applyFrequencyLock), and the completion engine's
+// frequency adjustments are applied on top.
+func WithFrequencyDistribution(dist func() time.Duration) Option {
+	return func(s *Swarm) error {
+		if dist == nil {
+			return errors.New("frequency distribution must not be nil")
+		}
+		s.frequencyDist = dist
+		return nil
+	}
+}
+
+// assignNaturalFrequencies samples a natural frequency for every agent.
+func (s *Swarm) assignNaturalFrequencies() error {
+	for _, a := range s.collectAgents() {
+		freq := s.frequencyDist()
+		if freq <= 0 {
+			return fmt.Errorf("non-positive frequency %v for agent %s", freq, a.ID)
+		}
+		a.SetNaturalFrequency(freq)
+	}
+	return nil
+}
+
+// applyFrequencyCoupling moves agents that have a natural frequency toward
+// the mean frequency of the swarm, balanced against their own natural
+// frequency, or runs Kuramoto's model on them if the swarm has a coupling
+// strength (see WithCouplingStrength). Agents without one are left to the
+// completion engine.
+func (gds *GoalDirectedSync) applyFrequencyCoupling() {
+	agents := gds.swarm.collectAgents()
+	if len(agents) == 0 {
+		return
+	}
+
+	var total time.Duration
+	natural := false
+	for _, a := range agents {
+		total += a.Frequency()
+		if a.NaturalFrequency() > 0 {
+			natural = true
+		}
+	}
+	if !natural {
+		return
+	}
+	if gds.swarm.CouplingStrength() > 0 {
+		gds.applyKuramotoCoupling(agents)
+		return
+	}
+	mean := float64(total) / float64(len(agents))
+	coupling := gds.swarm.coupling() * frequencyCouplingGain
+
+	for _, a := range agents {
+		nat := a.NaturalFrequency()
+		if nat <= 0 {
+			continue
+		}
+		current := float64(a.Frequency())
+		next := current + coupling*(mean-current) + naturalFrequencyPull*(float64(nat)-current)
+		if next > 0 {
+			a.SetFrequency(time.Duration(next))
+		}
+	}
+}
+
+// frequencyLocker is implemented by strategies that lock frequency, such as
+// strategy.FrequencyLock.
+type frequencyLocker interface {
+	LockFrequency(current, reference time.Duration) time.Duration
+}
+
+// applyFrequencyLock moves every agent whose strategy locks frequency toward
+// the swarm's mean frequency. Agents with other strategies keep their
+// frequency, but still count toward the mean.
+func (gds *GoalDirectedSync) applyFrequencyLock() {
+	agents := gds.swarm.collectAgents()
+	if len(agents) == 0 {
+		return
+	}
+
+	var total time.Duration
+	for _, a := range agents {
+		total += a.Frequency()
+	}
+	mean := total / time.Duration(len(agents))
+
+	for _, a := range agents {
+		locker, ok := a.Strategy().(frequencyLocker)
+		if !ok {
+			continue
+		}
+		if next := locker.LockFrequency(a.Frequency(), mean); next > 0 {
+			a.SetFrequency(next)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package swarm_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/goal"
+	"github.com/carlisia/bio-adapt/emerge/strategy"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+func TestFrequencyDistributionCouplesTowardMeanField(t *testing.T) {
+	t.Parallel()
+
+	// Two populations of oscillators with very different natural frequencies
+	var n int
+	dist := func() time.Duration {
+		n++
+		if n%2 == 0 {
+			return 80 * time.Millisecond
+		}
+		return 160 * time.Millisecond
+	}
+
+	s, err := swarm.New(20, core.State{
+		Phase:     0,
+		Frequency: 200 * time.Millisecond,
+		Coherence: 0.7,
+	}, swarm.WithFrequencyDistribution(dist))
+	require.NoError(t, err)
+	defer s.Close()
+
+	for _, a := range s.Agents() {
+		assert.Equal(t, a.NaturalFrequency(), a.Frequency(), "agents should start at their natural frequency")
+	}
+	initialSpread := spread(s.Frequencies())
+	assert.Equal(t, 80*time.Millisecond, initialSpread)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, s.Run(ctx))
+
+	// Coupling narrows the spread without erasing individual differences
+	assert.Less(t, spread(s.Frequencies()), initialSpread)
+	var slow, fast []time.Duration
+	for _, a := range s.Agents() {
+		if a.NaturalFrequency() == 80*time.Millisecond {
+			fast = append(fast, a.Frequency())
+		} else {
+			slow = append(slow, a.Frequency())
+		}
+	}
+	require.NotEmpty(t, fast)
+	require.NotEmpty(t, slow)
+	assert.Less(t, slices.Max(fast), slices.Min(slow), "agents should stay biased toward their natural frequency")
+	assert.GreaterOrEqual(t, s.MeasureCoherence(), 0.7, "phase coherence is independent of frequency spread")
+}
+
+func TestWithFrequencyDistributionValidation(t *testing.T) {
+	t.Parallel()
+
+	goal := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8}
+
+	_, err := swarm.New(5, goal, swarm.WithFrequencyDistribution(nil))
+	require.Error(t, err)
+
+	_, err = swarm.New(5, goal, swarm.WithFrequencyDistribution(func() time.Duration { return 0 }))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "natural frequencies")
+}
+
+func spread(freqs []time.Duration) time.Duration {
+	return slices.Max(freqs) - slices.Min(freqs)
+}
+
+func TestFrequencyLockConvergesFrequencies(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		opts []swarm.Option
+		lock bool
+	}{
+		{"frequency lock", []swarm.Option{swarm.WithGoal(goal.MaintainRhythm), swarm.WithStrategy(strategy.NameFrequencyLock)}, true},
+		{"default strategy", []swarm.Option{swarm.WithGoal(goal.MaintainRhythm)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			synctest.Test(t, func(t *testing.T) {
+				s, err := swarm.New(20, core.State{
+					Phase:     0,
+					Frequency: 200 * time.Millisecond,
+					Coherence: 0.7,
+				}, tt.opts...)
+				require.NoError(t, err)
+				defer s.Close()
+
+				// Spread frequencies from 50ms to 525ms
+				i := 0
+				for _, a := range s.Agents() {
+					a.SetFrequency(time.Duration(50+25*i) * time.Millisecond)
+					i++
+				}
+				initial := s.MeasureFrequencyCoherence()
+				initialSpread := spread(s.Frequencies())
+				require.Less(t, initial, 0.6)
+
+				// Frequency locking runs alongside phase synchronization, so
+				// whether the phase target is reached within the window is
+				// beside the point here
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				if err := s.Run(ctx); err != nil {
+					require.ErrorIs(t, err, context.DeadlineExceeded)
+				}
+
+				if tt.lock {
+					assert.Greater(t, s.MeasureFrequencyCoherence(), initial, "locked frequencies should cluster")
+					assert.Less(t, spread(s.Frequencies()), initialSpread/2)
+				} else {
+					assert.Greater(t, spread(s.Frequencies()), initialSpread*9/10, "without locking the spread is kept")
+				}
+			})
+		})
+	}
+}
+
+func TestMeasureFrequencyCoherence(t *testing.T) {
+	t.Parallel()
+
+	s, err := swarm.New(10, core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8})
+	require.NoError(t, err)
+	defer s.Close()
+
+	for _, a := range s.Agents() {
+		a.SetFrequency(100 * time.Millisecond)
+	}
+	assert.InDelta(t, 1.0, s.MeasureFrequencyCoherence(), 1e-9, "identical frequencies are fully coherent")
+
+	i := 0
+	for _, a := range s.Agents() {
+		if i%2 == 0 {
+			a.SetFrequency(50 * time.Millisecond)
+		} else {
+			a.SetFrequency(150 * time.Millisecond)
+		}
+		i++
+	}
+	assert.InDelta(t, 0.5, s.MeasureFrequencyCoherence(), 1e-9, "a spread of half the mean halves coherence")
+}
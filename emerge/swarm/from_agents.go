@@ -0,0 +1,66 @@
+package swarm
+
+import (
+	"fmt"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+// FromAgents creates a swarm that adopts already-created agents instead of
+// creating its own, for agents built with their own decision makers,
+// strategies, or energy profiles. The swarm sizes itself to the agents and
+// otherwise behaves as if created by New with the same options, so goals,
+// WithStrategy, WithDecisionMaker, and the other options apply to the
+// adopted agents too. Agents keep the state they were built with; WithSeed
+// does not redraw their phases.
+//
+// If no agent has neighbors yet, the swarm connects them as New would. If
+// some do, that wiring is kept as the swarm's topology, so a hand-built
+// ring stays a ring; WithTopology still runs on top of it. Neighbors must be
+// among the adopted agents.
+//
+// Agent IDs must be unique; a repeated ID returns ErrAgentExists.
+func FromAgents(agents []*agent.Agent, target core.State, opts ...Option) (*Swarm, error) {
+	members := make(map[string]*agent.Agent, len(agents))
+	wired := false
+	for i, a := range agents {
+		if a == nil {
+			return nil, fmt.Errorf("agent %d is nil", i)
+		}
+		if _, exists := members[a.ID]; exists {
+			return nil, fmt.Errorf("%w: %s", ErrAgentExists, a.ID)
+		}
+		members[a.ID] = a
+	}
+	for _, a := range agents {
+		for _, n := range a.NeighborList() {
+			if members[n.ID] != n {
+				return nil, fmt.Errorf("agent %s is connected to %s, which is not adopted by the swarm", a.ID, n.ID)
+			}
+			wired = true
+		}
+	}
+
+	return New(len(agents), target, append([]Option{withAgents(agents, wired)}, opts...)...)
+}
+
+// withAgents stores adopted agents, marking the swarm's connections as
+// established if they come wired.
+func withAgents(agents []*agent.Agent, wired bool) Option {
+	return func(s *Swarm) error {
+		s.initStorage()
+		for i, a := range agents {
+			if s.optimized {
+				s.agentSlice = append(s.agentSlice, a)
+				s.agentIndex[a.ID] = i
+			} else {
+				s.agents.Store(a.ID, a)
+			}
+		}
+		if wired {
+			s.connectionsEstablished = true
+		}
+		return nil
+	}
+}
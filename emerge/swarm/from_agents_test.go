@@ -0,0 +1,104 @@
+package swarm_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/decision"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+// buildAgents creates n agents with their own decision makers and energy.
+func buildAgents(n int) []*agent.Agent {
+	agents := make([]*agent.Agent, n)
+	for i := range agents {
+		agents[i] = agent.New(fmt.Sprintf("agent-%d", i),
+			agent.WithPhase(float64(i)*0.1),
+			agent.WithEnergy(80),
+			agent.WithDecisionMaker(decision.NewAdaptive(0.3, 5)))
+	}
+	return agents
+}
+
+func TestFromAgents(t *testing.T) {
+	t.Parallel()
+
+	target := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.8}
+
+	t.Run("adopts a hand-wired ring", func(t *testing.T) {
+		t.Parallel()
+
+		synctest.Test(t, func(t *testing.T) {
+			agents := buildAgents(12)
+			for i, a := range agents {
+				next := agents[(i+1)%len(agents)]
+				a.ConnectTo(next.ID, next)
+				next.ConnectTo(a.ID, a)
+			}
+
+			s, err := swarm.FromAgents(agents, target, swarm.WithSeed(3))
+			require.NoError(t, err)
+			defer s.Close()
+			assert.Equal(t, len(agents), s.Size())
+			for i, a := range agents {
+				got, ok := s.Agent(a.ID)
+				require.True(t, ok)
+				assert.Same(t, a, got, "the swarm keeps the caller's agents")
+				assert.IsType(t, &decision.Adaptive{}, got.DecisionMaker())
+				assert.InDelta(t, float64(i)*0.1, got.Phase(), 1e-9, "seeding leaves adopted phases alone")
+				assert.Equal(t, 2, got.NeighborCount(), "the ring is kept as the topology")
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+			require.NoError(t, s.Run(ctx))
+			assert.GreaterOrEqual(t, s.MeasureCoherence(), 0.8)
+		})
+	})
+
+	t.Run("connects unwired agents", func(t *testing.T) {
+		t.Parallel()
+
+		for _, size := range []int{20, 150} {
+			agents := buildAgents(size)
+			s, err := swarm.FromAgents(agents, target)
+			require.NoError(t, err)
+			defer s.Close()
+			assert.Equal(t, size, s.Size())
+			assert.Len(t, s.Agents(), size)
+			for _, a := range agents {
+				got, ok := s.Agent(a.ID)
+				require.True(t, ok)
+				assert.Same(t, a, got)
+				assert.Positive(t, a.NeighborCount(), "size %d", size)
+			}
+		}
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := swarm.FromAgents(append(buildAgents(3), agent.New("agent-1")), target)
+		require.ErrorIs(t, err, swarm.ErrAgentExists)
+
+		_, err = swarm.FromAgents([]*agent.Agent{agent.New("agent-0"), nil}, target)
+		require.Error(t, err)
+
+		agents := buildAgents(3)
+		outsider := agent.New("outsider")
+		agents[0].ConnectTo(outsider.ID, outsider)
+		_, err = swarm.FromAgents(agents, target)
+		require.Error(t, err, "neighbors must be adopted too")
+
+		_, err = swarm.FromAgents(nil, target)
+		require.ErrorIs(t, err, swarm.ErrInvalidSwarmSize)
+	})
+}
@@ -0,0 +1,57 @@
+package swarm
+
+import (
+	"fmt"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+// WithGoalBlend sets how strongly every agent, including agents added
+// later with AddAgent, pursues its local goal rather than the swarm's
+// target, from 0 (the target alone) to 1 (the local goal alone). After
+// each synchronization step an agent is pulled localWeight of the way
+// back toward its local goal, so agents whose local goals agree with each
+// other but not with the target hold a cluster of their own; this is the
+// mechanism a stubborn faction exploits, made explicit. agent.SetGoalBlend
+// called on an individual agent after New overrides it.
+//
+// Without a blend, agents pursue the target alone and their local goals
+// are ignored. The weight must be in [0, 1].
+func WithGoalBlend(localWeight float64) Option {
+	return func(s *Swarm) error {
+		if !(localWeight >= 0 && localWeight <= 1) {
+			return fmt.Errorf("goal blend must be in [0, 1], got %v", localWeight)
+		}
+		s.blendGoals = true
+		s.goalBlend = localWeight
+		return nil
+	}
+}
+
+// GoalBlend returns the local goal weight set with WithGoalBlend, or false
+// if there is none.
+func (s *Swarm) GoalBlend() (float64, bool) {
+	return s.goalBlend, s.blendGoals
+}
+
+// blendGoalsOf gives agents the swarm's goal blend, if it has one.
+func (s *Swarm) blendGoalsOf(agents ...*agent.Agent) {
+	if !s.blendGoals {
+		return
+	}
+	for _, a := range agents {
+		a.SetGoalBlend(s.goalBlend)
+	}
+}
+
+// towardLocalGoal pulls the phase an agent is about to take toward its
+// local goal by the agent's goal blend, and reports whether it moved.
+func towardLocalGoal(a *agent.Agent, next float64) (float64, bool) {
+	w, ok := a.GoalBlend()
+	if !ok || w == 0 {
+		return next, false
+	}
+	pull := w * core.PhaseDifference(a.LocalGoal(), next)
+	return next + pull, pull != 0
+}
@@ -0,0 +1,87 @@
+package swarm_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+	"github.com/carlisia/bio-adapt/emerge/swarm/clocktest"
+)
+
+// TestGoalBlend checks that raising the local goal weight of a faction
+// sharing a local goal away from the target holds that faction in a
+// cluster of its own, while without it the swarm pulls everyone in.
+func TestGoalBlend(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.85}
+	_, err := swarm.New(10, goalState, swarm.WithGoalBlend(1.5))
+	require.Error(t, err)
+
+	const size, faction = 40, 10
+	run := func(localWeight float64, steps int) []swarm.Cluster {
+		clock := clocktest.New(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+		s, err := swarm.New(size, goalState, swarm.WithSeed(3), swarm.WithClock(clock))
+		require.NoError(t, err)
+		defer s.Close()
+		for i := range faction {
+			a, ok := s.Agent(fmt.Sprintf("agent-%d", i))
+			require.True(t, ok)
+			a.SetLocalGoal(math.Pi)
+			a.SetGoalBlend(localWeight)
+		}
+		for range steps {
+			s.Step()
+			clock.Advance(100 * time.Millisecond)
+		}
+		return s.PhaseClusters(1)
+	}
+
+	together := run(0, 100)
+	require.NotEmpty(t, together)
+	assert.Greater(t, together[0].Size, size-faction, "without local weight the faction joins the swarm")
+
+	for _, steps := range []int{50, 150} {
+		apart := run(0.8, steps)
+		require.Len(t, apart, 2, "after %d steps", steps)
+		assert.Equal(t, size-faction, apart[0].Size)
+		assert.Less(t, math.Abs(core.PhaseDifference(apart[0].MeanPhase, goalState.Phase)), 0.5)
+		assert.Equal(t, faction, apart[1].Size)
+		assert.Less(t, math.Abs(core.PhaseDifference(apart[1].MeanPhase, math.Pi)), 0.5)
+	}
+}
+
+func TestWithGoalBlend(t *testing.T) {
+	t.Parallel()
+
+	s, err := swarm.New(5, core.State{Frequency: 100 * time.Millisecond, Coherence: 0.8}, swarm.WithGoalBlend(0.3))
+	require.NoError(t, err)
+	defer s.Close()
+
+	w, ok := s.GoalBlend()
+	assert.True(t, ok)
+	assert.InDelta(t, 0.3, w, 0)
+	for _, a := range s.Agents() {
+		w, ok := a.GoalBlend()
+		assert.True(t, ok)
+		assert.InDelta(t, 0.3, w, 0)
+	}
+
+	added, err := s.AddAgent(swarm.AgentConfig{})
+	require.NoError(t, err)
+	w, ok = added.GoalBlend()
+	assert.True(t, ok)
+	assert.InDelta(t, 0.3, w, 0)
+
+	plain, err := swarm.New(5, core.State{Frequency: 100 * time.Millisecond, Coherence: 0.8})
+	require.NoError(t, err)
+	defer plain.Close()
+	_, ok = plain.GoalBlend()
+	assert.False(t, ok)
+}
@@ -12,7 +12,6 @@ import (
 	"github.com/carlisia/bio-adapt/emerge/convergence"
 	"github.com/carlisia/bio-adapt/emerge/core"
 	"github.com/carlisia/bio-adapt/emerge/strategy"
-	"github.com/carlisia/bio-adapt/internal/random"
 )
 
 // GoalDirectedSync achieves synchronization through adaptive strategies.
@@ -26,6 +25,21 @@ type GoalDirectedSync struct {
 	strategyPerf       map[string]*StrategyPerformance
 	config             *Config // Configuration for goal-directed behavior
 	mu                 sync.RWMutex
+
+	// Threshold crossing state for the convergence callback, kept across
+	// runs so RunContinuous restarts don't report spurious crossings
+	callbackMu  sync.Mutex
+	aboveTarget bool
+
+	// Firing clock for pulse-coupled agents (see applyPulseCoupling)
+	pulseClock float64
+
+	// Run in progress across calls to Swarm.Step; nil between runs
+	stepping *syncRun
+
+	// Phases of agents with natural frequencies at the last tick, to
+	// observe their rates (see applyKuramotoCoupling)
+	lastPhases map[*agent.Agent]float64
 }
 
 // StrategyPerformance tracks how well a strategy works.
@@ -78,75 +92,334 @@ func NewGoalDirectedSyncWithConfig(s *Swarm, cfg *Config) *GoalDirectedSync {
 
 // AchieveSynchronization runs goal-directed synchronization loop.
 func (gds *GoalDirectedSync) AchieveSynchronization(ctx context.Context, target *core.TargetPattern) error {
-	gds.targetPattern = target
-	gds.convergenceMonitor.SetTarget(target)
-
-	// Check if target is achievable
-	swarmSize := len(gds.swarm.Agents())
-	limits := GetCoherenceLimits(swarmSize)
-	if target.Coherence > limits.Theoretical {
-		// Impossible target - adjust to theoretical maximum
-		target.Coherence = limits.Theoretical
-		gds.targetPattern = target
-	}
+	run := gds.newSyncRun(target)
 
 	// Adjust max iterations based on difficulty
-	timeFactor := GetConvergenceTimeFactor(swarmSize, target.Coherence)
+	swarmSize := gds.swarm.Size()
+	timeFactor := GetConvergenceTimeFactor(swarmSize, run.target.Coherence)
 	maxIterations := int(gds.config.Strategy.MaxIterationsFactor * timeFactor)
 	maxIterations = min(maxIterations, 1000) // Cap at reasonable limit
 
 	// Goal-directed loop
-	ticker := time.NewTicker(gds.config.Strategy.UpdateInterval)
+	ticker := gds.swarm.newTicker(run.interval)
 	defer ticker.Stop()
 
-	iterationCount := 0
-
-	for iterationCount < maxIterations {
+	for run.iteration < maxIterations {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case <-ticker.C:
-			iterationCount++
+		case <-gds.swarm.tick.woken():
+			// Disrupted: back to the minimum interval at once
+			run.interval = gds.swarm.tick.interval()
+			ticker.Reset(run.interval)
+		case <-ticker.C():
+			// A tick and cancellation can arrive together; cancellation wins
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if gds.swarm.Paused() {
+				continue
+			}
+			interval := run.interval
+			done, err := gds.step(ctx, run)
+			if run.interval != interval {
+				ticker.Reset(run.interval)
+			}
+			if done {
+				return err
+			}
+		}
+	}
 
-			// Step 1: Measure current pattern
-			currentPattern := gds.measureSystemPattern()
-			coherence := gds.swarm.MeasureCoherence()
+	return fmt.Errorf("%w after %d iterations", ErrNotConverged, maxIterations)
+}
 
-			// Step 2: Record convergence
-			gds.convergenceMonitor.RecordSample(currentPattern, coherence)
+// syncRun is the state of one synchronization run, carried from tick to
+// tick.
+type syncRun struct {
+	target        *core.TargetPattern
+	retargets     uint64 // SetTarget calls seen so far
+	interval      time.Duration
+	iteration     int
+	started       time.Time
+	plateau       *plateauDetector
+	failOnPlateau bool
+	warmup        *warmupRun // nil once warmed up, or with no warmup
+}
 
-			// Step 3: Check if we've achieved the goal
-			if gds.isPatternAchieved(currentPattern) {
-				return nil // Success!
-			}
+// newSyncRun starts a run toward target.
+func (gds *GoalDirectedSync) newSyncRun(target *core.TargetPattern) *syncRun {
+	run := &syncRun{
+		retargets: gds.swarm.retargets.Load(),
+		target:    gds.setTarget(target),
+		interval:  gds.swarm.tick.intervalOr(gds.config.Strategy.UpdateInterval),
+		started:   gds.swarm.now(),
+		plateau:   newPlateauDetector(gds.swarm.plateauWindow, gds.swarm.plateauEpsilon),
+	}
+	run.failOnPlateau = run.plateau != nil
+	if run.plateau == nil && gds.swarm.adaptiveMin > 0 {
+		run.plateau = newPlateauDetector(adaptiveWindow, adaptiveEpsilon)
+	}
+	run.warmup = gds.newWarmup(run.target)
+	gds.swarm.logEvent(logEntry{Kind: logRun, Time: run.started})
+	return run
+}
 
-			// Step 4: Check if we should switch strategy
-			if gds.convergenceMonitor.ShouldSwitchStrategy() {
-				gds.switchStrategy()
-			}
+// step runs one tick of run. It reports whether the run is over: with a
+// nil error once the target is reached, or with the reason it gave up.
+func (gds *GoalDirectedSync) step(ctx context.Context, run *syncRun) (bool, error) {
+	defer gds.swarm.recordTrajectory()
+	defer gds.swarm.logTick(gds.swarm.now(), run.interval)
+	run.iteration++
+
+	// Work toward a target changed by SetTarget from here on
+	if n := gds.swarm.retargets.Load(); n != run.retargets {
+		run.retargets = n
+		run.target = gds.setTarget(gds.swarm.targetPattern())
+		if run.warmup != nil {
+			run.warmup = gds.newWarmup(run.target)
+		}
+		run.plateau.reset()
+	}
 
-			// Step 5: Identify pattern gaps
-			gaps := core.IdentifyGaps(currentPattern, target)
+	// A warming run is judged against the warmup coherence
+	target := run.target
+	if run.warmup != nil {
+		target = run.warmup.pattern
+	}
 
-			// Step 6: Complete pattern using pattern memory
-			completedPattern := gds.completionEngine.CompletePattern(currentPattern, gaps)
+	agents := gds.swarm.collectAgents()
+	gds.swarm.recharge(agents, run.interval)
+	gds.swarm.gateEnergy(agents)
+	gds.swarm.addPhaseNoise(agents)
+	gds.swarm.injectFailures(gds.swarm.now())
+	gds.swarm.jitter.sample(agents)
+	gds.swarm.decay.sample(agents, gds.swarm.now())
 
-			// Step 7: Apply adjustments through current strategy
-			gds.applyPatternCompletion(completedPattern)
+	// Step 1: Measure current pattern
+	currentPattern := gds.measureSystemPattern()
+	coherence := gds.swarm.MeasureCoherence()
+	gds.swarm.recordCoherence(coherence)
+
+	// Step 2: Record convergence
+	gds.convergenceMonitor.RecordSample(currentPattern, coherence)
+	flat := run.plateau.record(coherence)
+	gds.swarm.observeRecovery(coherence)
+	run.interval = gds.swarm.tick.observe(coherence, run.interval)
+
+	// A new target frequency is tracked before anything else
+	if gds.retune(agents) {
+		run.plateau.reset()
+		return false, nil
+	}
 
-			// Step 8: Add noise if stuck to escape local minima
-			if gds.convergenceMonitor.IsStuck() {
-				gds.addStochasticResonance()
-			}
+	// Settle for the sustained level if the target is out of reach (opt-in)
+	relaxed := false
+	if flat && run.warmup == nil {
+		if lowered, ok := gds.swarm.relaxTarget(target.Coherence, run.plateau.floor()); ok {
+			next := *target
+			next.Coherence = lowered
+			target = &next
+			gds.targetPattern = target
+			run.target = target
+			run.plateau.reset()
+			flat, relaxed = false, true
 		}
 	}
 
-	return fmt.Errorf("failed to achieve synchronization after %d iterations", maxIterations)
+	// Warm up at the looser coherence, then tighten to the real target
+	if run.warmup != nil && run.warmup.warm(coherence, run.iteration, gds.swarm.now()) {
+		gds.endWarmup(run)
+		target = run.target
+	}
+
+	// Notify on threshold crossings in either direction
+	gds.notifyConvergence(ctx, ConvergenceEvent{
+		Coherence:      coherence,
+		Target:         run.target.Coherence,
+		OriginalTarget: gds.swarm.target().Coherence,
+		Elapsed:        gds.swarm.since(run.started),
+		Iteration:      run.iteration,
+		Converged:      gds.swarm.crossed(coherence, run.target.Coherence),
+		Relaxed:        relaxed,
+	})
+	if gds.swarm.monitor != nil {
+		gds.swarm.monitor.RecordSample(coherence)
+		gds.swarm.monitor.RecordFrequencies(gds.swarm)
+	}
+
+	// Pull frequencies toward the mean field, respecting natural frequencies,
+	// and lock agents whose strategy locks frequency
+	gds.applyFrequencyCoupling()
+	gds.applyFrequencyLock()
+
+	// Bridged swarms drift toward a shared phase
+	gds.followBridges(run)
+
+	// A swarm locked to a reference rhythm follows the reference
+	if ref, ok := gds.swarm.referencePhase(); ok {
+		if referenceFollowed(agents, ref, target.Coherence, gds.config.Convergence.PatternDistanceThreshold) &&
+			gds.converged(run) {
+			return true, nil
+		}
+		if run.stalled(flat) {
+			return true, run.plateau.err(coherence, target.Coherence)
+		}
+		gds.applyReference(agents, ref)
+		return false, nil
+	}
+
+	// A swarm with a hub follows the hub's phase, unless the hub has
+	// stood still long enough to lose its authority
+	if hub, ok := gds.swarm.liveHub(); ok {
+		if gds.swarm.hubFollowed(hub, agents, target.Coherence, gds.config.Convergence.PatternDistanceThreshold) &&
+			gds.converged(run) {
+			return true, nil
+		}
+		if run.stalled(flat) {
+			return true, run.plateau.err(coherence, target.Coherence)
+		}
+		gds.applyHub(hub, agents)
+		return false, nil
+	}
+
+	// Banded swarms converge each band to its own phase
+	if len(gds.swarm.bands) > 0 {
+		if gds.bandsAchieved() && gds.converged(run) {
+			return true, nil
+		}
+		if run.stalled(flat) {
+			return true, run.plateau.err(coherence, target.Coherence)
+		}
+		gds.applyBandAdjustments()
+		return false, nil
+	}
+
+	// Agents with goals of their own couple within their goal region
+	if gds.swarm.mixedGoals(agents) {
+		if gds.goalRegionsAchieved(target.Coherence) && gds.converged(run) {
+			return true, nil
+		}
+		if run.stalled(flat) {
+			return true, run.plateau.err(coherence, target.Coherence)
+		}
+		gds.applyGoalCoupling()
+		return false, nil
+	}
+
+	// Custom goals form one cluster per slot, judged by cluster purity
+	if _, ok := gds.swarm.goalType.Spec(); ok {
+		if coherence >= target.Coherence && gds.converged(run) {
+			return true, nil
+		}
+		if run.stalled(flat) {
+			return true, run.plateau.err(coherence, target.Coherence)
+		}
+		gds.applySlotSeeking(agents)
+		return false, nil
+	}
+
+	// A swarm of spreading agents works toward an even spread, judged
+	// by dispersion, or for duty cycles by how evenly the active
+	// windows cover the cycle
+	if spreading(agents) {
+		if gds.swarm.spreadAchieved(agents, target.Coherence) && gds.converged(run) {
+			return true, nil
+		}
+		if run.stalled(flat) {
+			return true, run.plateau.err(coherence, target.Coherence)
+		}
+		gds.applySplay(agents)
+		return false, nil
+	}
+
+	// A swarm of pulse-coupled agents synchronizes through firing alone
+	if pulseCoupled(agents) {
+		if coherence >= target.Coherence && gds.converged(run) {
+			return true, nil
+		}
+		if run.stalled(flat) {
+			return true, run.plateau.err(coherence, target.Coherence)
+		}
+		gds.applyPulseCoupling(agents)
+		return false, nil
+	}
+
+	// Step 3: Check if we've achieved the goal. A relaxed target
+	// is judged by coherence alone.
+	if (gds.isPatternAchieved(currentPattern) ||
+		(gds.swarm.TargetRelaxed() && coherence >= target.Coherence)) &&
+		gds.swarm.smoothedConverged(target.Coherence) && gds.converged(run) {
+		return true, nil // Success!
+	}
+
+	// Give up early if coherence has stopped moving (opt-in)
+	if run.stalled(flat) {
+		return true, run.plateau.err(coherence, target.Coherence)
+	}
+
+	// Step 4: Check if we should switch strategy
+	if gds.convergenceMonitor.ShouldSwitchStrategy() {
+		gds.switchStrategy()
+	}
+
+	// Step 5: Identify pattern gaps
+	gaps := core.IdentifyGaps(currentPattern, target)
+
+	// Step 6: Complete pattern using pattern memory
+	completedPattern := gds.completionEngine.CompletePattern(currentPattern, gaps)
+
+	// Step 7: Apply adjustments through current strategy
+	gds.applyPatternCompletion(completedPattern)
+
+	// Pulse-coupled agents also react to their neighbors firing
+	if pulseCoupledAny(agents) {
+		gds.applyPulseCoupling(agents)
+	}
+
+	// Step 8: Add noise if stuck to escape local minima
+	if gds.convergenceMonitor.IsStuck() {
+		gds.addStochasticResonance()
+	}
+	return false, nil
+}
+
+// setTarget makes target the pattern the run works toward, capping an
+// impossible coherence at the theoretical maximum, and returns it.
+func (gds *GoalDirectedSync) setTarget(target *core.TargetPattern) *core.TargetPattern {
+	limits := GetCoherenceLimits(gds.swarm.Size())
+	if target.Coherence > limits.Theoretical {
+		// Impossible target - adjust to theoretical maximum
+		target.Coherence = limits.Theoretical
+	}
+	gds.targetPattern = target
+	gds.convergenceMonitor.SetTarget(target)
+	return target
+}
+
+// notifyConvergence invokes the swarm's convergence callback when the
+// sample crosses the target or the target is relaxed, and publishes
+// EventDiverged when it falls back below. Calls are serialized so overlapping runs during
+// a RunContinuous restart never invoke the callback concurrently.
+func (gds *GoalDirectedSync) notifyConvergence(ctx context.Context, ev ConvergenceEvent) {
+	gds.callbackMu.Lock()
+	defer gds.callbackMu.Unlock()
+
+	if (ev.Converged == gds.aboveTarget && !ev.Relaxed) || ctx.Err() != nil {
+		return
+	}
+	if gds.aboveTarget && !ev.Converged {
+		gds.swarm.publishEvent(EventDiverged)
+	}
+	gds.aboveTarget = ev.Converged
+	if fn := gds.swarm.convergenceCallback; fn != nil {
+		fn(ev)
+	}
 }
 
 // measureSystemPattern calculates the current system-wide pattern.
 func (gds *GoalDirectedSync) measureSystemPattern() *core.TargetPattern {
-	agents := gds.swarm.Agents()
+	agents := gds.swarm.collectAgents()
 	if len(agents) == 0 {
 		return &core.TargetPattern{}
 	}
@@ -183,19 +456,9 @@ func (gds *GoalDirectedSync) isPatternAchieved(current *core.TargetPattern) bool
 	distance := core.PatternDistance(current, gds.targetPattern)
 
 	// Adaptive tolerance based on swarm size and theoretical limits
-	swarmSize := len(gds.swarm.Agents())
+	swarmSize := gds.swarm.Size()
 	limits := GetCoherenceLimits(swarmSize)
-
-	// Use larger tolerance for small swarms where variance is higher
-	var tolerance float64
-	switch {
-	case swarmSize < 10:
-		tolerance = gds.config.Convergence.ToleranceSmall
-	case swarmSize < 50:
-		tolerance = gds.config.Convergence.ToleranceMedium
-	default:
-		tolerance = gds.config.Convergence.ToleranceLarge
-	}
+	tolerance := gds.coherenceTolerance(swarmSize)
 
 	// If we're very close to practical limit, be more lenient
 	if gds.targetPattern.Coherence >= limits.Practical*0.95 {
@@ -216,11 +479,64 @@ func (gds *GoalDirectedSync) isPatternAchieved(current *core.TargetPattern) bool
 	return coherenceAchieved && distanceAchieved
 }
 
+// coherenceTolerance returns the coherence tolerance for a group of the given size.
+// Small groups get a larger tolerance because their variance is higher.
+func (gds *GoalDirectedSync) coherenceTolerance(size int) float64 {
+	switch {
+	case size < 10:
+		return gds.config.Convergence.ToleranceSmall
+	case size < 50:
+		return gds.config.Convergence.ToleranceMedium
+	default:
+		return gds.config.Convergence.ToleranceLarge
+	}
+}
+
+// bandsAchieved checks whether every phase band has formed a coherent
+// cluster at its target phase.
+func (gds *GoalDirectedSync) bandsAchieved() bool {
+	for _, st := range gds.swarm.BandStates() {
+		target := min(gds.targetPattern.Coherence, GetCoherenceLimits(st.Agents).Practical)
+		if st.Coherence < target-gds.coherenceTolerance(st.Agents) {
+			return false
+		}
+		if math.Abs(core.PhaseDifference(st.MeanPhase, st.Phase)) > gds.config.Convergence.PatternDistanceThreshold {
+			return false
+		}
+	}
+	return true
+}
+
+// applyBandAdjustments pulls each agent toward its band's phase.
+// A little randomness in the step keeps clusters from locking in perfect step.
+func (gds *GoalDirectedSync) applyBandAdjustments() {
+	adjustmentScale := gds.config.Convergence.BaseAdjustmentScale * 0.5
+	for _, a := range gds.swarm.collectAgents() {
+		b, ok := gds.swarm.BandOf(a.ID)
+		if !ok {
+			continue
+		}
+		currentPhase := a.Phase()
+		phaseDiff := core.PhaseDifference(b.Phase, currentPhase)
+		randomFactor := 0.8 + gds.swarm.randFloat64()*0.4
+		next := currentPhase + phaseDiff*adjustmentScale*randomFactor
+		if next, ok := gds.swarm.spend(a, currentPhase, next); ok {
+			a.SetPhase(next)
+		}
+	}
+}
+
 // applyPatternCompletion applies the completed coordination state to agents.
+// Each agent moves toward the phase worked out for it, plus the pull of its
+// neighbors scaled as its step toward the target is (see updateAgents).
+// Agent updates run on the swarm's update engine (see WithParallelism): the
+// swarm-wide measurements below are the read snapshot every agent sees, and
+// each agent's new phase depends only on that snapshot, its own phase and
+// its own random stream, so serial and parallel runs agree exactly.
 //
 //nolint:gocyclo // Complex pattern completion logic requires multiple decision branches
 func (gds *GoalDirectedSync) applyPatternCompletion(completedPattern *completion.CompletedPattern) {
-	agents := gds.swarm.Agents()
+	agents := gds.swarm.collectAgents()
 
 	// Get adjustments from completion
 	phaseAdjustment := completedPattern.GetPhaseAdjustment()
@@ -238,6 +554,7 @@ func (gds *GoalDirectedSync) applyPatternCompletion(completedPattern *completion
 	// Scale adjustment based on distance to target
 	// This prevents overshooting while ensuring adequate improvement
 	targetCoherence := gds.targetPattern.Coherence
+	targetPhase := gds.targetPattern.Phase
 	var adjustmentScale float64
 
 	// Calculate how far we are from target
@@ -247,23 +564,26 @@ func (gds *GoalDirectedSync) applyPatternCompletion(completedPattern *completion
 	if targetCoherence < 0.4 && coherence > targetCoherence {
 		// We want to maintain distributed phases, not synchronize
 		// Apply randomization to prevent synchronization
-		for _, a := range agents {
+		gds.swarm.updateAgents(agents, func(phase float64, rng *agentRand) (float64, bool) {
 			// Add random perturbation to maintain distribution
-			currentPhase := a.Phase()
-			perturbation := (random.Float64() - 0.5) * math.Pi
-			a.SetPhase(currentPhase + perturbation*0.3)
-		}
+			perturbation := (rng.float64() - 0.5) * math.Pi
+			return phase + perturbation*0.3, true
+		}, 0)
 		return // Skip normal synchronization logic
 	}
 
 	// Special handling for high coherence but poor phase convergence
 	// This occurs when agents are synchronized but at different phases
-	if coherence >= gds.config.Thresholds.HighCoherence && phaseVariance > gds.config.Thresholds.PhaseVariance {
+	misaligned := coherence >= gds.config.Thresholds.HighCoherence && phaseVariance > gds.config.Thresholds.PhaseVariance
+	if misaligned {
 		// We have high coherence but agents are at different phases
 		// Need aggressive phase alignment without breaking coherence
 		adjustmentScale = gds.config.Convergence.BaseAdjustmentScale * 1.2 // More aggressive to pull phases together
 	} else {
 		switch {
+		case distanceToTarget < -0.05:
+			// Well past the target - stop tightening so coherence doesn't overshoot
+			adjustmentScale = 0
 		case distanceToTarget <= 0.01:
 			// Extremely close to target - minimal adjustments to avoid overshooting
 			adjustmentScale = 0.1
@@ -291,14 +611,11 @@ func (gds *GoalDirectedSync) applyPatternCompletion(completedPattern *completion
 	sizeNormalized := math.Min(float64(swarmSize)/100.0, 1.0) // Normalize to 0-1
 
 	// Apply to all agents with some variation
-	agentIndex := 0
-	for _, a := range agents {
-		currentPhase := a.Phase()
-		targetPhase := gds.targetPattern.Phase
-		phaseDiff := core.WrapPhase(targetPhase - currentPhase)
+	gds.swarm.updateAgents(agents, gds.swarm.coupled(func(currentPhase float64, rng *agentRand) (float64, bool) {
+		phaseDiff := core.PhaseDifference(targetPhase, currentPhase)
 
 		// Special handling for high coherence but poor phase convergence
-		if coherence >= gds.config.Thresholds.HighCoherence && phaseVariance > gds.config.Thresholds.PhaseVariance {
+		if misaligned {
 			// Agents are synchronized but at different phases
 			// Need to pull them toward target phase more aggressively
 			// Use direct phase correction with less variation
@@ -306,66 +623,66 @@ func (gds *GoalDirectedSync) applyPatternCompletion(completedPattern *completion
 				// Strong pull toward target phase
 				correction := phaseDiff * adjustmentScale * 0.9
 				// Add small random factor to avoid perfect synchronization
-				randomFactor := 0.95 + random.Float64()*0.1
-				newPhase := core.WrapPhase(currentPhase + correction*randomFactor)
-				a.SetPhase(newPhase)
-			}
-		} else {
-			// Normal operation - balance coherence and variation
-			// Add variation to prevent perfect synchronization
-			// Small swarms get more variation, large swarms get less
-			// Also increase variation as we approach high coherence
-			rangeSize := gds.config.Variation.BaseRange[1] - gds.config.Variation.BaseRange[0]
-			baseVariation := gds.config.Variation.BaseRange[0] + (1.0-sizeNormalized)*rangeSize
-			coherenceVariation := coherence * gds.config.Variation.CoherenceFactor
-			variationScale := baseVariation + coherenceVariation
-			variation := (random.Float64() - 0.5) * variationScale
-
-			// Adaptive threshold based on coherence level and swarm size
-			// Larger threshold when coherence is high to maintain natural variation
-			// Small swarms get larger threshold to prevent over-synchronization
-			sizeThreshold := (1.0 - sizeNormalized) * 0.02     // 0-0.02 based on size
-			threshold := 0.01 + coherence*0.03 + sizeThreshold // Range: 0.01 to 0.06 radians
-
-			// Prevent over-synchronization by limiting adjustments when coherence is very high
-			// This ensures we stay below the suspicious threshold
-			if coherence > gds.config.Thresholds.VeryHighCoherence && phaseVariance < gds.config.Thresholds.PhaseVariance {
-				// When coherence is very high AND phases are already converged,
-				// only adjust a fraction of agents to maintain natural variation
-				if agentIndex%3 != 0 { // Skip 2/3 of agents
-					// Add small random walk to maintain variation
-					randomWalk := (random.Float64() - 0.5) * gds.config.Variation.RandomWalkMagnitude
-					a.SetPhase(core.WrapPhase(currentPhase + randomWalk))
-					agentIndex++
-					continue
-				}
-				// For the remaining 1/3, use very small adjustments
-				adjustmentScale *= 0.2
+				randomFactor := 0.95 + rng.float64()*0.1
+				return currentPhase + correction*randomFactor, true
 			}
+			return currentPhase, false
+		}
 
-			if math.Abs(phaseDiff) > threshold {
-				// Use combination of completion adjustment and direct pull to target
-				// Add some randomness to prevent perfect lock-step
-				// More randomness for small swarms
-				randomRange := 0.3 + (1.0-sizeNormalized)*0.2                      // 0.3-0.5 range based on size
-				randomFactor := 1.0 - randomRange/2 + random.Float64()*randomRange // Center around 1.0
-				effectiveAdjustment := (phaseAdjustment*0.3 + phaseDiff*adjustmentScale) * randomFactor
-
-				// Apply adjustment with variation
-				newPhase := core.WrapPhase(currentPhase + effectiveAdjustment*(1+variation))
-				a.SetPhase(newPhase)
-			} else if coherence > gds.config.Thresholds.ModerateCoherence && random.Float64() < gds.config.Variation.PerturbationChance {
-				// More frequent random perturbations when coherence is high
-				// This prevents perfect synchronization
-				perturbation := (random.Float64() - 0.5) * gds.config.Variation.PerturbationMagnitude
-				a.SetPhase(core.WrapPhase(currentPhase + perturbation))
+		// Normal operation - balance coherence and variation
+		// Add variation to prevent perfect synchronization
+		// Small swarms get more variation, large swarms get less
+		// Also increase variation as we approach high coherence
+		rangeSize := gds.config.Variation.BaseRange[1] - gds.config.Variation.BaseRange[0]
+		baseVariation := gds.config.Variation.BaseRange[0] + (1.0-sizeNormalized)*rangeSize
+		coherenceVariation := coherence * gds.config.Variation.CoherenceFactor
+		variationScale := baseVariation + coherenceVariation
+		variation := (rng.float64() - 0.5) * variationScale
+
+		// Adaptive threshold based on coherence level and swarm size
+		// Larger threshold when coherence is high to maintain natural variation
+		// Small swarms get larger threshold to prevent over-synchronization
+		sizeThreshold := (1.0 - sizeNormalized) * 0.02     // 0-0.02 based on size
+		threshold := 0.01 + coherence*0.03 + sizeThreshold // Range: 0.01 to 0.06 radians
+
+		// Prevent over-synchronization by limiting adjustments when coherence is very high
+		// This ensures we stay below the suspicious threshold
+		scale := adjustmentScale
+		if coherence > gds.config.Thresholds.VeryHighCoherence && phaseVariance < gds.config.Thresholds.PhaseVariance {
+			// When coherence is very high AND phases are already converged,
+			// only adjust a fraction of agents to maintain natural variation
+			// Agents are visited in a stable order, so pick the skipped ones at random
+			if rng.intn(3) != 0 { // Skip 2/3 of agents
+				// Add small random walk to maintain variation
+				randomWalk := (rng.float64() - 0.5) * gds.config.Variation.RandomWalkMagnitude
+				return currentPhase + randomWalk, true
 			}
+			// For the remaining 1/3, use very small adjustments
+			scale *= 0.2
 		}
 
-		agentIndex++
+		if math.Abs(phaseDiff) > threshold {
+			// Use combination of completion adjustment and direct pull to target
+			// Add some randomness to prevent perfect lock-step
+			// More randomness for small swarms
+			randomRange := 0.3 + (1.0-sizeNormalized)*0.2                   // 0.3-0.5 range based on size
+			randomFactor := 1.0 - randomRange/2 + rng.float64()*randomRange // Center around 1.0
+			effectiveAdjustment := (phaseAdjustment*0.3 + phaseDiff*scale) * randomFactor
+
+			// Apply adjustment with variation
+			return currentPhase + effectiveAdjustment*(1+variation), true
+		} else if coherence > gds.config.Thresholds.ModerateCoherence && rng.float64() < gds.config.Variation.PerturbationChance {
+			// More frequent random perturbations when coherence is high
+			// This prevents perfect synchronization
+			perturbation := (rng.float64() - 0.5) * gds.config.Variation.PerturbationMagnitude
+			return currentPhase + perturbation, true
+		}
+		return currentPhase, false
+	}), adjustmentScale)
 
-		// Adjust frequency if needed
-		if math.Abs(freqAdjustment.Seconds()) > 0.001 { // Only adjust if significant
+	// Adjust frequency if needed
+	if math.Abs(freqAdjustment.Seconds()) > 0.001 { // Only adjust if significant
+		for _, a := range agents {
 			currentFreq := a.Frequency()
 			newFreq := currentFreq + freqAdjustment
 			if newFreq > 0 {
@@ -384,7 +701,7 @@ func (gds *GoalDirectedSync) switchStrategy() {
 	if gds.currentStrategy != nil {
 		perf := gds.strategyPerf[gds.currentStrategy.Name()]
 		perf.Attempts++
-		perf.LastUsed = time.Now()
+		perf.LastUsed = gds.swarm.now()
 	}
 
 	// Select strategy with best success rate
@@ -401,13 +718,13 @@ func (gds *GoalDirectedSync) switchStrategy() {
 		}
 
 		// Add exploration bonus for less-used strategies
-		timeSinceUsed := time.Since(perf.LastUsed).Seconds()
+		timeSinceUsed := gds.swarm.since(perf.LastUsed).Seconds()
 		timeWindow := gds.config.Strategy.ExplorationTimeWindow.Seconds()
 		explorationBonus := math.Min(timeSinceUsed/timeWindow, gds.config.Strategy.ExplorationBonusMax)
 		score += explorationBonus
 
 		// Add randomness for exploration
-		score += random.Float64() * gds.config.Strategy.RandomExploration
+		score += gds.swarm.randFloat64() * gds.config.Strategy.RandomExploration
 
 		if score > bestScore {
 			bestScore = score
@@ -420,7 +737,10 @@ func (gds *GoalDirectedSync) switchStrategy() {
 
 // addStochasticResonance adds noise to help escape local minima.
 func (gds *GoalDirectedSync) addStochasticResonance() {
-	agents := gds.swarm.Agents()
+	agents := gds.swarm.collectAgents()
+	if len(agents) == 0 {
+		return
+	}
 
 	// Add small random perturbations to configured percentage of agents
 	perturbCount := int(float64(len(agents)) * gds.config.Resonance.AffectedAgents)
@@ -428,24 +748,15 @@ func (gds *GoalDirectedSync) addStochasticResonance() {
 
 	// Randomly select agents to perturb
 	for range perturbCount {
-		// Pick random agent
-		var targetAgent *agent.Agent
-		idx := random.Intn(len(agents))
-		count := 0
-		for _, a := range agents {
-			if count == idx {
-				targetAgent = a
-				break
-			}
-			count++
-		}
+		targetAgent := agents[gds.swarm.randIntn(len(agents))]
 
-		if targetAgent != nil {
-			// Add phase noise
-			noise := (random.Float64() - 0.5) * gds.config.Resonance.NoiseMagnitude
-			currentPhase := targetAgent.Phase()
-			targetAgent.SetPhase(core.WrapPhase(currentPhase + noise))
+		// Add phase noise, which stubborn agents resist like any adjustment
+		noise := (gds.swarm.randFloat64() - 0.5) * gds.config.Resonance.NoiseMagnitude
+		if gds.swarm.randFloat64() < targetAgent.Stubbornness() {
+			continue
 		}
+		currentPhase := targetAgent.Phase()
+		targetAgent.SetPhase(core.WrapPhase(currentPhase + noise))
 	}
 }
 
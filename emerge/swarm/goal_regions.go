@@ -0,0 +1,142 @@
+package swarm
+
+import (
+	"maps"
+	"math"
+	"slices"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/goal"
+)
+
+// goalRegionNoise is the amplitude of the random jitter added to repelling
+// agents, which keeps them from settling on an unstable symmetric spread.
+const goalRegionNoise = 0.05
+
+// agentGoal returns the goal an agent pursues: its own, set with
+// agent.SetGoal, or else the swarm's.
+func (s *Swarm) agentGoal(a *agent.Agent) goal.Type {
+	if g, ok := a.Goal(); ok {
+		return g
+	}
+	return s.goalType
+}
+
+// goalRegions groups agents by the goal they pursue, keeping the agents'
+// stable order within each region.
+func (s *Swarm) goalRegions(agents []*agent.Agent) map[goal.Type][]*agent.Agent {
+	regions := make(map[goal.Type][]*agent.Agent)
+	for _, a := range agents {
+		g := s.agentGoal(a)
+		regions[g] = append(regions[g], a)
+	}
+	return regions
+}
+
+// mixedGoals reports whether any agent pursues a goal other than the
+// swarm's (see agent.SetGoal).
+func (s *Swarm) mixedGoals(agents []*agent.Agent) bool {
+	for _, a := range agents {
+		if s.agentGoal(a) != s.goalType {
+			return true
+		}
+	}
+	return false
+}
+
+// CoherenceByGoal returns the coherence of each group of agents pursuing
+// the same goal, for swarms whose agents have their own goals (see
+// agent.SetGoal). Synchronizing goals aim for coherence near 1; goals that
+// prefer dispersion aim for coherence near 0. In a mixed swarm the overall
+// MeasureCoherence blends both and says little. Agents without a goal of
+// their own are counted under the swarm's goal.
+func (s *Swarm) CoherenceByGoal() map[goal.Type]float64 {
+	regions := s.goalRegions(s.collectAgents())
+	coherence := make(map[goal.Type]float64, len(regions))
+	for g, agents := range regions {
+		coherence[g] = core.MeasureCoherence(phasesOf(agents))
+	}
+	return coherence
+}
+
+// goalRegionsAchieved reports whether every region meets the target:
+// synchronizing regions reach the target coherence and dispersing regions
+// spread as far, reaching the target dispersion.
+func (gds *GoalDirectedSync) goalRegionsAchieved(target float64) bool {
+	for g, agents := range gds.swarm.goalRegions(gds.swarm.collectAgents()) {
+		phases := phasesOf(agents)
+		if g.PrefersDispersion() {
+			if core.MeasureDispersion(phases) < target {
+				return false
+			}
+		} else if core.MeasureCoherence(phases) < target {
+			return false
+		}
+	}
+	return true
+}
+
+// applyGoalCoupling couples every agent to the mean field of its goal
+// region, scaled by the swarm's coupling (see WithCouplingStrength):
+// toward it for synchronizing goals and away from it for dispersing ones.
+// Dispersing agents also repel the second harmonic of the mean field, so
+// they spread evenly rather than splitting into two opposite clusters.
+// Every step is computed from the phases at the start of the tick before
+// any is applied.
+func (gds *GoalDirectedSync) applyGoalCoupling() {
+	k := gds.swarm.coupling()
+
+	type step struct {
+		a           *agent.Agent
+		phase, next float64
+	}
+	var steps []step
+	regions := gds.swarm.goalRegions(gds.swarm.collectAgents())
+	for _, g := range slices.Sorted(maps.Keys(regions)) { // Stable order for WithSeed
+		agents := regions[g]
+		phases := phasesOf(agents)
+		r1, psi1 := orderParameter(phases, 1)
+		r2, psi2 := orderParameter(phases, 2)
+		for i, a := range agents {
+			theta := phases[i]
+			var delta float64
+			if g.PrefersDispersion() {
+				delta = -k * (r1*math.Sin(psi1-theta) + r2*math.Sin(psi2-2*theta)/2)
+				delta += (gds.swarm.randFloat64() - 0.5) * goalRegionNoise
+			} else {
+				delta = k * r1 * math.Sin(psi1-theta)
+			}
+			steps = append(steps, step{a: a, phase: theta, next: theta + delta})
+		}
+	}
+	for _, st := range steps {
+		if next, ok := gds.swarm.spend(st.a, st.phase, st.next); ok {
+			st.a.SetPhase(next)
+		}
+	}
+}
+
+// orderParameter returns the magnitude and angle of the n-th order Kuramoto
+// order parameter of phases.
+func orderParameter(phases []float64, n float64) (r, psi float64) {
+	if len(phases) == 0 {
+		return 0, 0
+	}
+	var sumCos, sumSin float64
+	for _, p := range phases {
+		sumCos += math.Cos(n * p)
+		sumSin += math.Sin(n * p)
+	}
+	count := float64(len(phases))
+	return math.Hypot(sumCos, sumSin) / count, math.Atan2(sumSin, sumCos)
+}
+
+// phasesOf returns the agents' current phases.
+func phasesOf(agents []*agent.Agent) []float64 {
+	phases := make([]float64, len(agents))
+	for i, a := range agents {
+		phases[i] = a.Phase()
+	}
+	return phases
+}
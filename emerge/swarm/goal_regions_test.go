@@ -0,0 +1,59 @@
+package swarm_test
+
+import (
+	"context"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/goal"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+// TestMixedGoalRegions splits a swarm between batching and load
+// distribution: the batching half clusters in phase while the other half
+// spreads around the circle.
+func TestMixedGoalRegions(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		s, err := swarm.New(40, core.State{
+			Phase:     0,
+			Frequency: 200 * time.Millisecond,
+			Coherence: 0.8,
+		}, swarm.WithGoal(goal.MinimizeAPICalls), swarm.WithSeed(11))
+		require.NoError(t, err)
+		defer s.Close()
+
+		for i, a := range s.AgentsSorted() {
+			if i%2 == 1 {
+				a.SetGoal(goal.DistributeLoad)
+			}
+		}
+		before := s.CoherenceByGoal()
+		require.Len(t, before, 2)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		require.NoError(t, s.Run(ctx))
+
+		after := s.CoherenceByGoal()
+		t.Logf("coherence by goal before %v, after %v", before, after)
+		assert.GreaterOrEqual(t, after[goal.MinimizeAPICalls], 0.8, "batching agents should cluster")
+		assert.LessOrEqual(t, after[goal.DistributeLoad], 0.2, "load-distributing agents should spread")
+
+		var spread []float64
+		s.ForEachAgent(func(a *agent.Agent) bool {
+			if g, ok := a.Goal(); ok && g == goal.DistributeLoad {
+				spread = append(spread, a.Phase())
+			}
+			return true
+		})
+		assert.GreaterOrEqual(t, core.MeasureDispersion(spread), 0.8, "spread should be even, not two clusters")
+	})
+}
@@ -0,0 +1,111 @@
+package swarm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+// WithHub makes one agent the swarm's pacemaker, for deployments that
+// already have a leader, e.g. one elected elsewhere. The hub is wired to
+// every other agent, on top of the swarm's topology, in a star. It is
+// given the influence weight and made fully stubborn, so coupling never
+// moves it, and Run and RunContinuous pull every other agent toward the
+// hub's phase, closing that fraction of the gap each tick instead of
+// converging on the target phase. Moving the hub, e.g. with
+// agent.SetPhase, moves the swarm after it.
+//
+// Influence must be in (0, 1]. A hub cannot be combined with phase bands.
+// If the hub is removed from the swarm, the rest synchronizes as usual,
+// and likewise while a hub that has stood still has lost its authority
+// under WithInfluenceDecay.
+func WithHub(agentID string, influence float64) Option {
+	return func(s *Swarm) error {
+		if agentID == "" {
+			return errors.New("hub agent ID must not be empty")
+		}
+		if !(influence > 0 && influence <= 1) {
+			return fmt.Errorf("hub influence must be in (0, 1], got %v", influence)
+		}
+		s.hubID, s.hubInfluence = agentID, influence
+		return nil
+	}
+}
+
+// Hub returns the ID of the hub agent set with WithHub, or an empty string
+// if the swarm has none.
+func (s *Swarm) Hub() string {
+	return s.hubID
+}
+
+// assignHub wires the hub to every other agent and makes it the
+// pacemaker.
+func (s *Swarm) assignHub() error {
+	hub, ok := s.Agent(s.hubID)
+	if !ok {
+		return fmt.Errorf("hub: %w: %s", ErrAgentNotFound, s.hubID)
+	}
+	for _, a := range s.collectAgents() {
+		if a != hub {
+			hub.ConnectTo(a.ID, a)
+			a.ConnectTo(hub.ID, hub)
+		}
+	}
+	hub.SetInfluence(s.hubInfluence)
+	hub.SetStubbornness(1)
+	return nil
+}
+
+// relinkHub restores the hub's star after membership changes rewired the
+// swarm, if the hub is present.
+func (s *Swarm) relinkHub() {
+	if _, ok := s.hub(); ok {
+		_ = s.assignHub() // Cannot fail with the hub present
+	}
+}
+
+// hub returns the hub agent, or false if the swarm has none or the hub has
+// left.
+func (s *Swarm) hub() (*agent.Agent, bool) {
+	if s.hubID == "" {
+		return nil, false
+	}
+	return s.Agent(s.hubID)
+}
+
+// hubFollowed reports whether the agents other than the hub are coherent
+// and centered on the hub's phase, within tolerance.
+func (s *Swarm) hubFollowed(hub *agent.Agent, agents []*agent.Agent, coherence, tolerance float64) bool {
+	phases := make([]float64, 0, len(agents))
+	for _, a := range agents {
+		if a != hub {
+			phases = append(phases, a.Phase())
+		}
+	}
+	if len(phases) == 0 {
+		return true
+	}
+	return core.MeasureCoherence(phases) >= coherence &&
+		math.Abs(core.PhaseDifference(circularMean(phases), hub.Phase())) <= tolerance
+}
+
+// applyHub pulls every agent but the hub toward the hub's phase, closing
+// the hub's influence fraction of the gap, less any decay (see
+// WithInfluenceDecay).
+func (gds *GoalDirectedSync) applyHub(hub *agent.Agent, agents []*agent.Agent) {
+	lead := hub.Phase()
+	pull := gds.swarm.hubPull(hub)
+	for _, a := range agents {
+		if a == hub {
+			continue
+		}
+		phase := a.Phase()
+		next := phase + pull*core.PhaseDifference(lead, phase)
+		if next, ok := gds.swarm.spend(a, phase, next); ok {
+			a.SetPhase(next)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package swarm_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+func TestHub(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		goalState := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.85}
+		s, err := swarm.New(30, goalState, swarm.WithSeed(8), swarm.WithHub("agent-7", 0.8))
+		require.NoError(t, err)
+		defer s.Close()
+		assert.Equal(t, "agent-7", s.Hub())
+
+		hub, ok := s.Agent("agent-7")
+		require.True(t, ok)
+		assert.Equal(t, 29, hub.NeighborCount(), "the hub reaches every agent")
+		assert.InDelta(t, 0.8, hub.Influence(), 1e-9)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		hub.SetPhase(1)
+		require.NoError(t, s.Run(ctx))
+		assert.InDelta(t, 1, hub.Phase(), 1e-9, "the swarm never moves its hub")
+		assertFollows(t, s, 1)
+
+		// Moving the hub moves the swarm within a few ticks
+		hub.SetPhase(4)
+		start := time.Now()
+		require.NoError(t, s.Run(ctx))
+		assert.LessOrEqual(t, time.Since(start), 5*s.EffectiveConfig().Strategy.UpdateInterval)
+		assertFollows(t, s, 4)
+	})
+}
+
+func TestHubContinuous(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		goalState := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.85}
+		s, err := swarm.New(30, goalState, swarm.WithSeed(9), swarm.WithHub("agent-0", 0.8))
+		require.NoError(t, err)
+		defer s.Close()
+		hub, _ := s.Agent("agent-0")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() { done <- s.RunContinuous(ctx) }()
+
+		time.Sleep(5 * time.Second)
+		hub.SetPhase(2)
+		time.Sleep(2 * time.Second)
+		assertFollows(t, s, 2)
+
+		cancel()
+		require.ErrorIs(t, <-done, context.Canceled)
+	})
+}
+
+// assertFollows checks that the agents other than the hub are coherent and
+// centered on the hub's phase.
+func assertFollows(t *testing.T, s *swarm.Swarm, phase float64) {
+	t.Helper()
+	var phases []float64
+	var sumCos, sumSin float64
+	for _, a := range s.AgentsSorted() {
+		if a.ID != s.Hub() {
+			phases = append(phases, a.Phase())
+			sumCos += math.Cos(a.Phase())
+			sumSin += math.Sin(a.Phase())
+		}
+	}
+	assert.GreaterOrEqual(t, core.MeasureCoherence(phases), 0.85)
+	assert.InDelta(t, 0, core.PhaseDifference(math.Atan2(sumSin, sumCos), phase), 0.1)
+}
+
+func TestHubValidation(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.85}
+	_, err := swarm.New(10, goalState, swarm.WithHub("", 0.5))
+	require.Error(t, err)
+	_, err = swarm.New(10, goalState, swarm.WithHub("agent-0", 0))
+	require.Error(t, err)
+	_, err = swarm.New(10, goalState, swarm.WithHub("agent-0", 1.5))
+	require.Error(t, err)
+	_, err = swarm.New(10, goalState, swarm.WithHub("ghost", 0.5))
+	require.ErrorIs(t, err, swarm.ErrAgentNotFound)
+	_, err = swarm.New(10, goalState, swarm.WithHub("agent-0", 0.5),
+		swarm.WithPhaseBands([]swarm.Band{{Name: "a"}, {Name: "b", Phase: math.Pi}}))
+	require.Error(t, err)
+}
@@ -0,0 +1,125 @@
+package swarm
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+)
+
+// influenceLapsed is the fraction of its influence below which a decayed
+// hub no longer leads the swarm: four half-lives of standing still.
+const influenceLapsed = 1.0 / 16
+
+// WithInfluenceDecay makes an agent's authority fade while it stands
+// still, so a pacemaker that has stalled or been partitioned away stops
+// pinning the swarm to its frozen phase. An agent whose phase has not
+// changed for d has its effective influence scaled by 2^(-d/halfLife); it
+// recovers its full influence as soon as its phase moves again. Phases are
+// sampled once per goal-directed iteration, and by RunContinuous once per
+// check interval between resyncs, on the swarm's clock.
+//
+// A hub (see WithHub) pulls with its effective influence, and once that
+// has decayed below a sixteenth of its configured influence the swarm
+// synchronizes as though it had no hub, until the hub moves again. See
+// EffectiveInfluence. The half-life must be positive; decay is off by
+// default.
+func WithInfluenceDecay(halfLife time.Duration) Option {
+	return func(s *Swarm) error {
+		if halfLife <= 0 {
+			return fmt.Errorf("influence half-life must be positive, got %v", halfLife)
+		}
+		s.decay.halfLife = halfLife
+		return nil
+	}
+}
+
+// EffectiveInfluence returns an agent's influence weight as the swarm
+// applies it: agent.Influence scaled down by how long the agent's phase
+// has stood still under WithInfluenceDecay, or agent.Influence itself
+// without decay. It returns false if the swarm has no such agent.
+func (s *Swarm) EffectiveInfluence(id string) (float64, bool) {
+	a, ok := s.Agent(id)
+	if !ok {
+		return 0, false
+	}
+	return a.Influence() * s.decay.factor(id, s.now()), true
+}
+
+// liveHub returns the hub, or false if the swarm has none, the hub has
+// left, or its influence has decayed away.
+func (s *Swarm) liveHub() (*agent.Agent, bool) {
+	hub, ok := s.hub()
+	if !ok || s.decay.factor(hub.ID, s.now()) < influenceLapsed {
+		return nil, false
+	}
+	return hub, true
+}
+
+// hubPull returns the fraction of the gap to the hub that agents close
+// each tick: the hub influence, decayed.
+func (s *Swarm) hubPull(hub *agent.Agent) float64 {
+	return s.hubInfluence * s.decay.factor(hub.ID, s.now())
+}
+
+// stillness is when an agent's phase last moved, and to where.
+type stillness struct {
+	phase float64
+	since time.Time
+}
+
+// influenceDecay follows how long each agent's phase has stood still.
+// With a zero half-life it is disabled and tracks nothing.
+type influenceDecay struct {
+	halfLife time.Duration
+	mu       sync.RWMutex
+	agents   map[string]stillness
+}
+
+// sample records the agents' current phases at now, restarting the clock
+// of every agent whose phase has moved.
+func (d *influenceDecay) sample(agents []*agent.Agent, now time.Time) {
+	if d.halfLife == 0 {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.agents == nil {
+		d.agents = make(map[string]stillness, len(agents))
+	}
+	for _, a := range agents {
+		phase := a.Phase()
+		if st, seen := d.agents[a.ID]; seen && st.phase == phase {
+			continue
+		}
+		d.agents[a.ID] = stillness{phase: phase, since: now}
+	}
+}
+
+// factor returns the share of its influence an agent keeps at now: 1
+// without decay or for an agent not yet sampled.
+func (d *influenceDecay) factor(id string, now time.Time) float64 {
+	if d.halfLife == 0 {
+		return 1
+	}
+	d.mu.RLock()
+	st, ok := d.agents[id]
+	d.mu.RUnlock()
+	if !ok {
+		return 1
+	}
+	idle := now.Sub(st.since)
+	if idle <= 0 {
+		return 1
+	}
+	return math.Exp2(-float64(idle) / float64(d.halfLife))
+}
+
+// forget drops a removed agent.
+func (d *influenceDecay) forget(id string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.agents, id)
+}
@@ -0,0 +1,92 @@
+package swarm_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+	"github.com/carlisia/bio-adapt/emerge/swarm/clocktest"
+)
+
+// followersMean returns the circular mean phase of every agent but the hub.
+func followersMean(s *swarm.Swarm, hubID string) float64 {
+	var sumSin, sumCos float64
+	for _, a := range s.Agents() {
+		if a.ID != hubID {
+			sumSin += math.Sin(a.Phase())
+			sumCos += math.Cos(a.Phase())
+		}
+	}
+	return math.Atan2(sumSin, sumCos)
+}
+
+func TestInfluenceDecay(t *testing.T) {
+	t.Parallel()
+
+	_, err := swarm.New(10, core.State{Frequency: time.Second, Coherence: 0.8}, swarm.WithInfluenceDecay(0))
+	require.Error(t, err)
+
+	const (
+		hubID    = "agent-0"
+		hubPhase = 2.0
+		tick     = 100 * time.Millisecond
+	)
+	goalState := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.85}
+	build := func(opts ...swarm.Option) (*swarm.Swarm, *clocktest.Clock) {
+		clock := clocktest.New(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+		s, err := swarm.New(30, goalState, append([]swarm.Option{
+			swarm.WithSeed(5), swarm.WithClock(clock), swarm.WithHub(hubID, 0.5),
+		}, opts...)...)
+		require.NoError(t, err)
+		hub, _ := s.Agent(hubID)
+		hub.SetPhase(hubPhase)
+		return s, clock
+	}
+	run := func(s *swarm.Swarm, clock *clocktest.Clock, steps int) {
+		for range steps {
+			s.Step()
+			clock.Advance(tick)
+		}
+	}
+
+	// Without decay a frozen hub pins the swarm to its phase for good
+	pinned, clock := build()
+	defer pinned.Close()
+	run(pinned, clock, 150)
+	assert.InDelta(t, 0, core.PhaseDifference(followersMean(pinned, hubID), hubPhase), 0.1)
+	influence, ok := pinned.EffectiveInfluence(hubID)
+	require.True(t, ok)
+	assert.InDelta(t, 0.5, influence, 1e-12)
+
+	// With decay the frozen hub loses its authority and the rest
+	// converge among themselves, away from it
+	s, clock := build(swarm.WithInfluenceDecay(200 * time.Millisecond))
+	defer s.Close()
+	run(s, clock, 150)
+	influence, ok = s.EffectiveInfluence(hubID)
+	require.True(t, ok)
+	assert.Less(t, influence, 0.5/16)
+	assert.GreaterOrEqual(t, s.MeasureCoherence(), 0.8)
+	assert.Greater(t, math.Abs(core.PhaseDifference(followersMean(s, hubID), hubPhase)), 1.0)
+
+	// A hub that moves again recovers its full influence and leads again
+	hub, _ := s.Agent(hubID)
+	hub.SetPhase(hubPhase + 0.5)
+	s.Step()
+	influence, _ = s.EffectiveInfluence(hubID)
+	assert.InDelta(t, 0.5, influence, 1e-12)
+	clock.Advance(tick)
+	for range 3 {
+		hub.SetPhase(hub.Phase() + 0.01) // A live hub keeps moving
+		run(s, clock, 5)
+	}
+	assert.InDelta(t, 0, core.PhaseDifference(followersMean(s, hubID), hub.Phase()), 0.1)
+
+	_, ok = s.EffectiveInfluence("missing")
+	assert.False(t, ok)
+}
@@ -0,0 +1,103 @@
+package swarm_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+// pace is the phase TestInfluenceShapesRun and TestEdgeWeightsShapeRun pin
+// their pacemaker to, well away from the target phase 0.
+const pace = 1.5
+
+// seeds is how many seeds the pacemaker tests average over, since single
+// runs are noisy.
+const seeds = 12
+
+// pacedMean runs a 20-agent swarm whose agent-0 is a pacemaker holding
+// still at pace, with every agent's influence 0.1 and then shape applied,
+// and returns the circular mean phase the others end at, averaged over
+// seeds.
+func pacedMean(t *testing.T, shape func(pacemaker *agent.Agent, others []*agent.Agent)) float64 {
+	t.Helper()
+	goal := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.85}
+	var total float64
+	for seed := range int64(seeds) {
+		synctest.Test(t, func(t *testing.T) {
+			s, err := swarm.New(20, goal, swarm.WithSeed(seed))
+			require.NoError(t, err)
+			defer s.Close()
+			pacemaker, ok := s.Agent("agent-0")
+			require.True(t, ok)
+			var others []*agent.Agent
+			for _, a := range s.Agents() {
+				a.SetInfluence(0.1)
+				if a != pacemaker {
+					others = append(others, a)
+				}
+			}
+			pacemaker.SetPhase(pace)
+			pacemaker.SetStrategy(&holdStill{})
+			shape(pacemaker, others)
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+			require.NoError(t, s.Run(ctx))
+			require.InDelta(t, pace, pacemaker.Phase(), 1e-9, "the pacemaker should hold still")
+			total += meanPhase(others)
+		})
+	}
+	return total / seeds
+}
+
+// TestInfluenceShapesRun compares where Run leaves the swarm around a
+// pacemaker of influence 0.1 against one of influence 1. The loop weighs
+// each neighbor's pull by its influence, so the stronger pacemaker drags
+// the others' mean phase toward its own.
+func TestInfluenceShapesRun(t *testing.T) {
+	t.Parallel()
+
+	plain := pacedMean(t, func(*agent.Agent, []*agent.Agent) {})
+	paced := pacedMean(t, func(pacemaker *agent.Agent, _ []*agent.Agent) {
+		pacemaker.SetInfluence(1)
+	})
+	assert.Greater(t, paced, plain, "the others should end nearer the pacemaker")
+	assert.Less(t, paced, pace)
+}
+
+// TestEdgeWeightsShapeRun links every agent to the pacemaker, once with
+// plain links and once with links weighted 10. The loop weighs each
+// neighbor's pull by the weight of the link to it, so the heavier links
+// drag the others' mean phase toward the pacemaker.
+func TestEdgeWeightsShapeRun(t *testing.T) {
+	t.Parallel()
+
+	watch := func(weight float64) func(*agent.Agent, []*agent.Agent) {
+		return func(pacemaker *agent.Agent, others []*agent.Agent) {
+			for _, a := range others {
+				a.ConnectWeighted(pacemaker.ID, pacemaker, weight)
+			}
+		}
+	}
+	plain, weighted := pacedMean(t, watch(1)), pacedMean(t, watch(10))
+	assert.Greater(t, weighted, plain, "the others should end nearer the pacemaker")
+	assert.Less(t, weighted, pace)
+}
+
+// meanPhase returns the circular mean of the agents' phases.
+func meanPhase(agents []*agent.Agent) float64 {
+	var x, y float64
+	for _, a := range agents {
+		x, y = x+math.Cos(a.Phase()), y+math.Sin(a.Phase())
+	}
+	return math.Atan2(y, x)
+}
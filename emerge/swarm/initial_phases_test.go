@@ -0,0 +1,73 @@
+package swarm_test
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+func TestWithInitialPhases(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8}
+	phases := []float64{0.5, 1, 1.5, 2, 2.5, 3, 3.5, 4, 4.5, 5, 5.5, 7}
+	s, err := swarm.New(len(phases), goalState, swarm.WithSeed(1), swarm.WithInitialPhases(phases))
+	require.NoError(t, err)
+	defer s.Close()
+
+	agents := s.Agents()
+	for i, want := range phases {
+		a := agents[fmt.Sprintf("agent-%d", i)]
+		require.NotNil(t, a)
+		assert.InDelta(t, core.WrapPhase(want), a.Phase(), 1e-9, "agent %d", i)
+	}
+}
+
+func TestWithInitialPhaseFuncAntiPhase(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		goalState := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.8}
+		s, err := swarm.New(20, goalState, swarm.WithSeed(9),
+			swarm.WithInitialPhaseFunc(func(i int) float64 {
+				return float64(i%2) * math.Pi
+			}))
+		require.NoError(t, err)
+		defer s.Close()
+		require.Less(t, s.MeasureCoherence(), 0.05, "two equal anti-phase clusters cancel out")
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		require.NoError(t, s.Run(ctx))
+		assert.GreaterOrEqual(t, s.MeasureCoherence(), 0.8)
+	})
+}
+
+func TestWithInitialPhasesValidation(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8}
+	tests := []struct {
+		name string
+		opt  swarm.Option
+	}{
+		{"too few phases", swarm.WithInitialPhases([]float64{0, 1})},
+		{"too many phases", swarm.WithInitialPhases(make([]float64, 11))},
+		{"NaN phase", swarm.WithInitialPhaseFunc(func(int) float64 { return math.NaN() })},
+		{"infinite phase", swarm.WithInitialPhaseFunc(func(int) float64 { return math.Inf(1) })},
+		{"nil func", swarm.WithInitialPhaseFunc(nil)},
+	}
+	for _, tt := range tests {
+		_, err := swarm.New(10, goalState, tt.opt)
+		assert.Error(t, err, tt.name)
+	}
+}
@@ -0,0 +1,100 @@
+package swarm
+
+import (
+	"math"
+	"sync"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+// stepDamper is implemented by strategies that smooth phase corrections,
+// such as strategy.JitterDamping.
+type stepDamper interface {
+	Damp(prevStep, step float64) float64
+}
+
+// phaseMotion is an agent's phase at the last sample and the step that led
+// to it.
+type phaseMotion struct {
+	phase   float64
+	step    float64
+	samples int
+}
+
+// jitterTracker follows every agent's phase from sample to sample. Steps
+// are phase velocities and changes between steps are phase accelerations,
+// both per sample.
+type jitterTracker struct {
+	mu     sync.RWMutex
+	agents map[string]phaseMotion
+	jitter float64 // Mean acceleration at the last sample
+}
+
+// sample records the agents' current phases and updates the jitter.
+func (j *jitterTracker) sample(agents []*agent.Agent) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.agents == nil {
+		j.agents = make(map[string]phaseMotion, len(agents))
+	}
+
+	var total float64
+	counted := 0
+	for _, a := range agents {
+		phase := a.Phase()
+		m, seen := j.agents[a.ID]
+		if !seen {
+			j.agents[a.ID] = phaseMotion{phase: phase, samples: 1}
+			continue
+		}
+		step := core.PhaseDifference(phase, m.phase)
+		if m.samples >= 2 {
+			total += math.Abs(step - m.step)
+			counted++
+		}
+		j.agents[a.ID] = phaseMotion{phase: phase, step: step, samples: m.samples + 1}
+	}
+	if counted > 0 {
+		j.jitter = total / float64(counted)
+	}
+}
+
+// lastStep returns the step that brought an agent to its last sampled phase.
+func (j *jitterTracker) lastStep(id string) float64 {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.agents[id].step
+}
+
+// forget drops a removed agent.
+func (j *jitterTracker) forget(id string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	delete(j.agents, id)
+}
+
+// MeasureJitter returns the mean phase acceleration across agents: how much
+// each agent's phase step changed between the last two samples, in radians,
+// averaged over the swarm. Low jitter means agents fire at
+// predictable times, which is what latency-sensitive goals care about; a
+// swarm can be coherent yet jittery if it keeps correcting hard. It is
+// sampled once per goal-directed iteration, and by RunContinuous once per
+// check interval between resyncs, so it decays to 0 once the swarm holds
+// still. It is 0 until three samples have been taken.
+func (s *Swarm) MeasureJitter() float64 {
+	s.jitter.mu.RLock()
+	defer s.jitter.mu.RUnlock()
+	return s.jitter.jitter
+}
+
+// dampStep smooths the step an agent is about to take if its strategy
+// damps corrections.
+func (s *Swarm) dampStep(a *agent.Agent, phase, next float64) float64 {
+	damper, ok := a.Strategy().(stepDamper)
+	if !ok {
+		return next
+	}
+	step := core.PhaseDifference(next, phase)
+	return phase + damper.Damp(s.jitter.lastStep(a.ID), step)
+}
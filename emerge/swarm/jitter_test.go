@@ -0,0 +1,57 @@
+package swarm_test
+
+import (
+	"context"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/goal"
+	"github.com/carlisia/bio-adapt/emerge/strategy"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+// TestMeasureJitterDecreasesAsSwarmStabilizes samples jitter while a
+// MinimizeLatency swarm converges and then holds: corrections are largest
+// and least steady early on, and jitter falls away once the swarm settles.
+func TestMeasureJitterDecreasesAsSwarmStabilizes(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		s, err := swarm.New(50, core.State{
+			Phase:     0,
+			Frequency: 200 * time.Millisecond,
+			Coherence: 0.85,
+		}, swarm.WithGoal(goal.MinimizeLatency), swarm.WithSeed(3))
+		require.NoError(t, err)
+		defer s.Close()
+
+		assert.Equal(t, strategy.NameJitterDamping, s.StrategyName(), "latency goal should damp jitter")
+		assert.Zero(t, s.MeasureJitter(), "no jitter before the swarm runs")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() { done <- s.RunContinuous(ctx) }()
+
+		// Track the peak while the swarm converges, then let it settle
+		interval := s.EffectiveConfig().Strategy.UpdateInterval
+		var peak float64
+		for range 20 {
+			time.Sleep(interval)
+			peak = max(peak, s.MeasureJitter())
+		}
+		time.Sleep(5 * time.Second)
+		settled := s.MeasureJitter()
+		cancel()
+		require.ErrorIs(t, <-done, context.Canceled)
+
+		t.Logf("peak jitter %.4f, settled %.4f", peak, settled)
+		assert.Positive(t, peak, "converging agents should jitter")
+		assert.Less(t, settled, peak/2, "jitter should fall as the swarm stabilizes")
+		assert.GreaterOrEqual(t, s.MeasureCoherence(), 0.8)
+	})
+}
@@ -0,0 +1,347 @@
+package swarm
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/decision"
+	"github.com/carlisia/bio-adapt/internal/config"
+)
+
+// AgentConfig describes an agent joining a running swarm. Zero values take
+// the swarm's defaults, the same ones agents created by New get.
+type AgentConfig struct {
+	ID           string        // Unique agent ID ("" = next free "agent-N")
+	Phase        float64       // Initial phase (0 = random)
+	Frequency    time.Duration // Oscillation frequency (0 = random 100-150ms)
+	Influence    float64       // Weight of local vs global goals (0 = swarm default)
+	Stubbornness float64       // Resistance to change (0 = swarm default)
+}
+
+// MembershipEventType identifies a membership change.
+type MembershipEventType int
+
+// Membership event types.
+const (
+	// AgentJoined fires after AddAgent splices an agent in.
+	AgentJoined MembershipEventType = iota + 1
+	// AgentLeft fires after RemoveAgent splices an agent out.
+	AgentLeft
+)
+
+// String returns the event type name.
+func (t MembershipEventType) String() string {
+	switch t {
+	case AgentJoined:
+		return "joined"
+	case AgentLeft:
+		return "left"
+	default:
+		return "unknown"
+	}
+}
+
+// MembershipEvent describes an agent joining or leaving the swarm.
+type MembershipEvent struct {
+	Type    MembershipEventType
+	AgentID string
+	Size    int // Agent count after the change
+	Time    time.Time
+}
+
+// WithMembershipObserver registers a function called after every AddAgent
+// and RemoveAgent. It runs synchronously on the caller's goroutine once the
+// topology has been rewired, one change at a time.
+func WithMembershipObserver(fn func(MembershipEvent)) Option {
+	return func(s *Swarm) error {
+		if fn == nil {
+			return errors.New("membership observer must not be nil")
+		}
+		s.membershipObservers = append(s.membershipObservers, fn)
+		return nil
+	}
+}
+
+// AddAgent creates an agent and splices it into the swarm. It is safe to
+// call while Run or RunContinuous is active; the agent takes part from the
+// next iteration and coherence is measured over the new membership.
+//
+// With WithTopology the topology builder is rerun over the new membership;
+// if it fails, for instance because it was made for a fixed agent count,
+// the agent is not added. Otherwise the agent is connected the way New
+// connects agents: to random peers up to the configured neighbor limits.
+// Swarm-wide settings such as
+// WithStrategy, WithGossipFanout, WithFrequencyDistribution and WithSeed
+// apply to the new agent too. Swarms with phase bands have fixed
+// membership.
+func (s *Swarm) AddAgent(cfg AgentConfig) (*agent.Agent, error) {
+	s.membershipMu.Lock()
+	defer s.membershipMu.Unlock()
+
+	if len(s.bands) > 0 {
+		return nil, errors.New("add agent: swarms with phase bands have fixed membership")
+	}
+	size := s.Size() + 1
+	if s.config.MaxSwarmSize > 0 && size > s.config.MaxSwarmSize {
+		return nil, fmt.Errorf("add agent: %w: swarm size %d exceeds configured maximum %d", ErrInvalidSwarmSize, size, s.config.MaxSwarmSize)
+	}
+	if err := s.checkMaxAgents(size); err != nil {
+		return nil, fmt.Errorf("add agent: %w", err)
+	}
+
+	id := cfg.ID
+	if id == "" {
+		next, err := s.nextAgentID()
+		if err != nil {
+			return nil, fmt.Errorf("add agent: %w", err)
+		}
+		id = next
+	} else if _, exists := s.Agent(id); exists {
+		return nil, fmt.Errorf("add agent: %w: %s", ErrAgentExists, id)
+	}
+
+	a, err := s.newMember(id, size, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("add agent: %w", err)
+	}
+
+	s.storeAgent(a)
+	if err := s.wireJoined(a); err != nil {
+		s.deleteAgent(a.ID)
+		s.unlink(a)
+		if s.topologyBuilder != nil {
+			_ = s.rebuildTopology() // Restore the previous wiring
+		}
+		return nil, fmt.Errorf("add agent: %w", err)
+	}
+
+	s.notifyMembership(AgentJoined, a.ID)
+	s.logEvent(logEntry{Kind: logAdd, Agent: &cfg})
+	return a, nil
+}
+
+// RemoveAgent splices an agent out of the swarm. It is safe to call while
+// Run or RunContinuous is active. Every link to the agent is removed, so no
+// remaining agent references it, and the agent's own links are cleared.
+//
+// With WithTopology the topology builder is rerun over the remaining
+// agents. Otherwise former neighbors left below the configured minimum are
+// reconnected to random peers. Either way WithMinNeighbors then tops up
+// any agent left short. The last agent cannot be removed.
+func (s *Swarm) RemoveAgent(id string) error {
+	s.membershipMu.Lock()
+	defer s.membershipMu.Unlock()
+
+	if len(s.bands) > 0 {
+		return errors.New("remove agent: swarms with phase bands have fixed membership")
+	}
+	a, ok := s.Agent(id)
+	if !ok {
+		return fmt.Errorf("remove agent: %w: %s", ErrAgentNotFound, id)
+	}
+	if s.Size() == 1 {
+		return fmt.Errorf("remove agent: %w: cannot remove the last agent", ErrInvalidSwarmSize)
+	}
+
+	s.deleteAgent(id)
+	s.energy.forget(id)
+	s.jitter.forget(id)
+	s.decay.forget(id)
+	orphans := s.unlink(a)
+	s.dropBridges(a)
+	if s.topologyBuilder != nil {
+		if err := s.rebuildTopology(); err != nil {
+			return fmt.Errorf("remove agent %s: agent removed but topology rebuild failed: %w", id, err)
+		}
+	} else {
+		agents := s.collectAgents()
+		for _, o := range orphans {
+			s.ensureMinimumConnectivity(o, agents, o.NeighborCount())
+		}
+	}
+	s.healConnectivity()
+
+	s.notifyMembership(AgentLeft, id)
+	s.logEvent(logEntry{Kind: logRemove, AgentID: id})
+	return nil
+}
+
+// newMember creates an agent for a swarm of the given size, applying the
+// swarm-wide settings New applies to its own agents.
+func (s *Swarm) newMember(id string, size int, cfg AgentConfig) (*agent.Agent, error) {
+	agentConfig := config.AgentFromSwarm(s.config)
+	agentConfig.SwarmSize = size
+	if cfg.Phase != 0 {
+		agentConfig.Phase = cfg.Phase
+		agentConfig.RandomizePhase = false
+	}
+	if cfg.Frequency < 0 {
+		return nil, fmt.Errorf("frequency must not be negative, got %v", cfg.Frequency)
+	}
+	if cfg.Frequency > 0 {
+		agentConfig.Frequency = cfg.Frequency
+		agentConfig.RandomizeFrequency = false
+	}
+	if cfg.Influence != 0 {
+		agentConfig.Influence = cfg.Influence
+	}
+	if cfg.Stubbornness != 0 {
+		agentConfig.Stubbornness = cfg.Stubbornness
+	}
+
+	var (
+		a   *agent.Agent
+		err error
+	)
+	if s.optimized {
+		a, err = agent.NewOptimizedFromConfig(id, agentConfig)
+	} else {
+		a, err = agent.NewFromConfig(id, agentConfig)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if s.rng != nil {
+		s.seedAgent(a, agentConfig)
+	}
+	s.capStubbornnessOf(a)
+	if s.frequencyDist != nil {
+		freq := s.frequencyDist()
+		if freq <= 0 {
+			return nil, fmt.Errorf("non-positive frequency %v for agent %s", freq, id)
+		}
+		a.SetNaturalFrequency(freq)
+	}
+	if k := s.GossipFanout(); k > 0 {
+		a.SetGossipFanout(k, s.randIntn)
+	}
+	if s.cooldown > 0 {
+		a.SetCooldown(s.cooldown)
+	}
+	if s.strategyName != "" {
+		st, err := s.newStrategy()
+		if err != nil {
+			return nil, err
+		}
+		a.SetStrategy(st)
+	}
+	if s.decisionMakerName != "" {
+		dm, err := decision.New(s.decisionMakerName)
+		if err != nil {
+			return nil, err
+		}
+		a.SetDecisionMaker(dm)
+	}
+	if s.costModel != nil {
+		a.SetCostModel(s.costModel)
+	}
+	s.blendGoalsOf(a)
+	return a, nil
+}
+
+// storeAgent adds an agent to the swarm's storage.
+func (s *Swarm) storeAgent(a *agent.Agent) {
+	s.agentsMutex.Lock()
+	defer s.agentsMutex.Unlock()
+
+	if s.optimized {
+		s.agentIndex[a.ID] = len(s.agentSlice)
+		s.agentSlice = append(s.agentSlice, a)
+	} else {
+		s.agents.Store(a.ID, a)
+	}
+	s.size++
+	s.phaseSums.invalidate()
+}
+
+// deleteAgent removes an agent from the swarm's storage, keeping the
+// optimized slice in order.
+func (s *Swarm) deleteAgent(id string) {
+	s.agentsMutex.Lock()
+	defer s.agentsMutex.Unlock()
+
+	if s.optimized {
+		idx, ok := s.agentIndex[id]
+		if !ok {
+			return
+		}
+		s.phaseSums.unwatch(s.agentSlice[idx])
+		s.agentSlice = slices.Delete(s.agentSlice, idx, idx+1)
+		delete(s.agentIndex, id)
+		for i := idx; i < len(s.agentSlice); i++ {
+			s.agentIndex[s.agentSlice[i].ID] = i
+		}
+	} else {
+		value, ok := s.agents.LoadAndDelete(id)
+		if !ok {
+			return
+		}
+		if a, ok := value.(*agent.Agent); ok {
+			s.phaseSums.unwatch(a)
+		}
+	}
+	s.size--
+	s.phaseSums.invalidate()
+}
+
+// unlink removes every connection to and from a, returning the agents that
+// were connected to it.
+func (s *Swarm) unlink(a *agent.Agent) []*agent.Agent {
+	var orphans []*agent.Agent
+	for _, other := range s.collectAgents() {
+		_, inMap := other.Neighbors().Load(a.ID)
+		if inMap || other.IsConnectedTo(a.ID) {
+			other.Neighbors().Delete(a.ID)
+			other.DisconnectFrom(a.ID)
+			orphans = append(orphans, other)
+		}
+	}
+	a.ClearNeighbors()
+	return orphans
+}
+
+// wireJoined connects a newly stored agent.
+func (s *Swarm) wireJoined(a *agent.Agent) error {
+	if s.topologyBuilder != nil {
+		return s.rebuildTopology()
+	}
+
+	agents := s.collectAgents()
+	if s.config.EnableConnectionOptim && len(agents) > s.config.ConnectionOptimThreshold {
+		s.connectMinimal(a, agents)
+	} else {
+		idx := slices.IndexFunc(agents, func(other *agent.Agent) bool { return other.ID == a.ID })
+		connected := s.connectToNeighbors(a, agents, idx)
+		s.ensureMinimumConnectivity(a, agents, connected)
+	}
+	s.relinkHub()
+	return nil
+}
+
+// rebuildTopology clears every connection and reruns the topology builder,
+// then restores bridges and the hub's star.
+func (s *Swarm) rebuildTopology() error {
+	for _, a := range s.collectAgents() {
+		a.ClearNeighbors()
+	}
+	if err := s.topologyBuilder(s); err != nil {
+		return fmt.Errorf("topology build failed: %w", err)
+	}
+	s.restoreBridges()
+	s.relinkHub()
+	return nil
+}
+
+// notifyMembership calls the membership observers and publishes
+// EventMembershipChanged.
+func (s *Swarm) notifyMembership(t MembershipEventType, id string) {
+	ev := MembershipEvent{Type: t, AgentID: id, Size: s.Size(), Time: s.now()}
+	s.publish(LifecycleEvent{Type: EventMembershipChanged, Membership: &ev})
+	for _, fn := range s.membershipObservers {
+		fn(ev)
+	}
+}
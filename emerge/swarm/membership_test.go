@@ -0,0 +1,225 @@
+package swarm_test
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"sync"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+	"github.com/carlisia/bio-adapt/internal/topology"
+)
+
+// referencesTo counts the agents still linked to id by either neighbor store.
+func referencesTo(s *swarm.Swarm, id string) int {
+	refs := 0
+	s.ForEachAgent(func(a *agent.Agent) bool {
+		_, inMap := a.Neighbors().Load(id)
+		if inMap || a.IsConnectedTo(id) {
+			refs++
+		}
+		return true
+	})
+	return refs
+}
+
+func TestAddAndRemoveAgent(t *testing.T) {
+	t.Parallel()
+
+	target := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8}
+
+	for _, size := range []int{10, 150} {
+		t.Run(strconv.Itoa(size), func(t *testing.T) {
+			t.Parallel()
+
+			var events []swarm.MembershipEvent
+			s, err := swarm.New(size, target, swarm.WithSeed(1),
+				swarm.WithMembershipObserver(func(ev swarm.MembershipEvent) {
+					events = append(events, ev)
+				}))
+			require.NoError(t, err)
+			defer s.Close()
+
+			joined, err := s.AddAgent(swarm.AgentConfig{Phase: 1.5})
+			require.NoError(t, err)
+			assert.Equal(t, size+1, s.Size())
+			assert.Equal(t, "agent-"+strconv.Itoa(size), joined.ID)
+			assert.InDelta(t, 1.5, joined.Phase(), 1e-9)
+			assert.Positive(t, joined.NeighborCount(), "new agent should be wired in")
+			got, ok := s.Agent(joined.ID)
+			require.True(t, ok)
+			assert.Same(t, joined, got)
+
+			// Remove an original agent: nothing may keep pointing at it
+			require.NoError(t, s.RemoveAgent("agent-3"))
+			assert.Equal(t, size, s.Size())
+			assert.Zero(t, referencesTo(s, "agent-3"))
+			_, ok = s.Agent("agent-3")
+			assert.False(t, ok)
+			assert.Len(t, s.Agents(), size)
+
+			require.Len(t, events, 2)
+			assert.Equal(t, swarm.AgentJoined, events[0].Type)
+			assert.Equal(t, joined.ID, events[0].AgentID)
+			assert.Equal(t, size+1, events[0].Size)
+			assert.Equal(t, swarm.AgentLeft, events[1].Type)
+			assert.Equal(t, "agent-3", events[1].AgentID)
+			assert.Equal(t, size, events[1].Size)
+		})
+	}
+}
+
+func TestRemoveAgentRecomputesCoherence(t *testing.T) {
+	t.Parallel()
+
+	s, err := swarm.New(5, core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8})
+	require.NoError(t, err)
+	defer s.Close()
+
+	for id, a := range s.Agents() {
+		if id == "agent-2" {
+			a.SetPhase(math.Pi)
+		} else {
+			a.SetPhase(0)
+		}
+	}
+	assert.InDelta(t, 0.6, s.MeasureCoherence(), 1e-9)
+
+	require.NoError(t, s.RemoveAgent("agent-2"))
+	assert.InDelta(t, 1.0, s.MeasureCoherence(), 1e-9, "the outlier no longer counts")
+}
+
+func TestMembershipRewiresTopologyBuilder(t *testing.T) {
+	t.Parallel()
+
+	s, err := swarm.New(6, core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8},
+		swarm.WithTopology(topology.Ring))
+	require.NoError(t, err)
+	defer s.Close()
+
+	ringDegrees := func() {
+		t.Helper()
+		s.ForEachAgent(func(a *agent.Agent) bool {
+			assert.Equal(t, 2, a.NeighborCount(), a.ID)
+			return true
+		})
+	}
+
+	_, err = s.AddAgent(swarm.AgentConfig{ID: "late"})
+	require.NoError(t, err)
+	ringDegrees()
+
+	require.NoError(t, s.RemoveAgent("agent-0"))
+	ringDegrees()
+	assert.Zero(t, referencesTo(s, "agent-0"))
+}
+
+func TestMembershipKeepsHubStar(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8}
+	for name, opts := range map[string][]swarm.Option{
+		"default wiring":   nil,
+		"topology builder": {swarm.WithTopology(topology.Ring)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			s, err := swarm.New(8, goalState, append(opts, swarm.WithHub("agent-3", 0.5))...)
+			require.NoError(t, err)
+			defer s.Close()
+			hub, _ := s.Agent("agent-3")
+
+			star := func() {
+				t.Helper()
+				s.ForEachAgent(func(a *agent.Agent) bool {
+					if a != hub {
+						assert.True(t, hub.IsConnectedTo(a.ID), "hub -> %s", a.ID)
+						assert.True(t, a.IsConnectedTo(hub.ID), "%s -> hub", a.ID)
+					}
+					return true
+				})
+			}
+
+			_, err = s.AddAgent(swarm.AgentConfig{ID: "late"})
+			require.NoError(t, err)
+			star()
+
+			require.NoError(t, s.RemoveAgent("agent-0"))
+			star()
+		})
+	}
+}
+
+func TestMembershipErrors(t *testing.T) {
+	t.Parallel()
+
+	target := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8}
+
+	s, err := swarm.New(2, target)
+	require.NoError(t, err)
+	defer s.Close()
+
+	_, err = s.AddAgent(swarm.AgentConfig{ID: "agent-0"})
+	require.ErrorIs(t, err, swarm.ErrAgentExists)
+	require.ErrorIs(t, s.RemoveAgent("ghost"), swarm.ErrAgentNotFound)
+
+	require.NoError(t, s.RemoveAgent("agent-0"))
+	require.ErrorIs(t, s.RemoveAgent("agent-1"), swarm.ErrInvalidSwarmSize)
+
+	// Builders made for a fixed agent count leave membership unchanged
+	sw, err := topology.SmallWorld(10, 2, 0.1, topology.WithSeed(1))
+	require.NoError(t, err)
+	fixed, err := swarm.New(10, target, swarm.WithTopology(sw))
+	require.NoError(t, err)
+	defer fixed.Close()
+
+	_, err = fixed.AddAgent(swarm.AgentConfig{})
+	require.Error(t, err)
+	assert.Equal(t, 10, fixed.Size())
+	fixed.ForEachAgent(func(a *agent.Agent) bool {
+		assert.Positive(t, a.NeighborCount(), "previous wiring is restored")
+		return true
+	})
+}
+
+func TestMembershipDuringRun(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		s, err := swarm.New(20, core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.99},
+			swarm.WithSeed(5))
+		require.NoError(t, err)
+		defer s.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		var wg sync.WaitGroup
+		wg.Go(func() { _ = s.Run(ctx) })
+
+		for i := range 10 {
+			time.Sleep(200 * time.Millisecond)
+			a, err := s.AddAgent(swarm.AgentConfig{})
+			require.NoError(t, err)
+			time.Sleep(200 * time.Millisecond)
+			require.NoError(t, s.RemoveAgent("agent-"+strconv.Itoa(i)))
+			assert.Zero(t, referencesTo(s, "agent-"+strconv.Itoa(i)))
+			_, ok := s.Agent(a.ID)
+			assert.True(t, ok)
+		}
+		cancel()
+		wg.Wait()
+
+		assert.Equal(t, 20, s.Size())
+		assert.Len(t, s.Agents(), 20)
+	})
+}
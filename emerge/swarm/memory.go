@@ -0,0 +1,82 @@
+package swarm
+
+import (
+	"github.com/carlisia/bio-adapt/internal/config"
+)
+
+// Approximate heap costs used by EstimateMemory, measured on 64-bit platforms.
+const (
+	// agentBytes covers an agent and its components: atomic state, decision
+	// maker, goal and resource managers, strategy and neighbor slots.
+	agentBytes = 900
+
+	// mapEntryBytes is the cost of one sync.Map entry. Small swarms store each
+	// agent this way, and every neighbor link is one entry per endpoint.
+	mapEntryBytes = 120
+
+	// indexedEntryBytes is the cost of one agent in optimized storage
+	// (slice slot plus ID index entry).
+	indexedEntryBytes = 64
+
+	// swarmBaseBytes covers fixed per-swarm structures: goal-directed sync,
+	// strategies, pattern templates and monitors.
+	swarmBaseBytes = 8 << 10
+
+	// monitoringSampleBytes is one coherence sample plus its timestamp, and
+	// monitoringHistoryCapacity the number of samples preallocated.
+	monitoringSampleBytes     = 8 + 24
+	monitoringHistoryCapacity = 1000
+)
+
+// EstimateMemory returns the approximate heap bytes a swarm of the given size
+// and configuration uses after construction. It accounts for agents, the
+// storage backend New selects for the size, the neighbor links the config's
+// topology parameters produce, and preallocated monitoring buffers.
+//
+// The estimate is meant for capacity planning and is within a factor of 2 of
+// the measured heap growth. It is closer for large swarms, where per-agent
+// and per-link costs dominate fixed overhead, and errs on the high side for
+// small sparse swarms. Edge count dominates for dense topologies, so
+// ConnectionProbability and MaxNeighbors matter most.
+func EstimateMemory(size int, cfg config.Swarm) int64 {
+	if size <= 0 {
+		return 0
+	}
+	n := int64(size)
+
+	storage := int64(mapEntryBytes)
+	if size > OptimizedSwarmThreshold {
+		storage = indexedEntryBytes
+	}
+
+	links := n * int64(expectedDegree(size, cfg))
+
+	return swarmBaseBytes +
+		monitoringSampleBytes*monitoringHistoryCapacity +
+		n*(agentBytes+storage) +
+		links*mapEntryBytes
+}
+
+// expectedDegree approximates the average neighbor count that
+// establishConnections produces for the given size and config.
+func expectedDegree(size int, cfg config.Swarm) int {
+	maxDegree := size - 1
+	if maxDegree <= 0 {
+		return 0
+	}
+
+	var initiated int
+	if cfg.EnableConnectionOptim && size > cfg.ConnectionOptimThreshold {
+		// Minimal connections: each agent links to MinNeighbors random peers
+		initiated = cfg.MinNeighbors
+	} else {
+		p := min(max(cfg.ConnectionProbability, 0), 1)
+		initiated = min(cfg.MaxNeighbors, int(p*float64(maxDegree)+0.5))
+		if size > cfg.MinNeighbors {
+			initiated = max(initiated, cfg.MinNeighbors)
+		}
+	}
+
+	// Links are bidirectional, so each agent also receives about as many as it initiates
+	return min(max(2*initiated, 0), maxDegree)
+}
@@ -0,0 +1,90 @@
+package swarm_test
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+	"github.com/carlisia/bio-adapt/internal/config"
+)
+
+// ringConfig approximates a ring: every agent keeps about two neighbors.
+func ringConfig(size int) config.Swarm {
+	cfg := config.AutoScaleConfig(size)
+	cfg.ConnectionProbability = 0
+	cfg.MinNeighbors = 2
+	cfg.MaxNeighbors = 2
+	return cfg
+}
+
+// meshConfig connects every agent to every other agent.
+func meshConfig(size int) config.Swarm {
+	cfg := config.AutoScaleConfig(size)
+	cfg.ConnectionProbability = 1
+	cfg.MaxNeighbors = size - 1
+	return cfg
+}
+
+func TestEstimateMemoryEdgesDominate(t *testing.T) {
+	t.Parallel()
+
+	const size = 100
+	ring := swarm.EstimateMemory(size, ringConfig(size))
+	mesh := swarm.EstimateMemory(size, meshConfig(size))
+
+	t.Logf("%d agents: ring %d bytes, full mesh %d bytes", size, ring, mesh)
+	assert.Greater(t, mesh, 5*ring, "full mesh should cost far more than a ring")
+	assert.Zero(t, swarm.EstimateMemory(0, ringConfig(1)))
+}
+
+//nolint:paralleltest // Heap measurements must not overlap with other tests
+func TestEstimateMemoryMatchesAllocation(t *testing.T) {
+	// The documented accuracy bound for EstimateMemory
+	const maxFactor = 2.0
+
+	goal := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.7}
+	tests := []struct {
+		size int
+		name string
+		cfg  func(int) config.Swarm
+	}{
+		{50, "ring", ringConfig},
+		{50, "mesh", meshConfig},
+		{50, "auto", config.AutoScaleConfig},
+		{500, "ring", ringConfig},
+		{500, "mesh", meshConfig},
+		{500, "auto", config.AutoScaleConfig},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s_%d", tt.name, tt.size), func(t *testing.T) {
+			cfg := tt.cfg(tt.size)
+			estimate := swarm.EstimateMemory(tt.size, cfg)
+
+			before := heapAlloc()
+			s, err := swarm.New(tt.size, goal, swarm.WithConfig(cfg))
+			require.NoError(t, err)
+			actual := int64(heapAlloc() - before)
+			runtime.KeepAlive(s)
+			s.Close()
+
+			ratio := float64(estimate) / float64(actual)
+			t.Logf("estimate %d bytes, actual %d bytes (ratio %.2f)", estimate, actual, ratio)
+			assert.InDelta(t, 1, ratio, maxFactor-1, "estimate should be within %.0fx of actual", maxFactor)
+			assert.GreaterOrEqual(t, ratio, 1/maxFactor)
+		})
+	}
+}
+
+func heapAlloc() uint64 {
+	runtime.GC()
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return m.HeapAlloc
+}
@@ -0,0 +1,94 @@
+package swarm
+
+import (
+	"math"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+// SwarmMetrics is a snapshot of a swarm's health, taken by Metrics.
+//
+//nolint:revive // SwarmMetrics reads better than Metrics next to the Metrics method
+type SwarmMetrics struct {
+	Agents        int     // Number of agents
+	Coherence     float64 // As MeasureCoherence
+	Dispersion    float64 // As MeasureDispersion
+	PhaseVariance float64 // As MeasurePhaseVariance
+	MeanEnergy    float64 // Mean agent energy
+	MinEnergy     float64 // Lowest agent energy
+	MaxEnergy     float64 // Highest agent energy
+	EnergyStdDev  float64 // Population standard deviation of agent energy
+	MeanDegree    float64 // Mean number of neighbors per agent
+}
+
+// Metrics takes a snapshot of the swarm's health in a single pass over the
+// agents. Use it instead of calling MeasureCoherence, MeasureDispersion,
+// MeasurePhaseVariance and the agent accessors one after another: those
+// each walk every agent, which is slow for large swarms, and a Run in
+// between can leave them describing different moments. Metrics is safe to
+// call while the swarm runs; Sample and the observers build on it.
+func (s *Swarm) Metrics() SwarmMetrics {
+	if s.optimized {
+		s.agentsMutex.RLock()
+		defer s.agentsMutex.RUnlock()
+		return s.metrics(s.agentSlice)
+	}
+	return s.metrics(s.collectAgents()) // Stable order so sums are reproducible
+}
+
+// metrics computes the snapshot for agents. Each agent is read once; its
+// phase is kept for the second pass that phase variance and slot purity
+// need.
+func (s *Swarm) metrics(agents []*agent.Agent) SwarmMetrics {
+	m := SwarmMetrics{Agents: len(agents)}
+	if len(agents) == 0 {
+		return m
+	}
+
+	phases := make([]float64, len(agents))
+	var sumCos1, sumSin1, sumCos2, sumSin2 float64
+	var energy, energySq float64
+	degree := 0
+	m.MinEnergy, m.MaxEnergy = math.Inf(1), math.Inf(-1)
+	for i, a := range agents {
+		p := a.Phase()
+		phases[i] = p
+		sumCos1 += math.Cos(p)
+		sumSin1 += math.Sin(p)
+		sumCos2 += math.Cos(2 * p)
+		sumSin2 += math.Sin(2 * p)
+
+		e := a.Energy()
+		energy += e
+		energySq += e * e
+		m.MinEnergy = math.Min(m.MinEnergy, e)
+		m.MaxEnergy = math.Max(m.MaxEnergy, e)
+
+		degree += a.NeighborCount()
+	}
+
+	n := float64(len(agents))
+	r1 := math.Hypot(sumCos1, sumSin1) / n
+	r2 := math.Hypot(sumCos2, sumSin2) / n
+	if len(agents) == 1 {
+		r1, r2 = 1, 1 // A lone agent is trivially coherent
+	}
+	m.Coherence = r1
+	if spec, ok := s.goalType.Spec(); ok {
+		m.Coherence = slotPurity(phases, s.target().Phase, spec.Slots)
+	}
+	m.Dispersion = math.Max(0, 1-math.Max(r1, r2)) // Clamp rounding error
+
+	meanPhase := math.Atan2(sumSin1, sumCos1)
+	for _, p := range phases {
+		d := core.PhaseDifference(p, meanPhase)
+		m.PhaseVariance += d * d
+	}
+	m.PhaseVariance /= n
+
+	m.MeanEnergy = energy / n
+	m.EnergyStdDev = math.Sqrt(math.Max(0, energySq/n-m.MeanEnergy*m.MeanEnergy))
+	m.MeanDegree = float64(degree) / n
+	return m
+}
@@ -0,0 +1,82 @@
+package swarm_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+func TestMetrics(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.85}
+
+	// Below and above the optimized storage threshold
+	for _, size := range []int{30, 150} {
+		s, err := swarm.New(size, goalState, swarm.WithSeed(3))
+		require.NoError(t, err)
+		t.Cleanup(s.Close)
+
+		for i, a := range s.AgentsSorted() {
+			a.SetEnergy(float64(10 + i%5*10))
+		}
+
+		m := s.Metrics()
+		assert.Equal(t, size, m.Agents)
+		assert.InDelta(t, s.MeasureCoherence(), m.Coherence, 1e-9)
+		assert.InDelta(t, s.MeasureDispersion(), m.Dispersion, 1e-9)
+		assert.InDelta(t, s.MeasurePhaseVariance(), m.PhaseVariance, 1e-9)
+		assert.InDelta(t, 30, m.MeanEnergy, 1e-9)
+		assert.InDelta(t, 10, m.MinEnergy, 1e-9)
+		assert.InDelta(t, 50, m.MaxEnergy, 1e-9)
+		assert.InDelta(t, math.Sqrt(200), m.EnergyStdDev, 1e-9)
+
+		degree := 0
+		for _, a := range s.Agents() {
+			degree += a.NeighborCount()
+		}
+		assert.InDelta(t, float64(degree)/float64(size), m.MeanDegree, 1e-9)
+		assert.Positive(t, m.MeanDegree)
+
+		sample := s.Sample()
+		assert.InDelta(t, m.Coherence, sample.Coherence, 1e-9)
+		assert.InDelta(t, m.MeanEnergy, sample.MeanEnergy, 1e-9)
+	}
+}
+
+func TestMetricsDuringRun(t *testing.T) {
+	t.Parallel()
+
+	s, err := swarm.New(150, core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.85},
+		swarm.WithSeed(4))
+	require.NoError(t, err)
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = s.Run(ctx)
+	}()
+
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+		m := s.Metrics()
+		assert.Equal(t, 150, m.Agents)
+		assert.LessOrEqual(t, m.MinEnergy, m.MeanEnergy)
+		assert.LessOrEqual(t, m.MeanEnergy, m.MaxEnergy)
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,81 @@
+package swarm
+
+import (
+	"fmt"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+)
+
+// WithMinNeighbors makes the swarm heal its own connectivity: whenever a
+// disruption or RemoveAgent leaves an agent with fewer than k neighbors,
+// the swarm links it to random peers until it has k again, or every other
+// agent. A Partition can otherwise strand agents with no neighbors at
+// all, and nothing would reconnect them; this complements recovering the
+// agents' phases with recovering their links. New links are two-way and
+// made the way the swarm's topology makes its links, with WithTopology or
+// without. Peers are drawn from the seeded source with WithSeed.
+//
+// Healing only adds links, so a partition whose groups each keep k
+// neighbors per agent stands. k must be positive; healing is off by
+// default.
+func WithMinNeighbors(k int) Option {
+	return func(s *Swarm) error {
+		if k <= 0 {
+			return fmt.Errorf("min neighbors must be positive, got %d", k)
+		}
+		s.minNeighbors = k
+		return nil
+	}
+}
+
+// MinNeighbors returns the neighbor count set with WithMinNeighbors, or 0
+// if the swarm does not heal its connectivity.
+func (s *Swarm) MinNeighbors() int {
+	return s.minNeighbors
+}
+
+// healConnectivity links every agent below the WithMinNeighbors count to
+// random peers until it reaches it.
+func (s *Swarm) healConnectivity() {
+	if s.minNeighbors == 0 {
+		return
+	}
+	agents := s.collectAgents()
+	want := min(s.minNeighbors, len(agents)-1)
+	for _, a := range agents {
+		have := a.NeighborCount()
+		if have >= want {
+			continue
+		}
+		// Visit the other agents in random order, linking the unlinked
+		order := make([]*agent.Agent, 0, len(agents)-1)
+		for _, b := range agents {
+			if b != a {
+				order = append(order, b)
+			}
+		}
+		for i := 0; i < len(order) && have < want; i++ {
+			j := i + s.randIntn(len(order)-i)
+			order[i], order[j] = order[j], order[i]
+			b := order[i]
+			if connected(a, b) {
+				continue
+			}
+			s.link(a, b)
+			have++
+		}
+	}
+}
+
+// link connects a and b both ways in the store the swarm's topology uses:
+// the one topology builders fill with ConnectTo, or the default
+// topology's Neighbors map.
+func (s *Swarm) link(a, b *agent.Agent) {
+	if s.topologyBuilder != nil {
+		a.ConnectTo(b.ID, b)
+		b.ConnectTo(a.ID, a)
+		return
+	}
+	a.Neighbors().Store(b.ID, b)
+	b.Neighbors().Store(a.ID, a)
+}
@@ -0,0 +1,96 @@
+package swarm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+	"github.com/carlisia/bio-adapt/emerge/swarm/clocktest"
+)
+
+// fewestNeighbors returns the lowest neighbor count of any agent.
+func fewestNeighbors(s *swarm.Swarm) int {
+	fewest := s.Size()
+	for _, a := range s.Agents() {
+		fewest = min(fewest, a.NeighborCount())
+	}
+	return fewest
+}
+
+func TestWithMinNeighbors(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.85}
+	_, err := swarm.New(10, goalState, swarm.WithMinNeighbors(0))
+	require.Error(t, err)
+
+	const k = 4
+	// A partition cutting off a few agents leaves them with at most each
+	// other for neighbors
+	cut := swarm.DisruptionSpec{Kind: swarm.Partition, Fraction: 0.1}
+	build := func(opts ...swarm.Option) (*swarm.Swarm, *clocktest.Clock) {
+		clock := clocktest.New(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+		s, err := swarm.New(30, goalState, append([]swarm.Option{swarm.WithSeed(8), swarm.WithClock(clock)}, opts...)...)
+		require.NoError(t, err)
+		return s, clock
+	}
+
+	stranded, _ := build()
+	defer stranded.Close()
+	_, err = stranded.Disrupt(cut)
+	require.NoError(t, err)
+	assert.Less(t, fewestNeighbors(stranded), k, "without healing the cut-off agents stay short")
+
+	t.Run("partition", func(t *testing.T) {
+		t.Parallel()
+
+		s, clock := build(swarm.WithMinNeighbors(k))
+		defer s.Close()
+		assert.Equal(t, k, s.MinNeighbors())
+		for range 20 {
+			s.Step()
+			clock.Advance(100 * time.Millisecond)
+		}
+		pre := s.MeasureCoherence()
+
+		report, err := s.Disrupt(cut)
+		require.NoError(t, err)
+		assert.Equal(t, 3, report.Affected)
+		assert.GreaterOrEqual(t, fewestNeighbors(s), k)
+		for _, id := range report.AgentIDs {
+			a, _ := s.Agent(id)
+			for _, n := range a.NeighborList() {
+				assert.Contains(t, n.NeighborList(), a, "healed links run both ways")
+			}
+		}
+
+		for range 50 {
+			s.Step()
+			clock.Advance(100 * time.Millisecond)
+		}
+		assert.GreaterOrEqual(t, s.MeasureCoherence(), 0.9*pre)
+	})
+
+	t.Run("removal", func(t *testing.T) {
+		t.Parallel()
+
+		s, _ := build(swarm.WithMinNeighbors(k), swarm.WithTopology(func(s *swarm.Swarm) error {
+			// A ring: every agent has exactly two neighbors
+			agents := s.AgentsSorted()
+			for i, a := range agents {
+				next := agents[(i+1)%len(agents)]
+				a.ConnectTo(next.ID, next)
+				next.ConnectTo(a.ID, a)
+			}
+			return nil
+		}))
+		defer s.Close()
+
+		require.NoError(t, s.RemoveAgent("agent-5"))
+		assert.GreaterOrEqual(t, fewestNeighbors(s), k)
+	})
+}
@@ -0,0 +1,118 @@
+package swarm
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+)
+
+// sustainedWindow is the number of ticks SustainedCoherence averages over.
+const sustainedWindow = 50
+
+// WithPhaseNoise adds Gaussian noise with the given standard deviation, in
+// radians, to every agent's phase on every tick of Run and RunContinuous.
+// This models clock jitter: rather than a one-shot disruption, the swarm
+// is perturbed continuously and holds coherence only as well as its
+// coupling outpaces the noise. Noise is drawn from the seeded source with
+// WithSeed and costs agents no energy. Use SustainedCoherence to read the
+// level the swarm holds under it.
+func WithPhaseNoise(stddev float64) Option {
+	return func(s *Swarm) error {
+		if !(stddev > 0) || math.IsInf(stddev, 0) {
+			return fmt.Errorf("phase noise must be positive and finite, got %v", stddev)
+		}
+		s.phaseNoise.Store(math.Float64bits(stddev))
+		return nil
+	}
+}
+
+// WithoutPhaseNoise turns phase noise off, as if WithPhaseNoise had never
+// been given. Pass it to Reconfigure to stop the jitter on a running swarm.
+func WithoutPhaseNoise() Option {
+	return func(s *Swarm) error {
+		s.phaseNoise.Store(0)
+		return nil
+	}
+}
+
+// PhaseNoise returns the standard deviation set with WithPhaseNoise, or 0
+// without noise.
+func (s *Swarm) PhaseNoise() float64 {
+	return math.Float64frombits(s.phaseNoise.Load())
+}
+
+// SustainedCoherence returns the mean coherence over the last 50 ticks of
+// Run and RunContinuous, the level the swarm actually holds rather than a
+// single measurement. Under WithPhaseNoise it settles below the noiseless
+// level, lower the stronger the noise. Before any tick it returns
+// MeasureCoherence.
+func (s *Swarm) SustainedCoherence() float64 {
+	if mean, ok := s.sustained.mean(); ok {
+		return mean
+	}
+	return s.MeasureCoherence()
+}
+
+// addPhaseNoise perturbs every agent's phase by the configured noise.
+func (s *Swarm) addPhaseNoise(agents []*agent.Agent) {
+	noise := s.PhaseNoise()
+	if noise == 0 {
+		return
+	}
+	for _, a := range agents {
+		a.SetPhase(a.Phase() + noise*s.randNorm())
+	}
+}
+
+// sustainedTracker keeps the coherence of recent ticks.
+type sustainedTracker struct {
+	size int // Ticks kept; 0 keeps sustainedWindow
+
+	mu     sync.Mutex
+	recent []float64 // Ring of the last size measurements
+	next   int
+}
+
+// capacity returns the number of ticks kept.
+func (t *sustainedTracker) capacity() int {
+	if t.size == 0 {
+		return sustainedWindow
+	}
+	return t.size
+}
+
+// record adds a tick's coherence, replacing the oldest once full.
+func (t *sustainedTracker) record(coherence float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.recent) < t.capacity() {
+		t.recent = append(t.recent, coherence)
+		return
+	}
+	t.recent[t.next] = coherence
+	t.next = (t.next + 1) % t.capacity()
+}
+
+// full reports whether a whole window of ticks has been recorded.
+func (t *sustainedTracker) full() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.recent) == t.capacity()
+}
+
+// mean returns the mean of the recorded coherence, reporting false if
+// nothing has been recorded.
+func (t *sustainedTracker) mean() (float64, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.recent) == 0 {
+		return 0, false
+	}
+	total := 0.0
+	for _, c := range t.recent {
+		total += c
+	}
+	return total / float64(len(t.recent)), true
+}
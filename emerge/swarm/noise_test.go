@@ -0,0 +1,84 @@
+package swarm_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+// TestPhaseNoise holds swarms against a target they cannot quite reach
+// under increasing phase noise. Each settles on a plateau below the
+// noiseless one, and the shortfall grows in proportion to the noise.
+func TestPhaseNoise(t *testing.T) {
+	t.Parallel()
+
+	sustained := func(t *testing.T, stddev float64) float64 {
+		t.Helper()
+		var level float64
+		synctest.Test(t, func(t *testing.T) {
+			opts := []swarm.Option{swarm.WithSeed(4)}
+			if stddev > 0 {
+				opts = append(opts, swarm.WithPhaseNoise(stddev))
+			}
+			s, err := swarm.New(30, core.State{
+				Phase:     0,
+				Frequency: 200 * time.Millisecond,
+				Coherence: 0.98,
+			}, opts...)
+			require.NoError(t, err)
+			defer s.Close()
+			assert.InDelta(t, stddev, s.PhaseNoise(), 1e-12)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			defer cancel()
+			require.Error(t, s.RunContinuous(ctx), "the target is out of reach")
+			level = s.SustainedCoherence()
+		})
+		return level
+	}
+
+	noiseless := sustained(t, 0)
+	require.Greater(t, noiseless, 0.9)
+
+	previous := noiseless
+	for _, stddev := range []float64{0.1, 0.2, 0.4} {
+		level := sustained(t, stddev)
+		assert.Less(t, level, previous, "stddev %v", stddev)
+		// Every 0.1 of stddev costs the plateau roughly 0.015-0.02 of coherence
+		shortfall := noiseless - level
+		assert.InDelta(t, 0.175, shortfall/stddev, 0.05, "stddev %v: shortfall %.3f", stddev, shortfall)
+		previous = level
+	}
+}
+
+func TestSustainedCoherence(t *testing.T) {
+	t.Parallel()
+
+	target := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.8}
+
+	t.Run("before any tick", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := swarm.New(10, target, swarm.WithSeed(1))
+		require.NoError(t, err)
+		defer s.Close()
+		assert.InDelta(t, s.MeasureCoherence(), s.SustainedCoherence(), 1e-12)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		t.Parallel()
+
+		for _, stddev := range []float64{0, -0.1, math.NaN(), math.Inf(1)} {
+			_, err := swarm.New(10, target, swarm.WithPhaseNoise(stddev))
+			require.Error(t, err, "stddev %v", stddev)
+		}
+	})
+}
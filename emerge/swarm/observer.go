@@ -0,0 +1,137 @@
+package swarm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/carlisia/bio-adapt/emerge/monitoring"
+)
+
+// swarmSeq numbers swarms that were not given an explicit ID.
+var swarmSeq atomic.Uint64
+
+// nextSwarmID returns a process-unique default swarm ID.
+func nextSwarmID() string {
+	return fmt.Sprintf("swarm-%d", swarmSeq.Add(1))
+}
+
+// WithID sets the swarm's identifier, used to label observer samples.
+// Swarms get a process-unique ID such as "swarm-3" by default.
+func WithID(id string) Option {
+	return func(s *Swarm) error {
+		if id == "" {
+			return errors.New("swarm ID must not be empty")
+		}
+		s.id = id
+		return nil
+	}
+}
+
+// WithObserver registers an observer that receives periodic health samples
+// while Run or RunContinuous is active. Samples are taken every
+// config MonitoringInterval, or as set by WithObserverInterval, on a
+// separate goroutine, so a slow observer delays later samples but never
+// the convergence loop.
+func WithObserver(o monitoring.Observer) Option {
+	return func(s *Swarm) error {
+		if o == nil {
+			return errors.New("observer must not be nil")
+		}
+		s.observers = append(s.observers, o)
+		return nil
+	}
+}
+
+// WithObserverInterval sets how often observers are sampled, overriding
+// config MonitoringInterval. Observation runs on its own ticker, so at
+// high scale with sub-millisecond update ticks observers can be sampled
+// far less often than agents are updated. Each sample is a single
+// Metrics snapshot, so its fields all describe the same moment.
+//
+// The interval is fixed: WithAdaptiveTickInterval slows the update loop
+// while coherence holds still, but not observation, so an idle swarm is
+// still sampled every d. The interval must be positive.
+func WithObserverInterval(d time.Duration) Option {
+	return func(s *Swarm) error {
+		if d <= 0 {
+			return fmt.Errorf("observer interval must be positive, got %v", d)
+		}
+		s.observeTick = d
+		return nil
+	}
+}
+
+// observerInterval returns the interval between observer samples.
+func (s *Swarm) observerInterval() time.Duration {
+	if s.observeTick > 0 {
+		return s.observeTick
+	}
+	return s.config.MonitoringInterval
+}
+
+// ID returns the swarm's identifier.
+func (s *Swarm) ID() string {
+	return s.id
+}
+
+// Sample takes a health sample of the swarm from Metrics.
+func (s *Swarm) Sample() monitoring.Sample {
+	m := s.Metrics()
+	return monitoring.Sample{
+		SwarmID:       s.id,
+		Time:          s.now(),
+		Coherence:     m.Coherence,
+		MeanEnergy:    m.MeanEnergy,
+		MinEnergy:     m.MinEnergy,
+		MaxEnergy:     m.MaxEnergy,
+		PhaseVariance: m.PhaseVariance,
+		Disruptions:   s.disruptions.Load(),
+	}
+}
+
+// startObservers begins delivering samples to the registered observers until
+// the returned stop function is called. Stop delivers a final sample and
+// waits for the sampling goroutine to exit.
+func (s *Swarm) startObservers(ctx context.Context) (stop func()) {
+	if len(s.observers) == 0 {
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		ticker := s.newTicker(s.observerInterval())
+		defer ticker.Stop()
+
+		s.notifyObservers()
+		for {
+			select {
+			case <-ctx.Done():
+				s.notifyObservers()
+				return
+			case <-ticker.C():
+				s.notifyObservers()
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+// notifyObservers delivers one sample to every observer.
+func (s *Swarm) notifyObservers() {
+	sample := s.Sample()
+	for _, o := range s.observers {
+		o.Observe(sample)
+	}
+}
@@ -0,0 +1,57 @@
+package swarm_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/monitoring"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+func TestWithObserverInterval(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.85}
+	_, err := swarm.New(10, goalState, swarm.WithObserverInterval(0))
+	require.Error(t, err)
+
+	synctest.Test(t, func(t *testing.T) {
+		const interval = 250 * time.Millisecond
+		var (
+			mu    sync.Mutex
+			times []time.Time
+		)
+		s, err := swarm.New(20, goalState, swarm.WithSeed(9),
+			swarm.WithAdaptiveTickInterval(time.Millisecond, time.Millisecond),
+			swarm.WithObserverInterval(interval),
+			swarm.WithObserver(monitoring.ObserverFunc(func(sample monitoring.Sample) {
+				mu.Lock()
+				defer mu.Unlock()
+				times = append(times, sample.Time)
+			})))
+		require.NoError(t, err)
+		defer s.Close()
+
+		s.Pause() // Keep the 1ms update loop ticking without converging
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = s.Run(ctx)
+
+		mu.Lock()
+		defer mu.Unlock()
+		// One sample on start, one per interval and a final one: nowhere
+		// near one per update tick
+		require.GreaterOrEqual(t, len(times), 5)
+		assert.LessOrEqual(t, len(times), 6)
+		for i := 1; i < len(times)-1; i++ {
+			assert.Equal(t, interval, times[i].Sub(times[i-1]), "sample %d", i)
+		}
+	})
+}
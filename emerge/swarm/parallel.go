@@ -0,0 +1,153 @@
+package swarm
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sync"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+// WithParallelism shards each tick's agent updates across n workers.
+// Updates are double-buffered: every agent's next phase is computed from a
+// read snapshot of the swarm (the swarm-wide measurements of the tick plus
+// the agent's current phase), and the new phases are committed only after
+// all of them are computed. Each agent draws from its own random stream,
+// derived from the swarm's random source and the agent's position, so for a
+// fixed WithSeed the trajectory is the same for every n.
+//
+// Parallelism pays off for large swarms; with n = 1, the default, updates
+// run serially on the loop's goroutine.
+func WithParallelism(n int) Option {
+	return func(s *Swarm) error {
+		if n < 1 {
+			return fmt.Errorf("parallelism must be at least 1, got %d", n)
+		}
+		s.parallelism = n
+		return nil
+	}
+}
+
+// Parallelism returns the number of workers agent updates are sharded
+// across, 1 when updates run serially.
+func (s *Swarm) Parallelism() int {
+	return max(s.parallelism, 1)
+}
+
+// agentRand is one agent's random stream for one tick.
+type agentRand struct {
+	pcg rand.PCG
+}
+
+// float64 returns a random float64 in [0, 1).
+func (r *agentRand) float64() float64 {
+	return float64(r.pcg.Uint64()>>11) / (1 << 53)
+}
+
+// intn returns a random int in [0, n).
+func (r *agentRand) intn(n int) int {
+	return int(r.pcg.Uint64() % uint64(n))
+}
+
+// agentUpdate computes an agent's next phase from its current phase. It must
+// not touch any agent; it reports false to leave the phase unchanged.
+type agentUpdate func(phase float64, rng *agentRand) (next float64, changed bool)
+
+// updateAgents applies update to every agent in two passes: compute every
+// next phase from the current phases, unless the agent's stubbornness
+// holds it where it is, plus neighborScale times the pull of its
+// neighbors, local and remote (see neighborPull and WithRemoteNeighbors),
+// taken as far as the agent's strategy goes if its decision maker agrees
+// (see WithStrategy and WithDecisionMaker), smoothed for agents whose
+// strategy damps jitter and pulled toward local goals by goal blends (see
+// WithGoalBlend), then commit the changed ones that the agent can pay for
+// (see WithEnergyCost). Decided steps are counted in the agents'
+// statistics (see AggregateStats). Agents resting after their last move
+// (see WithAdjustmentCooldown) are left out. Both passes are sharded
+// across the swarm's workers.
+func (s *Swarm) updateAgents(agents []*agent.Agent, update agentUpdate, neighborScale float64) {
+	n := len(agents)
+	if n == 0 {
+		return
+	}
+
+	// One draw from the swarm's source per tick seeds every agent's stream
+	tick := uint64(s.randFloat64() * (1 << 53))
+	remote := s.remoteNeighbors(s.now())
+	next := make([]float64, n)
+	changed := make([]bool, n)
+	steps := make([]core.Action, n)
+
+	s.forEachShard(n, func(lo, hi int) {
+		var rng agentRand
+		for i := lo; i < hi; i++ {
+			if agents[i].Rest() {
+				continue // Still cooling down from its last move
+			}
+			rng.pcg.Seed(tick, uint64(i))
+			phase := agents[i].Phase()
+			next[i], changed[i] = update(phase, &rng)
+			if resists(agents[i], &rng) {
+				changed[i] = false
+				continue
+			}
+			// Strategies and decision makers read the agent's context
+			shift, pulled := agents[i].Perceive(rng.intn)
+			pull := s.remotePull(remote, phase)
+			if pulled {
+				pull += s.neighborPull(shift)
+			}
+			if pull *= neighborScale; pull != 0 {
+				next[i], changed[i] = next[i]+pull, true
+			}
+			if changed[i] {
+				steps[i], changed[i] = decideStep(agents[i], phase, strategyProposal(agents[i], phase, next[i]), s.confidenceScaling)
+				next[i] = phase + steps[i].Value
+			}
+			if changed[i] {
+				next[i] = s.dampStep(agents[i], phase, next[i])
+			}
+			if pulled, moved := towardLocalGoal(agents[i], next[i]); moved {
+				next[i], changed[i] = pulled, true
+			}
+		}
+	})
+	s.forEachShard(n, func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			if !changed[i] {
+				continue
+			}
+			next, ok := s.spend(agents[i], agents[i].Phase(), next[i])
+			if ok {
+				agents[i].SetPhase(core.WrapPhase(next))
+				agents[i].StartCooldown()
+			}
+			if steps[i].Type != "" {
+				// The step its decision maker took, rather than a goal blend's pull
+				agents[i].RecordAction(steps[i], ok)
+			}
+		}
+	})
+}
+
+// forEachShard splits [0, n) into one contiguous shard per worker and runs
+// fn on each, returning when all are done.
+func (s *Swarm) forEachShard(n int, fn func(lo, hi int)) {
+	shards := min(s.Parallelism(), n)
+	if shards <= 1 || s.workerPool == nil {
+		fn(0, n)
+		return
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(shards)
+	for k := range shards {
+		lo, hi := k*n/shards, (k+1)*n/shards
+		s.workerPool.Submit(func() {
+			defer wg.Done()
+			fn(lo, hi)
+		})
+	}
+	wg.Wait()
+}
@@ -0,0 +1,77 @@
+package swarm_test
+
+import (
+	"context"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/monitoring"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+func TestParallelMatchesSerial(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		size int
+	}{
+		{"small", 30},
+		{"optimized", 150},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			synctest.Test(t, func(t *testing.T) {
+				run := func(parallelism int) ([]float64, map[string]float64) {
+					monitor := monitoring.New(monitoring.WithHistoryLimit(0))
+					s, err := swarm.New(tt.size, core.State{
+						Phase:     0,
+						Frequency: 200 * time.Millisecond,
+						Coherence: 0.7,
+					}, swarm.WithSeed(42), swarm.WithMonitor(monitor), swarm.WithParallelism(parallelism))
+					require.NoError(t, err)
+					defer s.Close()
+					assert.Equal(t, parallelism, s.Parallelism())
+
+					ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+					defer cancel()
+					require.NoError(t, s.Run(ctx))
+
+					phases := make(map[string]float64)
+					for id, a := range s.Agents() {
+						phases[id] = a.Phase()
+					}
+					return monitor.History(), phases
+				}
+
+				serial, serialPhases := run(1)
+				parallel, parallelPhases := run(4)
+				require.NotEmpty(t, serial)
+				assert.Equal(t, serial, parallel, "parallel and serial engines should follow the same coherence trajectory")
+				assert.Equal(t, serialPhases, parallelPhases)
+			})
+		})
+	}
+}
+
+func TestWithParallelismValidation(t *testing.T) {
+	t.Parallel()
+
+	goal := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8}
+
+	s, err := swarm.New(10, goal)
+	require.NoError(t, err)
+	defer s.Close()
+	assert.Equal(t, 1, s.Parallelism(), "updates are serial by default")
+
+	_, err = swarm.New(10, goal, swarm.WithParallelism(0))
+	require.Error(t, err)
+}
@@ -0,0 +1,65 @@
+package swarm_test
+
+import (
+	"context"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+func TestPauseFreezesRun(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		s, err := swarm.New(20, core.State{
+			Phase:     0,
+			Frequency: 200 * time.Millisecond,
+			Coherence: 0.7,
+		})
+		require.NoError(t, err)
+		defer s.Close()
+
+		s.Pause()
+		s.Pause() // Idempotent
+		assert.True(t, s.Paused())
+
+		phases := make(map[string]float64)
+		energies := make(map[string]float64)
+		for id, a := range s.Agents() {
+			phases[id] = a.Phase()
+			energies[id] = a.Energy()
+		}
+		frozen := s.MeasureCoherence()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		done := make(chan error, 1)
+		go func() { done <- s.Run(ctx) }()
+
+		// Well past the time Run needs to converge
+		time.Sleep(10 * time.Second)
+		synctest.Wait()
+
+		select {
+		case err := <-done:
+			t.Fatalf("Run returned while paused: %v", err)
+		default:
+		}
+		assert.InDelta(t, frozen, s.MeasureCoherence(), 1e-12, "coherence should stay frozen")
+		for id, a := range s.Agents() {
+			assert.InDelta(t, phases[id], a.Phase(), 1e-12, "agent %s moved while paused", id)
+			assert.InDelta(t, energies[id], a.Energy(), 1e-12, "agent %s spent energy while paused", id)
+		}
+
+		s.Resume()
+		s.Resume() // Idempotent
+		assert.False(t, s.Paused())
+		require.NoError(t, <-done, "Run should converge after Resume")
+	})
+}
@@ -2,6 +2,8 @@ package swarm
 
 import (
 	"math"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
 )
 
 // MeasurePhaseConvergence calculates how well agents have converged to a target phase.
@@ -16,13 +18,7 @@ func (s *Swarm) MeasurePhaseConvergence(targetPhase float64) float64 {
 	totalDistance := 0.0
 	for _, agent := range agents {
 		phase := agent.Phase()
-		// Calculate circular distance
-		diff := math.Abs(phase - targetPhase)
-		// Handle wraparound
-		if diff > math.Pi {
-			diff = 2*math.Pi - diff
-		}
-		totalDistance += diff
+		totalDistance += math.Abs(core.PhaseDifference(phase, targetPhase))
 	}
 
 	avgDistance := totalDistance / float64(len(agents))
@@ -62,14 +58,7 @@ func (s *Swarm) MeasurePhaseVariance() float64 {
 	variance := 0.0
 	for _, agent := range agents {
 		phase := agent.Phase()
-		diff := phase - meanPhase
-		// Normalize to [-π, π]
-		for diff > math.Pi {
-			diff -= 2 * math.Pi
-		}
-		for diff < -math.Pi {
-			diff += 2 * math.Pi
-		}
+		diff := core.PhaseDifference(phase, meanPhase)
 		variance += diff * diff
 	}
 
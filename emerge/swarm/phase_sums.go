@@ -0,0 +1,126 @@
+package swarm
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+)
+
+// Running phase sum tuning. Each cos θ and sin θ is held in fixed point,
+// scaled by phaseSumsScale, so the sums are exact integer arithmetic: they
+// come out the same whatever order updates land in, e.g. from parallel
+// workers, and do not drift. That keeps an error of at most 2⁻⁴¹ per agent
+// and room for 2²² agents. The sums are still rebuilt from scratch every
+// phaseSumsResync updates, as a safeguard against an update lost to a
+// concurrent write of the same agent's phase.
+const (
+	phaseSumsScale  = 1 << 40
+	phaseSumsResync = 1 << 16
+)
+
+// phaseSums keeps running sums of cos θ and sin θ over the swarm's agents,
+// so the order parameter is available in O(1). Agents report each phase
+// change (see agent.Agent.WatchPhase) and the change is folded into the
+// sums. The sums are rebuilt when membership changes and after
+// phaseSumsResync updates. An agent shared with another swarm reports to
+// both.
+type phaseSums struct {
+	mu       sync.RWMutex // Held for reading by updates, for writing by rebuilds
+	cos, sin atomic.Int64 // Fixed point, see phaseSumsScale
+	n        int
+	watching map[*agent.Agent]func() // Watched agents and how to stop watching
+	updates  atomic.Int64            // Updates since the last rebuild
+	gen      atomic.Uint64           // Bumped by every membership change
+	built    atomic.Uint64           // gen+1 as of the last rebuild; 0 before the first
+}
+
+// stale reports whether the sums need a rebuild before they can be used.
+func (p *phaseSums) stale() bool {
+	return p.built.Load() != p.gen.Load()+1
+}
+
+// fixedPoint returns x in the sums' fixed point.
+func fixedPoint(x float64) int64 {
+	return int64(math.Round(x * phaseSumsScale))
+}
+
+// orderParameter returns the Kuramoto order parameter r of the swarm's
+// current members, listed by agents, from the running sums, rebuilding
+// them first if needed.
+func (p *phaseSums) orderParameter(agents func() []*agent.Agent) float64 {
+	if p.stale() || p.updates.Load() >= phaseSumsResync {
+		gen := p.gen.Load()
+		p.rebuild(agents(), gen)
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	switch p.n {
+	case 0:
+		return 0
+	case 1:
+		return 1 // Exactly, not up to fixed-point rounding
+	}
+	c, s := float64(p.cos.Load()), float64(p.sin.Load())
+	return math.Hypot(c, s) / phaseSumsScale / float64(p.n)
+}
+
+// rebuild recomputes the sums over agents, the members as of membership
+// generation gen, watches their phases and stops watching agents that are
+// no longer members. A membership change since leaves the sums stale.
+func (p *phaseSums) rebuild(agents []*agent.Agent, gen uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	members := make(map[*agent.Agent]func(), len(agents))
+	var c, s int64
+	for _, a := range agents {
+		unwatch, ok := p.watching[a]
+		if !ok {
+			unwatch = a.WatchPhase(p.update)
+		}
+		members[a] = unwatch
+		phase := a.Phase()
+		c += fixedPoint(math.Cos(phase))
+		s += fixedPoint(math.Sin(phase))
+	}
+	for a, unwatch := range p.watching {
+		if _, ok := members[a]; !ok {
+			unwatch()
+		}
+	}
+	p.watching = members
+	p.cos.Store(c)
+	p.sin.Store(s)
+	p.n = len(agents)
+	p.updates.Store(0)
+	p.built.Store(gen + 1)
+}
+
+// update folds one agent's phase change into the sums.
+func (p *phaseSums) update(oldPhase, newPhase float64) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.stale() {
+		return // Rebuilt on the next read
+	}
+	p.cos.Add(fixedPoint(math.Cos(newPhase)) - fixedPoint(math.Cos(oldPhase)))
+	p.sin.Add(fixedPoint(math.Sin(newPhase)) - fixedPoint(math.Sin(oldPhase)))
+	p.updates.Add(1)
+}
+
+// invalidate makes the next read rebuild the sums, after membership
+// changes.
+func (p *phaseSums) invalidate() {
+	p.gen.Add(1)
+}
+
+// unwatch stops watching a removed agent's phase.
+func (p *phaseSums) unwatch(a *agent.Agent) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if unwatch, ok := p.watching[a]; ok {
+		unwatch()
+		delete(p.watching, a)
+	}
+}
@@ -0,0 +1,94 @@
+package swarm_test
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+// TestIncrementalCoherence checks the running coherence against a full
+// recompute as phases change by every route, membership changes, and
+// enough updates pass to force periodic rebuilds.
+func TestIncrementalCoherence(t *testing.T) {
+	t.Parallel()
+
+	for _, size := range []int{40, 150} { // Standard and optimized storage
+		s, err := swarm.New(size, core.State{Frequency: 200 * time.Millisecond, Coherence: 0.9},
+			swarm.WithSeed(8), swarm.WithParallelism(4))
+		require.NoError(t, err)
+		defer s.Close()
+
+		full := func() float64 {
+			agents := s.AgentsSorted()
+			phases := make([]float64, len(agents))
+			for i, a := range agents {
+				phases[i] = a.Phase()
+			}
+			return core.MeasureCoherence(phases)
+		}
+		check := func(what string) {
+			t.Helper()
+			assert.InDelta(t, full(), s.MeasureCoherence(), 1e-9, "size %d: %s", size, what)
+		}
+		check("new")
+
+		// Ticks of the goal-directed loop, on parallel workers
+		for range 20 {
+			s.Step()
+			check("step")
+		}
+
+		// Direct phase changes and applied actions
+		rng := rand.New(rand.NewPCG(1, 2))
+		agents := s.AgentsSorted()
+		for range 100_000 {
+			a := agents[rng.IntN(len(agents))]
+			if rng.IntN(2) == 0 {
+				a.SetPhase(rng.Float64() * 10)
+			} else {
+				_, _, _ = a.ApplyAction(core.Action{Type: "adjust_phase", Value: rng.NormFloat64()})
+			}
+		}
+		check("after many updates")
+
+		// Membership changes
+		joined, err := s.AddAgent(swarm.AgentConfig{Phase: 1})
+		require.NoError(t, err)
+		check("join")
+		joined.SetPhase(2)
+		check("joined agent moves")
+		require.NoError(t, s.RemoveAgent(agents[0].ID))
+		check("leave")
+		agents[0].SetPhase(agents[0].Phase() + 1)
+		check("departed agent moves")
+	}
+}
+
+// TestIncrementalCoherenceSharedAgents checks that swarms sharing agents
+// each keep their running coherence as the shared agents move.
+func TestIncrementalCoherenceSharedAgents(t *testing.T) {
+	t.Parallel()
+
+	goal := core.State{Frequency: 200 * time.Millisecond, Coherence: 0.9}
+	s1, err := swarm.New(20, goal, swarm.WithSeed(3))
+	require.NoError(t, err)
+	defer s1.Close()
+	s1.MeasureCoherence() // Watch the agents before the second swarm does
+
+	s2, err := swarm.FromAgents(s1.AgentsSorted(), goal)
+	require.NoError(t, err)
+	defer s2.Close()
+	s2.MeasureCoherence()
+
+	for _, a := range s1.AgentsSorted() {
+		a.SetPhase(1)
+	}
+	assert.InDelta(t, 1, s1.MeasureCoherence(), 1e-9)
+	assert.InDelta(t, 1, s2.MeasureCoherence(), 1e-9)
+}
@@ -0,0 +1,124 @@
+package swarm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// PlateauError reports that Run stopped early because coherence stopped
+// improving. It matches ErrPlateau with errors.Is; use errors.As to read the
+// best coherence reached and decide whether it is good enough.
+type PlateauError struct {
+	Best    float64 // Highest coherence seen during the run
+	Last    float64 // Coherence when the plateau was detected
+	Target  float64 // Coherence the run was aiming for
+	Samples int     // Number of samples in the plateau window
+}
+
+// Error implements error.
+func (e *PlateauError) Error() string {
+	return fmt.Sprintf("%v: coherence %.3f (best %.3f, target %.3f) over %d samples",
+		ErrPlateau, e.Last, e.Best, e.Target, e.Samples)
+}
+
+// Unwrap returns ErrPlateau.
+func (*PlateauError) Unwrap() error {
+	return ErrPlateau
+}
+
+// WithPlateauDetection makes Run give up with a *PlateauError once
+// coherence has varied by less than epsilon across the last window samples
+// without reaching the target. Samples are taken once per update interval.
+// Detection is off by default. RunContinuous treats a plateau like a
+// finished synchronization and keeps monitoring.
+func WithPlateauDetection(window int, epsilon float64) Option {
+	return func(s *Swarm) error {
+		if window < 2 {
+			return fmt.Errorf("plateau window must be at least 2, got %d", window)
+		}
+		if epsilon <= 0 || math.IsNaN(epsilon) {
+			return fmt.Errorf("plateau epsilon must be positive, got %v", epsilon)
+		}
+		s.plateauWindow = window
+		s.plateauEpsilon = epsilon
+		return nil
+	}
+}
+
+// plateauDetector tracks the most recent coherence samples of one run.
+type plateauDetector struct {
+	window  int
+	epsilon float64
+	samples []float64 // Ring buffer of the last window samples
+	next    int
+	best    float64
+}
+
+// newPlateauDetector returns nil when detection is disabled.
+func newPlateauDetector(window int, epsilon float64) *plateauDetector {
+	if window == 0 {
+		return nil
+	}
+	return &plateauDetector{
+		window:  window,
+		epsilon: epsilon,
+		samples: make([]float64, 0, window),
+	}
+}
+
+// record adds a sample and reports whether the window has gone flat.
+func (p *plateauDetector) record(coherence float64) bool {
+	if p == nil {
+		return false
+	}
+	p.best = math.Max(p.best, coherence)
+
+	if len(p.samples) < p.window {
+		p.samples = append(p.samples, coherence)
+	} else {
+		p.samples[p.next] = coherence
+		p.next = (p.next + 1) % p.window
+	}
+	if len(p.samples) < p.window {
+		return false
+	}
+
+	lo, hi := p.samples[0], p.samples[0]
+	for _, c := range p.samples[1:] {
+		lo, hi = math.Min(lo, c), math.Max(hi, c)
+	}
+	return hi-lo < p.epsilon
+}
+
+// floor returns the lowest coherence in the window, the level the swarm
+// has sustained throughout it.
+func (p *plateauDetector) floor() float64 {
+	if p == nil || len(p.samples) == 0 {
+		return 0
+	}
+	lo := p.samples[0]
+	for _, c := range p.samples[1:] {
+		lo = math.Min(lo, c)
+	}
+	return lo
+}
+
+// reset clears the window so the next plateau is judged afresh.
+func (p *plateauDetector) reset() {
+	if p == nil {
+		return
+	}
+	p.samples = p.samples[:0]
+	p.next = 0
+}
+
+// err builds the error returned when the run stops on a plateau.
+func (p *plateauDetector) err(last, target float64) error {
+	return &PlateauError{Best: p.best, Last: last, Target: target, Samples: p.window}
+}
+
+// isPlateau reports whether err is a plateau stop.
+func isPlateau(err error) bool {
+	return errors.Is(err, ErrPlateau)
+}
@@ -0,0 +1,52 @@
+package swarm_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+func TestPlateauDetectionStopsRun(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		// An epsilon wider than coherence's whole range flags the first full window
+		s, err := swarm.New(20, core.State{
+			Phase:     0,
+			Frequency: 200 * time.Millisecond,
+			Coherence: 0.9,
+		}, swarm.WithSeed(3), swarm.WithPlateauDetection(2, 1.5))
+		require.NoError(t, err)
+		defer s.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		err = s.Run(ctx)
+
+		require.ErrorIs(t, err, swarm.ErrPlateau)
+		var plateau *swarm.PlateauError
+		require.True(t, errors.As(err, &plateau))
+		assert.Equal(t, 2, plateau.Samples)
+		assert.GreaterOrEqual(t, plateau.Best, plateau.Last)
+		assert.InDelta(t, s.TargetState().Coherence, plateau.Target, 1e-9)
+	})
+}
+
+func TestWithPlateauDetectionValidation(t *testing.T) {
+	t.Parallel()
+
+	goal := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8}
+
+	_, err := swarm.New(10, goal, swarm.WithPlateauDetection(1, 0.01))
+	require.Error(t, err)
+	_, err = swarm.New(10, goal, swarm.WithPlateauDetection(10, 0))
+	require.Error(t, err)
+}
@@ -0,0 +1,105 @@
+package swarm
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+// pulseReceiver is implemented by strategies that react to neighbors
+// firing, such as strategy.PulseCoupling.
+type pulseReceiver interface {
+	Kick(phase, firing float64, period time.Duration) float64
+}
+
+// pulseCoupled reports whether every agent pulse-couples. Such a swarm
+// relies on pulses alone rather than being pulled toward the target.
+func pulseCoupled(agents []*agent.Agent) bool {
+	if len(agents) == 0 {
+		return false
+	}
+	for _, a := range agents {
+		if _, ok := a.Strategy().(pulseReceiver); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// applyPulseCoupling plays out one update interval of firing. Phases are
+// relative to a clock that turns once per target period, and an agent
+// fires when the clock brings its phase around to zero. Every neighbor
+// whose strategy pulse-couples reacts to the firing; neighbors pushed
+// past the threshold fire at once, and their pulses cascade. Each agent
+// fires at most once per interval.
+func (gds *GoalDirectedSync) applyPulseCoupling(agents []*agent.Agent) {
+	period := gds.swarm.target().Frequency
+	if period <= 0 {
+		return
+	}
+	window := math.Min(2*math.Pi, 2*math.Pi*float64(gds.config.Strategy.UpdateInterval)/float64(period))
+	clock := gds.pulseClock
+
+	fired := make(map[*agent.Agent]bool, len(agents))
+	for {
+		// The next agent to fire this interval, if any
+		var next *agent.Agent
+		soonest := window
+		for _, a := range agents {
+			if fired[a] {
+				continue
+			}
+			if wait := core.WrapPhase(-a.Phase() - clock); wait < soonest {
+				next, soonest = a, wait
+			}
+		}
+		if next == nil {
+			break
+		}
+		gds.firePulse(next, fired)
+	}
+
+	gds.pulseClock = core.WrapPhase(clock + window)
+}
+
+// firePulse fires an agent and the cascade of neighbors it absorbs.
+func (gds *GoalDirectedSync) firePulse(first *agent.Agent, fired map[*agent.Agent]bool) {
+	fired[first] = true
+	queue := []*agent.Agent{first}
+	for len(queue) > 0 {
+		firer := queue[0]
+		queue = queue[1:]
+		firing := firer.Phase()
+		for _, n := range firer.NeighborList() {
+			receiver, ok := n.Strategy().(pulseReceiver)
+			if !ok || fired[n] {
+				continue
+			}
+			phase := n.Phase()
+			next := receiver.Kick(phase, firing, n.Frequency())
+			if next == phase {
+				continue
+			}
+			next, ok = gds.swarm.spend(n, phase, next)
+			if !ok {
+				continue
+			}
+			n.SetPhase(next)
+			if next == firing { // Absorbed: fires along with the firer
+				fired[n] = true
+				queue = append(queue, n)
+			}
+		}
+	}
+}
+
+// pulseCoupledAny reports whether any agent pulse-couples.
+func pulseCoupledAny(agents []*agent.Agent) bool {
+	return slices.ContainsFunc(agents, func(a *agent.Agent) bool {
+		_, ok := a.Strategy().(pulseReceiver)
+		return ok
+	})
+}
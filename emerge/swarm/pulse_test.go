@@ -0,0 +1,96 @@
+package swarm_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/strategy"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+// ringTopology connects every agent to the next and previous agent in
+// sorted order.
+func ringTopology(s *swarm.Swarm) error {
+	agents := s.AgentsSorted()
+	for i, a := range agents {
+		next := agents[(i+1)%len(agents)]
+		a.ConnectTo(next.ID, next)
+		next.ConnectTo(a.ID, a)
+	}
+	return nil
+}
+
+// twist spreads the agents' phases once around the circle in ring order,
+// a state in which every agent sits exactly between its two neighbors.
+func twist(agents []*agent.Agent) {
+	for i, a := range agents {
+		a.SetPhase(2 * math.Pi * float64(i) / float64(len(agents)))
+	}
+}
+
+// noLinks leaves every agent without neighbors.
+func noLinks(*swarm.Swarm) error {
+	return nil
+}
+
+// TestPulseCouplingRing untwists a ring with each strategy at the default
+// coupling. Phase nudging pulls every agent toward the target and needs
+// no links; pulse coupling works through the ring's links alone, so
+// without them it cannot synchronize.
+func TestPulseCouplingRing(t *testing.T) {
+	t.Parallel()
+
+	const size = 12
+	goalState := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.9}
+	run := func(t *testing.T, name string, topology func(*swarm.Swarm) error) (float64, error) {
+		t.Helper()
+		s, err := swarm.New(size, goalState, swarm.WithStrategy(name),
+			swarm.WithTopology(topology), swarm.WithSeed(1))
+		require.NoError(t, err)
+		defer s.Close()
+		twist(s.AgentsSorted())
+		require.Less(t, s.MeasureCoherence(), 0.1)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		err = s.Run(ctx)
+		return s.MeasureCoherence(), err
+	}
+
+	for _, name := range []string{strategy.NamePhaseNudge, strategy.NamePulse} {
+		t.Run(name+" synchronizes the ring", func(t *testing.T) {
+			t.Parallel()
+			synctest.Test(t, func(t *testing.T) {
+				coherence, err := run(t, name, ringTopology)
+				require.NoError(t, err)
+				assert.GreaterOrEqual(t, coherence, goalState.Coherence)
+			})
+		})
+	}
+
+	t.Run("phase nudging needs no links", func(t *testing.T) {
+		t.Parallel()
+		synctest.Test(t, func(t *testing.T) {
+			coherence, err := run(t, strategy.NamePhaseNudge, noLinks)
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, coherence, goalState.Coherence)
+		})
+	})
+
+	t.Run("pulse coupling needs links", func(t *testing.T) {
+		t.Parallel()
+		synctest.Test(t, func(t *testing.T) {
+			coherence, err := run(t, strategy.NamePulse, noLinks)
+			require.ErrorIs(t, err, context.DeadlineExceeded)
+			assert.Less(t, coherence, 0.1, "no agent hears another fire")
+		})
+	})
+}
@@ -0,0 +1,106 @@
+package swarm
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// liveSettings are the settings Reconfigure can change. Nil fields are
+// left alone; a zero Noise or Fanout turns that setting off.
+type liveSettings struct {
+	Coupling *float64 `json:"coupling,omitempty"`
+	Noise    *float64 `json:"noise,omitempty"`
+	Fanout   *int     `json:"fanout,omitempty"`
+}
+
+// options returns the options that set ls.
+func (ls liveSettings) options() []Option {
+	var opts []Option
+	if ls.Coupling != nil {
+		opts = append(opts, WithCouplingStrength(*ls.Coupling))
+	}
+	switch {
+	case ls.Noise == nil:
+	case *ls.Noise > 0:
+		opts = append(opts, WithPhaseNoise(*ls.Noise))
+	default:
+		opts = append(opts, WithoutPhaseNoise())
+	}
+	switch {
+	case ls.Fanout == nil:
+	case *ls.Fanout > 0:
+		opts = append(opts, WithGossipFanout(*ls.Fanout))
+	default:
+		opts = append(opts, WithoutGossipFanout())
+	}
+	return opts
+}
+
+// Settings on a probe swarm that no option has touched. Neither is a value
+// an option can set, so a probe tells "set to 0" apart from "left alone".
+var unsetFloat = math.Float64bits(math.NaN())
+
+const unsetFanout = -1
+
+// Reconfigure changes settings of a built swarm, even while it runs:
+// WithCouplingStrength, WithPhaseNoise and WithGossipFanout, and
+// WithoutPhaseNoise and WithoutGossipFanout to turn the latter two off
+// again. Ticks from
+// the next one on use the new values, so an operator can raise coupling to
+// force convergence, or add noise to stress a swarm, without recreating it.
+//
+// Every option is checked before any is applied, so on error the swarm is
+// unchanged. Options that set anything else, such as WithStorage or
+// WithInitialPhases, only take effect when a swarm is built; passing one
+// fails with ErrNotReconfigurable.
+func (s *Swarm) Reconfigure(opts ...Option) error {
+	var live liveSettings
+	for i, opt := range opts {
+		// Apply the option to a bare swarm and see what it set
+		probe := s.probe()
+		if err := opt(probe); err != nil {
+			return fmt.Errorf("reconfigure: option %d: %w", i, err)
+		}
+		if bits := probe.couplingStrength.Swap(unsetFloat); bits != unsetFloat {
+			k := math.Float64frombits(bits)
+			live.Coupling = &k
+		}
+		if bits := probe.phaseNoise.Swap(unsetFloat); bits != unsetFloat {
+			noise := math.Float64frombits(bits)
+			live.Noise = &noise
+		}
+		if k := probe.gossipFanout.Swap(unsetFanout); k != unsetFanout {
+			fanout := int(k)
+			live.Fanout = &fanout
+		}
+		if !reflect.DeepEqual(probe, s.probe()) {
+			return fmt.Errorf("reconfigure: option %d: %w", i, ErrNotReconfigurable)
+		}
+	}
+
+	if live.Coupling != nil {
+		s.couplingStrength.Store(math.Float64bits(*live.Coupling))
+	}
+	if live.Noise != nil {
+		s.phaseNoise.Store(math.Float64bits(*live.Noise))
+	}
+	if live.Fanout != nil {
+		s.gossipFanout.Store(int64(*live.Fanout))
+		s.applyGossipFanout()
+	}
+	if live != (liveSettings{}) {
+		s.logEvent(logEntry{Kind: logReconfigure, Live: &live})
+	}
+	return nil
+}
+
+// probe returns a bare swarm with s's size, target and config, and its
+// live settings unset, for Reconfigure to apply options to.
+func (s *Swarm) probe() *Swarm {
+	p := &Swarm{size: s.Size(), goalState: s.target(), config: s.config}
+	p.couplingStrength.Store(unsetFloat)
+	p.phaseNoise.Store(unsetFloat)
+	p.gossipFanout.Store(unsetFanout)
+	return p
+}
@@ -0,0 +1,127 @@
+package swarm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/goal"
+	"github.com/carlisia/bio-adapt/emerge/scale"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+	"github.com/carlisia/bio-adapt/emerge/swarm/clocktest"
+)
+
+// TestReconfigureCoupling runs two identical weakly coupled swarms and
+// raises the coupling of one partway: from then on it converges faster.
+func TestReconfigureCoupling(t *testing.T) {
+	t.Parallel()
+
+	build := func() (*swarm.Swarm, *clocktest.Clock) {
+		clock := clocktest.New(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+		s, err := swarm.New(30, core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.95},
+			swarm.WithSeed(7), swarm.WithClock(clock), swarm.WithCouplingStrength(0.05))
+		require.NoError(t, err)
+		t.Cleanup(s.Close)
+		return s, clock
+	}
+	steps := func(s *swarm.Swarm, clock *clocktest.Clock, n int) float64 {
+		var coherence float64
+		for range n {
+			clock.Advance(s.TickInterval())
+			coherence = s.Step()
+		}
+		return coherence
+	}
+
+	weak, weakClock := build()
+	tuned, tunedClock := build()
+	require.Equal(t, steps(weak, weakClock, 5), steps(tuned, tunedClock, 5))
+
+	require.NoError(t, tuned.Reconfigure(swarm.WithCouplingStrength(1)))
+	assert.InDelta(t, 1.0, tuned.CouplingStrength(), 0)
+	assert.Greater(t, steps(tuned, tunedClock, 10), steps(weak, weakClock, 10)+0.2)
+}
+
+func TestReconfigure(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8}
+	s, err := swarm.New(20, goalState, swarm.WithPhaseNoise(0.1))
+	require.NoError(t, err)
+	defer s.Close()
+
+	require.NoError(t, s.Reconfigure(swarm.WithPhaseNoise(0.3), swarm.WithGossipFanout(2)))
+	assert.InDelta(t, 0.3, s.PhaseNoise(), 0)
+	assert.Equal(t, 2, s.GossipFanout())
+	for _, a := range s.AgentsSorted() {
+		assert.Equal(t, 2, a.GossipFanout(), a.ID)
+	}
+
+	// Nothing changes unless every option can be applied
+	for _, opt := range []swarm.Option{
+		swarm.WithStorage(swarm.StorageSlice),
+		swarm.WithInitialPhases(make([]float64, 20)),
+		swarm.WithSeed(1),
+	} {
+		err = s.Reconfigure(swarm.WithCouplingStrength(2), opt)
+		require.ErrorIs(t, err, swarm.ErrNotReconfigurable)
+	}
+	require.Error(t, s.Reconfigure(swarm.WithPhaseNoise(-1)))
+	assert.Zero(t, s.CouplingStrength())
+	assert.InDelta(t, 0.3, s.PhaseNoise(), 0)
+}
+
+// TestAccessorsAfterRetuning checks that the accessors report what a swarm
+// runs with after SetTarget and Reconfigure: the new target, clamped to
+// what the swarm can reach, with the goal, scale and config it was built
+// with.
+func TestAccessorsAfterRetuning(t *testing.T) {
+	t.Parallel()
+
+	s, err := swarm.New(5, core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.5},
+		swarm.WithGoal(goal.ReachConsensus))
+	require.NoError(t, err)
+	defer s.Close()
+
+	// 5 agents have a practical limit well below 0.99
+	require.NoError(t, s.SetTarget(core.State{Phase: 1, Frequency: 200 * time.Millisecond, Coherence: 0.99}))
+	require.NoError(t, s.Reconfigure(swarm.WithCouplingStrength(1)))
+
+	target := s.TargetState()
+	assert.InDelta(t, swarm.GetCoherenceLimits(5).Practical, target.Coherence, 1e-9, "the target is clamped")
+	assert.InDelta(t, 1.0, target.Phase, 1e-9)
+	assert.Equal(t, 200*time.Millisecond, target.Frequency)
+	assert.Equal(t, goal.ReachConsensus, s.Goal())
+	assert.Equal(t, scale.Tiny, s.Scale())
+	assert.Equal(t, *swarm.For(goal.ReachConsensus), s.EffectiveConfig())
+	assert.InDelta(t, 1.0, s.CouplingStrength(), 0)
+}
+
+// TestReconfigureOff turns phase noise and gossip fanout on in a running
+// swarm and then off again.
+func TestReconfigureOff(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8}
+	s, err := swarm.New(20, goalState, swarm.WithCouplingStrength(0.5))
+	require.NoError(t, err)
+	defer s.Close()
+
+	require.NoError(t, s.Reconfigure(swarm.WithPhaseNoise(0.3), swarm.WithGossipFanout(2)))
+	assert.InDelta(t, 0.3, s.PhaseNoise(), 0)
+	assert.Equal(t, 2, s.GossipFanout())
+
+	require.NoError(t, s.Reconfigure(swarm.WithoutPhaseNoise()))
+	assert.Zero(t, s.PhaseNoise())
+	assert.Equal(t, 2, s.GossipFanout(), "fanout is left alone")
+
+	require.NoError(t, s.Reconfigure(swarm.WithoutGossipFanout()))
+	assert.Zero(t, s.GossipFanout())
+	for _, a := range s.AgentsSorted() {
+		assert.Zero(t, a.GossipFanout(), a.ID)
+	}
+	assert.InDelta(t, 0.5, s.CouplingStrength(), 0, "coupling is left alone")
+}
@@ -0,0 +1,158 @@
+package swarm
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+)
+
+// recoveredRatio is the share of its pre-disruption coherence a swarm has
+// to regain to count as recovered.
+const recoveredRatio = 0.9
+
+// RecoveryReport describes how the swarm fared after a disruption.
+type RecoveryReport struct {
+	Kind         DisruptionKind
+	DisruptedAt  time.Time
+	PreCoherence float64       // Coherence just before the disruption
+	MinCoherence float64       // Lowest coherence seen since, the bottom of the dip
+	Recovered    bool          // Coherence is back to 90% of PreCoherence
+	RecoveredAt  time.Time     // When it got back; zero until recovered
+	RecoveryTime time.Duration // From the disruption to recovery; zero until recovered
+}
+
+// Dip returns how far coherence fell below its pre-disruption level.
+func (r RecoveryReport) Dip() float64 {
+	return math.Max(0, r.PreCoherence-r.MinCoherence)
+}
+
+// RecoveryEventType identifies a step in recovering from a disruption.
+type RecoveryEventType int
+
+// Recovery event types.
+const (
+	// RecoveryStarted fires after a disruption, with the coherence it left.
+	RecoveryStarted RecoveryEventType = iota + 1
+	// RecoveryComplete fires once coherence is back to 90% of its
+	// pre-disruption level.
+	RecoveryComplete
+)
+
+// String returns the event type name.
+func (t RecoveryEventType) String() string {
+	switch t {
+	case RecoveryStarted:
+		return "started"
+	case RecoveryComplete:
+		return "complete"
+	default:
+		return "unknown"
+	}
+}
+
+// RecoveryEvent reports a step in recovering from a disruption.
+type RecoveryEvent struct {
+	Type   RecoveryEventType
+	Report RecoveryReport
+}
+
+// WithDisruptionObserver registers a function called when a disruption
+// hits the swarm and again when the swarm has recovered from it. Recovery
+// is judged on the coherence Run and RunContinuous measure, so a swarm
+// that is not running does not recover unless the disruption left it
+// above the recovery level. Calls run synchronously: the first on the
+// goroutine that called Disrupt or DisruptAgents, the second on the
+// synchronization loop's, so the function should return quickly.
+func WithDisruptionObserver(fn func(RecoveryEvent)) Option {
+	return func(s *Swarm) error {
+		if fn == nil {
+			return errors.New("disruption observer must not be nil")
+		}
+		s.disruptionObservers = append(s.disruptionObservers, fn)
+		return nil
+	}
+}
+
+// LastRecovery reports on the most recent disruption. Until it has
+// recovered, Recovered is false and MinCoherence keeps tracking the dip.
+// It returns the zero report if the swarm was never disrupted.
+func (s *Swarm) LastRecovery() RecoveryReport {
+	return s.recovery.last()
+}
+
+// recoveryTracker follows the swarm's coherence after a disruption.
+// The zero value is ready to use.
+type recoveryTracker struct {
+	mu         sync.Mutex
+	report     RecoveryReport
+	recovering bool
+}
+
+func (t *recoveryTracker) last() RecoveryReport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.report
+}
+
+// start begins a report on a disruption. A disruption that hits while
+// the swarm is still recovering from an earlier one keeps the earlier
+// pre-disruption level, so recovery is measured against a healthy swarm.
+func (t *recoveryTracker) start(kind DisruptionKind, pre, post float64, now time.Time) RecoveryReport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.recovering {
+		pre = t.report.PreCoherence
+	}
+	t.report = RecoveryReport{
+		Kind:         kind,
+		DisruptedAt:  now,
+		PreCoherence: pre,
+		MinCoherence: post,
+	}
+	t.recovering = true
+	return t.report
+}
+
+// observe records a coherence measurement and reports whether it
+// completed a recovery.
+func (t *recoveryTracker) observe(coherence float64, now time.Time) (RecoveryReport, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.recovering {
+		return RecoveryReport{}, false
+	}
+	t.report.MinCoherence = math.Min(t.report.MinCoherence, coherence)
+	if coherence < recoveredRatio*t.report.PreCoherence {
+		return RecoveryReport{}, false
+	}
+	t.report.Recovered = true
+	t.report.RecoveredAt = now
+	t.report.RecoveryTime = now.Sub(t.report.DisruptedAt)
+	t.recovering = false
+	return t.report, true
+}
+
+// startRecovery begins tracking recovery from a disruption that took
+// coherence from pre to post.
+func (s *Swarm) startRecovery(kind DisruptionKind, pre, post float64) {
+	s.notifyRecovery(RecoveryStarted, s.recovery.start(kind, pre, post, s.now()))
+	s.observeRecovery(post)
+}
+
+// observeRecovery feeds a coherence measurement to the recovery tracker.
+func (s *Swarm) observeRecovery(coherence float64) {
+	if report, done := s.recovery.observe(coherence, s.now()); done {
+		s.recoveries.Add(1)
+		s.notifyRecovery(RecoveryComplete, report)
+	}
+}
+
+// notifyRecovery calls the disruption observers.
+func (s *Swarm) notifyRecovery(t RecoveryEventType, report RecoveryReport) {
+	for _, fn := range s.disruptionObservers {
+		fn(RecoveryEvent{Type: t, Report: report})
+	}
+}
@@ -0,0 +1,80 @@
+package swarm_test
+
+import (
+	"context"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+func TestLastRecovery(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		goalState := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.85}
+		var events []swarm.RecoveryEvent
+		s, err := swarm.New(30, goalState, swarm.WithSeed(11),
+			swarm.WithDisruptionObserver(func(e swarm.RecoveryEvent) {
+				events = append(events, e)
+			}))
+		require.NoError(t, err)
+		defer s.Close()
+		assert.Equal(t, swarm.RecoveryReport{}, s.LastRecovery(), "no disruption yet")
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		require.NoError(t, s.Run(ctx))
+		pre := s.MeasureCoherence()
+
+		s.DisruptAgents(0.6)
+		dipped := s.MeasureCoherence()
+		require.Less(t, dipped, 0.9*pre, "the disruption should break synchronization")
+
+		report := s.LastRecovery()
+		assert.False(t, report.Recovered)
+		assert.Equal(t, swarm.PhaseScramble, report.Kind)
+		assert.InDelta(t, pre, report.PreCoherence, 1e-9)
+		assert.InDelta(t, dipped, report.MinCoherence, 1e-9)
+		require.Len(t, events, 1)
+		assert.Equal(t, swarm.RecoveryStarted, events[0].Type)
+
+		time.Sleep(time.Second)
+		require.NoError(t, s.Run(ctx))
+
+		report = s.LastRecovery()
+		assert.True(t, report.Recovered)
+		assert.GreaterOrEqual(t, report.RecoveryTime, time.Second)
+		assert.Equal(t, report.RecoveredAt.Sub(report.DisruptedAt), report.RecoveryTime)
+		assert.InDelta(t, pre-dipped, report.Dip(), 1e-9)
+		require.Len(t, events, 2)
+		assert.Equal(t, swarm.RecoveryComplete, events[1].Type)
+		assert.Equal(t, report, events[1].Report)
+	})
+}
+
+func TestLastRecoveryMildDisruption(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.85}
+	s, err := swarm.New(10, goalState, swarm.WithSeed(2))
+	require.NoError(t, err)
+	defer s.Close()
+	for _, a := range s.Agents() {
+		a.SetPhase(0)
+	}
+
+	// Draining energy leaves phases alone, so the swarm never drops below
+	// the recovery level
+	_, err = s.Disrupt(swarm.DisruptionSpec{Kind: swarm.EnergyDrain, Fraction: 0.5})
+	require.NoError(t, err)
+	report := s.LastRecovery()
+	assert.True(t, report.Recovered)
+	assert.Less(t, report.RecoveryTime, time.Millisecond, "recovered on the spot")
+	assert.Zero(t, report.Dip())
+}
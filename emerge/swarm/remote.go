@@ -0,0 +1,275 @@
+package swarm
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/carlisia/bio-adapt/emerge/transport"
+)
+
+// Remote gossip timing. Each endpoint is gossiped with on its own goroutine
+// every gossipInterval, and an exchange that takes longer than gossipTimeout
+// is dropped. A remote agent's influence halves every remoteHalfLife without
+// fresh news and it is forgotten after remoteExpiry.
+const (
+	gossipInterval     = 200 * time.Millisecond
+	gossipTimeout      = time.Second
+	remoteHalfLife     = time.Second
+	remoteExpiry       = 10 * time.Second
+	remoteCouplingGain = 0.1
+)
+
+// RemoteNeighbor is the last known state of an agent in another process.
+type RemoteNeighbor struct {
+	AgentID   string
+	Phase     float64
+	Frequency time.Duration
+	LastSeen  time.Time // When the state was received, by the swarm's clock
+	Influence float64   // Coupling weight in (0, 1], decaying with staleness
+}
+
+// WithRemoteNeighbors couples the swarm to agents in other processes.
+// While Run or RunContinuous is active, the swarm gossips with each
+// endpoint's transport.GossipServer in the background, pushing its own
+// agents' state (see GossipMessages) and receiving the remote agents'.
+//
+// Every local agent treats every remote agent as a neighbor: each tick of
+// the goal-directed loop pulls agent i by
+//
+//	K · Σ_j w_j·sin(θ_j − θ_i) / n
+//
+// over the n known remote agents, where K is the swarm's coupling (see
+// WithCouplingStrength) scaled by 0.1 and w_j is the remote agent's
+// influence. The pull is added to the agent's update like its local
+// neighbors' (see WithCouplingStrength), so it eases off near the target
+// and the agent's stubbornness, decision maker, cooldown and energy apply
+// to it. Influence starts at 1 and halves for every second without a
+// fresh message, so a peer whose messages are dropped fades out instead of
+// pinning the swarm to a stale phase; after 10 seconds it is forgotten.
+// Network I/O never runs on the loop itself: the loop reads the latest
+// known state, and a slow or unreachable peer only delays its own gossip.
+//
+// Agent IDs must be unique across processes, so give each swarm a
+// distinct WithID. A swarm that only serves, with peers gossiping to it
+// rather than the other way round, uses AttachGossipServer instead.
+func WithRemoteNeighbors(endpoints []transport.Endpoint) Option {
+	return func(s *Swarm) error {
+		if len(endpoints) == 0 {
+			return errors.New("remote neighbors: no endpoints")
+		}
+		for _, ep := range endpoints {
+			if strings.TrimSpace(ep.Address) == "" {
+				return errors.New("remote neighbors: endpoint address must not be empty")
+			}
+		}
+		s.remoteEndpoints = slices.Clone(endpoints)
+		return nil
+	}
+}
+
+// GossipMessages snapshots the state of the swarm's agents for remote
+// peers. Agent IDs are qualified with the swarm ID, e.g. "swarm-1/agent-3".
+// It is the usual source for a transport.GossipServer.
+func (s *Swarm) GossipMessages() []transport.PhaseMessage {
+	agents := s.collectAgents()
+	now := s.now()
+	msgs := make([]transport.PhaseMessage, len(agents))
+	for i, a := range agents {
+		msgs[i] = transport.PhaseMessage{
+			AgentID:   s.id + "/" + a.ID,
+			Phase:     a.Phase(),
+			Frequency: a.Frequency(),
+			SentAt:    now,
+		}
+	}
+	return msgs
+}
+
+// AttachGossipServer couples the swarm to the remote agents that push
+// their state to srv, the server publishing this swarm's GossipMessages.
+// Every tick reads srv's latest messages, so a process whose peers gossip
+// with it forms one swarm with them without gossiping back itself. Pushed
+// agents are coupled in like those from WithRemoteNeighbors: their
+// staleness runs from when the swarm first read their latest message, and
+// srv forgets them after transport.PeerExpiry. A nil srv detaches the
+// server.
+func (s *Swarm) AttachGossipServer(srv *transport.GossipServer) {
+	s.gossipServer.Store(srv)
+}
+
+// RemoteNeighbors returns the known remote agents, sorted by ID, with
+// their current influence. It is empty without WithRemoteNeighbors or
+// AttachGossipServer.
+func (s *Swarm) RemoteNeighbors() []RemoteNeighbor {
+	return s.remoteNeighbors(s.now())
+}
+
+// remoteNeighbors merges in the agents pushed to an attached gossip server
+// and returns the known remote agents at now.
+func (s *Swarm) remoteNeighbors(now time.Time) []RemoteNeighbor {
+	if srv := s.gossipServer.Load(); srv != nil {
+		s.remote.merge(srv.Peers(), s.id, now)
+	}
+	return s.remote.snapshot(now)
+}
+
+// remoteTable holds the latest state received for each remote agent.
+type remoteTable struct {
+	mu      sync.Mutex
+	entries map[string]RemoteNeighbor
+	sent    map[string]time.Time // SentAt of each agent's latest merged message
+}
+
+// update records messages received at now, replacing older state.
+func (t *remoteTable) update(msgs []transport.PhaseMessage, own string, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.entries == nil {
+		t.entries = make(map[string]RemoteNeighbor)
+	}
+	for _, m := range msgs {
+		// A peer may relay our own agents back; they are not remote
+		if strings.HasPrefix(m.AgentID, own+"/") {
+			continue
+		}
+		t.entries[m.AgentID] = RemoteNeighbor{
+			AgentID:   m.AgentID,
+			Phase:     m.Phase,
+			Frequency: m.Frequency,
+			LastSeen:  now,
+		}
+	}
+}
+
+// merge records messages pushed to a gossip server, dated by now, when
+// the swarm reads them. Another process's clock is never compared with
+// ours: SentAt only tells whether a message is newer than the last one
+// merged from the same agent, so reading the same messages again does not
+// refresh them.
+func (t *remoteTable) merge(msgs []transport.PhaseMessage, own string, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.entries == nil {
+		t.entries = make(map[string]RemoteNeighbor)
+	}
+	if t.sent == nil {
+		t.sent = make(map[string]time.Time)
+	}
+	pushed := make(map[string]bool, len(msgs))
+	for _, m := range msgs {
+		if strings.HasPrefix(m.AgentID, own+"/") {
+			continue
+		}
+		pushed[m.AgentID] = true
+		if sent, ok := t.sent[m.AgentID]; ok && !m.SentAt.After(sent) {
+			continue
+		}
+		t.sent[m.AgentID] = m.SentAt
+		t.entries[m.AgentID] = RemoteNeighbor{
+			AgentID:   m.AgentID,
+			Phase:     m.Phase,
+			Frequency: m.Frequency,
+			LastSeen:  now,
+		}
+	}
+	// Once the server forgets an agent, a later message from it is news
+	for id := range t.sent {
+		if !pushed[id] {
+			delete(t.sent, id)
+		}
+	}
+}
+
+// snapshot returns the live entries with their influence at now and
+// drops expired ones.
+func (t *remoteTable) snapshot(now time.Time) []RemoteNeighbor {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	out := make([]RemoteNeighbor, 0, len(t.entries))
+	for id, n := range t.entries {
+		age := now.Sub(n.LastSeen)
+		if age > remoteExpiry {
+			delete(t.entries, id)
+			continue
+		}
+		n.Influence = math.Exp2(-max(age, 0).Seconds() / remoteHalfLife.Seconds())
+		out = append(out, n)
+	}
+	slices.SortFunc(out, func(a, b RemoteNeighbor) int { return strings.Compare(a.AgentID, b.AgentID) })
+	return out
+}
+
+// startGossip begins gossiping with the remote endpoints until the returned
+// stop function is called. Stop waits for the gossip goroutines to exit and
+// closes their connections.
+func (s *Swarm) startGossip(ctx context.Context) (stop func()) {
+	if len(s.remoteEndpoints) == 0 {
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	for _, ep := range s.remoteEndpoints {
+		client, err := transport.NewGossipClient(ep)
+		if err != nil {
+			// Only malformed targets fail here; treat the peer as unreachable
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { _ = client.Close() }()
+
+			ticker := s.newTicker(gossipInterval)
+			defer ticker.Stop()
+
+			for {
+				s.gossipOnce(ctx, client)
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C():
+				}
+			}
+		}()
+	}
+
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+// gossipOnce exchanges state with one peer. Failures are dropped: the
+// peer's agents simply go stale until a later exchange succeeds.
+func (s *Swarm) gossipOnce(ctx context.Context, client *transport.GossipClient) {
+	ctx, cancel := context.WithTimeout(ctx, gossipTimeout)
+	defer cancel()
+
+	msgs, err := client.Exchange(ctx, s.GossipMessages())
+	if err != nil {
+		return
+	}
+	s.remote.update(msgs, s.id, s.now())
+}
+
+// remotePull returns how far the known remote agents pull an agent at
+// phase, weighted by their influence (see WithRemoteNeighbors).
+func (s *Swarm) remotePull(remote []RemoteNeighbor, phase float64) float64 {
+	if len(remote) == 0 {
+		return 0
+	}
+	pull := 0.0
+	for _, r := range remote {
+		pull += r.Influence * math.Sin(r.Phase-phase)
+	}
+	return s.coupling() * remoteCouplingGain * pull / float64(len(remote))
+}
@@ -0,0 +1,306 @@
+package swarm
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/transport"
+)
+
+func TestRemoteNeighborsGossip(t *testing.T) {
+	t.Parallel()
+
+	// A "remote process" with five agents held at phase π/2
+	srv, err := transport.NewGossipServer("127.0.0.1:0", func() []transport.PhaseMessage {
+		msgs := make([]transport.PhaseMessage, 5)
+		for i := range msgs {
+			msgs[i] = transport.PhaseMessage{
+				AgentID:   fmt.Sprintf("remote/agent-%d", i),
+				Phase:     math.Pi / 2,
+				Frequency: 100 * time.Millisecond,
+			}
+		}
+		return msgs
+	})
+	require.NoError(t, err)
+	served := make(chan error, 1)
+	go func() { served <- srv.Serve() }()
+	stopped := false
+	stop := func() {
+		if !stopped {
+			srv.Stop()
+			require.NoError(t, <-served)
+			stopped = true
+		}
+	}
+	defer stop()
+
+	s, err := New(20, core.State{
+		Phase:     0,
+		Frequency: 100 * time.Millisecond,
+		Coherence: 0.8,
+	}, WithID("local"), WithRemoteNeighbors([]transport.Endpoint{{Address: srv.Addr()}}))
+	require.NoError(t, err)
+	defer s.Close()
+	assert.Empty(t, s.RemoteNeighbors(), "gossip only runs while the swarm runs")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.RunContinuous(ctx) }()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	require.Eventually(t, func() bool { return len(s.RemoteNeighbors()) == 5 }, 5*time.Second, 20*time.Millisecond)
+	for _, n := range s.RemoteNeighbors() {
+		assert.InDelta(t, math.Pi/2, n.Phase, 1e-12)
+		assert.Equal(t, 100*time.Millisecond, n.Frequency)
+		assert.Greater(t, n.Influence, 0.5)
+	}
+	require.Eventually(t, func() bool { return len(srv.Peers()) == 20 }, 5*time.Second, 20*time.Millisecond,
+		"the remote side should receive the local agents")
+	assert.Contains(t, srv.Peers()[0].AgentID, "local/")
+
+	// Once messages stop arriving, the remote agents go stale
+	stop()
+	require.Eventually(t, func() bool {
+		neighbors := s.RemoteNeighbors()
+		return len(neighbors) == 5 && neighbors[0].Influence < 0.4
+	}, 5*time.Second, 50*time.Millisecond, "stale remote agents should decay in influence")
+}
+
+// TestAttachGossipServer runs a swarm that only serves: a remote process
+// pushes its agents to the swarm's server and never answers gossip itself.
+func TestAttachGossipServer(t *testing.T) {
+	t.Parallel()
+
+	s, err := New(10, core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8}, WithID("local"))
+	require.NoError(t, err)
+	defer s.Close()
+	for _, a := range s.Agents() {
+		a.SetPhase(0)
+		a.SetStubbornness(0)
+	}
+
+	srv, err := transport.NewGossipServer("127.0.0.1:0", s.GossipMessages)
+	require.NoError(t, err)
+	served := make(chan error, 1)
+	go func() { served <- srv.Serve() }()
+	defer func() {
+		srv.Stop()
+		require.NoError(t, <-served)
+	}()
+	s.AttachGossipServer(srv)
+
+	client, err := transport.NewGossipClient(transport.Endpoint{Address: srv.Addr()})
+	require.NoError(t, err)
+	defer client.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	got, err := client.Exchange(ctx, []transport.PhaseMessage{
+		{AgentID: "remote/fresh", Phase: math.Pi / 2, SentAt: now},
+		{AgentID: "remote/skewed", Phase: math.Pi / 2, SentAt: now.Add(-time.Hour)},
+		{AgentID: "local/agent-0", Phase: math.Pi / 2, SentAt: now},
+	})
+	require.NoError(t, err)
+	assert.Len(t, got, 10, "the server should answer with the swarm's agents")
+
+	// Staleness runs from receipt, so a sender whose clock lags is still fresh
+	neighbors := s.RemoteNeighbors()
+	require.Len(t, neighbors, 2, "relayed own agents are not remote")
+	assert.Equal(t, "remote/fresh", neighbors[0].AgentID)
+	assert.InDelta(t, 1, neighbors[0].Influence, 0.1)
+	assert.Equal(t, "remote/skewed", neighbors[1].AgentID)
+	assert.InDelta(t, 1, neighbors[1].Influence, 0.1)
+
+	pullRemote(s)
+	for _, a := range s.Agents() {
+		assert.Greater(t, a.Phase(), 0.0, "agents should move toward the pushed phase")
+	}
+
+	s.AttachGossipServer(nil)
+	_, err = client.Exchange(ctx, []transport.PhaseMessage{{AgentID: "remote/late", SentAt: time.Now()}})
+	require.NoError(t, err)
+	assert.Len(t, s.RemoteNeighbors(), 2, "a detached server is no longer read")
+}
+
+// TestRemotePullAndPush runs two swarms that each pull from the other's
+// gossip server while the other pushes to theirs, so every swarm's table
+// takes both pulled and pushed messages.
+func TestRemotePullAndPush(t *testing.T) {
+	t.Parallel()
+
+	goal := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8}
+	serve := func(s **Swarm) *transport.GossipServer {
+		srv, err := transport.NewGossipServer("127.0.0.1:0", func() []transport.PhaseMessage {
+			return (*s).GossipMessages()
+		})
+		require.NoError(t, err)
+		served := make(chan error, 1)
+		go func() { served <- srv.Serve() }()
+		t.Cleanup(func() {
+			srv.Stop()
+			require.NoError(t, <-served)
+		})
+		return srv
+	}
+
+	var a, b *Swarm
+	srvA, srvB := serve(&a), serve(&b)
+	var err error
+	a, err = New(10, goal, WithID("a"), WithRemoteNeighbors([]transport.Endpoint{{Address: srvB.Addr()}}))
+	require.NoError(t, err)
+	defer a.Close()
+	b, err = New(10, goal, WithID("b"), WithRemoteNeighbors([]transport.Endpoint{{Address: srvA.Addr()}}))
+	require.NoError(t, err)
+	defer b.Close()
+	a.AttachGossipServer(srvA)
+	b.AttachGossipServer(srvB)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 2)
+	go func() { done <- a.RunContinuous(ctx) }()
+	go func() { done <- b.RunContinuous(ctx) }()
+	defer func() {
+		cancel()
+		<-done
+		<-done
+	}()
+
+	for _, s := range []*Swarm{a, b} {
+		require.Eventually(t, func() bool { return len(s.RemoteNeighbors()) == 10 }, 5*time.Second, 20*time.Millisecond,
+			"swarm %s should see the other swarm's agents", s.ID())
+	}
+}
+
+func TestRemoteTableMergeDatesByReceipt(t *testing.T) {
+	t.Parallel()
+
+	var table remoteTable
+	now := time.Now()
+	sent := now.Add(-time.Hour) // The sender's clock lags ours
+
+	table.merge([]transport.PhaseMessage{{AgentID: "other/agent-0", Phase: 1, SentAt: sent}}, "self", now)
+	neighbors := table.snapshot(now)
+	require.Len(t, neighbors, 1)
+	assert.InDelta(t, 1, neighbors[0].Influence, 1e-9, "dated by receipt, not by SentAt")
+
+	// Reading the same message again does not refresh it
+	later := now.Add(remoteHalfLife)
+	table.merge([]transport.PhaseMessage{{AgentID: "other/agent-0", Phase: 1, SentAt: sent}}, "self", later)
+	assert.InDelta(t, 0.5, table.snapshot(later)[0].Influence, 1e-9)
+
+	// A message sent earlier than the one known is out of order
+	table.merge([]transport.PhaseMessage{{AgentID: "other/agent-0", Phase: 3, SentAt: sent.Add(-time.Second)}}, "self", later)
+	assert.InDelta(t, 1.0, table.snapshot(later)[0].Phase, 1e-12)
+
+	// A newer one is fresh news
+	table.merge([]transport.PhaseMessage{{AgentID: "other/agent-0", Phase: 2, SentAt: sent.Add(time.Second)}}, "self", later)
+	neighbors = table.snapshot(later)
+	require.Len(t, neighbors, 1)
+	assert.InDelta(t, 2.0, neighbors[0].Phase, 1e-12)
+	assert.InDelta(t, 1, neighbors[0].Influence, 1e-9)
+}
+
+func TestWithRemoteNeighborsValidation(t *testing.T) {
+	t.Parallel()
+
+	goal := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8}
+
+	_, err := New(5, goal, WithRemoteNeighbors(nil))
+	require.Error(t, err)
+
+	_, err = New(5, goal, WithRemoteNeighbors([]transport.Endpoint{{Address: " "}}))
+	require.Error(t, err)
+}
+
+func TestRemoteTableInfluenceDecay(t *testing.T) {
+	t.Parallel()
+
+	var table remoteTable
+	now := time.Now()
+	table.update([]transport.PhaseMessage{
+		{AgentID: "other/agent-0", Phase: 1},
+		{AgentID: "self/agent-0", Phase: 2},
+	}, "self", now)
+
+	tests := []struct {
+		name      string
+		age       time.Duration
+		influence float64 // Negative means forgotten
+	}{
+		{"fresh", 0, 1},
+		{"one half-life", remoteHalfLife, 0.5},
+		{"two half-lives", 2 * remoteHalfLife, 0.25},
+		{"expired", remoteExpiry + time.Second, -1},
+	}
+	for _, tt := range tests {
+		neighbors := table.snapshot(now.Add(tt.age))
+		if tt.influence < 0 {
+			assert.Empty(t, neighbors, tt.name)
+			continue
+		}
+		require.Len(t, neighbors, 1, "%s: own agents relayed back are not remote", tt.name)
+		assert.Equal(t, "other/agent-0", neighbors[0].AgentID)
+		assert.InDelta(t, tt.influence, neighbors[0].Influence, 1e-9, tt.name)
+	}
+}
+
+// pullRemote runs one agent update in which agents move only by the pull
+// of their neighbors, local and remote.
+func pullRemote(s *Swarm) {
+	s.updateAgents(s.collectAgents(), func(phase float64, _ *agentRand) (float64, bool) {
+		return phase, false
+	}, 1)
+}
+
+func TestRemotePull(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		age          time.Duration
+		stubbornness float64
+	}{
+		{"fresh", 0, 0},
+		{"stale", 3 * remoteHalfLife, 0},
+		{"stubborn", 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, err := New(10, core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8})
+			require.NoError(t, err)
+			defer s.Close()
+
+			for _, a := range s.Agents() {
+				a.SetPhase(0)
+				a.SetStubbornness(tt.stubbornness)
+			}
+			s.remote.update([]transport.PhaseMessage{{AgentID: "remote/agent-0", Phase: math.Pi / 2}},
+				s.ID(), time.Now().Add(-tt.age))
+
+			pullRemote(s)
+
+			// Δθ = K·w·sin(π/2 − 0) with a single remote agent, unless the
+			// agent resists it like any other move
+			influence := math.Exp2(-tt.age.Seconds() / remoteHalfLife.Seconds())
+			want := s.config.CouplingStrength * remoteCouplingGain * influence * (1 - tt.stubbornness)
+			for _, a := range s.Agents() {
+				assert.InDelta(t, want, a.Phase(), 1e-3, "agents should move toward the remote phase by its influence")
+			}
+		})
+	}
+}
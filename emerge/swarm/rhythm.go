@@ -0,0 +1,112 @@
+package swarm
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+// referenceGain is the fraction of the gap to the reference phase each
+// agent closes per tick.
+const referenceGain = 0.5
+
+// WithReferenceRhythm locks the swarm to an external beat, such as a
+// wall-clock schedule, instead of to its own consensus. phase returns the
+// reference's phase at a moment; a schedule that fires every period at
+// offset o is
+//
+//	func(t time.Time) float64 { return 2*math.Pi*float64(t.Sub(anchor)%period)/float64(period) + o }
+//
+// Agent phases are relative to a clock that turns once per target period,
+// starting when the swarm is created, as for duty cycles. On each tick of
+// Run and RunContinuous the swarm works out where the reference sits on
+// that clock and pulls every agent toward it, like a phase-locked loop, so
+// drift, whether injected or from a reference running at a slightly
+// different period, is corrected as it appears. Run succeeds once the
+// swarm is coherent and centered on the reference; RunContinuous resyncs
+// whenever it falls behind. Use DriftFromReference to watch the lag.
+//
+// This is meant for the MaintainRhythm goal. A reference cannot be
+// combined with phase bands or a hub.
+func WithReferenceRhythm(phase func(t time.Time) float64) Option {
+	return func(s *Swarm) error {
+		if phase == nil {
+			return errors.New("reference rhythm must not be nil")
+		}
+		s.reference = phase
+		return nil
+	}
+}
+
+// DriftFromReference returns how far the swarm's mean phase runs ahead of
+// the reference set with WithReferenceRhythm, as a share of the target
+// period: positive when the swarm fires early, negative when it lags. It
+// is 0 without a reference.
+func (s *Swarm) DriftFromReference() time.Duration {
+	ref, ok := s.referencePhase()
+	if !ok {
+		return 0
+	}
+	agents := s.collectAgents()
+	if len(agents) == 0 {
+		return 0
+	}
+	phases := make([]float64, len(agents))
+	for i, a := range agents {
+		phases[i] = a.Phase()
+	}
+	gap := core.PhaseDifference(circularMean(phases), ref)
+	return time.Duration(gap / (2 * math.Pi) * float64(s.target().Frequency))
+}
+
+// referencePhase returns the reference's position on the swarm's rhythm
+// clock right now, or false without a reference.
+func (s *Swarm) referencePhase() (float64, bool) {
+	if s.reference == nil {
+		return 0, false
+	}
+	now := s.now()
+	return core.WrapPhase(s.reference(now) - s.rhythmClock(now)), true
+}
+
+// validateReference rejects a reference combined with bands or a hub.
+func (s *Swarm) validateReference() error {
+	if s.reference == nil {
+		return nil
+	}
+	if len(s.bands) > 0 {
+		return errors.New("a reference rhythm cannot be combined with phase bands")
+	}
+	if s.hubID != "" {
+		return errors.New("a reference rhythm cannot be combined with a hub")
+	}
+	return nil
+}
+
+// referenceFollowed reports whether agents are coherent and centered on
+// the reference phase ref, within tolerance.
+func referenceFollowed(agents []*agent.Agent, ref, coherence, tolerance float64) bool {
+	if len(agents) == 0 {
+		return true
+	}
+	phases := make([]float64, len(agents))
+	for i, a := range agents {
+		phases[i] = a.Phase()
+	}
+	return core.MeasureCoherence(phases) >= coherence &&
+		math.Abs(core.PhaseDifference(circularMean(phases), ref)) <= tolerance
+}
+
+// applyReference pulls every agent toward the reference phase ref.
+func (gds *GoalDirectedSync) applyReference(agents []*agent.Agent, ref float64) {
+	for _, a := range agents {
+		phase := a.Phase()
+		next := phase + referenceGain*core.PhaseDifference(ref, phase)
+		if next, ok := gds.swarm.spend(a, phase, next); ok {
+			a.SetPhase(next)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package swarm_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/goal"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+// TestReferenceRhythm locks a swarm to a schedule and knocks it off: the
+// injected drift is corrected back to near zero within a few periods.
+func TestReferenceRhythm(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		const period = 200 * time.Millisecond
+		anchor := time.Now()
+		schedule := func(t time.Time) float64 {
+			return 2*math.Pi*float64(t.Sub(anchor)%period)/float64(period) + 1
+		}
+		goalState := core.State{Phase: 0, Frequency: period, Coherence: 0.85}
+
+		s, err := swarm.New(30, goalState, swarm.WithSeed(3), swarm.WithGoal(goal.MaintainRhythm))
+		require.NoError(t, err)
+		assert.Zero(t, s.DriftFromReference(), "no reference, no drift")
+		s.Close()
+
+		_, err = swarm.New(30, goalState, swarm.WithReferenceRhythm(nil))
+		require.Error(t, err)
+		_, err = swarm.New(30, goalState, swarm.WithReferenceRhythm(schedule), swarm.WithHub("agent-0", 0.5))
+		require.Error(t, err)
+
+		s, err = swarm.New(30, goalState, swarm.WithSeed(3), swarm.WithGoal(goal.MaintainRhythm),
+			swarm.WithReferenceRhythm(schedule))
+		require.NoError(t, err)
+		defer s.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		require.NoError(t, s.Run(ctx))
+		assert.InDelta(t, 0, s.DriftFromReference(), float64(5*time.Millisecond))
+
+		// The reference keeps moving with the wall clock; the swarm keeps up
+		done := make(chan error, 1)
+		runCtx, stop := context.WithCancel(ctx)
+		go func() { done <- s.RunContinuous(runCtx) }()
+		time.Sleep(10 * period)
+		assert.InDelta(t, 0, s.DriftFromReference(), float64(5*time.Millisecond))
+
+		// Push every agent a quarter period ahead
+		for _, a := range s.Agents() {
+			a.SetPhase(a.Phase() + math.Pi/2)
+		}
+		drift := s.DriftFromReference()
+		assert.InDelta(t, float64(period/4), float64(drift), float64(10*time.Millisecond))
+
+		time.Sleep(10 * period)
+		assert.InDelta(t, 0, s.DriftFromReference(), float64(5*time.Millisecond), "drift corrected")
+
+		stop()
+		require.ErrorIs(t, <-done, context.Canceled)
+	})
+}
@@ -0,0 +1,66 @@
+package swarm_test
+
+import (
+	"context"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/monitoring"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+func TestWithSeedReproducesTrajectory(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		size int
+	}{
+		{"small", 30},
+		{"optimized", 150},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			synctest.Test(t, func(t *testing.T) {
+				run := func(seed int64) ([]float64, map[string]float64) {
+					monitor := monitoring.New(monitoring.WithHistoryLimit(0))
+					s, err := swarm.New(tt.size, core.State{
+						Phase:     0,
+						Frequency: 200 * time.Millisecond,
+						Coherence: 0.7,
+					}, swarm.WithSeed(seed), swarm.WithMonitor(monitor))
+					require.NoError(t, err)
+					defer s.Close()
+
+					ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+					defer cancel()
+					require.NoError(t, s.Run(ctx))
+
+					s.DisruptAgents(0.3)
+					phases := make(map[string]float64)
+					for id, a := range s.Agents() {
+						phases[id] = a.Phase()
+					}
+					return monitor.History(), phases
+				}
+
+				first, firstPhases := run(42)
+				second, secondPhases := run(42)
+				require.NotEmpty(t, first)
+				assert.Equal(t, first, second, "same seed should give the same coherence history")
+				assert.Equal(t, firstPhases, secondPhases, "same seed should disrupt the same agents the same way")
+
+				other, _ := run(7)
+				assert.NotEqual(t, first, other, "different seeds should give different histories")
+			})
+		})
+	}
+}
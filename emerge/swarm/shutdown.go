@@ -0,0 +1,40 @@
+package swarm
+
+import (
+	"context"
+	"errors"
+)
+
+// Shutdown stops every Run, RunContinuous and WaitForConvergence loop in
+// progress and waits for them to end. Each loop starts no new tick and
+// finishes the one in progress, so no update is left half applied, then
+// stops its observers, which receive a final sample, along with gossip and
+// batch triggers. Once Shutdown returns nil nothing is running and the
+// swarm can be snapshotted, e.g. with Clone, or run again. The stopped
+// loops return ErrShutdown.
+//
+// Canceling a loop's own context stops it the same way, but only the
+// caller holding that context can do it, and only the loop's return tells
+// it that the loop is done. Shutdown reaches every loop and does the
+// waiting. It returns ctx's error if ctx is done before the loops end; they
+// still stop, later. Loops started after Shutdown is called are not
+// affected.
+func (s *Swarm) Shutdown(ctx context.Context) error {
+	for _, done := range s.runs.stop() {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// shutdownErr returns ErrShutdown if Shutdown ended the run working under
+// ctx, and err otherwise.
+func shutdownErr(ctx context.Context, err error) error {
+	if errors.Is(err, context.Canceled) && errors.Is(context.Cause(ctx), ErrShutdown) {
+		return ErrShutdown
+	}
+	return err
+}
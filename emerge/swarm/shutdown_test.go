@@ -0,0 +1,114 @@
+package swarm_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/monitoring"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+func TestShutdown(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		var samples atomic.Int64
+		goalState := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.85}
+		s, err := swarm.New(30, goalState, swarm.WithSeed(6),
+			swarm.WithObserver(monitoring.ObserverFunc(func(monitoring.Sample) { samples.Add(1) })))
+		require.NoError(t, err)
+		defer s.Close()
+
+		done := make(chan error, 1)
+		go func() { done <- s.RunContinuous(context.Background()) }()
+		time.Sleep(2 * time.Second)
+		s.DisruptAgents(0.5) // Leave a resync in flight
+
+		require.NoError(t, s.Shutdown(context.Background()))
+		select {
+		case err := <-done:
+			require.ErrorIs(t, err, swarm.ErrShutdown)
+		default:
+			require.Fail(t, "RunContinuous should have returned before Shutdown")
+		}
+
+		// Nothing runs after Shutdown: state and samples hold still
+		before, flushed := phaseSnapshot(s), samples.Load()
+		assert.Positive(t, flushed)
+		time.Sleep(time.Second)
+		assert.Equal(t, before, phaseSnapshot(s))
+		assert.Equal(t, flushed, samples.Load())
+
+		// The swarm can run again
+		require.NoError(t, s.Run(context.Background()))
+	})
+}
+
+func TestShutdownRun(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		goalState := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.85}
+		s, err := swarm.New(20, goalState, swarm.WithSeed(7))
+		require.NoError(t, err)
+		defer s.Close()
+
+		require.NoError(t, s.Shutdown(context.Background()), "nothing to stop")
+
+		s.Pause() // Keep Run from converging before Shutdown
+		done := make(chan error, 1)
+		go func() { done <- s.Run(context.Background()) }()
+		time.Sleep(time.Second)
+
+		require.NoError(t, s.Shutdown(context.Background()))
+		require.ErrorIs(t, <-done, swarm.ErrShutdown)
+	})
+}
+
+func TestShutdownTimeout(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		release := make(chan struct{})
+		var blocked atomic.Bool
+		goalState := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.85}
+		s, err := swarm.New(20, goalState, swarm.WithSeed(8),
+			swarm.WithObserver(monitoring.ObserverFunc(func(monitoring.Sample) {
+				if blocked.Load() {
+					<-release
+				}
+			})))
+		require.NoError(t, err)
+		defer s.Close()
+
+		s.Pause()
+		done := make(chan error, 1)
+		go func() { done <- s.Run(context.Background()) }()
+		time.Sleep(time.Second)
+
+		// The run cannot end while an observer holds its final sample
+		blocked.Store(true)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		require.ErrorIs(t, s.Shutdown(ctx), context.DeadlineExceeded)
+
+		close(release)
+		require.ErrorIs(t, <-done, swarm.ErrShutdown)
+	})
+}
+
+// phaseSnapshot returns every agent's phase, keyed by agent ID.
+func phaseSnapshot(s *swarm.Swarm) map[string]float64 {
+	out := make(map[string]float64)
+	for id, a := range s.Agents() {
+		out[id] = a.Phase()
+	}
+	return out
+}
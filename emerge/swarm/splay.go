@@ -0,0 +1,84 @@
+package swarm
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+// spreader is implemented by strategies that spread agents evenly around
+// the cycle, such as strategy.Splay.
+type spreader interface {
+	Spread(phase, prev, next float64) float64
+}
+
+// spreading reports whether every agent's strategy spreads, so the swarm
+// works toward a splay state instead of synchronizing.
+func spreading(agents []*agent.Agent) bool {
+	if len(agents) == 0 {
+		return false
+	}
+	for _, a := range agents {
+		if _, ok := a.Strategy().(spreader); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// spreadAchieved reports whether spreading agents have spread far enough:
+// duty-cycling agents once their active windows are balanced (see
+// dutyCycleBalanced), others once dispersion reaches 1 minus the target
+// coherence.
+func (s *Swarm) spreadAchieved(agents []*agent.Agent, coherence float64) bool {
+	if dutyCycling(agents) {
+		return dutyCycleBalanced(agents)
+	}
+	return s.MeasureDispersion() >= 1-coherence
+}
+
+// applySplay moves every spreading agent toward the midpoint between its
+// neighbors on the cycle, the agents just behind and ahead of it in phase.
+// Steps are computed from the phases at the start of the tick before any
+// is applied. Agents sharing a phase with a neighbor also get a little
+// jitter, since otherwise they would move in lockstep and never separate.
+func (gds *GoalDirectedSync) applySplay(agents []*agent.Agent) {
+	type slot struct {
+		a     *agent.Agent
+		phase float64
+	}
+	ring := make([]slot, len(agents))
+	for i, a := range agents {
+		ring[i] = slot{a: a, phase: core.WrapPhase(a.Phase())}
+	}
+	slices.SortFunc(ring, func(x, y slot) int {
+		if c := cmp.Compare(x.phase, y.phase); c != 0 {
+			return c
+		}
+		return compareAgentIDs(x.a.ID, y.a.ID)
+	})
+
+	next := make([]float64, len(ring))
+	for i, s := range ring {
+		prev, ahead := ring[(i+len(ring)-1)%len(ring)].phase, ring[(i+1)%len(ring)].phase
+		spreader, ok := s.a.Strategy().(spreader)
+		if !ok {
+			next[i] = s.phase
+			continue
+		}
+		next[i] = spreader.Spread(s.phase, prev, ahead)
+		if prev == s.phase || ahead == s.phase {
+			next[i] += (gds.swarm.randFloat64() - 0.5) * goalRegionNoise
+		}
+	}
+	for i, s := range ring {
+		if next[i] == s.phase {
+			continue
+		}
+		if next, ok := gds.swarm.spend(s.a, s.phase, next[i]); ok {
+			s.a.SetPhase(next)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package swarm_test
+
+import (
+	"context"
+	"math"
+	"slices"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/goal"
+	"github.com/carlisia/bio-adapt/emerge/strategy"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+// TestSplay starts a load-distributing swarm in two anti-phase clusters,
+// which already has coherence near 0, and checks that it still spreads
+// into an even splay state: one agent per sector, equally spaced.
+func TestSplay(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		const size = 20
+		s, err := swarm.New(size, core.State{
+			Phase:     0,
+			Frequency: 200 * time.Millisecond,
+			Coherence: 0.02,
+		}, swarm.WithGoal(goal.DistributeLoad), swarm.WithSeed(5),
+			swarm.WithInitialPhaseFunc(func(i int) float64 { return float64(i%2) * math.Pi }))
+		require.NoError(t, err)
+		defer s.Close()
+		assert.Equal(t, strategy.NameSplay, s.StrategyName())
+		require.Less(t, s.MeasureCoherence(), 0.05, "two opposite clusters look incoherent")
+		require.Less(t, s.MeasureDispersion(), 0.05, "but they are not spread")
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		require.NoError(t, s.Run(ctx))
+		assert.GreaterOrEqual(t, s.MeasureDispersion(), 0.98)
+		assert.True(t, s.IsConverged())
+
+		phases := make([]float64, 0, size)
+		for _, a := range s.AgentsSorted() {
+			phases = append(phases, core.WrapPhase(a.Phase()))
+		}
+		slices.Sort(phases)
+		sector := 2 * math.Pi / size
+		variance := 0.0
+		for i, p := range phases {
+			gap := core.WrapPhase(phases[(i+1)%size] - p)
+			assert.InDelta(t, sector, gap, sector/4, "gap %d", i)
+			variance += (gap - sector) * (gap - sector) / size
+		}
+		assert.Less(t, math.Sqrt(variance), sector/10, "gaps should be nearly equal")
+	})
+}
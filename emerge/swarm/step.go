@@ -0,0 +1,30 @@
+package swarm
+
+import "context"
+
+// Step performs exactly one update tick across all agents, the same tick
+// Run performs on each beat of its ticker, and returns the coherence it
+// left. It decouples the dynamics from time, for driving the swarm from an
+// external loop, e.g. one step per incoming request, and for deterministic
+// tests: with WithSeed, n calls to Step leave a swarm exactly where a Run
+// of n ticks leaves an identically built one.
+//
+// Successive calls continue one run toward the target; once a step
+// reaches the target, or gives up on a plateau with WithPlateauDetection,
+// the next call starts a new run, as calling Run again would. Convergence
+// callbacks fire as they would under Run. A paused swarm does not move.
+//
+// Step must not be called concurrently with itself, Run, RunContinuous or
+// WaitForConvergence.
+func (s *Swarm) Step() float64 {
+	gds := s.goalDirectedSync
+	if !s.Paused() {
+		if gds.stepping == nil {
+			gds.stepping = gds.newSyncRun(s.targetPattern())
+		}
+		if done, _ := gds.step(context.Background(), gds.stepping); done {
+			gds.stepping = nil
+		}
+	}
+	return s.MeasureCoherence()
+}
@@ -0,0 +1,71 @@
+package swarm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+	"github.com/carlisia/bio-adapt/emerge/swarm/clocktest"
+)
+
+// TestStep runs one swarm under a fake clock and steps an identical one by
+// hand as many times as the run ticked. Both end in exactly the same
+// state.
+func TestStep(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.9}
+	build := func(clock *clocktest.Clock) *swarm.Swarm {
+		s, err := swarm.New(40, goalState, swarm.WithSeed(5), swarm.WithClock(clock))
+		require.NoError(t, err)
+		t.Cleanup(s.Close)
+		return s
+	}
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	runClock, stepClock := clocktest.New(start), clocktest.New(start)
+	ran, stepped := build(runClock), build(stepClock)
+	interval := ran.TickInterval()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- ran.Run(ctx) }()
+	runClock.WaitForTickers(1)
+	ticks := 0
+	for running := true; running; {
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+			running = false
+		default:
+			runClock.Advance(interval)
+			ticks++
+		}
+	}
+	require.Greater(t, ticks, 1)
+
+	var coherence float64
+	for range ticks {
+		stepClock.Advance(interval)
+		coherence = stepped.Step()
+	}
+	assert.InDelta(t, ran.MeasureCoherence(), coherence, 1e-12)
+	for _, a := range ran.AgentsSorted() {
+		b, ok := stepped.Agent(a.ID)
+		require.True(t, ok)
+		assert.InDelta(t, a.Phase(), b.Phase(), 1e-12, "agent %s", a.ID)
+		assert.InDelta(t, a.Energy(), b.Energy(), 1e-12, "agent %s", a.ID)
+	}
+
+	// A paused swarm does not move
+	stepped.Pause()
+	before := stepped.AgentsSorted()[0].Phase()
+	stepped.Step()
+	assert.InDelta(t, before, stepped.AgentsSorted()[0].Phase(), 1e-12)
+}
@@ -0,0 +1,122 @@
+package swarm
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+)
+
+// Storage selects how a swarm stores its agents.
+type Storage int
+
+const (
+	// StorageAuto uses map storage up to OptimizedSwarmThreshold agents
+	// and slice storage above it. It is the default.
+	StorageAuto Storage = iota
+	// StorageMap keeps agents in a sync.Map keyed by ID. It suits small
+	// swarms and swarms whose membership changes often.
+	StorageMap
+	// StorageSlice keeps agents in a slice with an ID index, which is
+	// cheaper to build and iterate for large swarms. Agents get fixed-size
+	// neighbor storage and ticks run on a worker pool.
+	StorageSlice
+)
+
+// String returns the storage's name.
+func (st Storage) String() string {
+	switch st {
+	case StorageAuto:
+		return "auto"
+	case StorageMap:
+		return "map"
+	case StorageSlice:
+		return "slice"
+	default:
+		return fmt.Sprintf("Storage(%d)", int(st))
+	}
+}
+
+// WithStorage chooses how agents are stored instead of deciding by size.
+func WithStorage(st Storage) Option {
+	return func(s *Swarm) error {
+		switch st {
+		case StorageAuto:
+			s.switchStorage(s.size > OptimizedSwarmThreshold)
+		case StorageMap:
+			s.switchStorage(false)
+		case StorageSlice:
+			s.switchStorage(true)
+		default:
+			return fmt.Errorf("unknown storage %v", st)
+		}
+		s.storage = st
+		return nil
+	}
+}
+
+// Storage returns the storage the swarm's agents are kept in, StorageMap
+// or StorageSlice.
+func (s *Swarm) Storage() Storage {
+	if s.optimized {
+		return StorageSlice
+	}
+	return StorageMap
+}
+
+// WithMaxAgents makes New fail with ErrInvalidSwarmSize, before allocating
+// anything for the agents, if the swarm would have more than n agents, and
+// AddAgent fail once the swarm has n. Unlike the config's MaxSwarmSize, it
+// is a guard set by the caller rather than a property of the workload.
+func WithMaxAgents(n int) Option {
+	return func(s *Swarm) error {
+		if n <= 0 {
+			return fmt.Errorf("max agents must be positive, got %d", n)
+		}
+		s.maxAgents = n
+		return nil
+	}
+}
+
+// checkMaxAgents reports whether a swarm of size agents is within the
+// limit set by WithMaxAgents.
+func (s *Swarm) checkMaxAgents(size int) error {
+	if s.maxAgents > 0 && size > s.maxAgents {
+		return fmt.Errorf("%w: swarm size %d exceeds max agents %d", ErrInvalidSwarmSize, size, s.maxAgents)
+	}
+	return nil
+}
+
+// initStorage allocates slice storage if the swarm uses it and it is not
+// allocated yet.
+func (s *Swarm) initStorage() {
+	if s.optimized && s.agentIndex == nil {
+		s.agentSlice = make([]*agent.Agent, 0, s.size)
+		s.agentIndex = make(map[string]int, s.size)
+	}
+}
+
+// switchStorage moves the swarm to slice storage if optimized is true and
+// to map storage otherwise, carrying over any agents already stored.
+func (s *Swarm) switchStorage(optimized bool) {
+	if optimized == s.optimized {
+		return
+	}
+	agents := s.collectAgents()
+	s.optimized = optimized
+	s.agents.Clear()
+	s.agentSlice, s.agentIndex = nil, nil
+	s.initStorage()
+
+	slices.SortFunc(agents, func(a, b *agent.Agent) int {
+		return compareAgentIDs(a.ID, b.ID)
+	})
+	for i, a := range agents {
+		if optimized {
+			s.agentSlice = append(s.agentSlice, a)
+			s.agentIndex[a.ID] = i
+		} else {
+			s.agents.Store(a.ID, a)
+		}
+	}
+}
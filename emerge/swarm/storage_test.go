@@ -0,0 +1,101 @@
+package swarm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+	"github.com/carlisia/bio-adapt/internal/config"
+)
+
+func TestWithStorage(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8}
+	tests := []struct {
+		name    string
+		size    int
+		storage swarm.Storage
+		want    swarm.Storage
+	}{
+		{"auto small", 20, swarm.StorageAuto, swarm.StorageMap},
+		{"auto large", swarm.OptimizedSwarmThreshold + 1, swarm.StorageAuto, swarm.StorageSlice},
+		{"slice small", 20, swarm.StorageSlice, swarm.StorageSlice},
+		{"map large", swarm.OptimizedSwarmThreshold + 1, swarm.StorageMap, swarm.StorageMap},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, err := swarm.New(tt.size, goalState, swarm.WithStorage(tt.storage), swarm.WithSeed(1))
+			require.NoError(t, err)
+			defer s.Close()
+
+			assert.Equal(t, tt.want, s.Storage())
+			assert.Equal(t, tt.size, s.Size())
+			assert.Len(t, s.Agents(), tt.size)
+			for _, a := range s.AgentsSorted() {
+				got, ok := s.Agent(a.ID)
+				require.True(t, ok, a.ID)
+				assert.Same(t, a, got)
+			}
+
+			c, err := s.Clone()
+			require.NoError(t, err)
+			defer c.Close()
+			assert.Equal(t, tt.want, c.Storage(), "clone keeps the storage")
+
+			// Storage can change after agents are in place
+			other := swarm.StorageMap
+			if tt.want == swarm.StorageMap {
+				other = swarm.StorageSlice
+			}
+			c, err = s.Clone(swarm.WithStorage(other))
+			require.NoError(t, err)
+			defer c.Close()
+			assert.Equal(t, other, c.Storage())
+			assert.Len(t, c.Agents(), tt.size)
+		})
+	}
+
+	_, err := swarm.New(20, goalState, swarm.WithStorage(swarm.Storage(9)))
+	assert.Error(t, err)
+}
+
+func TestWithMaxAgents(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8}
+
+	_, err := swarm.New(5000, goalState, swarm.WithMaxAgents(1000))
+	require.ErrorIs(t, err, swarm.ErrInvalidSwarmSize)
+
+	// The limit holds before any agent is built, whatever the option order
+	built := 0
+	_, err = swarm.New(5000, goalState,
+		swarm.WithAgentBuilder(func(id string, _ int, _ config.Swarm) *agent.Agent {
+			built++
+			return agent.New(id)
+		}),
+		swarm.WithMaxAgents(1000))
+	require.ErrorIs(t, err, swarm.ErrInvalidSwarmSize)
+	assert.Zero(t, built, "no agent should be built for an oversized swarm")
+
+	_, err = swarm.New(10, goalState, swarm.WithMaxAgents(0))
+	require.Error(t, err)
+
+	s, err := swarm.New(10, goalState, swarm.WithMaxAgents(11))
+	require.NoError(t, err)
+	defer s.Close()
+
+	_, err = s.AddAgent(swarm.AgentConfig{})
+	require.NoError(t, err)
+	_, err = s.AddAgent(swarm.AgentConfig{})
+	require.ErrorIs(t, err, swarm.ErrInvalidSwarmSize)
+	assert.Equal(t, 11, s.Size())
+}
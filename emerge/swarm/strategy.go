@@ -0,0 +1,152 @@
+package swarm
+
+import (
+	"fmt"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/goal"
+	"github.com/carlisia/bio-adapt/emerge/strategy"
+)
+
+// WithStrategy selects a registered synchronization strategy by name for
+// every agent in the swarm. Built-in strategies are listed by
+// strategy.Names; custom ones are added with strategy.Register before the
+// swarm is created. The goal-directed loop also starts with this strategy
+// instead of its default.
+//
+// Selection precedence, from lowest to highest:
+//   - the agent's default strategy (phase nudging)
+//   - any strategy set by agent options, including in WithAgentBuilder
+//   - the goal's strategy, if it has one: goal.MinimizeLatency selects
+//     jitter damping (strategy.JitterDamping), goal.DistributeLoad selects
+//     an even spread (strategy.Splay), goal.SaveEnergy selects staggered
+//     duty cycles (strategy.DutyCycle), and custom goals select slot
+//     seeking (strategy.SlotSeeking)
+//   - the swarm-level strategy chosen here, applied when the swarm is created
+//   - agent.SetStrategy called on an individual agent after New
+//
+// Each agent gets its own instance from the strategy's factory.
+//
+// The goal-directed loop works out where each agent should move next and
+// then asks the agent's strategy how far to go: the agent takes the step
+// its strategy proposes toward that phase, so a conservative strategy
+// such as strategy.EnergyAware moves in smaller steps and holds still when
+// close. The loop is itself a phase nudge tuned for the whole swarm, so
+// agents running the default strategy.PhaseNudge take the loop's step as
+// it is, and strategies that damp steps, such as strategy.JitterDamping,
+// smooth it instead.
+func WithStrategy(name string) Option {
+	return func(s *Swarm) error {
+		if _, err := strategy.New(name); err != nil {
+			return fmt.Errorf("invalid strategy: %w", err)
+		}
+		s.strategyName = name
+		return nil
+	}
+}
+
+// StrategyName returns the name of the swarm-level strategy selected with
+// WithStrategy or by the goal, or an empty string if there is none.
+func (s *Swarm) StrategyName() string {
+	return s.strategyName
+}
+
+// goalStrategy returns the strategy a goal selects when WithStrategy is
+// not used, or an empty string to keep the agents' own strategies.
+func goalStrategy(g goal.Type) string {
+	switch g {
+	case goal.MinimizeLatency:
+		return strategy.NameJitterDamping
+	case goal.DistributeLoad:
+		return strategy.NameSplay
+	case goal.SaveEnergy:
+		return strategy.NameDutyCycle
+	}
+	if _, ok := g.Spec(); ok {
+		return strategy.NameSlotSeeking
+	}
+	return ""
+}
+
+// applyStrategy gives every agent a fresh instance of the swarm-level strategy.
+func (s *Swarm) applyStrategy() error {
+	for _, a := range s.collectAgents() {
+		st, err := s.newStrategy()
+		if err != nil {
+			return err
+		}
+		a.SetStrategy(st)
+	}
+	return nil
+}
+
+// newStrategy creates an instance of the swarm-level strategy. Slot
+// seekers without slots of their own get the custom goal's slots, and
+// duty cycles get the fraction set with WithActiveFraction.
+func (s *Swarm) newStrategy() (strategy.Strategy, error) {
+	st, err := strategy.New(s.strategyName)
+	if err != nil {
+		return nil, err
+	}
+	if d, ok := st.(*strategy.DutyCycle); ok && s.activeFraction > 0 {
+		d.Fraction = s.activeFraction
+	}
+	if seeker, ok := st.(*strategy.SlotSeeking); ok && len(seeker.Slots) == 0 {
+		if spec, ok := s.goalType.Spec(); ok {
+			seeker.Slots = spec.Slots
+		}
+	}
+	return st, nil
+}
+
+// strategyProposal returns the step a's strategy proposes from phase
+// toward next, the phase the goal-directed loop proposes for it, as an
+// action whose Value is the step (see WithStrategy). Proposals are priced
+// by the agent's cost model if it has one; steps the agent takes as the
+// loop gives them are otherwise priced by core.DefaultCostModel, like the
+// phase nudge's own proposals.
+func strategyProposal(a *agent.Agent, phase, next float64) core.Action {
+	ctx := a.Context()
+	current := core.State{Phase: phase, Frequency: a.Frequency(), Coherence: ctx.LocalCoherence}
+	target := core.State{Phase: next, Frequency: a.Frequency()}
+
+	var action core.Action
+	switch st := a.Strategy().(type) {
+	case *strategy.PhaseNudge, stepDamper:
+		// Jitter dampers smooth the loop's step instead (see dampStep)
+		action = core.Action{Type: st.Name(), Value: next - phase}
+		action.Cost, action.Benefit = core.DefaultCostModel{}.Evaluate(current, target, target)
+	default:
+		action, _ = st.Propose(current, target, ctx)
+	}
+	if m := a.CostModel(); m != nil && action.Type != "maintain" {
+		to := current
+		to.Phase = phase + action.Value
+		action.Cost, action.Benefit = m.Evaluate(current, to, target)
+	}
+	return action
+}
+
+// useStrategy makes the named strategy the current one, adding it to the
+// rotation if it is not already there.
+func (gds *GoalDirectedSync) useStrategy(name string) error {
+	st, err := strategy.New(name)
+	if err != nil {
+		return err
+	}
+
+	gds.mu.Lock()
+	defer gds.mu.Unlock()
+
+	for _, existing := range gds.strategies {
+		if existing.Name() == st.Name() {
+			gds.currentStrategy = existing
+			return nil
+		}
+	}
+	gds.strategies = append(gds.strategies, st)
+	gds.strategyPerf[st.Name()] = &StrategyPerformance{Name: st.Name()}
+	gds.currentStrategy = st
+	return nil
+}
@@ -0,0 +1,96 @@
+package swarm_test
+
+import (
+	"context"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/strategy"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+type delayedKuramoto struct {
+	delay time.Duration // Non-empty so instances have distinct addresses
+}
+
+func (*delayedKuramoto) Propose(current, target core.State, _ core.Context) (core.Action, float64) {
+	return core.Action{Type: "adjust_phase", Value: 0.1 * core.PhaseDifference(target.Phase, current.Phase)}, 1
+}
+
+func (*delayedKuramoto) Name() string { return "swarm_test_delayed_kuramoto" }
+
+func TestWithStrategy(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, strategy.Register("swarm_test_delayed_kuramoto", func() strategy.Strategy {
+		return &delayedKuramoto{delay: 50 * time.Millisecond}
+	}))
+
+	goal := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8}
+
+	s, err := swarm.New(10, goal, swarm.WithStrategy("swarm_test_delayed_kuramoto"))
+	require.NoError(t, err)
+	defer s.Close()
+	assert.Equal(t, "swarm_test_delayed_kuramoto", s.StrategyName())
+
+	seen := make(map[core.SyncStrategy]bool)
+	for _, a := range s.Agents() {
+		assert.Equal(t, "swarm_test_delayed_kuramoto", a.Strategy().Name())
+		seen[a.Strategy()] = true
+	}
+	assert.Len(t, seen, 10, "each agent should get its own instance")
+
+	// Agent-level selection after New takes precedence
+	a, ok := s.Agent("agent-0")
+	require.True(t, ok)
+	a.SetStrategy(strategy.NewFrequencyLock(0.5))
+	assert.Equal(t, strategy.NameFrequencyLock, a.Strategy().Name())
+
+	_, err = swarm.New(10, goal, swarm.WithStrategy("swarm_test_missing"))
+	require.ErrorIs(t, err, strategy.ErrUnknownStrategy)
+}
+
+// holdStill is a strategy that never moves its agent.
+type holdStill struct {
+	_ byte // Non-empty so instances have distinct addresses
+}
+
+func (*holdStill) Propose(_, _ core.State, _ core.Context) (core.Action, float64) {
+	return core.Action{Type: "maintain"}, 1
+}
+
+func (*holdStill) Name() string { return "swarm_test_hold_still" }
+
+// TestStrategyShapesRun checks that Run moves agents by their strategy:
+// phase nudgers converge, while agents whose strategy always holds still
+// never do.
+func TestStrategyShapesRun(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, strategy.Register("swarm_test_hold_still", func() strategy.Strategy {
+		return &holdStill{}
+	}))
+
+	goal := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.85}
+	run := func(name string) error {
+		var err error
+		synctest.Test(t, func(t *testing.T) {
+			s, newErr := swarm.New(20, goal, swarm.WithStrategy(name))
+			require.NoError(t, newErr)
+			defer s.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			err = s.Run(ctx)
+		})
+		return err
+	}
+
+	require.NoError(t, run(strategy.NamePhaseNudge))
+	require.Error(t, run("swarm_test_hold_still"), "agents that hold still should never converge")
+}
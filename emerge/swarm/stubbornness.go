@@ -0,0 +1,155 @@
+package swarm
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+)
+
+// stubbornnessIterations bounds the fixed-point search for the consensus
+// phase in the achievable coherence estimate.
+const stubbornnessIterations = 50
+
+// StubbornnessReport describes how the swarm's stubbornness is spread and
+// how much it holds coherence back, taken by StubbornnessProfile.
+type StubbornnessReport struct {
+	Agents    int     // Number of agents
+	Mean      float64 // Mean stubbornness
+	Min       float64 // Lowest stubbornness
+	Max       float64 // Highest stubbornness
+	Median    float64 // Median stubbornness
+	P90       float64 // 90th percentile stubbornness
+	Immovable int     // Agents with stubbornness 1, which never adjust
+
+	// Estimated highest coherence the swarm can reach from its current
+	// phases; see StubbornnessProfile
+	AchievableCoherence float64
+}
+
+// WithMaxStubbornness caps every agent's stubbornness at maxStubbornness
+// when the swarm is created and when agents join with AddAgent. An agent
+// with stubbornness 1 refuses every adjustment and pins the swarm's
+// coherence below the target wherever it sits, so a cap below 1 keeps a
+// configuration from being unconvergeable by accident. The cap must be in
+// [0, 1). A hub (see WithHub) stays fully stubborn, and stubbornness
+// raised later, by agent.SetStubbornness or a Stubborn disruption, is not
+// capped.
+func WithMaxStubbornness(maxStubbornness float64) Option {
+	return func(s *Swarm) error {
+		if !(maxStubbornness >= 0 && maxStubbornness < 1) {
+			return fmt.Errorf("max stubbornness must be in [0, 1), got %v", maxStubbornness)
+		}
+		s.capStubbornness = true
+		s.maxStubbornness = maxStubbornness
+		return nil
+	}
+}
+
+// MaxStubbornness returns the cap set with WithMaxStubbornness, or false
+// if there is none.
+func (s *Swarm) MaxStubbornness() (float64, bool) {
+	return s.maxStubbornness, s.capStubbornness
+}
+
+// StubbornnessProfile reports the distribution of stubbornness across the
+// agents and estimates the highest coherence the swarm can reach given
+// it, to explain a swarm that will not converge.
+//
+// Run and RunContinuous honor stubbornness: each tick, an agent refuses
+// the loop's adjustment with probability equal to its stubbornness, so a
+// fully stubborn agent (1) never moves and one in between moves on fewer
+// ticks. The estimate models each agent as settling between the consensus
+// phase and its current phase in proportion to its stubbornness: a
+// compliant agent (0) joins the consensus, a fully stubborn one stays
+// where it is, and one in between settles part of the way. The consensus
+// phase is the one that maximizes the resulting coherence. Only fully
+// stubborn agents hold out for good; the rest join eventually, only more
+// slowly, so for them the estimate gauges the drag on a run rather than
+// where the swarm ends up.
+func (s *Swarm) StubbornnessProfile() StubbornnessReport {
+	agents := s.collectAgents()
+	r := StubbornnessReport{Agents: len(agents)}
+	if len(agents) == 0 {
+		return r
+	}
+
+	phases := make([]float64, len(agents))
+	stubbornness := make([]float64, len(agents))
+	total := 0.0
+	for i, a := range agents {
+		phases[i] = a.Phase()
+		stubbornness[i] = a.Stubbornness()
+		total += stubbornness[i]
+		if stubbornness[i] >= 1 {
+			r.Immovable++
+		}
+	}
+	r.AchievableCoherence = achievableCoherence(phases, stubbornness)
+
+	slices.Sort(stubbornness)
+	r.Mean = total / float64(len(agents))
+	r.Min, r.Max = stubbornness[0], stubbornness[len(stubbornness)-1]
+	r.Median = quantile(stubbornness, 0.5)
+	r.P90 = quantile(stubbornness, 0.9)
+	return r
+}
+
+// quantile returns the q-quantile of sorted values by nearest rank.
+func quantile(sorted []float64, q float64) float64 {
+	i := int(math.Ceil(q*float64(len(sorted)))) - 1
+	return sorted[max(i, 0)]
+}
+
+// achievableCoherence estimates the coherence agents at phases reach when
+// each settles between a consensus phase ψ and its own phase, weighted by
+// its stubbornness s: at arg((1−s)·e^{iψ} + s·e^{iθ}). ψ is found by
+// fixed-point iteration, starting from the pull of the stubborn agents.
+func achievableCoherence(phases, stubbornness []float64) float64 {
+	var c, sn float64
+	for i, p := range phases {
+		w := 1 + stubbornness[i]
+		c += w * math.Cos(p)
+		sn += w * math.Sin(p)
+	}
+	psi := math.Atan2(sn, c)
+
+	coherence := 0.0
+	for range stubbornnessIterations {
+		c, sn = 0, 0
+		for i, p := range phases {
+			st := stubbornness[i]
+			settled := math.Atan2((1-st)*math.Sin(psi)+st*math.Sin(p), (1-st)*math.Cos(psi)+st*math.Cos(p))
+			c += math.Cos(settled)
+			sn += math.Sin(settled)
+		}
+		next := math.Hypot(c, sn) / float64(len(phases))
+		psi = math.Atan2(sn, c)
+		if math.Abs(next-coherence) < 1e-12 {
+			return next
+		}
+		coherence = next
+	}
+	return coherence
+}
+
+// resists reports whether a's stubbornness holds it where it is this
+// tick: a refuses the loop's adjustment with probability equal to its
+// stubbornness, as it refuses proposals (see agent.ProposeAdjustment).
+func resists(a *agent.Agent, rng *agentRand) bool {
+	return rng.float64() < a.Stubbornness()
+}
+
+// capStubbornnessOf lowers the stubbornness of agents above the cap set
+// with WithMaxStubbornness.
+func (s *Swarm) capStubbornnessOf(agents ...*agent.Agent) {
+	if !s.capStubbornness {
+		return
+	}
+	for _, a := range agents {
+		if a.Stubbornness() > s.maxStubbornness {
+			a.SetStubbornness(s.maxStubbornness)
+		}
+	}
+}
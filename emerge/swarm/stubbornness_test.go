@@ -0,0 +1,122 @@
+package swarm_test
+
+import (
+	"context"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+func TestStubbornnessProfile(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{Frequency: 200 * time.Millisecond, Coherence: 0.9}
+	s, err := swarm.New(50, goalState, swarm.WithSeed(4))
+	require.NoError(t, err)
+	defer s.Close()
+
+	// Raising everyone's stubbornness lowers the achievable coherence
+	previous := 2.0
+	for _, level := range []float64{0, 0.3, 0.6, 0.9, 1} {
+		for _, a := range s.AgentsSorted() {
+			a.SetStubbornness(level)
+		}
+		r := s.StubbornnessProfile()
+		assert.Equal(t, 50, r.Agents)
+		assert.InDelta(t, level, r.Mean, 1e-12)
+		assert.InDelta(t, level, r.Median, 1e-12)
+		assert.Less(t, r.AchievableCoherence, previous, "stubbornness %v", level)
+		previous = r.AchievableCoherence
+	}
+	r := s.StubbornnessProfile()
+	assert.Equal(t, 50, r.Immovable)
+	assert.InDelta(t, s.MeasureCoherence(), r.AchievableCoherence, 1e-9, "nobody moves")
+
+	for _, a := range s.AgentsSorted() {
+		a.SetStubbornness(0)
+	}
+	assert.InDelta(t, 1, s.StubbornnessProfile().AchievableCoherence, 1e-9, "everyone joins")
+
+	// A few immovable agents hold the rest back
+	for _, a := range s.AgentsSorted()[:10] {
+		a.SetStubbornness(1)
+	}
+	r = s.StubbornnessProfile()
+	assert.Equal(t, 10, r.Immovable)
+	assert.InDelta(t, 0, r.Min, 1e-12)
+	assert.InDelta(t, 1, r.Max, 1e-12)
+	assert.InDelta(t, 1, r.P90, 1e-12)
+	assert.Less(t, r.AchievableCoherence, 1.0)
+	assert.Greater(t, r.AchievableCoherence, 0.8)
+}
+
+func TestWithMaxStubbornness(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{Frequency: 200 * time.Millisecond, Coherence: 0.9}
+	for _, bad := range []float64{-0.1, 1, 2} {
+		_, err := swarm.New(10, goalState, swarm.WithMaxStubbornness(bad))
+		require.Error(t, err, "cap %v", bad)
+	}
+
+	cfg := swarm.ConfigForBatching(20, time.Second)
+	cfg.Stubbornness = 0.9
+	s, err := swarm.New(20, goalState, swarm.WithConfig(cfg), swarm.WithMaxStubbornness(0.4))
+	require.NoError(t, err)
+	defer s.Close()
+
+	limit, ok := s.MaxStubbornness()
+	require.True(t, ok)
+	assert.InDelta(t, 0.4, limit, 1e-12)
+	assert.LessOrEqual(t, s.StubbornnessProfile().Max, 0.4)
+
+	a, err := s.AddAgent(swarm.AgentConfig{Stubbornness: 1})
+	require.NoError(t, err)
+	assert.InDelta(t, 0.4, a.Stubbornness(), 1e-12)
+
+	clone, err := s.Clone()
+	require.NoError(t, err)
+	defer clone.Close()
+	_, ok = clone.MaxStubbornness()
+	assert.True(t, ok)
+}
+
+// TestStubbornnessHoldsRun checks that the goal-directed loop honors
+// stubbornness: fully stubborn agents never move, so the swarm stays at
+// the coherence StubbornnessProfile reports as achievable, and the same
+// swarm under WithMaxStubbornness converges.
+func TestStubbornnessHoldsRun(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{Frequency: 200 * time.Millisecond, Coherence: 0.85}
+	newSwarm := func(t *testing.T, opts ...swarm.Option) *swarm.Swarm {
+		t.Helper()
+		cfg := swarm.ConfigForBatching(20, time.Second)
+		cfg.Stubbornness = 1
+		s, err := swarm.New(20, goalState, append([]swarm.Option{swarm.WithSeed(8), swarm.WithConfig(cfg)}, opts...)...)
+		require.NoError(t, err)
+		t.Cleanup(s.Close)
+		return s
+	}
+
+	synctest.Test(t, func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		immovable := newSwarm(t)
+		before := immovable.MeasureCoherence()
+		require.Less(t, before, goalState.Coherence)
+		assert.InDelta(t, before, immovable.StubbornnessProfile().AchievableCoherence, 1e-9)
+		require.ErrorIs(t, immovable.Run(ctx), context.DeadlineExceeded)
+		assert.InDelta(t, before, immovable.MeasureCoherence(), 1e-9, "nobody moves")
+
+		capped := newSwarm(t, swarm.WithMaxStubbornness(0.5))
+		require.NoError(t, capped.Run(context.Background()))
+	})
+}
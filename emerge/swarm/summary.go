@@ -0,0 +1,22 @@
+package swarm
+
+import "fmt"
+
+// Summary returns a one-line description of the swarm for logs and
+// interactive sessions, e.g.
+//
+//	agents=200 goal=minimize_api_calls coherence=0.82/0.85 energy(avg)=63.1 disrupted=false components=1
+//
+// Coherence is shown against the effective target (see
+// EffectiveTargetCoherence). Disrupted is true from a disruption until the
+// swarm has recovered from it (see LastRecovery), and components counts
+// the connected components of the topology, links treated as undirected.
+// The health figures come from one Metrics snapshot. The format is stable:
+// fields keep their names and order, and new fields are only appended.
+func (s *Swarm) Summary() string {
+	m := s.Metrics()
+	disrupted := s.disruptions.Load() > 0 && !s.LastRecovery().Recovered
+	return fmt.Sprintf("agents=%d goal=%s coherence=%.2f/%.2f energy(avg)=%.1f disrupted=%t components=%d",
+		m.Agents, s.goalType.ID(), m.Coherence, s.EffectiveTargetCoherence(), m.MeanEnergy,
+		disrupted, len(connectedComponents(s.collectAgents())))
+}
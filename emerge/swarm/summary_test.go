@@ -0,0 +1,49 @@
+package swarm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/goal"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+func TestSummary(t *testing.T) {
+	t.Parallel()
+
+	agents := buildAgents(10)
+	for i, a := range agents {
+		next := agents[(i+1)%len(agents)]
+		a.ConnectTo(next.ID, next)
+		next.ConnectTo(a.ID, a)
+	}
+	target := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.85}
+	s, err := swarm.FromAgents(agents, target, swarm.WithGoal(goal.MaintainRhythm), swarm.WithSeed(2))
+	require.NoError(t, err)
+	defer s.Close()
+
+	assert.Equal(t,
+		"agents=10 goal=maintain_rhythm coherence=0.96/0.85 energy(avg)=80.0 disrupted=false components=1",
+		s.Summary())
+
+	// Cutting two agents off leaves coherence intact, so the swarm counts
+	// as recovered at once
+	_, err = s.DisruptSpecific([]string{"agent-0", "agent-1"}, swarm.DisruptionSpec{Kind: swarm.Partition})
+	require.NoError(t, err)
+	assert.Equal(t,
+		"agents=10 goal=maintain_rhythm coherence=0.96/0.85 energy(avg)=80.0 disrupted=false components=2",
+		s.Summary())
+
+	// Scrambling most phases leaves it disrupted until it recovers
+	_, err = s.DisruptSpecific([]string{"agent-2", "agent-3", "agent-4", "agent-5", "agent-6", "agent-7"},
+		swarm.DisruptionSpec{Kind: swarm.PhaseScramble})
+	require.NoError(t, err)
+	require.False(t, s.LastRecovery().Recovered)
+	assert.Regexp(t,
+		`^agents=10 goal=maintain_rhythm coherence=0\.\d\d/0\.85 energy\(avg\)=80\.0 disrupted=true components=2$`,
+		s.Summary())
+}
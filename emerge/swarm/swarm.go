@@ -2,17 +2,24 @@ package swarm
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
+	"math/rand/v2"
 	"runtime"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/carlisia/bio-adapt/emerge/agent"
 	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/goal"
 	"github.com/carlisia/bio-adapt/emerge/monitoring"
+	"github.com/carlisia/bio-adapt/emerge/scale"
+	"github.com/carlisia/bio-adapt/emerge/transport"
 	"github.com/carlisia/bio-adapt/internal/config"
 	"github.com/carlisia/bio-adapt/internal/emerge"
-	"github.com/carlisia/bio-adapt/internal/random"
 )
 
 // Implementation selection thresholds
@@ -27,12 +34,14 @@ const (
 // Swarm represents a collection of autonomous agents achieving
 // synchronization through emergent behavior - no central control.
 type Swarm struct {
-	// Storage - automatically selected based on size
+	// Storage - selected by size unless set (see WithStorage)
 	agents      sync.Map       // map[string]*agent.Agent - used for small swarms
 	agentSlice  []*agent.Agent // Direct access by index - used for large swarms
 	agentIndex  map[string]int // ID to index mapping - used for large swarms
 	agentsMutex sync.RWMutex   // Protects slice access
 	optimized   bool           // Whether using optimized storage
+	storage     Storage        // Requested storage (see WithStorage)
+	maxAgents   int            // Size limit; 0 means none (see WithMaxAgents)
 
 	goalState core.State
 	config    config.Swarm
@@ -45,18 +54,190 @@ type Swarm struct {
 
 	// Track initialization state
 	connectionsEstablished bool
+	topologyBuilder        func(*Swarm) error // Custom topology from WithTopology
+
+	// Builds the agents; nil for the default ones (see WithAgentBuilder)
+	agentBuilder func(id string, swarmSize int, cfg config.Swarm) *agent.Agent
 
 	// Goal-directed synchronization
 	goalDirectedSync *GoalDirectedSync
-	goalConfig       *Config // Configuration for goal-directed sync
+	goalConfig       *Config   // Configuration for goal-directed sync
+	goalType         goal.Type // Business goal this swarm was configured for
 
 	// Performance optimization for large swarms
 	workerPool *WorkerPool // Goroutine pool for concurrent updates
 
 	// Recovery configuration for continuous operation
 	recoveryConfig RecoveryConfig
+
+	// Phase bands for multi-window coordination (read-only after New)
+	bands  []Band
+	bandOf map[string]int // Agent ID to band index
+
+	// Pacemaker agent and its pull (see WithHub; read-only after New)
+	hubID        string
+	hubInfluence float64
+
+	// Lifecycle event subscribers
+	events eventBus
+
+	// Called when coherence crosses the target (see WithConvergenceCallback)
+	convergenceCallback func(ConvergenceEvent)
+
+	// Called once per period while coherent enough (see WithBatchTrigger)
+	batchTrigger   func(BatchWindow)
+	batchThreshold float64
+
+	// Identity and periodic health observers
+	id          string
+	observers   []monitoring.Observer
+	observeTick time.Duration // Sampling interval; 0 uses MonitoringInterval (see WithObserverInterval)
+	disruptions atomic.Uint64
+	recoveries  atomic.Uint64 // Recoveries completed (see FailureStats)
+
+	// Update interval backing off while idle; nil keeps it fixed (see WithAdaptiveTickInterval)
+	tick *adaptiveTick
+
+	// Recovery from the latest disruption (see LastRecovery, WithDisruptionObserver)
+	recovery            recoveryTracker
+	disruptionObservers []func(RecoveryEvent)
+
+	// Samples per-agent natural frequencies (see WithFrequencyDistribution)
+	frequencyDist func() time.Duration
+
+	// Kuramoto coupling gain K; 0 keeps the default coupling (see WithCouplingStrength)
+	couplingStrength atomic.Uint64 // math.Float64bits
+
+	// Share of each cycle duty-cycling agents are active (see WithActiveFraction)
+	activeFraction float64
+
+	// Start of the rhythm clock (see ActiveFraction, WithReferenceRhythm)
+	epoch time.Time
+
+	// External beat the swarm locks to; nil follows consensus (see WithReferenceRhythm)
+	reference func(time.Time) float64
+
+	// Source of time; nil uses the system clock (see WithClock)
+	clock Clock
+
+	// Starting phase of the i-th agent (see WithInitialPhases)
+	initialPhase func(i int) float64
+
+	// Freezes agent updates while set (see Pause)
+	paused atomic.Bool
+
+	// Registered strategy applied to every agent (see WithStrategy)
+	strategyName string
+
+	// Registered decision maker applied to every agent (see WithDecisionMaker)
+	decisionMakerName string
+
+	// Model pricing every agent's proposals (see WithCostModel)
+	costModel core.CostModel
+
+	// Cuts loop steps short by decision confidence (see WithConfidenceScaling)
+	confidenceScaling bool
+
+	// Names agents by index; nil names them "agent-i" (see WithAgentIDFunc)
+	agentIDFunc func(i int) string
+
+	// Neighbor count agents are relinked up to after disruptions; 0 when off (see WithMinNeighbors)
+	minNeighbors int
+
+	// Local goal weight given to every agent, if set (see WithGoalBlend)
+	blendGoals bool
+	goalBlend  float64
+
+	// Seeded random source; nil uses the shared secure source (see WithSeed)
+	rng   *rand.Rand
+	rngMu sync.Mutex
+	seed  int64 // The seed rng was seeded with
+
+	// Agents in other processes coupled in via gossip (see WithRemoteNeighbors)
+	remoteEndpoints []transport.Endpoint
+	remote          remoteTable
+	gossipServer    atomic.Pointer[transport.GossipServer] // See AttachGossipServer
+
+	// Workers agent updates are sharded across; 0 or 1 is serial (see WithParallelism)
+	parallelism int
+
+	// Floor for relaxing an unreachable target, 0 when disabled, and the
+	// relaxed target as float bits, 0 until relaxed (see WithAdaptiveTarget)
+	adaptiveMin   float64
+	relaxedTarget atomic.Uint64
+
+	// Looser coherence each run converges to first and how long it is
+	// held there, 0 when disabled (see WithWarmupTarget)
+	warmupCoherence float64
+	warmupHold      time.Duration
+
+	// Guards goalState once the swarm is running; retargets counts SetTarget
+	// calls and retuneFrequency holds a new target frequency until agents
+	// track it, 0 when there is none (see SetTarget)
+	targetMu        sync.RWMutex
+	retargets       atomic.Uint64
+	retuneFrequency atomic.Int64
+
+	// Serializes AddAgent and RemoveAgent; observers see each change (see WithMembershipObserver)
+	membershipMu        sync.Mutex
+	membershipObservers []func(MembershipEvent)
+
+	// Per-agent phase motion sampled each iteration (see MeasureJitter)
+	jitter jitterTracker
+
+	// How long each agent's phase has stood still (see WithInfluenceDecay)
+	decay influenceDecay
+
+	// Standard deviation of per-tick phase noise (see WithPhaseNoise)
+	phaseNoise atomic.Uint64 // math.Float64bits
+
+	// Random disruptions applied while running (see WithFailureInjector)
+	failures failureInjector
+
+	// Coherence of recent ticks (see SustainedCoherence)
+	sustained sustainedTracker
+
+	// Coherence window convergence is judged over; nil judges each tick (see WithCoherenceWindow)
+	smoothed *sustainedTracker
+
+	// Per-tick phase snapshots for offline analysis (see WithTrajectoryRecorder)
+	trajectory trajectoryRecorder
+	// Runs, iterations and inputs for replay; nil logs nothing (see WithEventLog)
+	eventLog *eventLog
+
+	// Links to agents of other swarms by peer, guarded by bridgeMu (see Bridge)
+	bridges map[*Swarm][]bridgeEdge
+
+	// Running sums of agent phases behind MeasureCoherence
+	phaseSums phaseSums
+
+	// Cap on agent stubbornness at creation, if set (see WithMaxStubbornness)
+	capStubbornness bool
+	maxStubbornness float64
+
+	// Energy charged for phase adjustments and its recharge (see WithRechargePolicy)
+	energy energyModel
+
+	// Neighbors sampled per agent update; 0 means all (see WithGossipFanout)
+	gossipFanout atomic.Int64
+
+	// Ticks an agent rests after each adjustment (see WithAdjustmentCooldown)
+	cooldown int
+
+	// Early stopping when coherence stalls (see WithPlateauDetection)
+	plateauWindow  int
+	plateauEpsilon float64
+
+	// Runs in progress and callers waiting on them (see WaitForConvergence)
+	runs runTracker
 }
 
+// Swarm exposes its frequency distribution to monitoring.
+var _ monitoring.FrequencySource = (*Swarm)(nil)
+
+// Swarm can be driven and observed as a generic coordinator.
+var _ core.Coordinator = (*Swarm)(nil)
+
 // Option configures a Swarm.
 type Option func(*Swarm) error
 
@@ -66,12 +247,163 @@ type Option func(*Swarm) error
 //
 //nolint:gocyclo // Initialization requires multiple validation steps
 func New(size int, goal core.State, opts ...Option) (*Swarm, error) {
-	if size <= 0 {
-		return nil, fmt.Errorf("%w: got %d", ErrInvalidSwarmSize, size)
+	s, err := configure(size, goal, opts)
+	if err != nil {
+		return nil, err
+	}
+	if s.id == "" {
+		s.id = nextSwarmID()
+	}
+
+	// Create agents if not already created by options
+	if s.optimized {
+		// Use optimized creation for large swarms
+		if len(s.agentSlice) == 0 {
+			if err := s.createOptimizedAgents(); err != nil {
+				return nil, fmt.Errorf("failed to create agents: %w", err)
+			}
+			if s.rng != nil {
+				s.seedAgents()
+			}
+		}
+	} else {
+		// Use standard creation for small swarms
+		agentCount := 0
+		s.agents.Range(func(_, _ any) bool {
+			agentCount++
+			return false // Just need to check if any exist
+		})
+		if agentCount == 0 {
+			if err := s.createDefaultAgents(); err != nil {
+				return nil, fmt.Errorf("failed to create agents: %w", err)
+			}
+			if s.rng != nil {
+				s.seedAgents()
+			}
+		}
+	}
+
+	s.capStubbornnessOf(s.collectAgents()...)
+
+	if s.initialPhase != nil {
+		if err := s.assignInitialPhases(); err != nil {
+			return nil, fmt.Errorf("failed to assign initial phases: %w", err)
+		}
+	}
+
+	if s.frequencyDist != nil {
+		if err := s.assignNaturalFrequencies(); err != nil {
+			return nil, fmt.Errorf("failed to assign natural frequencies: %w", err)
+		}
+	}
+
+	// Establish connections if not already done
+	if s.topologyBuilder != nil {
+		if err := s.topologyBuilder(s); err != nil {
+			return nil, fmt.Errorf("topology build failed: %w", err)
+		}
+	} else if !s.connectionsEstablished {
+		s.establishConnections()
+	}
+
+	if len(s.bands) > 0 {
+		s.assignBands()
+	}
+
+	if s.hubID != "" {
+		if err := s.assignHub(); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.GossipFanout() > 0 {
+		s.applyGossipFanout()
+	}
+
+	if s.cooldown > 0 {
+		s.applyCooldown()
+	}
+
+	if s.strategyName != "" {
+		if err := s.applyStrategy(); err != nil {
+			return nil, fmt.Errorf("failed to apply strategy: %w", err)
+		}
+	}
+
+	if s.decisionMakerName != "" {
+		if err := s.applyDecisionMaker(); err != nil {
+			return nil, fmt.Errorf("failed to apply decision maker: %w", err)
+		}
+	}
+
+	if s.costModel != nil {
+		for _, a := range s.collectAgents() {
+			a.SetCostModel(s.costModel)
+		}
+	}
+	s.blendGoalsOf(s.collectAgents()...)
+
+	// Initialize goal-directed synchronization
+	if s.goalConfig != nil {
+		s.goalDirectedSync = NewGoalDirectedSyncWithConfig(s, s.goalConfig)
+	} else {
+		s.goalDirectedSync = NewGoalDirectedSync(s)
+	}
+	if s.strategyName != "" {
+		if err := s.goalDirectedSync.useStrategy(s.strategyName); err != nil {
+			return nil, fmt.Errorf("failed to apply strategy: %w", err)
+		}
+	}
+
+	// Start the worker pool last so failed construction leaves no goroutines behind
+	switch {
+	case s.parallelism > 1:
+		s.workerPool = NewWorkerPool(s.parallelism)
+	case s.optimized:
+		s.workerPool = NewWorkerPool(getOptimalWorkerCount(size))
+	}
+
+	// Start the running phase sums from the finished swarm
+	s.phaseSums.invalidate()
+
+	if err := s.logStart(); err != nil {
+		s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// checkSize rejects a swarm size: ErrEmptySwarm for none, and
+// ErrInvalidSwarmSize for a negative one.
+func checkSize(size int) error {
+	switch {
+	case size == 0:
+		return ErrEmptySwarm
+	case size < 0:
+		return fmt.Errorf("%w: got %d", ErrInvalidSwarmSize, size)
+	}
+	return nil
+}
+
+// validateState checks a goal or target state, marking a bad coherence
+// with ErrInvalidCoherence.
+func validateState(st core.State) error {
+	err := st.Validate()
+	if err != nil && (math.IsNaN(st.Coherence) || st.Coherence < 0 || st.Coherence > 1) {
+		return fmt.Errorf("%w: %w", ErrInvalidCoherence, err)
+	}
+	return err
+}
+
+// configure validates the size and goal and applies opts to a swarm with
+// no agents yet, checking the result as New and Validate need it.
+func configure(size int, goal core.State, opts []Option) (*Swarm, error) {
+	if err := checkSize(size); err != nil {
+		return nil, err
 	}
 
 	// Validate goal state using centralized validation
-	if err := goal.Validate(); err != nil {
+	if err := validateState(goal); err != nil {
 		return nil, fmt.Errorf("invalid goal state: %w", err)
 	}
 
@@ -100,15 +432,6 @@ func New(size int, goal core.State, opts ...Option) (*Swarm, error) {
 		recoveryConfig: DefaultRecoveryConfig(goal.Coherence),
 	}
 
-	// Initialize optimized storage for large swarms
-	if s.optimized {
-		s.agentSlice = make([]*agent.Agent, 0, size)
-		s.agentIndex = make(map[string]int, size)
-		// Initialize worker pool for concurrent operations
-		numWorkers := getOptimalWorkerCount(size)
-		s.workerPool = NewWorkerPool(numWorkers)
-	}
-
 	// Apply options
 	for _, opt := range opts {
 		if err := opt(s); err != nil {
@@ -116,6 +439,19 @@ func New(size int, goal core.State, opts ...Option) (*Swarm, error) {
 		}
 	}
 
+	// Fail before allocating anything that grows with size
+	if err := s.checkMaxAgents(size); err != nil {
+		return nil, err
+	}
+	s.initStorage()
+
+	s.epoch = s.now()
+
+	// Latency-sensitive swarms damp jitter unless told otherwise
+	if s.strategyName == "" {
+		s.strategyName = goalStrategy(s.goalType)
+	}
+
 	// Re-validate config after options are applied
 	if err := s.config.NormalizeAndValidate(size); err != nil {
 		return nil, fmt.Errorf("config validation failed after options: %w", err)
@@ -126,52 +462,25 @@ func New(size int, goal core.State, opts ...Option) (*Swarm, error) {
 		return nil, fmt.Errorf("swarm size %d exceeds configured maximum %d", size, s.config.MaxSwarmSize)
 	}
 
-	// Create agents if not already created by options
-	if s.optimized {
-		// Use optimized creation for large swarms
-		if len(s.agentSlice) == 0 {
-			if err := s.createOptimizedAgents(); err != nil {
-				return nil, fmt.Errorf("failed to create agents: %w", err)
-			}
-		}
-	} else {
-		// Use standard creation for small swarms
-		agentCount := 0
-		s.agents.Range(func(_, _ any) bool {
-			agentCount++
-			return false // Just need to check if any exist
-		})
-		if agentCount == 0 {
-			if err := s.createDefaultAgents(); err != nil {
-				return nil, fmt.Errorf("failed to create agents: %w", err)
-			}
-		}
-	}
-
-	// Establish connections if not already done
-	if !s.connectionsEstablished {
-		s.establishConnections()
+	if err := s.validateCombination(); err != nil {
+		return nil, err
 	}
 
-	// Initialize goal-directed synchronization
-	if s.goalConfig != nil {
-		s.goalDirectedSync = NewGoalDirectedSyncWithConfig(s, s.goalConfig)
-	} else {
-		s.goalDirectedSync = NewGoalDirectedSync(s)
+	if err := s.buildAgents(); err != nil {
+		return nil, err
 	}
-
 	return s, nil
 }
 
 // NewSwarmFromConfig creates a swarm using a configuration struct.
 // This provides an alternative to the functional options pattern.
 func NewSwarmFromConfig(size int, goal core.State, cfg config.Swarm) (*Swarm, error) {
-	if size <= 0 {
-		return nil, fmt.Errorf("%w: got %d", ErrInvalidSwarmSize, size)
+	if err := checkSize(size); err != nil {
+		return nil, err
 	}
 
 	// Validate goal state using centralized validation
-	if err := goal.Validate(); err != nil {
+	if err := validateState(goal); err != nil {
 		return nil, fmt.Errorf("invalid goal state: %w", err)
 	}
 
@@ -187,6 +496,7 @@ func NewSwarmFromConfig(size int, goal core.State, cfg config.Swarm) (*Swarm, er
 		monitor:     monitoring.New(),
 		basin:       emerge.NewAttractorBasin(goal, cfg.BasinStrength, cfg.BasinWidth),
 		convergence: monitoring.NewConvergence(goal, goal.Coherence),
+		id:          nextSwarmID(),
 	}
 
 	// Create agents
@@ -212,8 +522,12 @@ func (s *Swarm) createDefaultAgents() error {
 	agentConfig := config.AgentFromSwarm(s.config)
 	agentConfig.SwarmSize = s.size
 
-	for i := range s.size {
-		a, err := agent.NewFromConfig(fmt.Sprintf("agent-%d", i), agentConfig)
+	ids, err := s.agentIDs(s.size)
+	if err != nil {
+		return err
+	}
+	for i, id := range ids {
+		a, err := agent.NewFromConfig(id, agentConfig)
 		if err != nil {
 			return fmt.Errorf("failed to create agent %d: %w", i, err)
 		}
@@ -227,6 +541,11 @@ func (s *Swarm) createOptimizedAgents() error {
 	agentConfig := config.AgentFromSwarm(s.config)
 	agentConfig.SwarmSize = s.size
 
+	ids, err := s.agentIDs(s.size)
+	if err != nil {
+		return err
+	}
+
 	// Pre-allocate the slice
 	s.agentSlice = make([]*agent.Agent, s.size)
 
@@ -236,7 +555,7 @@ func (s *Swarm) createOptimizedAgents() error {
 		end := minInt(i+batchSize, s.size)
 
 		for j := i; j < end; j++ {
-			id := fmt.Sprintf("agent-%d", j)
+			id := ids[j]
 
 			// Create agent with pre-allocated neighbor storage
 			a, err := agent.NewOptimizedFromConfig(id, agentConfig)
@@ -277,6 +596,19 @@ func WithGoalConfig(cfg *Config) Option {
 	}
 }
 
+// WithGoal records the business goal the swarm serves and, unless a goal
+// config has already been supplied via WithGoalConfig, uses the goal's
+// tuned configuration for goal-directed synchronization.
+func WithGoal(g goal.Type) Option {
+	return func(s *Swarm) error {
+		s.goalType = g
+		if s.goalConfig == nil {
+			s.goalConfig = For(g)
+		}
+		return nil
+	}
+}
+
 // WithMonitor sets a custom monitor.
 func WithMonitor(monitor *monitoring.Monitor) Option {
 	return func(s *Swarm) error {
@@ -285,23 +617,42 @@ func WithMonitor(monitor *monitoring.Monitor) Option {
 	}
 }
 
-// WithAgentBuilder uses a custom function to create agents.
+// WithAgentBuilder uses a custom function to create agents. The agents
+// are built once every option is applied and the size is checked (see
+// WithMaxAgents), so option order does not matter.
 func WithAgentBuilder(builder func(id string, swarmSize int, cfg config.Swarm) *agent.Agent) Option {
 	return func(s *Swarm) error {
-		for i := range s.size {
-			a := builder(fmt.Sprintf("agent-%d", i), s.size, s.config)
-			s.agents.Store(a.ID, a)
-		}
+		s.agentBuilder = builder
 		return nil
 	}
 }
 
-// WithTopology uses a custom topology builder.
+// buildAgents creates the agents with the builder from WithAgentBuilder,
+// if there is one.
+func (s *Swarm) buildAgents() error {
+	if s.agentBuilder == nil {
+		return nil
+	}
+	ids, err := s.agentIDs(s.size)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		a := s.agentBuilder(id, s.size, s.config)
+		s.agents.Store(a.ID, a)
+	}
+	return nil
+}
+
+// WithTopology uses a custom topology builder instead of the default
+// probabilistic connections. The builder runs once all agents exist, so
+// option order relative to WithAgentBuilder does not matter.
 func WithTopology(builder func(*Swarm) error) Option {
 	return func(s *Swarm) error {
-		if err := builder(s); err != nil {
-			return fmt.Errorf("topology build failed: %w", err)
+		if builder == nil {
+			return errors.New("topology builder must not be nil")
 		}
+		s.topologyBuilder = builder
 		s.connectionsEstablished = true
 		return nil
 	}
@@ -338,7 +689,7 @@ func (s *Swarm) collectAgents() []*agent.Agent {
 		return result
 	}
 
-	// Standard path
+	// Standard path, sorted so iteration order is stable between calls
 	var agents []*agent.Agent
 	s.agents.Range(func(_, value any) bool {
 		if a, ok := value.(*agent.Agent); ok {
@@ -346,31 +697,38 @@ func (s *Swarm) collectAgents() []*agent.Agent {
 		}
 		return true
 	})
+	slices.SortFunc(agents, func(a, b *agent.Agent) int {
+		return compareAgentIDs(a.ID, b.ID)
+	})
 	return agents
 }
 
 // establishMinimalConnections creates minimal random connections for large swarms
 func (s *Swarm) establishMinimalConnections(agents []*agent.Agent) {
-	// For optimized agents, use ConnectTo method which handles optimized storage
 	for _, a := range agents {
-		connected := 0
-		attempts := 0
-		maxAttempts := len(agents) * 2
+		s.connectMinimal(a, agents)
+	}
+}
 
-		for connected < s.config.MinNeighbors && connected < len(agents)-1 && attempts < maxAttempts {
-			idx := random.Intn(len(agents))
-			neighbor := agents[idx]
+// connectMinimal links an agent to MinNeighbors random peers.
+func (s *Swarm) connectMinimal(a *agent.Agent, agents []*agent.Agent) {
+	connected := 0
+	attempts := 0
+	maxAttempts := len(agents) * 2
 
-			if neighbor.ID != a.ID {
-				// Check if already connected
-				if _, exists := a.Neighbors().Load(neighbor.ID); !exists {
-					a.Neighbors().Store(neighbor.ID, neighbor)
-					neighbor.Neighbors().Store(a.ID, a)
-					connected++
-				}
+	for connected < s.config.MinNeighbors && connected < len(agents)-1 && attempts < maxAttempts {
+		idx := s.randIntn(len(agents))
+		neighbor := agents[idx]
+
+		if neighbor.ID != a.ID {
+			// Check if already connected
+			if _, exists := a.Neighbors().Load(neighbor.ID); !exists {
+				a.Neighbors().Store(neighbor.ID, neighbor)
+				neighbor.Neighbors().Store(a.ID, a)
+				connected++
 			}
-			attempts++
 		}
+		attempts++
 	}
 }
 
@@ -400,7 +758,7 @@ func (s *Swarm) connectToNeighbors(a *agent.Agent, agents []*agent.Agent, agentI
 			continue
 		}
 
-		if random.Float64() < s.config.ConnectionProbability {
+		if s.randFloat64() < s.config.ConnectionProbability {
 			a.Neighbors().Store(neighbor.ID, neighbor)
 			neighbor.Neighbors().Store(a.ID, a)
 			connected++
@@ -414,7 +772,7 @@ func (s *Swarm) connectToNeighbors(a *agent.Agent, agents []*agent.Agent, agentI
 func (s *Swarm) ensureMinimumConnectivity(a *agent.Agent, agents []*agent.Agent, connected int) {
 	if connected < s.config.MinNeighbors && len(agents) > s.config.MinNeighbors {
 		for connected < s.config.MinNeighbors {
-			idx := random.Intn(len(agents))
+			idx := s.randIntn(len(agents))
 			neighbor := agents[idx]
 
 			if neighbor.ID == a.ID {
@@ -433,6 +791,14 @@ func (s *Swarm) ensureMinimumConnectivity(a *agent.Agent, agents []*agent.Agent,
 // Run starts the swarm and achieves synchronization using goal-directed pattern completion.
 // Uses adaptive strategies and convergence dynamics to ensure goal achievement.
 // This method exits once the target coherence is achieved or the context is canceled.
+// Either way the tick in progress completes first; see Shutdown to stop
+// runs from elsewhere and wait for them to end.
+//
+// Run returns nil once the target is reached. When ctx ends it returns
+// ctx.Err() as is, so callers can tell a normal stop from a failure with
+// errors.Is(err, context.Canceled) or context.DeadlineExceeded. Other
+// errors match ErrShutdown, ErrPlateau, or ErrNotConverged when the
+// iterations ran out first.
 //
 // Use Run() when you need:
 //   - One-time synchronization (batch processing, initialization)
@@ -444,45 +810,118 @@ func (s *Swarm) ensureMinimumConnectivity(a *agent.Agent, agents []*agent.Agent,
 //   - Continuous monitoring and maintenance of synchronization
 //   - Production systems that must maintain coherence over time
 func (s *Swarm) Run(ctx context.Context) error {
-	// Create target pattern from goal state
-	targetPattern := &core.TargetPattern{
-		Phase:     s.goalState.Phase,
-		Frequency: s.goalState.Frequency,
-		Coherence: s.goalState.Coherence,
-		Amplitude: 1.0,
-		Stability: 0.9,
-	}
+	ctx, id := s.runs.start(ctx)
+	err := shutdownErr(ctx, s.run(ctx))
+	s.runs.finish(id, err)
+	s.events.endRun()
+	return err
+}
+
+// run is the body of Run, for callers that have already registered the run.
+func (s *Swarm) run(ctx context.Context) error {
+	stopObservers := s.startObservers(ctx)
+	defer stopObservers()
+	stopGossip := s.startGossip(ctx)
+	defer stopGossip()
+	stopBatches := s.startBatchTrigger(ctx)
+	defer stopBatches()
 
 	// Use goal-directed synchronization
-	return s.goalDirectedSync.AchieveSynchronization(ctx, targetPattern)
+	return s.synchronize(ctx, s.targetPattern())
+}
+
+// Frequencies returns the current oscillation frequency of every agent.
+// It lets monitoring tools observe the frequency distribution.
+func (s *Swarm) Frequencies() []time.Duration {
+	agents := s.collectAgents()
+	freqs := make([]time.Duration, len(agents))
+	for i, a := range agents {
+		freqs[i] = a.Frequency()
+	}
+	return freqs
 }
 
 // MeasureCoherence calculates global synchronization level.
 // This is for monitoring only - agents don't have access to this.
+// Coherence reflects phase alignment only; agents with different
+// frequencies can still be fully coherent.
+//
+// For custom goals (see goal.Custom) coherence is cluster purity instead:
+// how tightly agents sit on their nearest slots, scaled by the share of
+// slots holding an agent (see slotPurity). A swarm split evenly over three
+// slots 2π/3 apart has a plain coherence near 0 but a purity near 1.
+//
+// The order parameter is kept up to date as agents' phases change, so
+// measuring it costs the same for any swarm size. It is rebuilt from
+// scratch after membership changes and every 65,536 phase changes, so
+// floating-point drift stays far below anything a caller could notice.
+// Purity is computed in full on each call.
+//
+// A lone agent is trivially coherent: its coherence is exactly 1.
 func (s *Swarm) MeasureCoherence() float64 {
+	if _, ok := s.goalType.Spec(); !ok {
+		return s.phaseSums.orderParameter(s.collectAgents)
+	}
+
+	var phases []float64
 	if s.optimized {
 		// Optimized path for large swarms - better cache locality
 		s.agentsMutex.RLock()
-		phases := make([]float64, len(s.agentSlice))
+		phases = make([]float64, len(s.agentSlice))
 		for i, a := range s.agentSlice {
 			phases[i] = a.Phase()
 		}
 		s.agentsMutex.RUnlock()
-		return core.MeasureCoherence(phases)
+	} else {
+		// Standard path for small swarms, in stable order so sums are reproducible
+		agents := s.collectAgents()
+		phases = make([]float64, len(agents))
+		for i, a := range agents {
+			phases[i] = a.Phase()
+		}
 	}
 
-	// Standard path for small swarms
-	var phases []float64
-	s.agents.Range(func(_, value any) bool {
-		if a, ok := value.(*agent.Agent); ok {
-			phases = append(phases, a.Phase())
-		}
-		return true
-	})
-	return core.MeasureCoherence(phases)
+	return s.coherence(phases)
+}
+
+// MeasureDispersion calculates how evenly agent phases are spread.
+// It returns a value near 1.0 when phases are evenly spread and near 0
+// when they are clustered, giving anti-phase goals such as DistributeLoad
+// a metric where higher is better. See core.MeasureDispersion.
+func (s *Swarm) MeasureDispersion() float64 {
+	agents := s.collectAgents()
+	phases := make([]float64, len(agents))
+	for i, a := range agents {
+		phases[i] = a.Phase()
+	}
+	return core.MeasureDispersion(phases)
+}
+
+// LoadFairness reports how evenly agents share load when the circle is
+// split into sectors equal arcs, each standing for a server or time slot:
+// the most loaded sector's agent count over the least loaded one's. It is
+// 1.0 when load is perfectly fair and +Inf when some sector has no agents,
+// as when the whole swarm is synchronized. It complements MeasureDispersion
+// for DistributeLoad. See core.MeasureLoadFairness.
+func (s *Swarm) LoadFairness(sectors int) float64 {
+	agents := s.collectAgents()
+	phases := make([]float64, len(agents))
+	for i, a := range agents {
+		phases[i] = a.Phase()
+	}
+	return core.MeasureLoadFairness(phases, sectors)
 }
 
-// Agents returns all agents in the swarm.
+// MeasureFrequencyCoherence calculates how tightly agent frequencies
+// cluster, from 1.0 when every agent runs at the same frequency down toward
+// 0 as they spread. It complements MeasureCoherence, which ignores
+// frequency. See core.MeasureFrequencyCoherence.
+func (s *Swarm) MeasureFrequencyCoherence() float64 {
+	return core.MeasureFrequencyCoherence(s.Frequencies())
+}
+
+// Agents returns all agents in the swarm, keyed by ID. Map iteration order
+// is random; use AgentsSorted for a stable order.
 func (s *Swarm) Agents() map[string]*agent.Agent {
 	if s.optimized {
 		// Optimized path - convert slice to map
@@ -508,6 +947,25 @@ func (s *Swarm) Agents() map[string]*agent.Agent {
 	return agents
 }
 
+// AgentsSorted returns all agents ordered by ID: shorter IDs first, then
+// alphabetically, so the default "agent-N" IDs come out in numeric order
+// ("agent-2" before "agent-10"). The order is
+// the same on every call and every run, which makes assigning roles or
+// strategies by index reproducible.
+//
+// The slice is a snapshot: agents added or removed later are not reflected,
+// although the agents themselves are live.
+func (s *Swarm) AgentsSorted() []*agent.Agent {
+	agents := s.collectAgents()
+	if s.optimized {
+		// Storage order is creation order, which AddAgent can break
+		slices.SortFunc(agents, func(a, b *agent.Agent) int {
+			return compareAgentIDs(a.ID, b.ID)
+		})
+	}
+	return agents
+}
+
 // Agent retrieves an agent by ID.
 func (s *Swarm) Agent(id string) (*agent.Agent, bool) {
 	if s.optimized {
@@ -533,6 +991,8 @@ func (s *Swarm) Agent(id string) (*agent.Agent, bool) {
 
 // Size returns the number of agents in the swarm.
 func (s *Swarm) Size() int {
+	s.agentsMutex.RLock()
+	defer s.agentsMutex.RUnlock()
 	return s.size
 }
 
@@ -541,8 +1001,52 @@ func (s *Swarm) Config() config.Swarm {
 	return s.config
 }
 
+// TargetState returns the target state the swarm is actually working toward.
+// This reflects any adjustment made at construction, such as clamping an
+// unreachable coherence target to the practical limit for the swarm size,
+// but not relaxation by WithAdaptiveTarget (see EffectiveTargetCoherence).
+func (s *Swarm) TargetState() core.State {
+	return s.target()
+}
+
+// Goal returns the business goal the swarm was configured for.
+// Swarms created without WithGoal report goal.MinimizeAPICalls.
+func (s *Swarm) Goal() goal.Type {
+	return s.goalType
+}
+
+// Scale returns the size category matching the swarm's agent count.
+func (s *Swarm) Scale() scale.Size {
+	return scale.FromCount(s.Size())
+}
+
+// EffectiveConfig returns a copy of the goal-directed configuration
+// currently driving synchronization.
+func (s *Swarm) EffectiveConfig() Config {
+	if s.goalDirectedSync == nil || s.goalDirectedSync.config == nil {
+		return *defaultConfig()
+	}
+	return *s.goalDirectedSync.config
+}
+
 // IsConverged returns whether the swarm has reached convergence.
+// For goals that prefer dispersion (see goal.Type.PrefersDispersion) the
+// swarm is converged once MeasureDispersion reaches one minus the target
+// coherence, so a coherence target of 0.3 asks for a dispersion of 0.7.
+// A duty-cycling swarm (see WithActiveFraction) is converged once its
+// agents' active windows cover the cycle evenly. Otherwise, with
+// WithCoherenceWindow, it is converged while SmoothedCoherence over a full
+// window reaches the target.
 func (s *Swarm) IsConverged() bool {
+	if agents := s.collectAgents(); dutyCycling(agents) {
+		return dutyCycleBalanced(agents)
+	}
+	if s.goalType.PrefersDispersion() {
+		return s.MeasureDispersion() >= 1-s.target().Coherence
+	}
+	if s.smoothed != nil {
+		return s.smoothedConverged(s.EffectiveTargetCoherence())
+	}
 	return s.convergence.IsConverged()
 }
 
@@ -556,29 +1060,18 @@ func (s *Swarm) CurrentCoherence() float64 {
 	return s.convergence.CurrentCoherence()
 }
 
-// DisruptAgents randomly disrupts a percentage of agents.
+// DisruptAgents randomly disrupts a percentage of agents by scrambling
+// their phases. It is shorthand for Disrupt with a PhaseScramble spec.
 func (s *Swarm) DisruptAgents(percentage float64) {
-	// Clamp percentage to valid range [0, 1]
-	if percentage < 0 {
-		percentage = 0
-	} else if percentage > 1 {
-		percentage = 1
-	}
-
-	targetCount := int(float64(s.Size()) * percentage)
-	disrupted := 0
-
-	s.agents.Range(func(_, value any) bool {
-		if disrupted >= targetCount {
-			return false
-		}
+	_, _ = s.Disrupt(DisruptionSpec{Kind: PhaseScramble, Fraction: percentage}) // Cannot fail for PhaseScramble
+}
 
-		if a, ok := value.(*agent.Agent); ok {
-			a.SetPhase(random.Phase())
-			disrupted++
-		}
-		return true
-	})
+// afterDisruption records a disruption and clears convergence state so the
+// swarm knows it has to converge again.
+func (s *Swarm) afterDisruption(report DisruptionReport) {
+	s.disruptions.Add(1)
+	s.publish(LifecycleEvent{Type: EventDisrupted, Disruption: &report})
+	s.tick.reset()
 
 	// Important: After disruption, the goal-directed sync may have already
 	// completed and returned from its AchieveSynchronization loop.
@@ -607,15 +1100,49 @@ func (s *Swarm) DisruptAgents(percentage float64) {
 	}
 }
 
-// ForEachAgent applies a function to each agent in the swarm.
-// This provides controlled access to agents without exposing the internal map.
+// Close releases background resources held by the swarm.
+// Large swarms keep a worker pool alive between runs; Close stops it so no
+// goroutines outlive the swarm. This matters for leak checks and for tests
+// inside a testing/synctest bubble, which require every goroutine to exit.
+// Close also closes every event subscription (see Events). It is safe to
+// call more than once and on swarms without a pool.
+func (s *Swarm) Close() {
+	if s.workerPool != nil {
+		s.workerPool.Stop()
+	}
+	s.events.close()
+}
+
+// Pause halts agent updates without canceling a running Run or
+// RunContinuous. All state is preserved, so MeasureCoherence keeps
+// returning the frozen value and agents spend no energy until Resume.
+// Paused iterations do not count against Run's iteration budget.
+// Pause is safe to call concurrently with Run and is a no-op if the
+// swarm is already paused.
+func (s *Swarm) Pause() {
+	s.paused.Store(true)
+}
+
+// Resume continues agent updates after Pause. It is a no-op if the swarm
+// is not paused.
+func (s *Swarm) Resume() {
+	s.paused.Store(false)
+}
+
+// Paused reports whether the swarm is paused.
+func (s *Swarm) Paused() bool {
+	return s.paused.Load()
+}
+
+// ForEachAgent applies a function to each agent in the swarm until fn
+// returns false. This provides controlled access to agents without exposing
+// the internal storage.
 func (s *Swarm) ForEachAgent(fn func(*agent.Agent) bool) {
-	s.agents.Range(func(_, value any) bool {
-		if agent, ok := value.(*agent.Agent); ok {
-			return fn(agent)
+	for _, a := range s.collectAgents() {
+		if !fn(a) {
+			return
 		}
-		return true
-	})
+	}
 }
 
 // AutoScaleConfig returns a configuration that automatically scales based on swarm size.
@@ -634,6 +1161,11 @@ type WorkerPool struct {
 	workers   int
 	workQueue chan func()
 	quit      chan struct{}
+	stopOnce  sync.Once
+
+	// Guards against queuing work no worker will run (see Submit)
+	mu      sync.RWMutex
+	stopped bool
 }
 
 // NewWorkerPool creates a new worker pool.
@@ -659,19 +1191,40 @@ func (wp *WorkerPool) worker() {
 		case work := <-wp.workQueue:
 			work()
 		case <-wp.quit:
-			return
+			// Finish work queued before Stop so no submitter waits forever
+			for {
+				select {
+				case work := <-wp.workQueue:
+					work()
+				default:
+					return
+				}
+			}
 		}
 	}
 }
 
-// Submit adds work to the queue.
+// Submit adds work to the queue. Once the pool is stopped, work runs on the
+// caller's goroutine instead, so callers waiting for it never hang.
 func (wp *WorkerPool) Submit(work func()) {
+	wp.mu.RLock()
+	defer wp.mu.RUnlock()
+
+	if wp.stopped {
+		work()
+		return
+	}
 	wp.workQueue <- work
 }
 
-// Stop shuts down the worker pool.
+// Stop shuts down the worker pool. It is safe to call more than once.
 func (wp *WorkerPool) Stop() {
-	close(wp.quit)
+	wp.stopOnce.Do(func() {
+		wp.mu.Lock()
+		defer wp.mu.Unlock()
+		wp.stopped = true
+		close(wp.quit)
+	})
 }
 
 // getOptimalWorkerCount determines the best number of workers based on swarm size.
@@ -5,8 +5,6 @@ import (
 	"errors"
 	"math"
 	"time"
-
-	"github.com/carlisia/bio-adapt/emerge/core"
 )
 
 // RecoveryConfig defines thresholds for disruption detection and recovery.
@@ -84,14 +82,32 @@ type monitorState struct {
 	peakCoherence float64 // Best coherence achieved recently
 	stableCount   int     // Consecutive measurements without improvement
 	syncActive    bool    // Is synchronization currently running
+	recovering    bool    // Resync was started because coherence degraded
 	lastSyncTime  time.Time
+	retargets     uint64 // SetTarget calls seen so far
 }
 
 // needsResync determines if synchronization should be restarted based on
 // the recovery configuration thresholds.
 func (s *Swarm) needsResync(state *monitorState, currentCoherence float64) bool {
 	cfg := s.recoveryConfig
-	target := s.goalState.Coherence
+	target := s.EffectiveTargetCoherence()
+
+	// Condition 0: The swarm has fallen behind its hub (see WithHub)
+	if hub, ok := s.liveHub(); ok {
+		tolerance := s.EffectiveConfig().Convergence.PatternDistanceThreshold
+		if !s.hubFollowed(hub, s.collectAgents(), 0, tolerance) {
+			return true
+		}
+	}
+
+	// Condition 0b: The swarm has drifted off its reference (see WithReferenceRhythm)
+	if ref, ok := s.referencePhase(); ok {
+		tolerance := s.EffectiveConfig().Convergence.PatternDistanceThreshold
+		if !referenceFollowed(s.collectAgents(), ref, 0, tolerance) {
+			return true
+		}
+	}
 
 	// Condition 1: Below minimum viable coherence (system non-functional)
 	if currentCoherence < cfg.MinimumViableCoherence {
@@ -157,22 +173,31 @@ func (s *Swarm) needsResync(state *monitorState, currentCoherence float64) bool
 // via WithRecoveryConfig() option. By default, it uses thresholds based on the
 // target coherence level (see DefaultRecoveryConfig).
 //
-// This method only exits when the context is canceled. For one-time
-// synchronization without continuous monitoring, use Run() instead.
+// This method only exits when the context is canceled, after the tick in
+// progress completes; see Shutdown to stop it from elsewhere and wait for
+// it to end. For one-time synchronization without continuous monitoring,
+// use Run() instead.
 func (s *Swarm) RunContinuous(ctx context.Context) error {
+	ctx, id := s.runs.start(ctx)
+	err := shutdownErr(ctx, s.runContinuous(ctx))
+	s.runs.finish(id, err)
+	s.events.endRun()
+	return err
+}
+
+// runContinuous is the body of RunContinuous.
+func (s *Swarm) runContinuous(ctx context.Context) error {
 	// Initialize recovery config if not already set
 	if s.recoveryConfig.CheckInterval == 0 {
-		s.recoveryConfig = DefaultRecoveryConfig(s.goalState.Coherence)
+		s.recoveryConfig = DefaultRecoveryConfig(s.target().Coherence)
 	}
 
-	// Create target pattern from goal state
-	targetPattern := &core.TargetPattern{
-		Phase:     s.goalState.Phase,
-		Frequency: s.goalState.Frequency,
-		Coherence: s.goalState.Coherence,
-		Amplitude: 1.0,
-		Stability: 0.9,
-	}
+	stopObservers := s.startObservers(ctx)
+	defer stopObservers()
+	stopGossip := s.startGossip(ctx)
+	defer stopGossip()
+	stopBatches := s.startBatchTrigger(ctx)
+	defer stopBatches()
 
 	// Helper function to start synchronization
 	startSync := func(ctx context.Context) (<-chan error, context.CancelFunc) {
@@ -187,8 +212,10 @@ func (s *Swarm) RunContinuous(ctx context.Context) error {
 			s.goalDirectedSync.convergenceMonitor.Reset()
 		}
 
+		// Each run works toward the target as it stands, see SetTarget
+		targetPattern := s.targetPattern()
 		go func() {
-			done <- s.goalDirectedSync.AchieveSynchronization(syncCtx, targetPattern)
+			done <- s.synchronize(syncCtx, targetPattern)
 		}()
 
 		return done, cancel
@@ -196,19 +223,28 @@ func (s *Swarm) RunContinuous(ctx context.Context) error {
 
 	// Start initial synchronization
 	syncDone, syncCancel := startSync(ctx)
-	defer syncCancel()
 
 	// Monitoring state
-	ticker := time.NewTicker(s.recoveryConfig.CheckInterval)
+	interval := s.tick.intervalOr(s.recoveryConfig.CheckInterval)
+	ticker := s.newTicker(interval)
 	defer ticker.Stop()
 
 	state := &monitorState{
 		lastCoherence: s.MeasureCoherence(),
 		peakCoherence: 0.0,
 		syncActive:    true,
-		lastSyncTime:  time.Now(),
+		lastSyncTime:  s.now(),
+		retargets:     s.retargets.Load(),
 	}
 
+	// Let the synchronization pass in flight finish its tick before returning
+	defer func() {
+		syncCancel()
+		if state.syncActive {
+			<-syncDone
+		}
+	}()
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -217,14 +253,46 @@ func (s *Swarm) RunContinuous(ctx context.Context) error {
 		case err := <-syncDone:
 			state.syncActive = false
 
-			if err != nil && !errors.Is(err, context.Canceled) {
+			if err != nil && !errors.Is(err, context.Canceled) && !isPlateau(err) {
 				// Real error occurred
 				return err
 			}
 			// Synchronization completed successfully, continue monitoring
+			if err == nil && state.recovering {
+				state.recovering = false
+				s.publishEvent(EventRecovered)
+			}
+
+		case <-s.tick.woken():
+			// Disrupted: back to the minimum interval at once
+			interval = s.tick.interval()
+			ticker.Reset(interval)
 
-		case <-ticker.C:
+		case <-ticker.C():
+			// A paused swarm is frozen, not degraded
+			if s.Paused() {
+				continue
+			}
+			// Between resyncs agents rest and recharge, still jittered by noise
+			resting := !state.syncActive
+			if resting {
+				agents := s.collectAgents()
+				s.recharge(agents, interval)
+				s.gateEnergy(agents)
+				s.addPhaseNoise(agents)
+				s.jitter.sample(agents)
+				s.decay.sample(agents, s.now())
+			}
 			currentCoherence := s.MeasureCoherence()
+			if resting {
+				s.recordCoherence(currentCoherence)
+				s.recordTrajectory()
+			}
+			s.observeRecovery(currentCoherence)
+			if next := s.tick.observe(currentCoherence, interval); next != interval {
+				interval = next
+				ticker.Reset(interval)
+			}
 
 			// Update peak coherence with slow decay
 			if currentCoherence > state.peakCoherence {
@@ -237,14 +305,27 @@ func (s *Swarm) RunContinuous(ctx context.Context) error {
 			// Determine if synchronization is needed
 			shouldSync := s.needsResync(state, currentCoherence)
 
+			// A new target calls for a run toward it, even while coherent
+			if retargets := s.retargets.Load(); retargets != state.retargets {
+				state.retargets = retargets
+				if !state.syncActive {
+					syncCancel()
+					syncDone, syncCancel = startSync(ctx)
+					state.syncActive = true
+					state.lastSyncTime = s.now()
+				}
+			}
+
 			// Start synchronization if needed and not already running
 			if shouldSync && !state.syncActive {
 				// Avoid too frequent restarts
-				if time.Since(state.lastSyncTime) > MinResyncInterval {
+				if s.since(state.lastSyncTime) > MinResyncInterval {
+					s.publishEvent(EventDegraded)
+					state.recovering = true
 					syncCancel() // Cancel any lingering sync
 					syncDone, syncCancel = startSync(ctx)
 					state.syncActive = true
-					state.lastSyncTime = time.Now()
+					state.lastSyncTime = s.now()
 					state.peakCoherence = currentCoherence // Reset peak after restart
 				}
 			}
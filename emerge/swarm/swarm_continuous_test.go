@@ -365,44 +365,59 @@ func TestRunContinuousDetectsDisruption(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 4*time.Second)
 	defer cancel()
 
-	coherenceDropDetected := false
-	recoveryDetected := false
+	events, unsubscribe := s.Subscribe()
+	defer unsubscribe()
 
-	// Monitor coherence changes
+	// Monitor coherence changes. The monitor alone records what it sees
+	// and hands its findings over once the run is done.
+	type findings struct{ drop, recovery bool }
+	result := make(chan findings, 1)
 	go func() {
+		var found findings
+		defer func() { result <- found }()
+
 		ticker := time.NewTicker(50 * time.Millisecond)
 		defer ticker.Stop()
 
 		var lastCoherence float64
 		highCoherenceReached := false
 
+		observe := func(coherence float64) {
+			// Detect when we reach high coherence
+			if coherence > 0.85 && !highCoherenceReached {
+				highCoherenceReached = true
+				lastCoherence = coherence
+				t.Logf("High coherence reached: %.3f", coherence)
+			}
+
+			// Detect significant drop
+			if highCoherenceReached && coherence < lastCoherence-0.1 {
+				found.drop = true
+				t.Logf("Coherence drop detected: %.3f -> %.3f",
+					lastCoherence, coherence)
+			}
+
+			// Detect recovery
+			if found.drop && coherence > 0.8 {
+				found.recovery = true
+				t.Logf("Recovery detected: %.3f", coherence)
+			}
+
+			lastCoherence = coherence
+		}
+
 		for {
 			select {
 			case <-ticker.C:
-				coherence := s.MeasureCoherence()
-
-				// Detect when we reach high coherence
-				if coherence > 0.85 && !highCoherenceReached {
-					highCoherenceReached = true
-					lastCoherence = coherence
-					t.Logf("High coherence reached: %.3f", coherence)
-				}
+				observe(s.MeasureCoherence())
 
-				// Detect significant drop
-				if highCoherenceReached && coherence < lastCoherence-0.1 {
-					coherenceDropDetected = true
-					t.Logf("Coherence drop detected: %.3f -> %.3f",
-						lastCoherence, coherence)
+			case event := <-events:
+				// Resync can restore coherence between two ticks; the
+				// disruption event carries the coherence it left behind
+				if event.Type == swarm.EventDisrupted {
+					observe(event.Coherence)
 				}
 
-				// Detect recovery
-				if coherenceDropDetected && coherence > 0.8 {
-					recoveryDetected = true
-					t.Logf("Recovery detected: %.3f", coherence)
-				}
-
-				lastCoherence = coherence
-
 			case <-ctx.Done():
 				return
 			}
@@ -420,8 +435,9 @@ func TestRunContinuousDetectsDisruption(t *testing.T) {
 	_ = s.RunContinuous(ctx)
 
 	// Verify disruption detection and recovery
-	assert.True(t, coherenceDropDetected,
+	found := <-result
+	assert.True(t, found.drop,
 		"Should detect coherence drop after disruption")
-	assert.True(t, recoveryDetected,
+	assert.True(t, found.recovery,
 		"Should detect recovery after disruption")
 }
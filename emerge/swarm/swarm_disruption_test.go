@@ -307,3 +307,192 @@ func TestDisruptAgentsRaceCondition(t *testing.T) {
 	assert.GreaterOrEqual(t, finalCoherence, 0.0,
 		"Coherence should be valid after concurrent operations")
 }
+
+// TestDisruptSpec tests each typed disruption kind
+func TestDisruptSpec(t *testing.T) {
+	t.Parallel()
+
+	goal := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8}
+
+	t.Run("phase scramble", func(t *testing.T) {
+		t.Parallel()
+		s, err := swarm.New(20, goal, swarm.WithSeed(1))
+		require.NoError(t, err)
+		for _, a := range s.Agents() {
+			a.SetPhase(0)
+		}
+
+		report, err := s.Disrupt(swarm.DisruptionSpec{Kind: swarm.PhaseScramble, Fraction: 0.5})
+		require.NoError(t, err)
+		assert.Equal(t, swarm.PhaseScramble, report.Kind)
+		assert.Equal(t, 10, report.Affected)
+		require.Len(t, report.AgentIDs, 10)
+		for _, id := range report.AgentIDs {
+			a, ok := s.Agent(id)
+			require.True(t, ok)
+			assert.NotZero(t, a.Phase(), "agent %s should have a scrambled phase", id)
+		}
+	})
+
+	t.Run("energy drain", func(t *testing.T) {
+		t.Parallel()
+		s, err := swarm.New(20, goal)
+		require.NoError(t, err)
+
+		report, err := s.Disrupt(swarm.DisruptionSpec{Kind: swarm.EnergyDrain, Fraction: 0.25, DrainRatio: 0.5})
+		require.NoError(t, err)
+		assert.Equal(t, 5, report.Affected)
+
+		drained := make(map[string]bool)
+		for _, id := range report.AgentIDs {
+			drained[id] = true
+		}
+		for id, a := range s.Agents() {
+			if drained[id] {
+				assert.InDelta(t, 50, a.Energy(), 1e-9, "drained agent %s should keep half its energy", id)
+			} else {
+				assert.InDelta(t, 100, a.Energy(), 1e-9, "agent %s should be untouched", id)
+			}
+		}
+	})
+
+	t.Run("partition", func(t *testing.T) {
+		t.Parallel()
+		s, err := swarm.New(20, goal)
+		require.NoError(t, err)
+
+		report, err := s.Disrupt(swarm.DisruptionSpec{Kind: swarm.Partition, Fraction: 0.5})
+		require.NoError(t, err)
+		assert.Equal(t, 10, report.Affected)
+
+		group := make(map[string]bool)
+		for _, id := range report.AgentIDs {
+			group[id] = true
+		}
+		for id, a := range s.Agents() {
+			a.Neighbors().Range(func(key, _ any) bool {
+				other, _ := key.(string)
+				assert.Equal(t, group[id], group[other], "link %s-%s crosses the partition", id, other)
+				return true
+			})
+		}
+	})
+
+	t.Run("stubborn", func(t *testing.T) {
+		t.Parallel()
+		s, err := swarm.New(20, goal, swarm.WithSeed(1))
+		require.NoError(t, err)
+		defer s.Close()
+
+		report, err := s.Disrupt(swarm.DisruptionSpec{Kind: swarm.Stubborn, Fraction: 0.1})
+		require.NoError(t, err)
+		require.Equal(t, 2, report.Affected)
+		stubborn := make(map[string]bool)
+		for _, id := range report.AgentIDs {
+			a, _ := s.Agent(id)
+			assert.InDelta(t, 1.0, a.Stubbornness(), 1e-9)
+			stubborn[id] = true
+		}
+
+		// The loop no longer moves the stubborn agents while the rest move on
+		before := make(map[string]float64)
+		for id, a := range s.Agents() {
+			before[id] = a.Phase()
+		}
+		for range 10 {
+			s.Step()
+		}
+		moved := 0
+		for id, a := range s.Agents() {
+			if stubborn[id] {
+				assert.InDelta(t, before[id], a.Phase(), 1e-12, "stubborn agent %s moved", id)
+			} else if a.Phase() != before[id] {
+				moved++
+			}
+		}
+		assert.Positive(t, moved, "the other agents should keep adjusting")
+	})
+
+	t.Run("cascade", func(t *testing.T) {
+		t.Parallel()
+		s, err := swarm.New(20, goal)
+		require.NoError(t, err)
+
+		report, err := s.Disrupt(swarm.DisruptionSpec{Kind: swarm.Cascade, Fraction: 0.1, SpreadProbability: 1})
+		require.NoError(t, err)
+		assert.Greater(t, report.Affected, 2, "a certain cascade should spread past its seeds")
+		for _, id := range report.AgentIDs {
+			a, _ := s.Agent(id)
+			assert.Zero(t, a.Energy(), "failed agent %s should have no energy", id)
+		}
+	})
+
+	t.Run("invalid spec", func(t *testing.T) {
+		t.Parallel()
+		s, err := swarm.New(20, goal)
+		require.NoError(t, err)
+
+		invalid := []swarm.DisruptionSpec{
+			{Kind: swarm.DisruptionKind(99), Fraction: 0.5},
+			{Kind: swarm.EnergyDrain, Fraction: 0.5, DrainRatio: 2},
+			{Kind: swarm.Cascade, Fraction: math.NaN()},
+		}
+		for _, spec := range invalid {
+			_, err := s.Disrupt(spec)
+			require.ErrorIs(t, err, swarm.ErrInvalidDisruption, "spec %+v", spec)
+		}
+	})
+}
+
+// TestDisruptSpecific fails the hub or a leaf of a star. A cascade from
+// the hub reaches many leaves while one from a leaf mostly stays put, so
+// failing the hub dips coherence further.
+func TestDisruptSpecific(t *testing.T) {
+	t.Parallel()
+
+	goal := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8}
+	spec := swarm.DisruptionSpec{Kind: swarm.Cascade, SpreadProbability: 0.3}
+
+	// star builds a synchronized star around agent-0.
+	star := func(t *testing.T, seed int64) *swarm.Swarm {
+		t.Helper()
+		agents := buildAgents(30)
+		for _, leaf := range agents[1:] {
+			agents[0].ConnectTo(leaf.ID, leaf)
+			leaf.ConnectTo(agents[0].ID, agents[0])
+		}
+		for _, a := range agents {
+			a.SetPhase(0)
+		}
+		s, err := swarm.FromAgents(agents, goal, swarm.WithSeed(seed))
+		require.NoError(t, err)
+		t.Cleanup(s.Close)
+		return s
+	}
+
+	var hubDip, leafDip float64
+	for seed := range int64(10) {
+		s := star(t, seed)
+		report, err := s.DisruptSpecific([]string{"agent-0", "agent-0"}, spec)
+		require.NoError(t, err)
+		assert.Equal(t, "agent-0", report.AgentIDs[0], "the named agent is hit first, once")
+		hubDip += s.LastRecovery().Dip()
+
+		again, err := star(t, seed).DisruptSpecific([]string{"agent-0"}, spec)
+		require.NoError(t, err)
+		assert.Equal(t, report.AgentIDs, again.AgentIDs, "seeded disruptions are reproducible")
+
+		s = star(t, seed)
+		_, err = s.DisruptSpecific([]string{"agent-17"}, spec)
+		require.NoError(t, err)
+		leafDip += s.LastRecovery().Dip()
+	}
+	assert.Greater(t, hubDip, 2*leafDip, "failing the hub hurts more than failing a leaf")
+
+	s := star(t, 1)
+	_, err := s.DisruptSpecific([]string{"agent-3", "nobody"}, spec)
+	require.ErrorIs(t, err, swarm.ErrAgentNotFound)
+	_, err = s.DisruptSpecific([]string{"agent-3"}, swarm.DisruptionSpec{Kind: swarm.DisruptionKind(99)})
+	require.ErrorIs(t, err, swarm.ErrInvalidDisruption)
+	assert.Zero(t, s.LastRecovery().Dip(), "a rejected disruption leaves the swarm untouched")
+}
@@ -115,14 +115,22 @@ func TestSwarmRecoveryAfterDisruption(t *testing.T) {
 						t.Logf("Coherence before disruption: %.3f", coherenceBeforeDisruption)
 
 						// Apply disruption - exactly as demo
+						events, unsubscribe := s.Subscribe()
 						s.DisruptAgents(tt.disruptionPercent)
 						disruptionApplied = true
 
-						// Immediate measurement after disruption
+						// Immediate measurement after disruption. Resync can
+						// restore coherence within one update, so the drop is
+						// read from the disruption event
+						disrupted := receive(t, events)
+						for disrupted.Type != swarm.EventDisrupted {
+							disrupted = receive(t, events)
+						}
+						unsubscribe()
 						time.Sleep(100 * time.Millisecond)
-						coherenceAfter := s.MeasureCoherence()
-						t.Logf("Coherence immediately after disruption: %.3f", coherenceAfter)
-						assert.Less(t, coherenceAfter, coherenceBeforeDisruption,
+						t.Logf("Coherence immediately after disruption: %.3f, 100ms later: %.3f",
+							disrupted.Coherence, s.MeasureCoherence())
+						assert.Less(t, disrupted.Coherence, coherenceBeforeDisruption,
 							"Coherence should drop after disruption")
 					}
 
@@ -0,0 +1,91 @@
+package swarm_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+// TestSwarmRunSynctest verifies that Run works on synctest's fake clock:
+// convergence that takes seconds of swarm time finishes almost instantly,
+// and every run ends on an exact update-interval boundary.
+func TestSwarmRunSynctest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		size      int
+		coherence float64
+	}{
+		{"small", 20, 0.7},
+		{"optimized", 150, 0.6}, // Exercises the worker pool
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			wallStart := time.Now()
+
+			synctest.Test(t, func(t *testing.T) {
+				s, err := swarm.New(tt.size, core.State{
+					Phase:     0,
+					Frequency: 200 * time.Millisecond,
+					Coherence: tt.coherence,
+				})
+				require.NoError(t, err)
+				defer s.Close()
+
+				ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+				defer cancel()
+
+				start := time.Now()
+				err = s.Run(ctx)
+				elapsed := time.Since(start)
+
+				require.NoError(t, err, "swarm should converge within a minute of fake time")
+				assert.GreaterOrEqual(t, s.MeasureCoherence(), tt.coherence-0.1)
+
+				interval := s.EffectiveConfig().Strategy.UpdateInterval
+				assert.Positive(t, elapsed)
+				assert.Zero(t, elapsed%interval, "fake time should advance in whole update intervals, got %v", elapsed)
+				t.Logf("converged after %v of fake time", elapsed)
+			})
+
+			t.Logf("wall time: %v", time.Since(wallStart))
+		})
+	}
+}
+
+// TestSwarmRunContinuousSynctest verifies RunContinuous stops exactly at the
+// context deadline on the fake clock, leaving no goroutines behind.
+func TestSwarmRunContinuousSynctest(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		s, err := swarm.New(20, core.State{
+			Phase:     0,
+			Frequency: 200 * time.Millisecond,
+			Coherence: 0.7,
+		})
+		require.NoError(t, err)
+		defer s.Close()
+
+		const runFor = 5 * time.Second
+		ctx, cancel := context.WithTimeout(context.Background(), runFor)
+		defer cancel()
+
+		start := time.Now()
+		err = s.RunContinuous(ctx)
+
+		assert.True(t, errors.Is(err, context.DeadlineExceeded), "expected deadline error, got %v", err)
+		assert.Equal(t, runFor, time.Since(start))
+	})
+}
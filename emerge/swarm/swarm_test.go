@@ -12,6 +12,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/goal"
+	"github.com/carlisia/bio-adapt/emerge/scale"
 	"github.com/carlisia/bio-adapt/internal/config"
 )
 
@@ -259,6 +261,96 @@ func TestConfigurableLimits(t *testing.T) {
 	}
 }
 
+func TestSwarmAccessors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("target_state_reflects_clamping", func(t *testing.T) {
+		t.Parallel()
+		// 5 agents have a practical limit well below 0.99
+		swarm, err := New(5, core.State{
+			Phase:     1.0,
+			Frequency: 100 * time.Millisecond,
+			Coherence: 0.99,
+		})
+		require.NoError(t, err)
+
+		limits := GetCoherenceLimits(5)
+		target := swarm.TargetState()
+		assert.InDelta(t, limits.Practical, target.Coherence, 1e-9, "TargetState should report the clamped coherence")
+		assert.InDelta(t, 1.0, target.Phase, 1e-9)
+		assert.Equal(t, 100*time.Millisecond, target.Frequency)
+	})
+
+	t.Run("goal_and_config_reflect_options", func(t *testing.T) {
+		t.Parallel()
+		swarm, err := New(20, core.State{
+			Phase:     0,
+			Frequency: 100 * time.Millisecond,
+			Coherence: 0.3,
+		}, WithGoal(goal.DistributeLoad))
+		require.NoError(t, err)
+
+		assert.Equal(t, goal.DistributeLoad, swarm.Goal())
+		assert.Equal(t, scale.Tiny, swarm.Scale())
+		assert.Equal(t, *For(goal.DistributeLoad), swarm.EffectiveConfig())
+	})
+
+	t.Run("explicit_goal_config_wins", func(t *testing.T) {
+		t.Parallel()
+		cfg := For(goal.ReachConsensus).WithSize(200)
+		swarm, err := New(200, core.State{
+			Phase:     0,
+			Frequency: 100 * time.Millisecond,
+			Coherence: 0.7,
+		}, WithGoalConfig(cfg), WithGoal(goal.ReachConsensus))
+		require.NoError(t, err)
+
+		assert.Equal(t, goal.ReachConsensus, swarm.Goal())
+		assert.Equal(t, scale.Medium, swarm.Scale())
+		assert.Equal(t, *cfg, swarm.EffectiveConfig())
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		t.Parallel()
+		swarm, err := New(10, core.State{
+			Phase:     0,
+			Frequency: 100 * time.Millisecond,
+			Coherence: 0.7,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, goal.MinimizeAPICalls, swarm.Goal())
+		assert.Equal(t, *defaultConfig(), swarm.EffectiveConfig())
+	})
+
+	for _, size := range []int{12, 150} { // Standard and optimized storage
+		t.Run(fmt.Sprintf("agents_sorted_%d", size), func(t *testing.T) {
+			t.Parallel()
+			swarm, err := New(size, core.State{
+				Phase:     0,
+				Frequency: 100 * time.Millisecond,
+				Coherence: 0.7,
+			})
+			require.NoError(t, err)
+			_, err = swarm.AddAgent(AgentConfig{ID: "probe"}) // Shorter IDs sort first
+			require.NoError(t, err)
+
+			sorted := swarm.AgentsSorted()
+			require.Len(t, sorted, size+1)
+			assert.Equal(t, "probe", sorted[0].ID)
+			for i := range size {
+				assert.Equal(t, fmt.Sprintf("agent-%d", i), sorted[i+1].ID)
+			}
+			assert.Equal(t, sorted, swarm.AgentsSorted(), "order should be stable between calls")
+
+			// The slice is a snapshot
+			require.NoError(t, swarm.RemoveAgent("agent-1"))
+			assert.Len(t, sorted, size+1)
+			assert.Len(t, swarm.AgentsSorted(), size)
+		})
+	}
+}
+
 func BenchmarkSwarmCreationScalability(b *testing.B) {
 	sizes := []int{10, 100, 500, 1000}
 
@@ -0,0 +1,108 @@
+package swarm
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+// Retuning to a new target frequency: each iteration moves agents this
+// fraction of the way, until their mean is within retuneTolerance of the
+// target (relative).
+const (
+	retunePull      = 0.1
+	retuneTolerance = 0.05
+)
+
+// SetTarget changes the state the swarm works toward, without recreating
+// it. The new target is validated like the one given to New, and a
+// coherence target beyond the practical limit for the swarm size is
+// clamped to it. Any relaxation by WithAdaptiveTarget is dropped.
+//
+// A run in progress picks up the new target on its next iteration, and
+// RunContinuous starts a run toward it even while the swarm holds its
+// coherence. When the frequency changes, agents without a natural
+// frequency retune to it before a run goes on or reports convergence:
+// each iteration moves them a tenth of the way, until their mean frequency
+// is within 5% of the target. Phases and energy carry over, so the swarm
+// re-converges from where it is instead of starting over.
+//
+// Subscribers receive an EventRetargeted lifecycle event.
+func (s *Swarm) SetTarget(target core.State) error {
+	if err := validateState(target); err != nil {
+		return fmt.Errorf("invalid target state: %w", err)
+	}
+	target.Coherence = min(target.Coherence, GetCoherenceLimits(s.Size()).Practical)
+
+	s.targetMu.Lock()
+	previous := s.goalState
+	s.goalState = target
+	s.relaxedTarget.Store(0)
+	s.targetMu.Unlock()
+
+	if s.convergence != nil {
+		s.convergence.SetTarget(target, target.Coherence)
+	}
+	if target.Frequency != previous.Frequency {
+		s.retuneFrequency.Store(int64(target.Frequency))
+	}
+	s.retargets.Add(1)
+	s.tick.reset()
+	s.publish(LifecycleEvent{Type: EventRetargeted, Target: &target})
+	s.logEvent(logEntry{Kind: logRetarget, Target: &target})
+	return nil
+}
+
+// target returns the current target state.
+func (s *Swarm) target() core.State {
+	s.targetMu.RLock()
+	defer s.targetMu.RUnlock()
+	return s.goalState
+}
+
+// targetPattern builds the pattern a run works toward from the current
+// target.
+func (s *Swarm) targetPattern() *core.TargetPattern {
+	target := s.target()
+	return &core.TargetPattern{
+		Phase:     target.Phase,
+		Frequency: target.Frequency,
+		Coherence: s.EffectiveTargetCoherence(),
+		Amplitude: 1.0,
+		Stability: 0.9,
+	}
+}
+
+// retune moves agents without a natural frequency toward a target
+// frequency set by SetTarget. It reports whether they are still retuning;
+// once their mean frequency is close enough the retune is done.
+func (gds *GoalDirectedSync) retune(agents []*agent.Agent) bool {
+	target := time.Duration(gds.swarm.retuneFrequency.Load())
+	if target <= 0 {
+		return false
+	}
+
+	var total time.Duration
+	var tuning []*agent.Agent
+	for _, a := range agents {
+		if a.NaturalFrequency() > 0 {
+			continue
+		}
+		total += a.Frequency()
+		tuning = append(tuning, a)
+	}
+	if len(tuning) == 0 ||
+		math.Abs(float64(total/time.Duration(len(tuning))-target)) <= retuneTolerance*float64(target) {
+		gds.swarm.retuneFrequency.CompareAndSwap(int64(target), 0)
+		return false
+	}
+
+	for _, a := range tuning {
+		current := a.Frequency()
+		a.SetFrequency(current + time.Duration(retunePull*float64(target-current)))
+	}
+	return true
+}
@@ -0,0 +1,81 @@
+package swarm_test
+
+import (
+	"context"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+func TestSetTarget(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		goalState := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8}
+		s, err := swarm.New(20, goalState, swarm.WithSeed(5))
+		require.NoError(t, err)
+		defer s.Close()
+		events, unsubscribe := s.Subscribe()
+		defer unsubscribe()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() { done <- s.RunContinuous(ctx) }()
+		time.Sleep(10 * time.Second)
+		require.GreaterOrEqual(t, s.MeasureCoherence(), 0.8)
+
+		retarget := core.State{Phase: 0, Frequency: 300 * time.Millisecond, Coherence: 0.8}
+		require.NoError(t, s.SetTarget(retarget))
+		assert.Equal(t, retarget, s.TargetState())
+		time.Sleep(10 * time.Second)
+
+		var total time.Duration
+		freqs := s.Frequencies()
+		for _, f := range freqs {
+			total += f
+		}
+		mean := total / time.Duration(len(freqs))
+		assert.InEpsilon(t, 300*time.Millisecond, mean, 0.05, "agents should track the new frequency")
+		assert.GreaterOrEqual(t, s.MeasureCoherence(), 0.8, "retuning keeps the swarm coherent")
+
+		cancel()
+		require.ErrorIs(t, <-done, context.Canceled)
+
+		retargeted := false
+		for len(events) > 0 {
+			if e := <-events; e.Type == swarm.EventRetargeted {
+				retargeted = true
+			}
+		}
+		assert.True(t, retargeted, "subscribers should see the retarget")
+	})
+}
+
+func TestSetTargetValidation(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8}
+	s, err := swarm.New(10, goalState)
+	require.NoError(t, err)
+	defer s.Close()
+
+	tests := []struct {
+		name   string
+		target core.State
+	}{
+		{"zero frequency", core.State{Frequency: 0, Coherence: 0.8}},
+		{"negative frequency", core.State{Frequency: -time.Second, Coherence: 0.8}},
+		{"coherence above one", core.State{Frequency: time.Second, Coherence: 1.5}},
+		{"negative coherence", core.State{Frequency: time.Second, Coherence: -0.1}},
+	}
+	for _, tt := range tests {
+		assert.Error(t, s.SetTarget(tt.target), tt.name)
+	}
+	assert.Equal(t, goalState, s.TargetState(), "a rejected target leaves the old one in place")
+}
@@ -0,0 +1,124 @@
+package swarm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// WithAdaptiveTickInterval lets the swarm slow down while nothing happens.
+// The update loop of Run and RunContinuous, and RunContinuous's coherence
+// checks, start at minInterval. Every tick on which coherence holds still
+// doubles the interval, up to maxInterval; as soon as coherence moves the
+// interval drops back to minInterval, so a swarm that is converging runs
+// at full rate. A disruption wakes the loops at once. Recharging is scaled
+// to the time that actually passed, so energy budgets are unaffected.
+//
+// This is meant for long-running swarms that are idle most of the time.
+// Use TickInterval to observe the current interval.
+func WithAdaptiveTickInterval(minInterval, maxInterval time.Duration) Option {
+	return func(s *Swarm) error {
+		if minInterval <= 0 {
+			return fmt.Errorf("minimum tick interval must be positive, got %v", minInterval)
+		}
+		if maxInterval < minInterval {
+			return errors.New("maximum tick interval must not be below the minimum")
+		}
+		s.tick = newAdaptiveTick(minInterval, maxInterval)
+		return nil
+	}
+}
+
+// TickInterval returns the update loop's current interval: the adaptive
+// interval with WithAdaptiveTickInterval, or else the configured update
+// interval.
+func (s *Swarm) TickInterval() time.Duration {
+	if s.tick != nil {
+		return s.tick.interval()
+	}
+	if s.goalDirectedSync != nil {
+		return s.goalDirectedSync.config.Strategy.UpdateInterval
+	}
+	return DefaultUpdateInterval
+}
+
+// adaptiveTick tracks the interval set by WithAdaptiveTickInterval.
+// A nil *adaptiveTick leaves the loops at their configured intervals.
+type adaptiveTick struct {
+	minInterval, maxInterval time.Duration
+
+	mu       sync.Mutex
+	current  time.Duration
+	last     float64
+	measured bool
+	wake     chan struct{} // Closed and replaced when a disruption resets the interval
+}
+
+func newAdaptiveTick(minInterval, maxInterval time.Duration) *adaptiveTick {
+	return &adaptiveTick{
+		minInterval: minInterval,
+		maxInterval: maxInterval,
+		current:     minInterval,
+		wake:        make(chan struct{}),
+	}
+}
+
+// intervalOr returns the current interval, or base if t is nil.
+func (t *adaptiveTick) intervalOr(base time.Duration) time.Duration {
+	if t == nil {
+		return base
+	}
+	return t.interval()
+}
+
+func (t *adaptiveTick) interval() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.current
+}
+
+// observe records a coherence measurement and returns the next interval:
+// doubled if coherence held still since the last one, back to the minimum
+// if it moved. It returns base if t is nil.
+func (t *adaptiveTick) observe(coherence float64, base time.Duration) time.Duration {
+	if t == nil {
+		return base
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.measured && math.Abs(coherence-t.last) < ImprovementThreshold {
+		t.current = min(2*t.current, t.maxInterval)
+	} else {
+		t.current = t.minInterval
+	}
+	t.last, t.measured = coherence, true
+	return t.current
+}
+
+// reset drops back to the minimum interval and wakes the loops.
+func (t *adaptiveTick) reset() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.current = t.minInterval
+	t.measured = false
+	close(t.wake)
+	t.wake = make(chan struct{})
+}
+
+// woken returns a channel closed by the next reset. It is nil, blocking
+// forever, if t is nil.
+func (t *adaptiveTick) woken() <-chan struct{} {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.wake
+}
@@ -0,0 +1,86 @@
+package swarm_test
+
+import (
+	"context"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+func TestAdaptiveTickInterval(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.85}
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		_, err := swarm.New(10, goalState, swarm.WithAdaptiveTickInterval(0, time.Second))
+		require.Error(t, err)
+		_, err = swarm.New(10, goalState, swarm.WithAdaptiveTickInterval(time.Second, time.Millisecond))
+		require.Error(t, err)
+
+		s, err := swarm.New(10, goalState)
+		require.NoError(t, err)
+		defer s.Close()
+		assert.Equal(t, swarm.DefaultUpdateInterval, s.TickInterval(), "fixed without the option")
+	})
+
+	t.Run("backs off while idle and wakes on disruption", func(t *testing.T) {
+		t.Parallel()
+		for seed := int64(1); seed <= 8; seed++ {
+			synctest.Test(t, func(t *testing.T) {
+				minInterval, maxInterval := 50*time.Millisecond, 800*time.Millisecond
+				s, err := swarm.New(20, goalState, swarm.WithSeed(seed),
+					swarm.WithAdaptiveTickInterval(minInterval, maxInterval))
+				require.NoError(t, err)
+				defer s.Close()
+				assert.Equal(t, minInterval, s.TickInterval())
+
+				ctx, cancel := context.WithCancel(context.Background())
+				done := make(chan error, 1)
+				go func() { done <- s.RunContinuous(ctx) }()
+
+				time.Sleep(30 * time.Second)
+				// Some seeds settle a hair under the target; that still counts as idle
+				assert.GreaterOrEqual(t, s.MeasureCoherence(), goalState.Coherence-0.01, "seed %d", seed)
+				assert.Equal(t, maxInterval, s.TickInterval(), "seed %d: a converged swarm should back off", seed)
+
+				s.DisruptAgents(0.6)
+				assert.Equal(t, minInterval, s.TickInterval(), "seed %d: a disruption should reset the interval", seed)
+				time.Sleep(5 * time.Second)
+				assert.True(t, s.LastRecovery().Recovered, "seed %d: the woken loops should recover", seed)
+				assert.Less(t, s.LastRecovery().RecoveryTime, 2*time.Second, "seed %d", seed)
+
+				cancel()
+				require.ErrorIs(t, <-done, context.Canceled)
+			})
+		}
+	})
+
+	t.Run("does not slow convergence", func(t *testing.T) {
+		t.Parallel()
+		synctest.Test(t, func(t *testing.T) {
+			converge := func(seed int64, opts ...swarm.Option) time.Duration {
+				s, err := swarm.New(20, goalState, append([]swarm.Option{swarm.WithSeed(seed)}, opts...)...)
+				require.NoError(t, err)
+				defer s.Close()
+				start := time.Now()
+				require.NoError(t, s.Run(context.Background()))
+				return time.Since(start)
+			}
+			// Any one seed could converge quickly by luck; the interval must
+			// not back off while any of them is still making progress
+			for seed := int64(1); seed <= 8; seed++ {
+				fixed := converge(seed)
+				adaptive := converge(seed, swarm.WithAdaptiveTickInterval(swarm.DefaultUpdateInterval, 10*time.Second))
+				assert.LessOrEqual(t, adaptive, fixed+swarm.DefaultUpdateInterval, "seed %d", seed)
+			}
+		})
+	})
+}
@@ -0,0 +1,99 @@
+package swarm_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+	"github.com/carlisia/bio-adapt/internal/config"
+)
+
+func TestEmptySwarm(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.9}
+
+	_, err := swarm.New(0, goalState)
+	require.ErrorIs(t, err, swarm.ErrEmptySwarm)
+	require.ErrorIs(t, err, swarm.ErrInvalidSwarmSize, "an empty swarm is an invalid size too")
+	require.ErrorIs(t, swarm.Validate(0, goalState), swarm.ErrEmptySwarm)
+	_, err = swarm.NewSwarmFromConfig(0, goalState, config.AutoScaleConfig(1))
+	require.ErrorIs(t, err, swarm.ErrEmptySwarm)
+	_, err = swarm.FromAgents(nil, goalState)
+	require.ErrorIs(t, err, swarm.ErrEmptySwarm)
+
+	// A negative size is invalid but not empty
+	_, err = swarm.New(-1, goalState)
+	require.ErrorIs(t, err, swarm.ErrInvalidSwarmSize)
+	require.NotErrorIs(t, err, swarm.ErrEmptySwarm)
+}
+
+// TestSingleAgentSwarm checks the main accessors give sensible, finite
+// answers for a swarm of one.
+func TestSingleAgentSwarm(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{Phase: 1, Frequency: 100 * time.Millisecond, Coherence: 0.9}
+	s, err := swarm.New(1, goalState, swarm.WithSeed(1))
+	require.NoError(t, err)
+	defer s.Close()
+	require.Equal(t, 1, s.Size())
+
+	assert.Equal(t, 1.0, s.MeasureCoherence(), "a lone agent is exactly coherent")
+	assert.Equal(t, 1.0, core.MeasureCoherence([]float64{2.5}))
+	assert.Zero(t, core.MeasureCoherence(nil))
+
+	m := s.Metrics()
+	assert.Equal(t, 1, m.Agents)
+	assert.Equal(t, 1.0, m.Coherence)
+	assert.Zero(t, m.Dispersion)
+	assert.InDelta(t, 0, m.PhaseVariance, 1e-12)
+	assert.Zero(t, m.EnergyStdDev)
+	for name, v := range map[string]float64{
+		"phase variance": s.MeasurePhaseVariance(),
+		"dispersion":     s.MeasureDispersion(),
+		"convergence":    s.MeasurePhaseConvergence(goalState.Phase),
+		"frequency":      s.MeasureFrequencyCoherence(),
+		"jitter":         s.MeasureJitter(),
+		"active":         s.ActiveFraction(),
+		"mean energy":    m.MeanEnergy,
+		"mean degree":    m.MeanDegree,
+	} {
+		assert.False(t, math.IsNaN(v) || math.IsInf(v, 0), "%s is %v", name, v)
+	}
+
+	clusters := s.PhaseClusters(0.1)
+	require.Len(t, clusters, 1)
+	assert.Equal(t, 1, clusters[0].Size)
+	assert.InDelta(t, 0, core.PhaseDifference(clusters[0].MeanPhase, s.Agents()["agent-0"].Phase()), 1e-12)
+
+	profile := s.StubbornnessProfile()
+	assert.Equal(t, 1, profile.Agents)
+	assert.Equal(t, profile.Min, profile.Max)
+	assert.Equal(t, map[int]float64{0: 1}, s.CoherenceByComponent())
+	assert.NotEmpty(t, s.Summary())
+
+	// Running keeps it exactly coherent
+	for range 20 {
+		assert.Equal(t, 1.0, s.Step())
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	if err := s.Run(ctx); err != nil {
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	}
+	assert.Equal(t, 1.0, s.MeasureCoherence())
+
+	// Its only agent cannot be removed, and the swarm grows normally
+	require.ErrorIs(t, s.RemoveAgent("agent-0"), swarm.ErrInvalidSwarmSize)
+	_, err = s.AddAgent(swarm.AgentConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, s.Size())
+	assert.LessOrEqual(t, s.MeasureCoherence(), 1.0)
+}
@@ -0,0 +1,69 @@
+package swarm
+
+import (
+	"errors"
+	"fmt"
+)
+
+// trajectoryRecorder hands agent phases to a sink every few ticks (see
+// WithTrajectoryRecorder).
+type trajectoryRecorder struct {
+	sink  func(tick int, phases []float64)
+	every int // Record every every-th tick
+	ticks int // Ticks committed so far
+}
+
+// WithTrajectoryRecorder calls sink after every tick with every agent's
+// phase, for rendering animations or analyzing how phases evolve offline.
+// Polling Agents from another goroutine races with the run and misses
+// ticks; sink instead runs synchronously on the run's goroutine once the
+// tick is complete, so it sees each tick exactly once, in a consistent
+// state.
+//
+// Ticks are numbered from 1 over the life of the swarm, across Run,
+// RunContinuous and Step. Phases are ordered by agent ID, as AgentsSorted
+// orders them, and the slice is sink's to keep.
+//
+// Recording costs an O(N) copy and sort per recorded tick, and the tick
+// waits for sink to return, so at high N, or with a slow sink, it slows the
+// run. Use WithTrajectorySampling to record only every k-th tick.
+func WithTrajectoryRecorder(sink func(tick int, phases []float64)) Option {
+	return func(s *Swarm) error {
+		if sink == nil {
+			return errors.New("trajectory sink must not be nil")
+		}
+		s.trajectory.sink = sink
+		return nil
+	}
+}
+
+// WithTrajectorySampling makes the trajectory recorder record only every
+// k-th tick: ticks k, 2k, 3k and so on. The default, 1, records every tick.
+func WithTrajectorySampling(k int) Option {
+	return func(s *Swarm) error {
+		if k < 1 {
+			return fmt.Errorf("trajectory sampling must be at least 1, got %d", k)
+		}
+		s.trajectory.every = k
+		return nil
+	}
+}
+
+// recordTrajectory counts a committed tick and hands the agents' phases to
+// the trajectory sink when the tick is sampled.
+func (s *Swarm) recordTrajectory() {
+	t := &s.trajectory
+	if t.sink == nil {
+		return
+	}
+	t.ticks++
+	if t.ticks%max(t.every, 1) != 0 {
+		return
+	}
+	agents := s.AgentsSorted()
+	phases := make([]float64, len(agents))
+	for i, a := range agents {
+		phases[i] = a.Phase()
+	}
+	t.sink(t.ticks, phases)
+}
@@ -0,0 +1,117 @@
+package swarm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+	"github.com/carlisia/bio-adapt/emerge/swarm/clocktest"
+)
+
+func TestTrajectoryRecorder(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{Phase: 0, Frequency: 200 * time.Millisecond, Coherence: 0.9}
+	_, err := swarm.New(10, goalState, swarm.WithTrajectoryRecorder(nil))
+	require.Error(t, err)
+	_, err = swarm.New(10, goalState, swarm.WithTrajectorySampling(0))
+	require.Error(t, err)
+
+	t.Run("step", func(t *testing.T) {
+		t.Parallel()
+
+		var ticks []int
+		var last []float64
+		s, err := swarm.New(30, goalState, swarm.WithSeed(3),
+			swarm.WithTrajectoryRecorder(func(tick int, phases []float64) {
+				ticks = append(ticks, tick)
+				last = phases
+			}))
+		require.NoError(t, err)
+		defer s.Close()
+
+		for range 25 {
+			s.Step()
+		}
+		require.Len(t, ticks, 25)
+		for i, tick := range ticks {
+			assert.Equal(t, i+1, tick)
+		}
+		require.Len(t, last, 30)
+		for i, a := range s.AgentsSorted() {
+			assert.InDelta(t, a.Phase(), last[i], 1e-12, "agent %s", a.ID)
+		}
+	})
+
+	t.Run("sampled", func(t *testing.T) {
+		t.Parallel()
+
+		var ticks []int
+		s, err := swarm.New(30, goalState, swarm.WithSeed(3), swarm.WithTrajectorySampling(4),
+			swarm.WithTrajectoryRecorder(func(tick int, _ []float64) { ticks = append(ticks, tick) }))
+		require.NoError(t, err)
+		defer s.Close()
+
+		for range 25 {
+			s.Step()
+		}
+		assert.Equal(t, []int{4, 8, 12, 16, 20, 24}, ticks)
+	})
+
+	// A run's trajectory is the one stepping an identical swarm as many
+	// times records: no tick is missed or recorded twice
+	t.Run("run", func(t *testing.T) {
+		t.Parallel()
+
+		type frame struct {
+			tick   int
+			phases []float64
+		}
+		start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		build := func(clock *clocktest.Clock, frames *[]frame) *swarm.Swarm {
+			s, err := swarm.New(30, goalState, swarm.WithSeed(3), swarm.WithClock(clock),
+				swarm.WithTrajectoryRecorder(func(tick int, phases []float64) {
+					*frames = append(*frames, frame{tick, phases})
+				}))
+			require.NoError(t, err)
+			t.Cleanup(s.Close)
+			return s
+		}
+		var ranFrames, steppedFrames []frame
+		runClock, stepClock := clocktest.New(start), clocktest.New(start)
+		ran, stepped := build(runClock, &ranFrames), build(stepClock, &steppedFrames)
+		interval := ran.TickInterval()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		done := make(chan error, 1)
+		go func() { done <- ran.Run(ctx) }()
+		runClock.WaitForTickers(1)
+		for running := true; running; {
+			select {
+			case err := <-done:
+				require.NoError(t, err)
+				running = false
+			default:
+				runClock.Advance(interval)
+			}
+		}
+		require.Greater(t, len(ranFrames), 1)
+
+		for range ranFrames {
+			stepClock.Advance(interval)
+			stepped.Step()
+		}
+		require.Len(t, steppedFrames, len(ranFrames))
+		for i, f := range ranFrames {
+			assert.Equal(t, i+1, f.tick)
+			assert.Equal(t, steppedFrames[i].tick, f.tick)
+			assert.InDeltaSlice(t, steppedFrames[i].phases, f.phases, 1e-12, "tick %d", f.tick)
+		}
+	})
+}
@@ -0,0 +1,39 @@
+package swarm
+
+import (
+	"errors"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+// Validate reports whether New would accept the given size, goal and
+// options, without creating any agents, e.g. to reject a user-supplied
+// configuration in an API server before building the swarm. It catches a
+// non-positive size, an invalid goal (coherence outside [0, 1], a
+// non-positive frequency), invalid option arguments, a size over the
+// configured maximum, and options that cannot be combined, such as a hub
+// with phase bands.
+//
+// Checks that need the agents themselves, such as whether a hub's agent
+// exists or a topology builds, still happen only in New. Options are
+// applied to a scratch swarm, so one that does work when applied, like
+// WithConfigFile reading its file, does it here too.
+func Validate(size int, goal core.State, opts ...Option) error {
+	_, err := configure(size, goal, opts)
+	return err
+}
+
+// validateCombination rejects options that are valid on their own but not
+// together.
+func (s *Swarm) validateCombination() error {
+	if err := s.validateReference(); err != nil {
+		return err
+	}
+	if s.hubID != "" && len(s.bands) > 0 {
+		return errors.New("a hub cannot be combined with phase bands")
+	}
+	if s.eventLog != nil && s.rng == nil {
+		return errors.New("an event log needs WithSeed to be replayable")
+	}
+	return nil
+}
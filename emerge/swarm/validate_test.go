@@ -0,0 +1,68 @@
+package swarm_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+	"github.com/carlisia/bio-adapt/internal/config"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8}
+	capped := config.AutoScaleConfig(50)
+	capped.MaxSwarmSize = 20
+	bands := []swarm.Band{{Name: "a"}, {Name: "b", Phase: math.Pi}}
+	reference := func(time.Time) float64 { return 0 }
+
+	tests := []struct {
+		name  string
+		size  int
+		goal  core.State
+		opts  []swarm.Option
+		valid bool
+	}{
+		{name: "valid", size: 50, goal: goalState, valid: true},
+		{name: "valid with options", size: 50, goal: goalState, valid: true,
+			opts: []swarm.Option{swarm.WithHub("agent-0", 0.5), swarm.WithSeed(1)}},
+		{name: "zero size", size: 0, goal: goalState},
+		{name: "negative size", size: -5, goal: goalState},
+		{name: "coherence above 1", size: 50, goal: core.State{Frequency: time.Second, Coherence: 1.2}},
+		{name: "negative coherence", size: 50, goal: core.State{Frequency: time.Second, Coherence: -0.1}},
+		{name: "zero frequency", size: 50, goal: core.State{Coherence: 0.8}},
+		{name: "negative frequency", size: 50, goal: core.State{Frequency: -time.Second, Coherence: 0.8}},
+		{name: "invalid option", size: 50, goal: goalState,
+			opts: []swarm.Option{swarm.WithCouplingStrength(-1)}},
+		{name: "over configured maximum", size: 50, goal: goalState,
+			opts: []swarm.Option{swarm.WithConfig(capped)}},
+		{name: "hub with bands", size: 50, goal: goalState,
+			opts: []swarm.Option{swarm.WithHub("agent-0", 0.5), swarm.WithPhaseBands(bands)}},
+		{name: "reference with bands", size: 50, goal: goalState,
+			opts: []swarm.Option{swarm.WithReferenceRhythm(reference), swarm.WithPhaseBands(bands)}},
+		{name: "reference with hub", size: 50, goal: goalState,
+			opts: []swarm.Option{swarm.WithReferenceRhythm(reference), swarm.WithHub("agent-0", 0.5)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := swarm.Validate(tt.size, tt.goal, tt.opts...)
+			s, newErr := swarm.New(tt.size, tt.goal, tt.opts...)
+			if tt.valid {
+				require.NoError(t, err)
+				require.NoError(t, newErr)
+				s.Close()
+				return
+			}
+			require.Error(t, err)
+			assert.Error(t, newErr, "New rejects what Validate rejects")
+		})
+	}
+}
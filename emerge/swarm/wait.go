@@ -0,0 +1,155 @@
+package swarm
+
+import (
+	"context"
+	"sync"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+)
+
+// runTracker follows the Run and RunContinuous loops in progress so that
+// WaitForConvergence can attach to one rather than start another, and
+// Shutdown can stop them all.
+type runTracker struct {
+	mu      sync.Mutex
+	active  int
+	waiters map[chan error]struct{}
+	runs    map[uint64]trackedRun // Keyed by run number
+	seq     uint64
+}
+
+// trackedRun lets Shutdown stop a run and wait for it to end.
+type trackedRun struct {
+	cancel context.CancelCauseFunc
+	done   chan struct{} // Closed by finish
+}
+
+// start registers a run. The run works under the returned context, which
+// Shutdown cancels, and ends with finish.
+func (r *runTracker) start(ctx context.Context) (context.Context, uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.active++
+	return r.stoppableLocked(ctx)
+}
+
+func (r *runTracker) stoppableLocked(ctx context.Context) (context.Context, uint64) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	if r.runs == nil {
+		r.runs = make(map[uint64]trackedRun)
+	}
+	r.seq++
+	r.runs[r.seq] = trackedRun{cancel: cancel, done: make(chan struct{})}
+	return ctx, r.seq
+}
+
+// finish unregisters a run. When the last run ends, waiters get its error.
+func (r *runTracker) finish(id uint64, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if run, ok := r.runs[id]; ok {
+		run.cancel(nil)
+		close(run.done)
+		delete(r.runs, id)
+	}
+	r.active--
+	if r.active == 0 {
+		r.notifyLocked(err)
+	}
+}
+
+// join registers the caller as a run when none is in progress, returning
+// the context to run under as start does. Otherwise it returns a channel
+// that receives the outcome of the run in progress.
+func (r *runTracker) join(ctx context.Context) (wait chan error, runCtx context.Context, id uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.active == 0 {
+		r.active++
+		runCtx, id = r.stoppableLocked(ctx)
+		return nil, runCtx, id
+	}
+	if r.waiters == nil {
+		r.waiters = make(map[chan error]struct{})
+	}
+	wait = make(chan error, 1)
+	r.waiters[wait] = struct{}{}
+	return wait, nil, 0
+}
+
+// stop cancels every run in progress with ErrShutdown as the cause and
+// returns channels that are closed as each of them ends.
+func (r *runTracker) stop() []<-chan struct{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	done := make([]<-chan struct{}, 0, len(r.runs))
+	for _, run := range r.runs {
+		run.cancel(ErrShutdown)
+		done = append(done, run.done)
+	}
+	return done
+}
+
+// leave drops a waiter that stopped waiting before being notified.
+func (r *runTracker) leave(wait chan error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.waiters, wait)
+}
+
+// notify hands an outcome to every waiter.
+func (r *runTracker) notify(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.notifyLocked(err)
+}
+
+func (r *runTracker) notifyLocked(err error) {
+	for wait := range r.waiters {
+		wait <- err // Buffered, and each waiter is notified once
+		delete(r.waiters, wait)
+	}
+}
+
+// synchronize runs one goal-directed synchronization pass and tells
+// waiters when it converges or plateaus.
+func (s *Swarm) synchronize(ctx context.Context, target *core.TargetPattern) error {
+	err := s.goalDirectedSync.AchieveSynchronization(ctx, target)
+	if err == nil || isPlateau(err) {
+		s.runs.notify(err)
+	}
+	return err
+}
+
+// WaitForConvergence blocks until the swarm reaches its target coherence
+// and returns the coherence it ended with. If no Run or RunContinuous is in
+// progress it runs the synchronization loop itself, on the caller's
+// goroutine, so nothing is left running once it returns. Otherwise it
+// attaches to the loop in progress and returns as soon as that loop
+// converges, without starting another.
+//
+// It returns early with the context's error when ctx is done, with a
+// *PlateauError when the loop stalls (see WithPlateauDetection), or with
+// the loop's own error when the loop it attached to ends first. Callbacks
+// registered with WithConvergenceCallback fire as usual while it waits.
+func (s *Swarm) WaitForConvergence(ctx context.Context) (float64, error) {
+	wait, runCtx, id := s.runs.join(ctx)
+	if wait == nil {
+		err := shutdownErr(runCtx, s.run(runCtx))
+		s.runs.finish(id, err)
+		return s.MeasureCoherence(), err
+	}
+	defer s.runs.leave(wait)
+
+	// A RunContinuous loop holding the target has nothing left to report
+	if coherence := s.MeasureCoherence(); coherence >= s.EffectiveTargetCoherence() {
+		return coherence, nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return s.MeasureCoherence(), ctx.Err()
+	case err := <-wait:
+		return s.MeasureCoherence(), err
+	}
+}
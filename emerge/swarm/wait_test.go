@@ -0,0 +1,140 @@
+package swarm_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+func TestWaitForConvergence(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{
+		Phase:     0,
+		Frequency: 200 * time.Millisecond,
+		Coherence: 0.85,
+	}
+
+	t.Run("runs the loop itself", func(t *testing.T) {
+		t.Parallel()
+		synctest.Test(t, func(t *testing.T) {
+			var events []swarm.ConvergenceEvent
+			s, err := swarm.New(20, goalState, swarm.WithSeed(5),
+				swarm.WithConvergenceCallback(func(e swarm.ConvergenceEvent) {
+					events = append(events, e)
+				}))
+			require.NoError(t, err)
+			defer s.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+
+			coherence, err := s.WaitForConvergence(ctx)
+			require.NoError(t, err)
+			assert.InDelta(t, s.MeasureCoherence(), coherence, 1e-9)
+			require.NotEmpty(t, events, "convergence callback should fire")
+			assert.True(t, events[len(events)-1].Converged)
+
+			// Nothing is left running: the swarm holds still
+			synctest.Wait()
+			before := s.MeasureCoherence()
+			time.Sleep(time.Second)
+			assert.InDelta(t, before, s.MeasureCoherence(), 1e-9)
+		})
+	})
+
+	t.Run("attaches to RunContinuous", func(t *testing.T) {
+		t.Parallel()
+		synctest.Test(t, func(t *testing.T) {
+			s, err := swarm.New(20, goalState, swarm.WithSeed(5))
+			require.NoError(t, err)
+			defer s.Close()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error, 1)
+			go func() { done <- s.RunContinuous(ctx) }()
+			synctest.Wait() // Let the loop register before attaching
+
+			coherence, err := s.WaitForConvergence(ctx)
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, coherence, goalState.Coherence-0.1)
+
+			// RunContinuous keeps going until its own context ends
+			select {
+			case err := <-done:
+				t.Fatalf("RunContinuous returned early: %v", err)
+			default:
+			}
+			cancel()
+			require.ErrorIs(t, <-done, context.Canceled)
+		})
+	})
+
+	t.Run("stops on plateau", func(t *testing.T) {
+		t.Parallel()
+		synctest.Test(t, func(t *testing.T) {
+			// An epsilon wider than coherence's whole range flags the first full window
+			s, err := swarm.New(20, goalState, swarm.WithSeed(3), swarm.WithPlateauDetection(2, 1.5))
+			require.NoError(t, err)
+			defer s.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+
+			coherence, err := s.WaitForConvergence(ctx)
+			require.ErrorIs(t, err, swarm.ErrPlateau)
+			var plateau *swarm.PlateauError
+			require.True(t, errors.As(err, &plateau))
+			assert.InDelta(t, plateau.Last, coherence, 1e-9)
+		})
+	})
+
+	t.Run("returns when the context is done", func(t *testing.T) {
+		t.Parallel()
+		synctest.Test(t, func(t *testing.T) {
+			s, err := swarm.New(20, goalState, swarm.WithSeed(5))
+			require.NoError(t, err)
+			defer s.Close()
+			s.Pause() // Never converges
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			coherence, err := s.WaitForConvergence(ctx)
+			require.ErrorIs(t, err, context.DeadlineExceeded)
+			assert.InDelta(t, s.MeasureCoherence(), coherence, 1e-9)
+		})
+	})
+
+	t.Run("waiters share one run", func(t *testing.T) {
+		t.Parallel()
+		synctest.Test(t, func(t *testing.T) {
+			s, err := swarm.New(20, goalState, swarm.WithSeed(5), swarm.WithPlateauDetection(2, 1.5))
+			require.NoError(t, err)
+			defer s.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+
+			errs := make(chan error, 2)
+			for range 2 {
+				go func() {
+					_, err := s.WaitForConvergence(ctx)
+					errs <- err
+				}()
+			}
+			// Both get the plateau of the single run, not one run each
+			first, second := <-errs, <-errs
+			require.ErrorIs(t, first, swarm.ErrPlateau)
+			assert.Same(t, first, second)
+		})
+	})
+}
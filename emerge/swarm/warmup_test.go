@@ -0,0 +1,130 @@
+package swarm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+	"github.com/carlisia/bio-adapt/emerge/swarm/clocktest"
+)
+
+// timeToCoherence steps s on clock, one tick interval per step, and
+// returns the clock time it took to reach coherence, or limit if it never
+// did. It also returns the time of each lifecycle event of type want.
+func timeToCoherence(t *testing.T, s *swarm.Swarm, clock *clocktest.Clock, coherence float64,
+	want swarm.LifecycleEventType,
+) (time.Duration, []time.Duration) {
+	t.Helper()
+	const (
+		tick  = 100 * time.Millisecond
+		limit = 500
+	)
+	events := s.Events()
+	start := clock.Now()
+	var fired []time.Duration
+	elapsed := time.Duration(limit) * tick
+	for range limit {
+		reached := s.Step() >= coherence
+		for len(events) > 0 {
+			if e := <-events; e.Type == want {
+				fired = append(fired, e.Time.Sub(start))
+			}
+		}
+		if reached {
+			elapsed = clock.Now().Sub(start)
+			break
+		}
+		clock.Advance(tick)
+	}
+	return elapsed, fired
+}
+
+func TestWarmupTarget(t *testing.T) {
+	t.Parallel()
+
+	goalState := core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.9}
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, tt := range []struct {
+		coherence float64
+		hold      time.Duration
+	}{{0, 0}, {1, 0}, {-0.5, 0}, {0.6, -time.Second}} {
+		_, err := swarm.New(10, goalState, swarm.WithWarmupTarget(tt.coherence, tt.hold))
+		require.Error(t, err, "coherence %v hold %v", tt.coherence, tt.hold)
+	}
+
+	// Warming up first reaches 0.9 on a large swarm no later than aiming
+	// for it directly
+	t.Run("time to target", func(t *testing.T) {
+		t.Parallel()
+
+		for seed := range int64(4) {
+			clock := clocktest.New(start)
+			direct, err := swarm.New(500, goalState, swarm.WithSeed(seed), swarm.WithClock(clock))
+			require.NoError(t, err)
+			directTime, _ := timeToCoherence(t, direct, clock, 0.9, swarm.EventWarmedUp)
+			direct.Close()
+
+			clock = clocktest.New(start)
+			warm, err := swarm.New(500, goalState, swarm.WithSeed(seed), swarm.WithClock(clock),
+				swarm.WithWarmupTarget(0.6, 0))
+			require.NoError(t, err)
+			warmTime, warmedUp := timeToCoherence(t, warm, clock, 0.9, swarm.EventWarmedUp)
+			warm.Close()
+
+			assert.Less(t, directTime, 50*time.Second, "seed %d: direct run never reached 0.9", seed)
+			assert.LessOrEqual(t, warmTime, directTime, "seed %d", seed)
+			assert.Len(t, warmedUp, 1, "seed %d: warmup should end exactly once", seed)
+		}
+	})
+
+	// The swarm holds the warmup coherence for the hold before tightening,
+	// and the run does not count as converged meanwhile
+	t.Run("hold", func(t *testing.T) {
+		t.Parallel()
+
+		clock := clocktest.New(start)
+		s, err := swarm.New(200, goalState, swarm.WithSeed(1), swarm.WithClock(clock),
+			swarm.WithWarmupTarget(0.6, 500*time.Millisecond))
+		require.NoError(t, err)
+		defer s.Close()
+
+		events := s.Events()
+		var warmedUp bool
+		for step := range 5 {
+			assert.Less(t, s.Step(), 0.9, "step %d: should hold near the warmup coherence", step)
+			for len(events) > 0 {
+				e := <-events
+				assert.NotEqual(t, swarm.EventConverged, e.Type, "step %d", step)
+				warmedUp = warmedUp || e.Type == swarm.EventWarmedUp
+			}
+			clock.Advance(100 * time.Millisecond)
+		}
+		assert.False(t, warmedUp, "warmup ended before the hold elapsed")
+
+		took, fired := timeToCoherence(t, s, clock, 0.9, swarm.EventWarmedUp)
+		assert.Less(t, took, 10*time.Second)
+		assert.Len(t, fired, 1)
+	})
+
+	// A target no tighter than the warmup skips it
+	t.Run("loose target", func(t *testing.T) {
+		t.Parallel()
+
+		clock := clocktest.New(start)
+		loose := goalState
+		loose.Coherence = 0.5
+		s, err := swarm.New(100, loose, swarm.WithSeed(2), swarm.WithClock(clock),
+			swarm.WithWarmupTarget(0.6, time.Hour))
+		require.NoError(t, err)
+		defer s.Close()
+
+		took, fired := timeToCoherence(t, s, clock, 0.5, swarm.EventWarmedUp)
+		assert.Less(t, took, 10*time.Second)
+		assert.Empty(t, fired)
+	})
+}
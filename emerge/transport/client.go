@@ -0,0 +1,52 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// GossipClient exchanges agent state with one remote GossipServer.
+// It is safe for concurrent use.
+type GossipClient struct {
+	endpoint Endpoint
+	conn     *grpc.ClientConn
+}
+
+// NewGossipClient creates a client for the endpoint. The connection is
+// established lazily on the first exchange, so an unreachable endpoint is
+// not an error here. Connections are unencrypted; run gossip on a trusted
+// network.
+func NewGossipClient(endpoint Endpoint) (*GossipClient, error) {
+	conn, err := grpc.NewClient(endpoint.Address,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(jsonCodec{})),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("gossip client %s: %w", endpoint.Address, err)
+	}
+	return &GossipClient{endpoint: endpoint, conn: conn}, nil
+}
+
+// Endpoint returns the endpoint the client talks to.
+func (c *GossipClient) Endpoint() Endpoint {
+	return c.endpoint
+}
+
+// Exchange pushes the local messages to the server and returns the
+// server's. Use a context deadline to bound how long a slow or dropped
+// peer can hold the caller.
+func (c *GossipClient) Exchange(ctx context.Context, local []PhaseMessage) ([]PhaseMessage, error) {
+	resp := new(exchangeResponse)
+	if err := c.conn.Invoke(ctx, exchangeMethod, &exchangeRequest{Messages: local}, resp); err != nil {
+		return nil, fmt.Errorf("gossip exchange with %s: %w", c.endpoint.Address, err)
+	}
+	return resp.Messages, nil
+}
+
+// Close releases the connection.
+func (c *GossipClient) Close() error {
+	return c.conn.Close()
+}
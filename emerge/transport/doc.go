@@ -0,0 +1,29 @@
+// Package transport lets agents in separate processes form one swarm by
+// gossiping their phase over gRPC.
+//
+// A GossipServer publishes the state of a process's local agents, and a
+// GossipClient exchanges state with a remote server: each Exchange pushes
+// the caller's messages and returns the server's. Messages carry only
+// (agentID, phase, frequency) plus a send time; agents never share memory
+// across the wire.
+//
+// The service is defined by hand rather than generated from a .proto file,
+// and messages are encoded as JSON, so no code generation is needed. Both
+// ends must use this package.
+//
+// Most users do not use the client directly; swarm.WithRemoteNeighbors
+// gossips with a list of endpoints in the background and feeds the remote
+// agents into the swarm's coupling:
+//
+//	srv, _ := transport.NewGossipServer(":7946", local.GossipMessages)
+//	go srv.Serve()
+//	defer srv.Stop()
+//
+//	s, _ := swarm.New(50, target, swarm.WithRemoteNeighbors([]transport.Endpoint{
+//		{Address: "peer-1:7946"},
+//	}))
+//
+// Swarm.AttachGossipServer also couples in the agents that peers push to
+// the process's own server, so a process that only serves joins the swarm
+// too.
+package transport
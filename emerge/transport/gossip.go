@@ -0,0 +1,74 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// PhaseMessage is the state one agent shares with remote peers.
+type PhaseMessage struct {
+	AgentID   string        `json:"agent_id"`
+	Phase     float64       `json:"phase"`
+	Frequency time.Duration `json:"frequency"`
+	SentAt    time.Time     `json:"sent_at"`
+}
+
+// Endpoint identifies a remote GossipServer.
+type Endpoint struct {
+	Address string // host:port, or any gRPC target
+}
+
+// exchangeRequest carries the caller's messages to the server.
+type exchangeRequest struct {
+	Messages []PhaseMessage `json:"messages"`
+}
+
+// exchangeResponse carries the server's messages back to the caller.
+type exchangeResponse struct {
+	Messages []PhaseMessage `json:"messages"`
+}
+
+// Service and method names on the wire.
+const (
+	serviceName    = "bioadapt.transport.Gossip"
+	exchangeMethod = "/" + serviceName + "/Exchange"
+)
+
+// jsonCodec encodes gossip messages as JSON.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+func (jsonCodec) Name() string                       { return "json" }
+
+// gossipHandler is the server-side interface of the gossip service.
+type gossipHandler interface {
+	exchange(ctx context.Context, req *exchangeRequest) (*exchangeResponse, error)
+}
+
+// serviceDesc describes the gossip service to grpc in place of generated code.
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*gossipHandler)(nil),
+	Methods: []grpc.MethodDesc{{
+		MethodName: "Exchange",
+		Handler: func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+			req := new(exchangeRequest)
+			if err := dec(req); err != nil {
+				return nil, err
+			}
+			h, _ := srv.(gossipHandler)
+			if interceptor == nil {
+				return h.exchange(ctx, req)
+			}
+			info := &grpc.UnaryServerInfo{Server: srv, FullMethod: exchangeMethod}
+			return interceptor(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+				r, _ := req.(*exchangeRequest)
+				return h.exchange(ctx, r)
+			})
+		},
+	}},
+}
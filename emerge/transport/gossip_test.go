@@ -0,0 +1,137 @@
+package transport_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/transport"
+)
+
+func startServer(t *testing.T, local func() []transport.PhaseMessage) *transport.GossipServer {
+	t.Helper()
+
+	srv, err := transport.NewGossipServer("127.0.0.1:0", local)
+	require.NoError(t, err)
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve() }()
+	t.Cleanup(func() {
+		srv.Stop()
+		assert.NoError(t, <-done)
+	})
+	return srv
+}
+
+func TestGossipExchange(t *testing.T) {
+	t.Parallel()
+
+	remote := []transport.PhaseMessage{
+		{AgentID: "b/agent-0", Phase: 1.5, Frequency: 200 * time.Millisecond},
+		{AgentID: "b/agent-1", Phase: 2.5, Frequency: 250 * time.Millisecond},
+	}
+	srv := startServer(t, func() []transport.PhaseMessage { return remote })
+
+	client, err := transport.NewGossipClient(transport.Endpoint{Address: srv.Addr()})
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sent := time.Now()
+	got, err := client.Exchange(ctx, []transport.PhaseMessage{
+		{AgentID: "a/agent-0", Phase: 0.5, Frequency: 100 * time.Millisecond, SentAt: sent},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, got, 2)
+	for i, m := range got {
+		assert.Equal(t, remote[i].AgentID, m.AgentID)
+		assert.InDelta(t, remote[i].Phase, m.Phase, 1e-12)
+		assert.Equal(t, remote[i].Frequency, m.Frequency)
+		assert.False(t, m.SentAt.IsZero(), "the server should stamp outgoing messages")
+	}
+
+	peers := srv.Peers()
+	require.Len(t, peers, 1, "the server should record pushed messages")
+	assert.Equal(t, "a/agent-0", peers[0].AgentID)
+	assert.True(t, sent.Equal(peers[0].SentAt))
+}
+
+func TestGossipServerKeepsNewestPeerState(t *testing.T) {
+	t.Parallel()
+
+	srv := startServer(t, func() []transport.PhaseMessage { return nil })
+	client, err := transport.NewGossipClient(transport.Endpoint{Address: srv.Addr()})
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	_, err = client.Exchange(ctx, []transport.PhaseMessage{{AgentID: "a/agent-0", Phase: 1, SentAt: now}})
+	require.NoError(t, err)
+	// A delayed, older message must not overwrite newer state
+	_, err = client.Exchange(ctx, []transport.PhaseMessage{{AgentID: "a/agent-0", Phase: 2, SentAt: now.Add(-time.Second)}})
+	require.NoError(t, err)
+
+	peers := srv.Peers()
+	require.Len(t, peers, 1)
+	assert.InDelta(t, 1.0, peers[0].Phase, 1e-12)
+}
+
+func TestGossipServerDatesPeersByReceipt(t *testing.T) {
+	t.Parallel()
+
+	srv := startServer(t, func() []transport.PhaseMessage { return nil })
+	client, err := transport.NewGossipClient(transport.Endpoint{Address: srv.Addr()})
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err = client.Exchange(ctx, []transport.PhaseMessage{
+		{AgentID: "a/fresh", SentAt: time.Now()},
+		{AgentID: "a/skewed", SentAt: time.Now().Add(-transport.PeerExpiry - time.Second)},
+		{AgentID: "a/unstamped"},
+	})
+	require.NoError(t, err)
+
+	peers := srv.Peers()
+	require.Len(t, peers, 3, "expiry runs from receipt, not from a sender's clock")
+	for _, m := range peers {
+		assert.False(t, m.SentAt.IsZero(), "unstamped messages count as sent on arrival")
+	}
+}
+
+func TestGossipExchangeUnreachable(t *testing.T) {
+	t.Parallel()
+
+	srv, err := transport.NewGossipServer("127.0.0.1:0", func() []transport.PhaseMessage { return nil })
+	require.NoError(t, err)
+	addr := srv.Addr()
+	srv.Stop() // Never served; the port is closed
+
+	client, err := transport.NewGossipClient(transport.Endpoint{Address: addr})
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	_, err = client.Exchange(ctx, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), addr)
+}
+
+func TestNewGossipServerValidation(t *testing.T) {
+	t.Parallel()
+
+	_, err := transport.NewGossipServer("127.0.0.1:0", nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,129 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// PeerExpiry is how long a GossipServer remembers a remote agent after it
+// last received a message from it.
+const PeerExpiry = 10 * time.Second
+
+// GossipServer answers gossip exchanges with the state of a process's local
+// agents and remembers the latest state pushed by each remote agent.
+type GossipServer struct {
+	local    func() []PhaseMessage
+	listener net.Listener
+	server   *grpc.Server
+
+	mu    sync.RWMutex
+	peers map[string]peerState
+}
+
+// peerState is the latest message from a remote agent and when it arrived.
+// Expiry runs from the server's own receipt time, so a sender whose clock
+// is skewed is neither dropped early nor kept forever; SentAt only orders
+// the sender's own messages.
+type peerState struct {
+	msg      PhaseMessage
+	received time.Time
+}
+
+// NewGossipServer listens on addr (e.g. ":7946", or "127.0.0.1:0" for an
+// ephemeral port). local is called on every exchange to snapshot the
+// local agents, so it must be safe for concurrent use; Swarm.GossipMessages
+// is a ready-made source. Call Serve to start answering.
+func NewGossipServer(addr string, local func() []PhaseMessage) (*GossipServer, error) {
+	if local == nil {
+		return nil, errors.New("gossip server: local source must not be nil")
+	}
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("gossip server: %w", err)
+	}
+
+	g := &GossipServer{
+		local:    local,
+		listener: lis,
+		server:   grpc.NewServer(grpc.ForceServerCodec(jsonCodec{})),
+		peers:    make(map[string]peerState),
+	}
+	g.server.RegisterService(&serviceDesc, g)
+	return g, nil
+}
+
+// Addr returns the address the server is listening on.
+func (g *GossipServer) Addr() string {
+	return g.listener.Addr().String()
+}
+
+// Serve answers exchanges until Stop is called. It returns nil after Stop.
+func (g *GossipServer) Serve() error {
+	if err := g.server.Serve(g.listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return fmt.Errorf("gossip server: %w", err)
+	}
+	return nil
+}
+
+// Stop closes the listener and waits for in-flight exchanges to finish.
+func (g *GossipServer) Stop() {
+	g.server.GracefulStop()
+	_ = g.listener.Close() // Already closed if Serve was running
+}
+
+// Peers returns the latest message received from each remote agent.
+// Agents not heard from for longer than PeerExpiry are forgotten, so a
+// peer that stops gossiping drops out.
+func (g *GossipServer) Peers() []PhaseMessage {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.expire(time.Now())
+	peers := make([]PhaseMessage, 0, len(g.peers))
+	for _, p := range g.peers {
+		peers = append(peers, p.msg)
+	}
+	return peers
+}
+
+// expire forgets the agents last heard from more than PeerExpiry before
+// now. g.mu must be held.
+func (g *GossipServer) expire(now time.Time) {
+	for id, p := range g.peers {
+		if now.Sub(p.received) > PeerExpiry {
+			delete(g.peers, id)
+		}
+	}
+}
+
+// exchange records the caller's messages and replies with the local ones.
+func (g *GossipServer) exchange(_ context.Context, req *exchangeRequest) (*exchangeResponse, error) {
+	now := time.Now()
+	g.mu.Lock()
+	for _, m := range req.Messages {
+		// Unstamped messages count as sent now
+		if m.SentAt.IsZero() {
+			m.SentAt = now
+		}
+		// Messages can arrive out of order; keep the newest per agent
+		if old, ok := g.peers[m.AgentID]; !ok || !m.SentAt.Before(old.msg.SentAt) {
+			g.peers[m.AgentID] = peerState{msg: m, received: now}
+		}
+	}
+	g.expire(now)
+	g.mu.Unlock()
+
+	local := g.local()
+	for i := range local {
+		if local[i].SentAt.IsZero() {
+			local[i].SentAt = now
+		}
+	}
+	return &exchangeResponse{Messages: local}, nil
+}
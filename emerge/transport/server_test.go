@@ -0,0 +1,24 @@
+package transport
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGossipServerExpiresByReceipt(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	g := &GossipServer{peers: map[string]peerState{
+		// Sent long ago by a lagging clock, but only just received
+		"a/fresh": {msg: PhaseMessage{AgentID: "a/fresh", SentAt: now.Add(-time.Hour)}, received: now},
+		// Sent just now by a fast clock, but received long ago
+		"a/stale": {msg: PhaseMessage{AgentID: "a/stale", SentAt: now}, received: now.Add(-PeerExpiry - time.Second)},
+	}}
+
+	g.expire(now)
+	assert.Contains(t, g.peers, "a/fresh")
+	assert.NotContains(t, g.peers, "a/stale")
+}
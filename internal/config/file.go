@@ -0,0 +1,290 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Supported configuration file formats.
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// Config is a swarm configuration as stored in a file: the goal the swarm
+// serves, an optional target coherence and the swarm parameters.
+//
+// In a file, keys are snake_case and durations are strings such as "50ms":
+//
+//	goal: minimize_api_calls
+//	target_coherence: 0.9
+//	swarm:
+//	  coupling_strength: 0.7
+//	  agent_update_interval: 25ms
+//
+// Swarm parameters missing from the file keep their DefaultConfig values.
+type Config struct {
+	Goal            string  // Goal identifier, e.g. "minimize_api_calls"
+	TargetCoherence float64 // Target coherence in [0,1] (0 = keep the swarm's target)
+	Swarm           Swarm
+}
+
+// LoadFile reads and validates a configuration file. The format is taken
+// from the extension: .json, .yaml or .yml.
+func LoadFile(path string) (Config, error) {
+	format, err := formatOf(path)
+	if err != nil {
+		return Config{}, err
+	}
+
+	f, err := os.Open(path) // #nosec G304 -- path is chosen by the operator
+	if err != nil {
+		return Config{}, fmt.Errorf("open config: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	cfg, err := Parse(f, format)
+	if err != nil {
+		return Config{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Parse decodes and validates a configuration in the given format
+// ("json" or "yaml"). Unknown keys are rejected so that a misspelled
+// parameter does not silently fall back to its default.
+func Parse(r io.Reader, format string) (Config, error) {
+	doc := newFileConfig(Config{Swarm: DefaultConfig()})
+
+	switch normalizeFormat(format) {
+	case FormatJSON:
+		dec := json.NewDecoder(r)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&doc); err != nil {
+			return Config{}, fmt.Errorf("decode json config: %w", err)
+		}
+	case FormatYAML:
+		dec := yaml.NewDecoder(r)
+		dec.KnownFields(true)
+		if err := dec.Decode(&doc); err != nil && !errors.Is(err, io.EOF) {
+			return Config{}, fmt.Errorf("decode yaml config: %w", err)
+		}
+	default:
+		return Config{}, fmt.Errorf("unsupported config format %q", format)
+	}
+
+	cfg := doc.config()
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// Marshal encodes a configuration in the given format ("json" or "yaml").
+// Every parameter is written, so dumping a swarm's effective config records
+// auto-scaled values too; the output parses back to the same Config.
+func Marshal(c Config, format string) ([]byte, error) {
+	doc := newFileConfig(c)
+
+	switch normalizeFormat(format) {
+	case FormatJSON:
+		return json.MarshalIndent(doc, "", "  ")
+	case FormatYAML:
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(doc); err != nil {
+			return nil, fmt.Errorf("encode yaml config: %w", err)
+		}
+		if err := enc.Close(); err != nil {
+			return nil, fmt.Errorf("encode yaml config: %w", err)
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unsupported config format %q", format)
+	}
+}
+
+// Validate rejects nonsensical configurations. Unlike NormalizeAndValidate
+// it does not correct anything and does not depend on the swarm size:
+// values a file could only contain by mistake, such as negative energy or
+// a coherence above 1, are errors.
+func (c Config) Validate() error {
+	var errs ValidationErrors
+
+	if strings.TrimSpace(c.Goal) == "" {
+		errs = append(errs, ValidationError{
+			Field: "Goal", Value: c.Goal, Message: "must not be empty",
+		})
+	}
+	if c.TargetCoherence < 0 || c.TargetCoherence > 1 {
+		errs = append(errs, ValidationError{
+			Field: "TargetCoherence", Value: c.TargetCoherence, Message: "must be between 0 and 1",
+		})
+	}
+
+	s := c.Swarm
+	s.validateProbabilities(&errs)
+	s.validatePositiveParameters(&errs)
+	s.validateConcurrencyParameters(&errs)
+	if s.MinNeighbors < 0 {
+		errs = append(errs, ValidationError{
+			Field: "MinNeighbors", Value: s.MinNeighbors, Message: "cannot be negative",
+		})
+	}
+	if s.MinNeighbors > s.MaxNeighbors {
+		errs = append(errs, ValidationError{
+			Field: "MinNeighbors", Value: fmt.Sprintf("MinNeighbors=%d, MaxNeighbors=%d", s.MinNeighbors, s.MaxNeighbors),
+			Message: "cannot exceed MaxNeighbors",
+		})
+	}
+	if s.MaxSwarmSize < 0 {
+		errs = append(errs, ValidationError{
+			Field: "MaxSwarmSize", Value: s.MaxSwarmSize, Message: "cannot be negative",
+		})
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// formatOf infers the file format from the path's extension.
+func formatOf(path string) (string, error) {
+	ext := strings.TrimPrefix(filepath.Ext(path), ".")
+	format := normalizeFormat(ext)
+	if format != FormatJSON && format != FormatYAML {
+		return "", fmt.Errorf("cannot infer config format from %q: use .json, .yaml or .yml", path)
+	}
+	return format, nil
+}
+
+func normalizeFormat(format string) string {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "yml" {
+		return FormatYAML
+	}
+	return format
+}
+
+// fileConfig is the on-disk layout of Config.
+type fileConfig struct {
+	Goal            string    `json:"goal"                       yaml:"goal"`
+	TargetCoherence float64   `json:"target_coherence,omitempty" yaml:"target_coherence,omitempty"`
+	Swarm           fileSwarm `json:"swarm"                      yaml:"swarm"`
+}
+
+// fileSwarm is the on-disk layout of Swarm.
+type fileSwarm struct {
+	ConnectionProbability    float64  `json:"connection_probability"     yaml:"connection_probability"`
+	MaxNeighbors             int      `json:"max_neighbors"              yaml:"max_neighbors"`
+	MinNeighbors             int      `json:"min_neighbors"              yaml:"min_neighbors"`
+	CouplingStrength         float64  `json:"coupling_strength"          yaml:"coupling_strength"`
+	Stubbornness             float64  `json:"stubbornness"               yaml:"stubbornness"`
+	InitialEnergy            float64  `json:"initial_energy"             yaml:"initial_energy"`
+	AssumedMaxNeighbors      int      `json:"assumed_max_neighbors"      yaml:"assumed_max_neighbors"`
+	BasinStrength            float64  `json:"basin_strength"             yaml:"basin_strength"`
+	BasinWidth               float64  `json:"basin_width"                yaml:"basin_width"`
+	BaseConfidence           float64  `json:"base_confidence"            yaml:"base_confidence"`
+	InfluenceDefault         float64  `json:"influence_default"          yaml:"influence_default"`
+	MaxSwarmSize             int      `json:"max_swarm_size"             yaml:"max_swarm_size"`
+	MaxConcurrentAgents      int      `json:"max_concurrent_agents"      yaml:"max_concurrent_agents"`
+	UseBatchProcessing       bool     `json:"use_batch_processing"       yaml:"use_batch_processing"`
+	BatchSize                int      `json:"batch_size"                 yaml:"batch_size"`
+	WorkerPoolSize           int      `json:"worker_pool_size"           yaml:"worker_pool_size"`
+	AgentUpdateInterval      duration `json:"agent_update_interval"      yaml:"agent_update_interval"`
+	MonitoringInterval       duration `json:"monitoring_interval"        yaml:"monitoring_interval"`
+	ConnectionOptimThreshold int      `json:"connection_optim_threshold" yaml:"connection_optim_threshold"`
+	EnableConnectionOptim    bool     `json:"enable_connection_optim"    yaml:"enable_connection_optim"`
+	AutoScale                bool     `json:"auto_scale"                 yaml:"auto_scale"`
+}
+
+func newFileConfig(c Config) fileConfig {
+	s := c.Swarm
+	return fileConfig{
+		Goal:            c.Goal,
+		TargetCoherence: c.TargetCoherence,
+		Swarm: fileSwarm{
+			ConnectionProbability:    s.ConnectionProbability,
+			MaxNeighbors:             s.MaxNeighbors,
+			MinNeighbors:             s.MinNeighbors,
+			CouplingStrength:         s.CouplingStrength,
+			Stubbornness:             s.Stubbornness,
+			InitialEnergy:            s.InitialEnergy,
+			AssumedMaxNeighbors:      s.AssumedMaxNeighbors,
+			BasinStrength:            s.BasinStrength,
+			BasinWidth:               s.BasinWidth,
+			BaseConfidence:           s.BaseConfidence,
+			InfluenceDefault:         s.InfluenceDefault,
+			MaxSwarmSize:             s.MaxSwarmSize,
+			MaxConcurrentAgents:      s.MaxConcurrentAgents,
+			UseBatchProcessing:       s.UseBatchProcessing,
+			BatchSize:                s.BatchSize,
+			WorkerPoolSize:           s.WorkerPoolSize,
+			AgentUpdateInterval:      duration(s.AgentUpdateInterval),
+			MonitoringInterval:       duration(s.MonitoringInterval),
+			ConnectionOptimThreshold: s.ConnectionOptimThreshold,
+			EnableConnectionOptim:    s.EnableConnectionOptim,
+			AutoScale:                s.AutoScale,
+		},
+	}
+}
+
+func (f fileConfig) config() Config {
+	s := f.Swarm
+	return Config{
+		Goal:            strings.TrimSpace(f.Goal),
+		TargetCoherence: f.TargetCoherence,
+		Swarm: Swarm{
+			ConnectionProbability:    s.ConnectionProbability,
+			MaxNeighbors:             s.MaxNeighbors,
+			MinNeighbors:             s.MinNeighbors,
+			CouplingStrength:         s.CouplingStrength,
+			Stubbornness:             s.Stubbornness,
+			InitialEnergy:            s.InitialEnergy,
+			AssumedMaxNeighbors:      s.AssumedMaxNeighbors,
+			BasinStrength:            s.BasinStrength,
+			BasinWidth:               s.BasinWidth,
+			BaseConfidence:           s.BaseConfidence,
+			InfluenceDefault:         s.InfluenceDefault,
+			MaxSwarmSize:             s.MaxSwarmSize,
+			MaxConcurrentAgents:      s.MaxConcurrentAgents,
+			UseBatchProcessing:       s.UseBatchProcessing,
+			BatchSize:                s.BatchSize,
+			WorkerPoolSize:           s.WorkerPoolSize,
+			AgentUpdateInterval:      time.Duration(s.AgentUpdateInterval),
+			MonitoringInterval:       time.Duration(s.MonitoringInterval),
+			ConnectionOptimThreshold: s.ConnectionOptimThreshold,
+			EnableConnectionOptim:    s.EnableConnectionOptim,
+			AutoScale:                s.AutoScale,
+		},
+	}
+}
+
+// duration is a time.Duration written as a string such as "50ms".
+type duration time.Duration
+
+// MarshalText implements encoding.TextMarshaler.
+func (d duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *duration) UnmarshalText(text []byte) error {
+	v, err := time.ParseDuration(strings.TrimSpace(string(text)))
+	if err != nil {
+		return err
+	}
+	*d = duration(v)
+	return nil
+}
@@ -0,0 +1,124 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/internal/config"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("yaml", func(t *testing.T) {
+		t.Parallel()
+
+		cfg, err := config.Parse(strings.NewReader(`
+goal: minimize_api_calls
+target_coherence: 0.9
+swarm:
+  coupling_strength: 0.7
+  max_neighbors: 12
+  agent_update_interval: 25ms
+`), "yaml")
+		require.NoError(t, err)
+
+		assert.Equal(t, "minimize_api_calls", cfg.Goal)
+		assert.InDelta(t, 0.9, cfg.TargetCoherence, 1e-9)
+		assert.InDelta(t, 0.7, cfg.Swarm.CouplingStrength, 1e-9)
+		assert.Equal(t, 12, cfg.Swarm.MaxNeighbors)
+		assert.Equal(t, 25*time.Millisecond, cfg.Swarm.AgentUpdateInterval)
+		// Omitted parameters keep their defaults
+		assert.Equal(t, config.DefaultConfig().MonitoringInterval, cfg.Swarm.MonitoringInterval)
+		assert.InDelta(t, config.DefaultConfig().InitialEnergy, cfg.Swarm.InitialEnergy, 1e-9)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		cfg, err := config.Parse(strings.NewReader(
+			`{"goal": "save_energy", "swarm": {"initial_energy": 50, "monitoring_interval": "1s"}}`), "JSON")
+		require.NoError(t, err)
+
+		assert.Equal(t, "save_energy", cfg.Goal)
+		assert.Zero(t, cfg.TargetCoherence)
+		assert.InDelta(t, 50.0, cfg.Swarm.InitialEnergy, 1e-9)
+		assert.Equal(t, time.Second, cfg.Swarm.MonitoringInterval)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name   string
+			format string
+			input  string
+		}{
+			{"empty goal", "yaml", "goal: ''"},
+			{"missing goal", "yaml", "swarm: {stubbornness: 0.1}"},
+			{"negative energy", "yaml", "goal: save_energy\nswarm: {initial_energy: -5}"},
+			{"coherence above one", "yaml", "goal: save_energy\ntarget_coherence: 1.2"},
+			{"coupling above one", "json", `{"goal": "save_energy", "swarm": {"coupling_strength": 1.5}}`},
+			{"min above max neighbors", "yaml", "goal: save_energy\nswarm: {min_neighbors: 6, max_neighbors: 3}"},
+			{"unknown key", "yaml", "goal: save_energy\nswarm: {couplng_strength: 0.5}"},
+			{"bad duration", "json", `{"goal": "save_energy", "swarm": {"agent_update_interval": "soon"}}`},
+			{"unsupported format", "toml", `goal = "save_energy"`},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				_, err := config.Parse(strings.NewReader(tt.input), tt.format)
+				assert.Error(t, err)
+			})
+		}
+	})
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	want := config.Config{
+		Goal:            "reach_consensus",
+		TargetCoherence: 0.85,
+		Swarm:           config.AutoScaleConfig(500),
+	}
+
+	for _, format := range []string{config.FormatJSON, config.FormatYAML} {
+		t.Run(format, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := config.Marshal(want, format)
+			require.NoError(t, err)
+
+			got, err := config.Parse(strings.NewReader(string(data)), format)
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "swarm.yml")
+	require.NoError(t, os.WriteFile(path, []byte("goal: maintain_rhythm\n"), 0o600))
+
+	cfg, err := config.LoadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "maintain_rhythm", cfg.Goal)
+	assert.Equal(t, config.DefaultConfig(), cfg.Swarm)
+
+	_, err = config.LoadFile(filepath.Join(dir, "swarm.ini"))
+	require.Error(t, err, "unknown extension")
+
+	_, err = config.LoadFile(filepath.Join(dir, "missing.json"))
+	require.Error(t, err)
+}
@@ -38,11 +38,7 @@ func (b *AttractorBasin) IsInBasin(state core.State) bool {
 
 // DistanceToTarget calculates the phase distance from a state to the target.
 func (b *AttractorBasin) DistanceToTarget(state core.State) float64 {
-	// Use math.Remainder for proper phase distance calculation
-	// This handles both positive and negative phases correctly
-	diff := state.Phase - b.target.Phase
-	diff = math.Remainder(diff, 2*math.Pi)
-	return math.Abs(diff)
+	return math.Abs(core.PhaseDifference(state.Phase, b.target.Phase))
 }
 
 // AttractionForce calculates the strength of attraction for a given state.
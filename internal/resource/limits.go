@@ -0,0 +1,52 @@
+package resource
+
+import (
+	"fmt"
+	"math"
+)
+
+// Limits bounds how agents spend energy. The zero value is unbounded.
+type Limits struct {
+	MaxSpendPerTick float64 // Most energy an agent spends per update; 0 is unbounded
+}
+
+// LimitOption configures Limits.
+type LimitOption func(*Limits) error
+
+// WithMaxSpendPerTick caps the energy an agent spends per update at amount,
+// however expensive the action it would like to take.
+func WithMaxSpendPerTick(amount float64) LimitOption {
+	return func(l *Limits) error {
+		if !(amount > 0) || math.IsInf(amount, 0) {
+			return fmt.Errorf("max spend per tick must be positive and finite, got %v", amount)
+		}
+		l.MaxSpendPerTick = amount
+		return nil
+	}
+}
+
+// NewLimits builds Limits from options.
+func NewLimits(opts ...LimitOption) (Limits, error) {
+	var l Limits
+	for _, opt := range opts {
+		if err := opt(&l); err != nil {
+			return Limits{}, err
+		}
+	}
+	return l, nil
+}
+
+// Allow returns the share, from 0 to 1, of an action costing cost that an
+// agent may carry out having already spent spent this update. Actions
+// within the cap are allowed in full; others are scaled down to what is
+// left of it, and deferred, with share 0, once it is used up.
+func (l Limits) Allow(cost, spent float64) float64 {
+	if l.MaxSpendPerTick <= 0 || cost <= 0 {
+		return 1
+	}
+	left := l.MaxSpendPerTick - spent
+	if left <= 0 {
+		return 0
+	}
+	return math.Min(1, left/cost)
+}
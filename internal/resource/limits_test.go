@@ -0,0 +1,27 @@
+package resource
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimits(t *testing.T) {
+	t.Parallel()
+
+	l, err := NewLimits(WithMaxSpendPerTick(10))
+	require.NoError(t, err)
+
+	assert.InDelta(t, 1, l.Allow(4, 0), 1e-12, "within the cap")
+	assert.InDelta(t, 0.5, l.Allow(20, 0), 1e-12, "scaled down to the cap")
+	assert.InDelta(t, 0.25, l.Allow(8, 8), 1e-12, "scaled down to what is left")
+	assert.Zero(t, l.Allow(1, 10), "deferred once the cap is used up")
+	assert.InDelta(t, 1, Limits{}.Allow(1e9, 1e9), 1e-12, "zero value is unbounded")
+
+	for _, amount := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+		_, err := NewLimits(WithMaxSpendPerTick(amount))
+		require.Error(t, err, "amount %v", amount)
+	}
+}
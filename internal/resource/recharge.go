@@ -0,0 +1,87 @@
+package resource
+
+import (
+	"math"
+	"time"
+)
+
+// RechargePolicy decides how much energy an agent regains over a period.
+// Policies are stateless: everything they need is passed in, so one policy
+// can serve every agent of a swarm.
+type RechargePolicy interface {
+	// Recharge returns the energy to add to an agent holding current out
+	// of capacity after elapsed has passed. idle is how long the agent has
+	// gone without spending energy. The result may overshoot capacity;
+	// callers clamp with Recharge.
+	Recharge(current, capacity float64, elapsed, idle time.Duration) float64
+}
+
+// Recharge applies p to an agent's energy and returns the new level. Energy
+// never rises above capacity, and an agent already at or above capacity is
+// left as it is.
+func Recharge(p RechargePolicy, current, capacity float64, elapsed, idle time.Duration) float64 {
+	if p == nil || current >= capacity || elapsed <= 0 {
+		return current
+	}
+	gain := p.Recharge(current, capacity, elapsed, idle)
+	if gain <= 0 || math.IsNaN(gain) {
+		return current
+	}
+	return math.Min(current+gain, capacity)
+}
+
+// ConstantRate regenerates a fixed amount of energy per second,
+// like a steady metabolic supply.
+type ConstantRate struct {
+	PerSecond float64 // Energy regained per second
+}
+
+// Recharge implements RechargePolicy.
+func (c ConstantRate) Recharge(_, _ float64, elapsed, _ time.Duration) float64 {
+	return c.PerSecond * elapsed.Seconds()
+}
+
+// Logistic regenerates fastest at half capacity and slows as energy
+// approaches either end, following logistic growth dE/dt = r·E·(1-E/C).
+type Logistic struct {
+	Rate float64 // Growth rate r per second
+
+	// Floor is the level growth is computed from when energy is lower,
+	// so exhausted agents recover. Zero uses 1% of capacity.
+	Floor float64
+}
+
+// Recharge implements RechargePolicy.
+func (l Logistic) Recharge(current, capacity float64, elapsed, _ time.Duration) float64 {
+	if capacity <= 0 {
+		return 0
+	}
+	floor := l.Floor
+	if floor <= 0 {
+		floor = capacity * 0.01
+	}
+	e := math.Max(current, floor)
+	return l.Rate * e * (1 - e/capacity) * elapsed.Seconds()
+}
+
+// BurstOnIdle regenerates nothing while an agent is busy and restores
+// energy in bursts once it has been idle for a while, like rest after
+// exertion.
+type BurstOnIdle struct {
+	IdleAfter time.Duration // Idle time before bursts start
+
+	// Amount is the energy restored per recharge once the agent is idle.
+	// Zero refills to capacity.
+	Amount float64
+}
+
+// Recharge implements RechargePolicy.
+func (b BurstOnIdle) Recharge(current, capacity float64, _, idle time.Duration) float64 {
+	if idle < b.IdleAfter {
+		return 0
+	}
+	if b.Amount > 0 {
+		return b.Amount
+	}
+	return capacity - current
+}
@@ -0,0 +1,38 @@
+package resource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecharge(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		policy  RechargePolicy
+		current float64
+		idle    time.Duration
+		want    float64
+	}{
+		{"constant rate", ConstantRate{PerSecond: 10}, 20, 0, 30},
+		{"constant rate stops at capacity", ConstantRate{PerSecond: 10}, 95, 0, 100},
+		{"above capacity is kept", ConstantRate{PerSecond: 10}, 120, 0, 120},
+		{"logistic at half capacity", Logistic{Rate: 0.4}, 50, 0, 60},
+		{"logistic recovers from exhaustion", Logistic{Rate: 1}, 0, 0, 0.99},
+		{"burst waits for idle", BurstOnIdle{IdleAfter: time.Second}, 10, 500 * time.Millisecond, 10},
+		{"burst refills when idle", BurstOnIdle{IdleAfter: time.Second}, 10, time.Second, 100},
+		{"burst amount", BurstOnIdle{IdleAfter: time.Second, Amount: 25}, 10, 2 * time.Second, 35},
+		{"nil policy", nil, 10, 0, 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := Recharge(tt.policy, tt.current, 100, time.Second, tt.idle)
+			assert.InDelta(t, tt.want, got, 1e-9)
+		})
+	}
+}
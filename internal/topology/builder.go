@@ -1,7 +1,15 @@
 package topology
 
 import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strings"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
 	"github.com/carlisia/bio-adapt/emerge/swarm"
+	"github.com/carlisia/bio-adapt/internal/random"
 )
 
 // Builder defines how to construct network topologies for agent connections.
@@ -12,3 +20,67 @@ type BuilderWithParams interface {
 	Build(s *swarm.Swarm) error
 	WithParams(params map[string]interface{}) BuilderWithParams
 }
+
+// Option configures a randomized topology builder.
+type Option func(*options)
+
+type options struct {
+	seed   uint64
+	seeded bool
+}
+
+// WithSeed makes a randomized topology reproducible: the same seed and
+// parameters always produce the same connections.
+func WithSeed(seed int64) Option {
+	return func(o *options) {
+		o.seed = uint64(seed) //nolint:gosec // Any bit pattern is a valid seed
+		o.seeded = true
+	}
+}
+
+// newRand returns the random source for a builder, seeded if requested.
+func newRand(opts []Option) *rand.Rand {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if !o.seeded {
+		o.seed = uint64(random.Intn(math.MaxInt32))<<32 | uint64(random.Intn(math.MaxInt32)) //nolint:gosec // Intn is non-negative
+	}
+	return rand.New(rand.NewPCG(o.seed, o.seed)) //nolint:gosec // Topology layout is not security sensitive
+}
+
+// sortedAgents returns the swarm's agents ordered by ID, with numeric
+// suffixes compared by value so "agent-2" precedes "agent-10".
+// A stable order is what makes seeded builders reproducible.
+func sortedAgents(s *swarm.Swarm) []*agent.Agent {
+	var agents []*agent.Agent
+	s.ForEachAgent(func(a *agent.Agent) bool {
+		agents = append(agents, a)
+		return true
+	})
+	slices.SortFunc(agents, func(a, b *agent.Agent) int {
+		if len(a.ID) != len(b.ID) {
+			return len(a.ID) - len(b.ID)
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return agents
+}
+
+// connectEdges links each undirected edge in both directions.
+func connectEdges(agents []*agent.Agent, edges [][2]int) {
+	for _, e := range edges {
+		a, b := agents[e[0]], agents[e[1]]
+		a.ConnectTo(b.ID, b)
+		b.ConnectTo(a.ID, a)
+	}
+}
+
+// checkSize verifies the swarm has the agent count a builder was created for.
+func checkSize(agents []*agent.Agent, n int, name string) error {
+	if len(agents) != n {
+		return fmt.Errorf("%s topology built for %d agents, swarm has %d", name, n, len(agents))
+	}
+	return nil
+}
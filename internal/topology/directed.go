@@ -0,0 +1,82 @@
+package topology
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+// Edge is a directed, weighted link: From couples toward To's phase with
+// the given weight. A weight of 1 is a plain link.
+type Edge struct {
+	From, To string
+	Weight   float64
+}
+
+// Directed creates a topology of exactly the given edges, for influence
+// hierarchies that undirected builders cannot express: a manager's reports
+// can watch the manager closely (a heavy edge from each report to the
+// manager) while the manager watches them loosely (light edges back).
+// Edges weighted 0 are pruned rather than stored. An agent may appear in
+// no edge at all; it then couples with nobody.
+//
+// Weights must be non-negative and finite. Building fails if an edge names
+// an agent the swarm does not have. Coherence is a property of the agents'
+// phases alone, so MeasureCoherence is unaffected by direction or weight.
+func Directed(edges ...Edge) (Builder, error) {
+	for _, e := range edges {
+		if err := checkWeight(e.Weight); err != nil {
+			return nil, fmt.Errorf("edge %s->%s: %w", e.From, e.To, err)
+		}
+		if e.From == e.To {
+			return nil, fmt.Errorf("edge %s->%s links an agent to itself", e.From, e.To)
+		}
+	}
+
+	return func(s *swarm.Swarm) error {
+		for _, e := range edges {
+			from, ok := s.Agent(e.From)
+			if !ok {
+				return fmt.Errorf("edge %s->%s: %w: %s", e.From, e.To, swarm.ErrAgentNotFound, e.From)
+			}
+			to, ok := s.Agent(e.To)
+			if !ok {
+				return fmt.Errorf("edge %s->%s: %w: %s", e.From, e.To, swarm.ErrAgentNotFound, e.To)
+			}
+			from.ConnectWeighted(to.ID, to, e.Weight)
+		}
+		return nil
+	}, nil
+}
+
+// Weighted wraps a builder so every link it creates carries the weight
+// weight(from, to) returns, e.g. to make a Star's leaves watch the hub more
+// closely than the hub watches them. Links weighted 0 are pruned. Building
+// fails if weight returns a negative or non-finite value.
+func Weighted(b Builder, weight func(from, to *agent.Agent) float64) Builder {
+	return func(s *swarm.Swarm) error {
+		if err := b(s); err != nil {
+			return err
+		}
+		for _, from := range sortedAgents(s) {
+			for _, to := range from.NeighborList() {
+				w := weight(from, to)
+				if err := checkWeight(w); err != nil {
+					return fmt.Errorf("edge %s->%s: %w", from.ID, to.ID, err)
+				}
+				from.ConnectWeighted(to.ID, to, w)
+			}
+		}
+		return nil
+	}
+}
+
+// checkWeight verifies an edge weight is non-negative and finite.
+func checkWeight(w float64) error {
+	if !(w >= 0) || math.IsInf(w, 0) {
+		return fmt.Errorf("weight must be non-negative and finite, got %v", w)
+	}
+	return nil
+}
@@ -1,4 +1,6 @@
 // Package topology provides network topology builders for agent connections.
-// These builders create different connection patterns (ring, star, full mesh)
-// that determine how agents communicate and influence each other in the swarm.
+// These builders create different connection patterns (ring, star, full mesh,
+// small-world, scale-free, and directed, weighted graphs) that determine how
+// agents communicate and influence each other in the swarm. Inspect and
+// ExportDOT help diagnose the topology a swarm ended up with.
 package topology
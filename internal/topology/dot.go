@@ -0,0 +1,134 @@
+package topology
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+// DefaultDOTEdgeLimit is the swarm size above which ExportDOT leaves out
+// edges unless told otherwise (see WithDOTEdgeLimit). Graphviz lays out a
+// few thousand edges slowly and draws tens of thousands unreadably.
+const DefaultDOTEdgeLimit = 1000
+
+// DOTOption configures ExportDOT.
+type DOTOption func(*dotOptions)
+
+type dotOptions struct {
+	phaseBuckets int
+	influence    bool
+	edgeLimit    int
+}
+
+// ColorByPhase fills each node with a hue for its phase, quantized into
+// buckets arcs of the circle, so clusters of agents in step stand out as
+// patches of one color. Buckets below 1 count as 1.
+func ColorByPhase(buckets int) DOTOption {
+	return func(o *dotOptions) {
+		o.phaseBuckets = max(buckets, 1)
+	}
+}
+
+// SizeByInfluence sizes each node by the agent's influence, so hubs and
+// other strong pullers stand out.
+func SizeByInfluence() DOTOption {
+	return func(o *dotOptions) {
+		o.influence = true
+	}
+}
+
+// WithDOTEdgeLimit sets the swarm size above which edges are left out;
+// 0 or less draws edges at any size.
+func WithDOTEdgeLimit(agents int) DOTOption {
+	return func(o *dotOptions) {
+		o.edgeLimit = agents
+	}
+}
+
+// ExportDOT writes the swarm's topology to w as a Graphviz DOT digraph,
+// e.g. for rendering with dot -Tsvg. Each agent is a node named by its ID.
+// An edge points from an agent to a neighbor it couples toward; links both
+// ends list with the same weight are drawn once with arrows both ways, and
+// weights other than 1 are labeled. Links to agents outside the swarm,
+// such as bridges, are left out, as in Inspect.
+//
+// Above DefaultDOTEdgeLimit agents only nodes are written, with a comment
+// noting the omission. Work is linear in agents and links.
+func ExportDOT(s *swarm.Swarm, w io.Writer, opts ...DOTOption) error {
+	o := dotOptions{edgeLimit: DefaultDOTEdgeLimit}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	agents := sortedAgents(s)
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph swarm {")
+	fmt.Fprintln(bw, "  node [shape=circle];")
+
+	for _, a := range agents {
+		var attrs []string
+		if o.phaseBuckets > 0 {
+			bucket := int(a.Phase() / (2 * math.Pi) * float64(o.phaseBuckets))
+			hue := float64(min(bucket, o.phaseBuckets-1)) / float64(o.phaseBuckets)
+			attrs = append(attrs, "style=filled", fmt.Sprintf(`fillcolor="%.3f 0.600 0.950"`, hue))
+		}
+		if o.influence {
+			attrs = append(attrs, fmt.Sprintf("width=%.2f", 0.3*(1+max(a.Influence(), 0))), "fixedsize=true")
+		}
+		writeStmt(bw, strconv.Quote(a.ID), attrs)
+	}
+
+	if o.edgeLimit > 0 && len(agents) > o.edgeLimit {
+		fmt.Fprintf(bw, "  // edges omitted: %d agents exceeds the edge limit of %d\n", len(agents), o.edgeLimit)
+	} else {
+		writeEdges(bw, agents)
+	}
+
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// writeEdges writes a statement per link between agents, drawing links
+// both ends list with the same weight once.
+func writeEdges(w io.Writer, agents []*agent.Agent) {
+	members := make(map[string]*agent.Agent, len(agents))
+	for _, a := range agents {
+		members[a.ID] = a
+	}
+	for _, a := range agents {
+		for _, n := range a.NeighborList() {
+			if members[n.ID] != n || n == a {
+				continue
+			}
+			weight := a.EdgeWeight(n.ID)
+			mutual := n.IsConnectedTo(a.ID) && n.EdgeWeight(a.ID) == weight
+			if mutual && n.ID < a.ID {
+				continue // Drawn from n's side
+			}
+
+			var attrs []string
+			if mutual {
+				attrs = append(attrs, "dir=both")
+			}
+			if weight != 1 {
+				attrs = append(attrs, fmt.Sprintf(`label="%g"`, weight))
+			}
+			writeStmt(w, strconv.Quote(a.ID)+" -> "+strconv.Quote(n.ID), attrs)
+		}
+	}
+}
+
+// writeStmt writes one DOT statement with its attributes.
+func writeStmt(w io.Writer, stmt string, attrs []string) {
+	if len(attrs) == 0 {
+		fmt.Fprintf(w, "  %s;\n", stmt)
+		return
+	}
+	fmt.Fprintf(w, "  %s [%s];\n", stmt, strings.Join(attrs, ", "))
+}
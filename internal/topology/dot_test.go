@@ -0,0 +1,81 @@
+package topology_test
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+	"github.com/carlisia/bio-adapt/internal/topology"
+)
+
+// dotStmt matches a node or edge statement as ExportDOT writes them.
+var dotStmt = regexp.MustCompile(`^  "[^"]+"( -> "[^"]+")?( \[[^\]]+\])?;$`)
+
+func TestExportDOT(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ring", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := swarm.New(5, testGoal, swarm.WithTopology(topology.Ring))
+		require.NoError(t, err)
+		defer s.Close()
+
+		var buf bytes.Buffer
+		require.NoError(t, topology.ExportDOT(s, &buf))
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		require.GreaterOrEqual(t, len(lines), 3)
+		assert.Equal(t, "digraph swarm {", lines[0])
+		assert.Equal(t, "}", lines[len(lines)-1])
+
+		var nodes, edges int
+		for _, line := range lines[2 : len(lines)-1] {
+			require.Regexp(t, dotStmt, line)
+			if strings.Contains(line, "->") {
+				edges++
+				assert.Contains(t, line, "dir=both", "ring links go both ways")
+			} else {
+				nodes++
+			}
+		}
+		assert.Equal(t, 5, nodes)
+		assert.Equal(t, 5, edges, "each link is drawn once")
+	})
+
+	t.Run("colors, sizes and weights", func(t *testing.T) {
+		t.Parallel()
+
+		directed, err := topology.Directed(topology.Edge{From: "agent-0", To: "agent-1", Weight: 2.5})
+		require.NoError(t, err)
+		s, err := swarm.New(3, testGoal, swarm.WithTopology(directed))
+		require.NoError(t, err)
+		defer s.Close()
+
+		var buf bytes.Buffer
+		require.NoError(t, topology.ExportDOT(s, &buf, topology.ColorByPhase(8), topology.SizeByInfluence()))
+		out := buf.String()
+		assert.Equal(t, 3, strings.Count(out, "fillcolor="))
+		assert.Equal(t, 3, strings.Count(out, "width="))
+		assert.Contains(t, out, `"agent-0" -> "agent-1" [label="2.5"];`)
+		assert.Equal(t, 1, strings.Count(out, "->"))
+	})
+
+	t.Run("edges omitted above the limit", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := swarm.New(50, testGoal, swarm.WithTopology(topology.Ring))
+		require.NoError(t, err)
+		defer s.Close()
+
+		var buf bytes.Buffer
+		require.NoError(t, topology.ExportDOT(s, &buf, topology.WithDOTEdgeLimit(20)))
+		assert.NotContains(t, buf.String(), "->")
+		assert.Contains(t, buf.String(), "// edges omitted: 50 agents exceeds the edge limit of 20")
+		assert.Equal(t, 50, strings.Count(buf.String(), `  "agent-`))
+	})
+}
@@ -0,0 +1,174 @@
+package topology
+
+import (
+	"math/rand/v2"
+	"slices"
+
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+// Bounds on the work Inspect spends estimating the clustering coefficient:
+// at most clusteringNodes agents are sampled, and for each at most
+// clusteringPairs pairs of neighbors.
+const (
+	clusteringNodes = 200
+	clusteringPairs = 500
+)
+
+// TopologyReport describes the health of a swarm's connection graph.
+// Links are treated as undirected: two agents are adjacent if either lists
+// the other as a neighbor.
+//
+//nolint:revive // TopologyReport reads better than Report at call sites outside the package
+type TopologyReport struct {
+	Agents     int
+	Edges      int         // Undirected links
+	MinDegree  int         // Fewest neighbors of any agent
+	MaxDegree  int         // Most neighbors of any agent
+	MeanDegree float64     // Average neighbors per agent
+	Degrees    map[int]int // Number of agents with each degree
+
+	IsConnected    bool  // Every agent can reach every other
+	Components     int   // Number of connected components
+	ComponentSizes []int // Agents per component, largest first
+
+	// Average local clustering coefficient: how often two neighbors of an
+	// agent are neighbors themselves. Estimated from a sample on large or
+	// dense graphs.
+	Clustering float64
+}
+
+// Inspect reports on the connectivity of a swarm's topology. A swarm whose
+// graph falls apart into several components cannot converge as a whole,
+// since no coupling crosses between them; IsConnected and Components
+// surface that.
+func Inspect(s *swarm.Swarm) TopologyReport {
+	agents := sortedAgents(s)
+	index := make(map[string]int, len(agents))
+	for i, a := range agents {
+		index[a.ID] = i
+	}
+
+	// Undirected adjacency over agents in the swarm
+	adj := make([]map[int]bool, len(agents))
+	for i := range adj {
+		adj[i] = make(map[int]bool)
+	}
+	for i, a := range agents {
+		for _, n := range a.NeighborList() {
+			if j, ok := index[n.ID]; ok && j != i {
+				adj[i][j] = true
+				adj[j][i] = true
+			}
+		}
+	}
+
+	r := TopologyReport{
+		Agents:  len(agents),
+		Degrees: make(map[int]int),
+	}
+	if len(agents) == 0 {
+		return r
+	}
+
+	r.MinDegree = len(agents)
+	total := 0
+	for _, neighbors := range adj {
+		d := len(neighbors)
+		r.Degrees[d]++
+		r.MinDegree = min(r.MinDegree, d)
+		r.MaxDegree = max(r.MaxDegree, d)
+		total += d
+	}
+	r.Edges = total / 2
+	r.MeanDegree = float64(total) / float64(len(agents))
+
+	r.ComponentSizes = components(adj)
+	r.Components = len(r.ComponentSizes)
+	r.IsConnected = r.Components == 1
+	r.Clustering = clustering(adj)
+	return r
+}
+
+// components returns the size of each connected component, largest first.
+func components(adj []map[int]bool) []int {
+	seen := make([]bool, len(adj))
+	var sizes []int
+	for start := range adj {
+		if seen[start] {
+			continue
+		}
+		seen[start] = true
+		queue := []int{start}
+		size := 0
+		for len(queue) > 0 {
+			i := queue[0]
+			queue = queue[1:]
+			size++
+			for j := range adj[i] {
+				if !seen[j] {
+					seen[j] = true
+					queue = append(queue, j)
+				}
+			}
+		}
+		sizes = append(sizes, size)
+	}
+	slices.SortFunc(sizes, func(a, b int) int { return b - a })
+	return sizes
+}
+
+// clustering estimates the average local clustering coefficient. Agents
+// with fewer than two neighbors count as 0. The sample is drawn from a
+// fixed seed, so the estimate is the same for the same graph.
+func clustering(adj []map[int]bool) float64 {
+	rng := rand.New(rand.NewPCG(1, 1)) //nolint:gosec // Sampling is not security sensitive
+
+	nodes := make([]int, len(adj))
+	for i := range nodes {
+		nodes[i] = i
+	}
+	if len(nodes) > clusteringNodes {
+		rng.Shuffle(len(nodes), func(i, j int) { nodes[i], nodes[j] = nodes[j], nodes[i] })
+		nodes = nodes[:clusteringNodes]
+	}
+
+	sum := 0.0
+	for _, i := range nodes {
+		neighbors := make([]int, 0, len(adj[i]))
+		for j := range adj[i] {
+			neighbors = append(neighbors, j)
+		}
+		d := len(neighbors)
+		if d < 2 {
+			continue
+		}
+		slices.Sort(neighbors)
+
+		linked, pairs := 0, 0
+		if d*(d-1)/2 <= clusteringPairs {
+			for a := range neighbors {
+				for _, b := range neighbors[a+1:] {
+					if adj[neighbors[a]][b] {
+						linked++
+					}
+					pairs++
+				}
+			}
+		} else {
+			for range clusteringPairs {
+				a := rng.IntN(d)
+				b := rng.IntN(d - 1)
+				if b >= a {
+					b++
+				}
+				if adj[neighbors[a]][neighbors[b]] {
+					linked++
+				}
+				pairs++
+			}
+		}
+		sum += float64(linked) / float64(pairs)
+	}
+	return sum / float64(len(nodes))
+}
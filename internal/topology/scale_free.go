@@ -0,0 +1,68 @@
+package topology
+
+import (
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+// ScaleFree creates a Barabási-Albert scale-free topology builder for n agents.
+// The first m agents form a fully connected core; every later agent links to
+// m distinct existing agents chosen with probability proportional to their
+// degree. The result has a few highly connected hubs and many sparsely
+// connected agents.
+//
+// m must satisfy 1 <= m <= n. With m == n the topology is the core alone,
+// i.e. fully connected.
+func ScaleFree(n, m int, opts ...Option) (Builder, error) {
+	switch {
+	case n < 2:
+		return nil, fmt.Errorf("%w for scale-free topology: got %d, need at least 2", core.ErrInsufficientAgents, n)
+	case m < 1 || m > n:
+		return nil, fmt.Errorf("scale-free attachment count m must satisfy 1 <= m <= n, got m=%d n=%d", m, n)
+	}
+
+	return func(s *swarm.Swarm) error {
+		agents := sortedAgents(s)
+		if err := checkSize(agents, n, "scale-free"); err != nil {
+			return err
+		}
+		connectEdges(agents, scaleFreeEdges(n, m, newRand(opts)))
+		return nil
+	}, nil
+}
+
+// scaleFreeEdges generates Barabási-Albert edges over agent indices.
+func scaleFreeEdges(n, m int, rng *rand.Rand) [][2]int {
+	var edges [][2]int
+
+	// pool holds each agent once per link end (plus once for core agents so a
+	// single-agent core is selectable), making a uniform pick degree-weighted.
+	var pool []int
+	for a := range m {
+		pool = append(pool, a)
+		for b := a + 1; b < m; b++ {
+			edges = append(edges, [2]int{a, b})
+			pool = append(pool, a, b)
+		}
+	}
+
+	for newcomer := m; newcomer < n; newcomer++ {
+		chosen := make(map[int]bool, m)
+		targets := make([]int, 0, m)
+		for len(targets) < m {
+			t := pool[rng.IntN(len(pool))]
+			if !chosen[t] {
+				chosen[t] = true
+				targets = append(targets, t)
+			}
+		}
+		for _, t := range targets {
+			edges = append(edges, [2]int{t, newcomer})
+			pool = append(pool, t, newcomer)
+		}
+	}
+	return edges
+}
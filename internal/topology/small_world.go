@@ -0,0 +1,85 @@
+package topology
+
+import (
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+)
+
+// SmallWorld creates a Watts-Strogatz small-world topology builder for n agents.
+// Agents start on a ring, each linked to its k nearest neighbors (k/2 per
+// side); each link is then rewired to a random agent with probability
+// rewireProb. Low rewiring keeps local clustering while a few long-range
+// links shorten paths across the swarm.
+//
+// k must be even and 2 <= k < n, and rewireProb must be in [0, 1].
+func SmallWorld(n, k int, rewireProb float64, opts ...Option) (Builder, error) {
+	switch {
+	case n < 3:
+		return nil, fmt.Errorf("%w for small-world topology: got %d, need at least 3", core.ErrInsufficientAgents, n)
+	case k < 2 || k >= n:
+		return nil, fmt.Errorf("small-world degree k must satisfy 2 <= k < n, got k=%d n=%d", k, n)
+	case k%2 != 0:
+		return nil, fmt.Errorf("small-world degree k must be even, got %d", k)
+	case rewireProb < 0 || rewireProb > 1:
+		return nil, fmt.Errorf("small-world rewire probability must be in [0, 1], got %v", rewireProb)
+	}
+
+	return func(s *swarm.Swarm) error {
+		agents := sortedAgents(s)
+		if err := checkSize(agents, n, "small-world"); err != nil {
+			return err
+		}
+		connectEdges(agents, smallWorldEdges(n, k, rewireProb, newRand(opts)))
+		return nil
+	}, nil
+}
+
+// smallWorldEdges generates Watts-Strogatz edges over agent indices.
+func smallWorldEdges(n, k int, p float64, rng *rand.Rand) [][2]int {
+	adj := make([]map[int]bool, n)
+	for i := range adj {
+		adj[i] = make(map[int]bool, k)
+	}
+	link := func(a, b int) { adj[a][b], adj[b][a] = true, true }
+	unlink := func(a, b int) { delete(adj[a], b); delete(adj[b], a) }
+
+	// Ring lattice
+	for i := range n {
+		for j := 1; j <= k/2; j++ {
+			link(i, (i+j)%n)
+		}
+	}
+
+	// Rewire each lattice edge (i, i+j) with probability p
+	for j := 1; j <= k/2; j++ {
+		for i := range n {
+			old := (i + j) % n
+			if !adj[i][old] || rng.Float64() >= p {
+				continue
+			}
+			// Skip if i is already linked to everyone
+			if len(adj[i]) >= n-1 {
+				continue
+			}
+			target := rng.IntN(n)
+			for target == i || adj[i][target] {
+				target = rng.IntN(n)
+			}
+			unlink(i, old)
+			link(i, target)
+		}
+	}
+
+	var edges [][2]int
+	for a := range n {
+		for b := a + 1; b < n; b++ {
+			if adj[a][b] {
+				edges = append(edges, [2]int{a, b})
+			}
+		}
+	}
+	return edges
+}
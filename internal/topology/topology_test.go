@@ -0,0 +1,292 @@
+package topology_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlisia/bio-adapt/emerge/agent"
+	"github.com/carlisia/bio-adapt/emerge/core"
+	"github.com/carlisia/bio-adapt/emerge/swarm"
+	"github.com/carlisia/bio-adapt/internal/topology"
+)
+
+var testGoal = core.State{Phase: 0, Frequency: 100 * time.Millisecond, Coherence: 0.8}
+
+// degrees returns each agent's neighbor count keyed by agent ID.
+func degrees(s *swarm.Swarm) map[string]int {
+	d := make(map[string]int)
+	s.ForEachAgent(func(a *agent.Agent) bool {
+		d[a.ID] = a.NeighborCount()
+		return true
+	})
+	return d
+}
+
+func TestSmallWorld(t *testing.T) {
+	t.Parallel()
+
+	const n, k = 30, 4
+
+	// Without rewiring every agent keeps exactly k lattice neighbors
+	lattice, err := topology.SmallWorld(n, k, 0, topology.WithSeed(1))
+	require.NoError(t, err)
+	s, err := swarm.New(n, testGoal, swarm.WithTopology(lattice))
+	require.NoError(t, err)
+	for id, d := range degrees(s) {
+		assert.Equal(t, k, d, "agent %s", id)
+	}
+
+	// Rewiring preserves the edge count, so the mean degree stays k
+	rewired, err := topology.SmallWorld(n, k, 0.3, topology.WithSeed(1))
+	require.NoError(t, err)
+	s, err = swarm.New(n, testGoal, swarm.WithTopology(rewired))
+	require.NoError(t, err)
+	total := 0
+	for _, d := range degrees(s) {
+		total += d
+	}
+	assert.Equal(t, n*k, total)
+}
+
+func TestScaleFree(t *testing.T) {
+	t.Parallel()
+
+	const n, m = 200, 2
+	b, err := topology.ScaleFree(n, m, topology.WithSeed(7))
+	require.NoError(t, err)
+	s, err := swarm.New(n, testGoal, swarm.WithTopology(b))
+	require.NoError(t, err)
+
+	total, maxDegree := 0, 0
+	for id, d := range degrees(s) {
+		assert.GreaterOrEqual(t, d, 1, "agent %s should be connected", id)
+		total += d
+		maxDegree = max(maxDegree, d)
+	}
+
+	// Core edges plus m per newcomer, counted at both ends
+	edges := m*(m-1)/2 + (n-m)*m
+	assert.Equal(t, 2*edges, total)
+	assert.Greater(t, maxDegree, 20, "preferential attachment should grow hubs well beyond the mean degree")
+}
+
+func TestSeededTopologiesAreReproducible(t *testing.T) {
+	t.Parallel()
+
+	build := func(b topology.Builder) map[string]int {
+		s, err := swarm.New(50, testGoal, swarm.WithTopology(b))
+		require.NoError(t, err)
+		return degrees(s)
+	}
+
+	sw1, err := topology.SmallWorld(50, 4, 0.5, topology.WithSeed(42))
+	require.NoError(t, err)
+	sw2, err := topology.SmallWorld(50, 4, 0.5, topology.WithSeed(42))
+	require.NoError(t, err)
+	assert.Equal(t, build(sw1), build(sw2))
+
+	sf1, err := topology.ScaleFree(50, 3, topology.WithSeed(42))
+	require.NoError(t, err)
+	sf2, err := topology.ScaleFree(50, 3, topology.WithSeed(42))
+	require.NoError(t, err)
+	assert.Equal(t, build(sf1), build(sf2))
+}
+
+func TestTopologyValidation(t *testing.T) {
+	t.Parallel()
+
+	_, err := topology.SmallWorld(10, 10, 0.1)
+	require.Error(t, err, "k must be less than n")
+	_, err = topology.SmallWorld(10, 3, 0.1)
+	require.Error(t, err, "k must be even")
+	_, err = topology.SmallWorld(10, 4, 1.5)
+	require.Error(t, err, "rewire probability must be a probability")
+	_, err = topology.SmallWorld(2, 2, 0.1)
+	require.ErrorIs(t, err, core.ErrInsufficientAgents)
+
+	_, err = topology.ScaleFree(10, 11)
+	require.Error(t, err, "m must not exceed n")
+	_, err = topology.ScaleFree(10, 0)
+	require.Error(t, err)
+
+	full, err := topology.ScaleFree(5, 5)
+	require.NoError(t, err, "m == n is allowed")
+	s, err := swarm.New(5, testGoal, swarm.WithTopology(full))
+	require.NoError(t, err)
+	for _, d := range degrees(s) {
+		assert.Equal(t, 4, d)
+	}
+
+	// The builder checks the swarm matches the size it was created for
+	mismatched, err := topology.SmallWorld(20, 4, 0.1)
+	require.NoError(t, err)
+	_, err = swarm.New(10, testGoal, swarm.WithTopology(mismatched))
+	require.Error(t, err)
+}
+
+func TestInspect(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fully connected", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := swarm.New(10, testGoal, swarm.WithTopology(topology.FullyConnected))
+		require.NoError(t, err)
+		r := topology.Inspect(s)
+		assert.Equal(t, 10, r.Agents)
+		assert.Equal(t, 45, r.Edges)
+		assert.Equal(t, map[int]int{9: 10}, r.Degrees)
+		assert.True(t, r.IsConnected)
+		assert.Equal(t, []int{10}, r.ComponentSizes)
+		assert.InDelta(t, 1.0, r.Clustering, 1e-9)
+	})
+
+	t.Run("ring", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := swarm.New(20, testGoal, swarm.WithTopology(topology.Ring))
+		require.NoError(t, err)
+		r := topology.Inspect(s)
+		assert.Equal(t, 2, r.MinDegree)
+		assert.Equal(t, 2, r.MaxDegree)
+		assert.InDelta(t, 2.0, r.MeanDegree, 1e-9)
+		assert.True(t, r.IsConnected)
+		assert.Zero(t, r.Clustering, "a ring has no triangles")
+	})
+
+	t.Run("partitioned", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := swarm.New(20, testGoal, swarm.WithSeed(4), swarm.WithTopology(topology.FullyConnected))
+		require.NoError(t, err)
+		_, err = s.Disrupt(swarm.DisruptionSpec{Kind: swarm.Partition, Fraction: 0.5})
+		require.NoError(t, err)
+
+		r := topology.Inspect(s)
+		assert.False(t, r.IsConnected)
+		assert.Equal(t, 2, r.Components)
+		assert.Equal(t, []int{10, 10}, r.ComponentSizes)
+		assert.Equal(t, 2*45, r.Edges)
+		assert.InDelta(t, 1.0, r.Clustering, 1e-9, "each half is still a clique")
+	})
+
+	t.Run("dense graph is sampled", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := swarm.New(300, testGoal, swarm.WithTopology(topology.FullyConnected))
+		require.NoError(t, err)
+		r := topology.Inspect(s)
+		assert.True(t, r.IsConnected)
+		assert.InDelta(t, 1.0, r.Clustering, 1e-9)
+	})
+}
+
+// TestDirected couples two groups that watch each other unequally. The
+// swarm settles near the phase of the group that is watched more, where
+// symmetric links would settle near the larger group's phase.
+func TestDirected(t *testing.T) {
+	t.Parallel()
+
+	const n, observed = 20, 5
+	id := func(i int) string { return fmt.Sprintf("agent-%d", i) }
+
+	// consensus wires the groups, couples every agent with its neighbors
+	// until they agree, and returns the phase they agree on
+	consensus := func(t *testing.T, upward, downward float64) float64 {
+		t.Helper()
+		var edges []topology.Edge
+		for i := range n {
+			for j := range n {
+				switch {
+				case i == j:
+				case (i < observed) == (j < observed):
+					edges = append(edges, topology.Edge{From: id(i), To: id(j), Weight: 1})
+				case i >= observed:
+					edges = append(edges, topology.Edge{From: id(i), To: id(j), Weight: upward})
+				default:
+					edges = append(edges, topology.Edge{From: id(i), To: id(j), Weight: downward})
+				}
+			}
+		}
+		b, err := topology.Directed(edges...)
+		require.NoError(t, err)
+		s, err := swarm.New(n, testGoal, swarm.WithTopology(b))
+		require.NoError(t, err)
+		defer s.Close()
+
+		agents := s.AgentsSorted()
+		for i, a := range agents {
+			if i < observed {
+				a.SetPhase(0)
+			} else {
+				a.SetPhase(2)
+			}
+		}
+		for range 200 {
+			for _, a := range agents {
+				a.UpdateContext()
+			}
+			for _, a := range agents {
+				a.SetPhase(a.LocalGoal())
+			}
+		}
+		assert.InDelta(t, 1, s.MeasureCoherence(), 1e-3)
+		return agents[0].Phase()
+	}
+
+	symmetric := consensus(t, 1, 1)
+	biased := consensus(t, 1, 0.02)
+	assert.Greater(t, symmetric, 1.0, "symmetric links follow the larger group")
+	assert.Less(t, biased, 0.5, "the watched group leads")
+}
+
+func TestDirectedEdges(t *testing.T) {
+	t.Parallel()
+
+	b, err := topology.Directed(
+		topology.Edge{From: "agent-1", To: "agent-0", Weight: 3},
+		topology.Edge{From: "agent-0", To: "agent-1", Weight: 0},
+		topology.Edge{From: "agent-2", To: "agent-0", Weight: 1},
+	)
+	require.NoError(t, err)
+	s, err := swarm.New(3, testGoal, swarm.WithTopology(b))
+	require.NoError(t, err)
+	defer s.Close()
+
+	a0, _ := s.Agent("agent-0")
+	a1, _ := s.Agent("agent-1")
+	a2, _ := s.Agent("agent-2")
+	assert.True(t, a1.IsConnectedTo("agent-0"))
+	assert.InDelta(t, 3, a1.EdgeWeight("agent-0"), 1e-12)
+	assert.InDelta(t, 1, a2.EdgeWeight("agent-0"), 1e-12)
+	assert.False(t, a0.IsConnectedTo("agent-1"), "zero-weight edges are pruned")
+	assert.Zero(t, a0.NeighborCount(), "links are one-way")
+
+	// Weighted reweights any builder's links, pruning those weighted 0
+	s, err = swarm.New(6, testGoal, swarm.WithTopology(topology.Weighted(topology.Star,
+		func(from, _ *agent.Agent) float64 {
+			if from.ID == "agent-0" {
+				return 0
+			}
+			return 2
+		})))
+	require.NoError(t, err)
+	defer s.Close()
+	hub, _ := s.Agent("agent-0")
+	leaf, _ := s.Agent("agent-3")
+	assert.Zero(t, hub.NeighborCount(), "the hub no longer watches its leaves")
+	assert.InDelta(t, 2, leaf.EdgeWeight("agent-0"), 1e-12)
+
+	_, err = topology.Directed(topology.Edge{From: "agent-0", To: "agent-1", Weight: -1})
+	require.Error(t, err)
+	_, err = topology.Directed(topology.Edge{From: "agent-0", To: "agent-0", Weight: 1})
+	require.Error(t, err)
+	b, err = topology.Directed(topology.Edge{From: "agent-0", To: "agent-9", Weight: 1})
+	require.NoError(t, err)
+	_, err = swarm.New(3, testGoal, swarm.WithTopology(b))
+	require.ErrorIs(t, err, swarm.ErrAgentNotFound)
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/mum4k/termdash/widgets/linechart"
 	"github.com/mum4k/termdash/widgets/text"
 
+	"github.com/carlisia/bio-adapt/emerge/core"
 	"github.com/carlisia/bio-adapt/emerge/goal"
 	"github.com/carlisia/bio-adapt/emerge/scale"
 	"github.com/carlisia/bio-adapt/simulations/emerge/simulation/pattern"
@@ -1053,6 +1054,11 @@ func (d *TerminalDisplay) updateAgentsDisplay(agents []AgentSnapshot) {
 	for i := range maxShow {
 		a := agents[i]
 
+		// near reports whether the agent is within width of phase, either way round
+		near := func(phase, width float64) bool {
+			return math.Abs(core.PhaseDifference(a.Phase, phase)) < width
+		}
+
 		// Goal-aware sync indicator
 		var syncIndicator string
 		var phaseColor cell.Color
@@ -1062,10 +1068,10 @@ func (d *TerminalDisplay) updateAgentsDisplay(agents []AgentSnapshot) {
 			// These goals want ANTI-PHASE (spread out)
 			// Red when synchronized, green when distributed
 			switch {
-			case math.Abs(a.Phase) < 0.5 || math.Abs(a.Phase-2*math.Pi) < 0.5:
+			case near(0, 0.5):
 				syncIndicator = syncRed // BAD - too synchronized
 				phaseColor = cell.ColorRed
-			case math.Abs(a.Phase-math.Pi) < 0.5 || math.Abs(a.Phase-math.Pi/2) < 0.5 || math.Abs(a.Phase-3*math.Pi/2) < 0.5:
+			case near(math.Pi, 0.5) || near(math.Pi/2, 0.5) || near(3*math.Pi/2, 0.5):
 				syncIndicator = syncGreen // GOOD - distributed
 				phaseColor = cell.ColorGreen
 			default:
@@ -1077,10 +1083,10 @@ func (d *TerminalDisplay) updateAgentsDisplay(agents []AgentSnapshot) {
 			// Wants SPARSE sync (some coordination but not tight)
 			// Best when some agents rest while others work
 			switch {
-			case math.Abs(a.Phase) < 0.3 || math.Abs(a.Phase-2*math.Pi) < 0.3:
+			case near(0, 0.3):
 				syncIndicator = syncYellow // OK - partially synced
 				phaseColor = cell.ColorYellow
-			case math.Abs(a.Phase-math.Pi) < 0.5:
+			case near(math.Pi, 0.5):
 				syncIndicator = syncGreen // GOOD - taking turns
 				phaseColor = cell.ColorGreen
 			default:
@@ -1091,7 +1097,7 @@ func (d *TerminalDisplay) updateAgentsDisplay(agents []AgentSnapshot) {
 		case goal.ReachConsensus:
 			// Wants CLUSTERS (voting blocs)
 			// Green when in a cluster with others
-			clustered := math.Abs(a.Phase) < 0.5 || math.Abs(a.Phase-2) < 0.5 || math.Abs(a.Phase-4) < 0.5
+			clustered := near(0, 0.5) || near(2, 0.5) || near(4, 0.5)
 			switch {
 			case clustered:
 				syncIndicator = syncGreen // GOOD - in a voting bloc
@@ -1105,10 +1111,10 @@ func (d *TerminalDisplay) updateAgentsDisplay(agents []AgentSnapshot) {
 			// Most goals want IN-PHASE (synchronized)
 			// MinimizeAPICalls, MinimizeLatency, MaintainRhythm, AdaptToTraffic
 			switch {
-			case math.Abs(a.Phase) < 0.5 || math.Abs(a.Phase-2*math.Pi) < 0.5:
+			case near(0, 0.5):
 				syncIndicator = syncGreen // GOOD - synchronized
 				phaseColor = cell.ColorGreen
-			case math.Abs(a.Phase) < 1.0:
+			case near(0, 1.0):
 				syncIndicator = syncYellow // Almost there
 				phaseColor = cell.ColorYellow
 			default:
@@ -1841,10 +1847,7 @@ func getAgentColor(phase, meanPhase, meanMagnitude float64) cell.Color {
 	}
 
 	// Higher coherence - color by alignment with mean field
-	deviation := math.Abs(phase - meanPhase)
-	if deviation > math.Pi {
-		deviation = 2*math.Pi - deviation
-	}
+	deviation := math.Abs(core.PhaseDifference(phase, meanPhase))
 
 	if deviation < math.Pi*(1-meanMagnitude) {
 		return cell.ColorGreen
@@ -2018,7 +2021,7 @@ func (d *TerminalDisplay) displayLatencyHistogram(snapshot SimulationSnapshot) {
 
 	for _, agent := range snapshot.Agents {
 		// Phase variance affects latency
-		variance := math.Abs(agent.Phase)
+		variance := math.Abs(core.PhaseDifference(agent.Phase, 0))
 		latency := baseLatency + variance*5
 		bin := int(latency / 10)
 		if bin >= bins {
@@ -2160,7 +2163,7 @@ func (d *TerminalDisplay) displaySystemHealth(snapshot SimulationSnapshot) {
 
 	for _, agent := range snapshot.Agents {
 		switch {
-		case snapshot.Disrupted && math.Abs(agent.Phase) > 2:
+		case snapshot.Disrupted && math.Abs(core.PhaseDifference(agent.Phase, 0)) > 2:
 			failed++
 		case math.Abs(agent.Phase) > 1:
 			degraded++
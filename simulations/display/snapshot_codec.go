@@ -0,0 +1,333 @@
+// snapshot_codec.go provides a compact binary encoding for SimulationSnapshot.
+// JSON repeats every field name for every agent, which gets too heavy for a
+// browser dashboard at Huge scale. The binary form is a fixed header followed
+// by a string table and fixed-width per-agent columns.
+
+package display
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// snapshotMagic identifies a binary snapshot payload.
+var snapshotMagic = [4]byte{'B', 'A', 'S', 'N'}
+
+// snapshotVersion is bumped whenever the wire layout changes.
+const snapshotVersion uint8 = 1
+
+// Snapshot status flags
+const (
+	flagPaused uint8 = 1 << iota
+	flagDisrupted
+	flagReset
+	flagBatchJustSent
+)
+
+// agentRecordSize is the number of bytes each agent occupies across the
+// fixed-width columns: phase, pending, batches, 4 string refs, flags.
+const agentRecordSize = 4 + 4 + 4 + 4*4 + 1
+
+// ErrInvalidSnapshot indicates a payload that is not a valid binary snapshot.
+var ErrInvalidSnapshot = errors.New("invalid binary snapshot")
+
+// snapshotHeader is the fixed-size header written before the variable sections.
+type snapshotHeader struct {
+	Magic            [4]byte
+	Version          uint8
+	Flags            uint8
+	_                uint16 // Reserved
+	Timestamp        int64  // Unix nanoseconds (0 = zero time)
+	ElapsedTime      int64
+	LastBatchTime    int64 // Unix nanoseconds (0 = zero time)
+	Coherence        float64
+	TargetCoherence  float64
+	CostWithoutSync  float64
+	CostWithSync     float64
+	Savings          float64
+	SavingsPercent   float64
+	PendingTasks     int64
+	CurrentBatchSize int64
+	BatchesProcessed int64
+	LastBatchSize    int64
+	AgentCount       uint32
+	StringCount      uint32
+}
+
+// Marshal encodes the snapshot into the compact binary wire format.
+//
+// Layout (little endian):
+//   - header (see snapshotHeader)
+//   - string table: StringCount entries of uint16 length + bytes
+//   - phases: AgentCount float32 values
+//   - pending tasks: AgentCount uint32 values
+//   - batches sent: AgentCount uint32 values
+//   - string references: AgentCount x 4 uint32 indices (ID, Type, Icon, ActivityLevel)
+//   - agent flags: AgentCount bytes (bit 0 = InBurstMode)
+//
+// Phases are stored as float32, which is well below display resolution.
+func (s SimulationSnapshot) Marshal() ([]byte, error) {
+	strs := newStringTable()
+	refs := make([]uint32, 0, len(s.Agents)*4)
+	for _, a := range s.Agents {
+		for _, str := range [...]string{a.ID, a.Type, a.Icon, a.ActivityLevel} {
+			idx, err := strs.add(str)
+			if err != nil {
+				return nil, err
+			}
+			refs = append(refs, idx)
+		}
+	}
+
+	hdr := snapshotHeader{
+		Magic:            snapshotMagic,
+		Version:          snapshotVersion,
+		Flags:            s.flags(),
+		Timestamp:        encodeTime(s.Timestamp),
+		ElapsedTime:      int64(s.ElapsedTime),
+		LastBatchTime:    encodeTime(s.LastBatchTime),
+		Coherence:        s.Coherence,
+		TargetCoherence:  s.TargetCoherence,
+		CostWithoutSync:  s.CostWithoutSync,
+		CostWithSync:     s.CostWithSync,
+		Savings:          s.Savings,
+		SavingsPercent:   s.SavingsPercent,
+		PendingTasks:     int64(s.PendingTasks),
+		CurrentBatchSize: int64(s.CurrentBatchSize),
+		BatchesProcessed: int64(s.BatchesProcessed),
+		LastBatchSize:    int64(s.LastBatchSize),
+		AgentCount:       uint32(len(s.Agents)), //nolint:gosec // Agent counts are far below MaxUint32
+		StringCount:      uint32(len(strs.values)),
+	}
+
+	n := len(s.Agents)
+	buf := bytes.NewBuffer(make([]byte, 0, binary.Size(hdr)+strs.size+n*agentRecordSize))
+	le := binary.LittleEndian
+
+	// Header
+	if err := binary.Write(buf, le, &hdr); err != nil {
+		return nil, fmt.Errorf("failed to write snapshot header: %w", err)
+	}
+
+	// String table
+	var lenBuf [2]byte
+	for _, str := range strs.values {
+		le.PutUint16(lenBuf[:], uint16(len(str))) //nolint:gosec // Length checked in stringTable.add
+		buf.Write(lenBuf[:])
+		buf.WriteString(str)
+	}
+
+	// Fixed-width agent columns
+	col := make([]byte, 0, n*4)
+	for _, a := range s.Agents {
+		col = le.AppendUint32(col, math.Float32bits(float32(a.Phase)))
+	}
+	buf.Write(col)
+
+	col = col[:0]
+	for _, a := range s.Agents {
+		col = le.AppendUint32(col, clampUint32(a.PendingTasks))
+	}
+	buf.Write(col)
+
+	col = col[:0]
+	for _, a := range s.Agents {
+		col = le.AppendUint32(col, clampUint32(a.BatchesSent))
+	}
+	buf.Write(col)
+
+	col = make([]byte, 0, len(refs)*4)
+	for _, r := range refs {
+		col = le.AppendUint32(col, r)
+	}
+	buf.Write(col)
+
+	for _, a := range s.Agents {
+		var f byte
+		if a.InBurstMode {
+			f |= 1
+		}
+		buf.WriteByte(f)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Unmarshal decodes a binary snapshot produced by Marshal, replacing the
+// receiver's contents.
+func (s *SimulationSnapshot) Unmarshal(data []byte) error {
+	le := binary.LittleEndian
+	r := bytes.NewReader(data)
+
+	var hdr snapshotHeader
+	if err := binary.Read(r, le, &hdr); err != nil {
+		return fmt.Errorf("%w: short header: %w", ErrInvalidSnapshot, err)
+	}
+	if hdr.Magic != snapshotMagic {
+		return fmt.Errorf("%w: bad magic %q", ErrInvalidSnapshot, hdr.Magic[:])
+	}
+	if hdr.Version != snapshotVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidSnapshot, hdr.Version)
+	}
+
+	// String table; each string takes at least its length prefix, so a
+	// count the data cannot hold is rejected before allocating for it
+	if int64(hdr.StringCount) > int64(r.Len()/2) {
+		return fmt.Errorf("%w: %d strings in %d bytes", ErrInvalidSnapshot, hdr.StringCount, r.Len())
+	}
+	strs := make([]string, hdr.StringCount)
+	for i := range strs {
+		var l uint16
+		if err := binary.Read(r, le, &l); err != nil {
+			return fmt.Errorf("%w: truncated string table: %w", ErrInvalidSnapshot, err)
+		}
+		if int(l) > r.Len() {
+			return fmt.Errorf("%w: truncated string table", ErrInvalidSnapshot)
+		}
+		b := make([]byte, l)
+		_, _ = r.Read(b)
+		strs[i] = string(b)
+	}
+
+	// Remaining bytes must hold exactly the per-agent columns
+	n := int(hdr.AgentCount)
+	if r.Len() != n*agentRecordSize {
+		return fmt.Errorf("%w: expected %d bytes of agent data, got %d", ErrInvalidSnapshot, n*agentRecordSize, r.Len())
+	}
+	rest := data[len(data)-r.Len():]
+
+	phases := rest[:n*4]
+	pending := rest[n*4 : n*8]
+	batches := rest[n*8 : n*12]
+	refs := rest[n*12 : n*28]
+	flags := rest[n*28:]
+
+	lookup := func(off int) (string, error) {
+		idx := le.Uint32(refs[off*4:])
+		if int(idx) >= len(strs) {
+			return "", fmt.Errorf("%w: string index %d out of range", ErrInvalidSnapshot, idx)
+		}
+		return strs[idx], nil
+	}
+
+	agents := make([]AgentSnapshot, n)
+	for i := range agents {
+		a := &agents[i]
+		a.Phase = float64(math.Float32frombits(le.Uint32(phases[i*4:])))
+		a.PendingTasks = int(le.Uint32(pending[i*4:]))
+		a.BatchesSent = int(le.Uint32(batches[i*4:]))
+		a.InBurstMode = flags[i]&1 != 0
+
+		var err error
+		if a.ID, err = lookup(i * 4); err != nil {
+			return err
+		}
+		if a.Type, err = lookup(i*4 + 1); err != nil {
+			return err
+		}
+		if a.Icon, err = lookup(i*4 + 2); err != nil {
+			return err
+		}
+		if a.ActivityLevel, err = lookup(i*4 + 3); err != nil {
+			return err
+		}
+	}
+
+	*s = SimulationSnapshot{
+		Timestamp:        decodeTime(hdr.Timestamp),
+		ElapsedTime:      time.Duration(hdr.ElapsedTime),
+		Agents:           agents,
+		Coherence:        hdr.Coherence,
+		TargetCoherence:  hdr.TargetCoherence,
+		PendingTasks:     int(hdr.PendingTasks),
+		CurrentBatchSize: int(hdr.CurrentBatchSize),
+		BatchesProcessed: int(hdr.BatchesProcessed),
+		CostWithoutSync:  hdr.CostWithoutSync,
+		CostWithSync:     hdr.CostWithSync,
+		Savings:          hdr.Savings,
+		SavingsPercent:   hdr.SavingsPercent,
+		Paused:           hdr.Flags&flagPaused != 0,
+		Disrupted:        hdr.Flags&flagDisrupted != 0,
+		Reset:            hdr.Flags&flagReset != 0,
+		BatchJustSent:    hdr.Flags&flagBatchJustSent != 0,
+		LastBatchTime:    decodeTime(hdr.LastBatchTime),
+		LastBatchSize:    int(hdr.LastBatchSize),
+	}
+
+	return nil
+}
+
+// flags packs the boolean status fields into a single byte.
+func (s SimulationSnapshot) flags() uint8 {
+	var f uint8
+	if s.Paused {
+		f |= flagPaused
+	}
+	if s.Disrupted {
+		f |= flagDisrupted
+	}
+	if s.Reset {
+		f |= flagReset
+	}
+	if s.BatchJustSent {
+		f |= flagBatchJustSent
+	}
+	return f
+}
+
+// stringTable deduplicates repeated strings (agent types, icons, activity levels).
+type stringTable struct {
+	index  map[string]uint32
+	values []string
+	size   int
+}
+
+func newStringTable() *stringTable {
+	return &stringTable{index: make(map[string]uint32)}
+}
+
+// add returns the table index for str, inserting it if needed.
+func (t *stringTable) add(str string) (uint32, error) {
+	if idx, ok := t.index[str]; ok {
+		return idx, nil
+	}
+	if len(str) > math.MaxUint16 {
+		return 0, fmt.Errorf("string too long for snapshot encoding: %d bytes", len(str))
+	}
+	idx := uint32(len(t.values)) //nolint:gosec // Bounded by agent count
+	t.index[str] = idx
+	t.values = append(t.values, str)
+	t.size += 2 + len(str)
+	return idx, nil
+}
+
+// encodeTime converts a time to Unix nanoseconds, mapping the zero time to 0.
+func encodeTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+// decodeTime is the inverse of encodeTime.
+func decodeTime(ns int64) time.Time {
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ns)
+}
+
+// clampUint32 converts a non-negative count to uint32, saturating at the bounds.
+func clampUint32(v int) uint32 {
+	if v < 0 {
+		return 0
+	}
+	if v > math.MaxUint32 {
+		return math.MaxUint32
+	}
+	return uint32(v)
+}
@@ -0,0 +1,253 @@
+package display
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+// buildSnapshot creates a representative snapshot with the given agent count.
+func buildSnapshot(agentCount int) SimulationSnapshot {
+	types := []string{"DataETL", "Leader", "Cache", "Reporter"}
+	icons := []string{"📊", "👑", "💾", "📝"}
+	levels := []string{"burst", "quiet", "steady", "active"}
+
+	agents := make([]AgentSnapshot, agentCount)
+	for i := range agents {
+		agents[i] = AgentSnapshot{
+			ID:            fmt.Sprintf("agent-%d", i),
+			Type:          types[i%len(types)],
+			Icon:          icons[i%len(icons)],
+			Phase:         float64(i) * 2 * math.Pi / float64(agentCount),
+			PendingTasks:  i % 17,
+			BatchesSent:   i * 3,
+			InBurstMode:   i%5 == 0,
+			ActivityLevel: levels[i%len(levels)],
+		}
+	}
+
+	now := time.Now()
+	return SimulationSnapshot{
+		Timestamp:        now,
+		ElapsedTime:      42 * time.Second,
+		Agents:           agents,
+		Coherence:        0.8731,
+		TargetCoherence:  0.9,
+		PendingTasks:     123,
+		CurrentBatchSize: 17,
+		BatchesProcessed: 99,
+		CostWithoutSync:  12.5,
+		CostWithSync:     3.25,
+		Savings:          9.25,
+		SavingsPercent:   74,
+		Paused:           true,
+		BatchJustSent:    true,
+		LastBatchTime:    now.Add(-time.Second),
+		LastBatchSize:    8,
+	}
+}
+
+func TestSnapshotBinaryRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		agents int
+	}{
+		{"empty", 0},
+		{"single", 1},
+		{"huge", 5000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			original := buildSnapshot(tt.agents)
+
+			data, err := original.Marshal()
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+
+			var decoded SimulationSnapshot
+			if err := decoded.Unmarshal(data); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+
+			if !decoded.Timestamp.Equal(original.Timestamp) {
+				t.Errorf("Timestamp = %v, want %v", decoded.Timestamp, original.Timestamp)
+			}
+			if !decoded.LastBatchTime.Equal(original.LastBatchTime) {
+				t.Errorf("LastBatchTime = %v, want %v", decoded.LastBatchTime, original.LastBatchTime)
+			}
+
+			// Compare everything else with times normalized
+			decoded.Timestamp, original.Timestamp = time.Time{}, time.Time{}
+			decoded.LastBatchTime, original.LastBatchTime = time.Time{}, time.Time{}
+			decodedAgents, originalAgents := decoded.Agents, original.Agents
+			decoded.Agents, original.Agents = nil, nil
+			if fmt.Sprintf("%+v", decoded) != fmt.Sprintf("%+v", original) {
+				t.Errorf("header mismatch:\n got %+v\nwant %+v", decoded, original)
+			}
+
+			if len(decodedAgents) != len(originalAgents) {
+				t.Fatalf("agent count = %d, want %d", len(decodedAgents), len(originalAgents))
+			}
+			for i := range originalAgents {
+				got, want := decodedAgents[i], originalAgents[i]
+				if math.Abs(got.Phase-want.Phase) > 1e-6 {
+					t.Errorf("agent %d phase = %v, want %v", i, got.Phase, want.Phase)
+				}
+				got.Phase, want.Phase = 0, 0
+				if got != want {
+					t.Errorf("agent %d = %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSnapshotBinaryZeroTimes(t *testing.T) {
+	t.Parallel()
+
+	data, err := SimulationSnapshot{}.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var decoded SimulationSnapshot
+	if err := decoded.Unmarshal(data); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !decoded.Timestamp.IsZero() || !decoded.LastBatchTime.IsZero() {
+		t.Errorf("zero times should round-trip as zero, got %v and %v", decoded.Timestamp, decoded.LastBatchTime)
+	}
+}
+
+func TestSnapshotBinaryRejectsInvalid(t *testing.T) {
+	t.Parallel()
+
+	valid, err := buildSnapshot(10).Marshal()
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	badMagic := append([]byte(nil), valid...)
+	badMagic[0] = 'X'
+
+	badVersion := append([]byte(nil), valid...)
+	badVersion[4] = 0xFF
+
+	// A string count far beyond what the data can hold
+	hugeStrings := append([]byte(nil), valid...)
+	binary.LittleEndian.PutUint32(hugeStrings[binary.Size(snapshotHeader{})-4:], math.MaxUint32)
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"bad magic", badMagic},
+		{"bad version", badVersion},
+		{"truncated", valid[:len(valid)-1]},
+		{"truncated header", valid[:binary.Size(snapshotHeader{})-1]},
+		{"huge string count", hugeStrings},
+		{"trailing bytes", append(append([]byte(nil), valid...), 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var s SimulationSnapshot
+			if err := s.Unmarshal(tt.data); !errors.Is(err, ErrInvalidSnapshot) {
+				t.Errorf("Unmarshal error = %v, want ErrInvalidSnapshot", err)
+			}
+		})
+	}
+}
+
+func TestSnapshotBinarySmallerThanJSON(t *testing.T) {
+	t.Parallel()
+	snapshot := buildSnapshot(5000)
+
+	jsonData, err := json.Marshal(snapshot)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	binData, err := snapshot.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	t.Logf("5000 agents: JSON %d bytes, binary %d bytes (%.1fx smaller)",
+		len(jsonData), len(binData), float64(len(jsonData))/float64(len(binData)))
+	if len(binData)*3 > len(jsonData) {
+		t.Errorf("binary encoding (%d bytes) should be at least 3x smaller than JSON (%d bytes)", len(binData), len(jsonData))
+	}
+}
+
+func BenchmarkSnapshotEncode(b *testing.B) {
+	snapshot := buildSnapshot(5000)
+
+	b.Run("json", func(b *testing.B) {
+		b.ReportAllocs()
+		var size int
+		for b.Loop() {
+			data, err := json.Marshal(snapshot)
+			if err != nil {
+				b.Fatal(err)
+			}
+			size = len(data)
+		}
+		b.ReportMetric(float64(size), "bytes/snapshot")
+	})
+
+	b.Run("binary", func(b *testing.B) {
+		b.ReportAllocs()
+		var size int
+		for b.Loop() {
+			data, err := snapshot.Marshal()
+			if err != nil {
+				b.Fatal(err)
+			}
+			size = len(data)
+		}
+		b.ReportMetric(float64(size), "bytes/snapshot")
+	})
+}
+
+func BenchmarkSnapshotDecode(b *testing.B) {
+	snapshot := buildSnapshot(5000)
+	jsonData, err := json.Marshal(snapshot)
+	if err != nil {
+		b.Fatal(err)
+	}
+	binData, err := snapshot.Marshal()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("json", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			var s SimulationSnapshot
+			if err := json.Unmarshal(jsonData, &s); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("binary", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			var s SimulationSnapshot
+			if err := s.Unmarshal(binData); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package simulation
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/carlisia/bio-adapt/emerge/goal"
+	"github.com/carlisia/bio-adapt/emerge/scale"
+)
+
+func TestRunHeadless(t *testing.T) {
+	t.Parallel()
+
+	const ticks = 50
+	cases := []struct {
+		goal  goal.Type
+		scale scale.Size
+	}{
+		{goal.MinimizeAPICalls, scale.Tiny},
+		{goal.MinimizeAPICalls, scale.Medium},
+		{goal.DistributeLoad, scale.Small},
+	}
+	for _, tc := range cases {
+		t.Run(tc.goal.String()+"/"+tc.scale.String(), func(t *testing.T) {
+			t.Parallel()
+
+			result := RunHeadless(BuildConfig{Goal: tc.goal, Scale: tc.scale}, ticks)
+			if result.Err != nil {
+				t.Fatalf("RunHeadless failed: %v", result.Err)
+			}
+			if len(result.Coherence) != ticks {
+				t.Errorf("Expected %d coherence samples, got %d", ticks, len(result.Coherence))
+			}
+			if result.TargetCoherence != tc.scale.DefaultTargetCoherence() {
+				t.Errorf("Expected target %.2f, got %.2f", tc.scale.DefaultTargetCoherence(), result.TargetCoherence)
+			}
+			if !result.Converged() {
+				t.Errorf("Did not reach target %.2f within %d ticks", result.TargetCoherence, ticks)
+			}
+			if result.Final.Agents != tc.scale.DefaultAgentCount() {
+				t.Errorf("Expected %d agents, got %d", tc.scale.DefaultAgentCount(), result.Final.Agents)
+			}
+			record := result.Record(tc.goal.String())
+			if record.TicksToTarget != result.TicksToTarget || len(record.Coherence) != ticks {
+				t.Errorf("Record does not match the run: %+v", record)
+			}
+			if record.EnergyUsed < 0 {
+				t.Errorf("Expected non-negative energy use, got %.2f", record.EnergyUsed)
+			}
+			t.Logf("Reached target %.2f in %d ticks, final coherence %.2f",
+				result.TargetCoherence, result.TicksToTarget, result.Final.Coherence)
+		})
+	}
+}
+
+// TestRunHeadlessReleasesSwarm checks that a run stops its swarm's
+// workers, so repeated runs, e.g. in a benchmark, do not leak goroutines.
+// It is not parallel, so no other test's goroutines come and go meanwhile.
+func TestRunHeadlessReleasesSwarm(t *testing.T) {
+	before := runtime.NumGoroutine()
+	for range 5 {
+		if result := RunHeadless(BuildConfig{Goal: goal.MinimizeAPICalls, Scale: scale.Medium}, 5); result.Err != nil {
+			t.Fatalf("RunHeadless failed: %v", result.Err)
+		}
+	}
+
+	// Stopped workers exit asynchronously
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("Expected at most %d goroutines after the runs, got %d", before, after)
+	}
+}